@@ -0,0 +1,64 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConfigFreezeSpec defines a cluster-wide change freeze.
+type ConfigFreezeSpec struct {
+	// Active enables the freeze. While any ConfigFreeze is active, no pool
+	// renders a new revision or starts updating further nodes; nodes
+	// already in progress finish their current update.
+	// +kubebuilder:default=true
+	// +optional
+	Active bool `json:"active"`
+
+	// Reason is shown in the pools' Frozen condition.
+	// +kubebuilder:validation:MaxLength=1024
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Active",type=boolean,JSONPath=`.spec.active`
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.spec.reason`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ConfigFreeze blocks new rollouts across all MachineConfigPools while it
+// is active. Delete it or set spec.active=false to lift the freeze.
+type ConfigFreeze struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ConfigFreezeSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ConfigFreezeList contains a list of ConfigFreeze.
+type ConfigFreezeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ConfigFreeze `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ConfigFreeze{}, &ConfigFreezeList{})
+}
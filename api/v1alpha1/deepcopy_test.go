@@ -21,6 +21,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 )
 
 // TestMachineConfigDeepCopy verifies MachineConfig DeepCopy creates independent copies.
@@ -34,7 +35,7 @@ func TestMachineConfigDeepCopy(t *testing.T) {
 			},
 		},
 		Spec: MachineConfigSpec{
-			Priority: 50,
+			Priority: ptr.To(50),
 			Files: []FileSpec{
 				{
 					Path:    "/etc/test.conf",
@@ -70,7 +71,7 @@ func TestMachineConfigDeepCopy(t *testing.T) {
 
 	// Modify the copy
 	copied.Name = "modified-mc"
-	copied.Spec.Priority = 100
+	*copied.Spec.Priority = 100
 	copied.Spec.Files[0].Content = "modified content"
 	*copied.Spec.Systemd.Units[0].Enabled = false
 
@@ -78,8 +79,8 @@ func TestMachineConfigDeepCopy(t *testing.T) {
 	if original.Name != "test-mc" {
 		t.Errorf("original.Name was modified: got %q, want %q", original.Name, "test-mc")
 	}
-	if original.Spec.Priority != 50 {
-		t.Errorf("original.Spec.Priority was modified: got %d, want %d", original.Spec.Priority, 50)
+	if *original.Spec.Priority != 50 {
+		t.Errorf("original.Spec.Priority was modified: got %d, want %d", *original.Spec.Priority, 50)
 	}
 	if original.Spec.Files[0].Content != "original content" {
 		t.Errorf("original.Spec.Files[0].Content was modified: got %q", original.Spec.Files[0].Content)
@@ -27,10 +27,18 @@ type FileSpec struct {
 	Path string `json:"path"`
 
 	// Content is the file content. Required when state=present.
+	// With state=line-present it is the single line that must exist in the file.
 	// +kubebuilder:validation:MaxLength=1048576
 	// +optional
 	Content string `json:"content,omitempty"`
 
+	// Encoding is how Content is encoded: plain (the default), base64, or
+	// gzip+base64 for compressed binary payloads. The agent writes the
+	// decoded bytes. Only supported with state=present.
+	// +kubebuilder:validation:Enum="";plain;base64;gzip+base64
+	// +optional
+	Encoding string `json:"encoding,omitempty"`
+
 	// Mode is the Unix file permissions (e.g., 0644).
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=4095
@@ -44,8 +52,11 @@ type FileSpec struct {
 	// +optional
 	Owner string `json:"owner,omitempty"`
 
-	// State is the desired state of the file: present or absent.
-	// +kubebuilder:validation:Enum=present;absent
+	// State is the desired state of the file: present, absent or
+	// line-present. line-present ensures Content exists as a line in the
+	// file, appending it if missing and leaving the rest of the file alone;
+	// Mode and Owner then only apply when the file has to be created.
+	// +kubebuilder:validation:Enum=present;absent;line-present
 	// +kubebuilder:default="present"
 	// +optional
 	State string `json:"state,omitempty"`
@@ -54,7 +65,7 @@ type FileSpec struct {
 // UnitSpec defines a systemd unit to be managed.
 type UnitSpec struct {
 	// Name is the unit name (e.g., "nginx.service").
-	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9@._-]+\.(service|socket|timer|mount|target)$`
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9@._-]+\.(service|socket|timer|mount|automount|path|slice|swap|target)$`
 	Name string `json:"name"`
 
 	// Enabled sets whether the unit should start at boot.
@@ -92,15 +103,21 @@ type RebootRequirementSpec struct {
 	Reason string `json:"reason,omitempty"`
 }
 
+// DefaultPriority is the merge priority of a MachineConfig that leaves
+// priority unset when its pool sets no defaultMachineConfigPriority.
+const DefaultPriority = 50
+
 // MachineConfigSpec defines the desired state of MachineConfig.
 type MachineConfigSpec struct {
 	// Priority determines the merge order. Lower values are applied first,
-	// higher values win on conflicts. Range: 0-99999.
+	// higher values win on conflicts. Range: 0-99999. When unset, the
+	// pool's defaultMachineConfigPriority applies, or DefaultPriority (50)
+	// if the pool sets none. There is no CRD default, so an explicit 0 is
+	// kept apart from an unset priority.
 	// +kubebuilder:validation:Minimum=0
 	// +kubebuilder:validation:Maximum=99999
-	// +kubebuilder:default=50
 	// +optional
-	Priority int `json:"priority,omitempty"`
+	Priority *int `json:"priority,omitempty"`
 
 	// Files is the list of files to manage on the host.
 	// +optional
@@ -113,6 +130,41 @@ type MachineConfigSpec struct {
 	// Reboot defines reboot requirements for this configuration.
 	// +optional
 	Reboot RebootRequirementSpec `json:"reboot,omitempty"`
+
+	// PrepullImages lists container images the agent pulls before rebooting
+	// the node, so workloads (e.g. updated static pods) can start without
+	// waiting for a pull after the reboot.
+	// +listType=set
+	// +optional
+	PrepullImages []string `json:"prepullImages,omitempty"`
+
+	// KernelArgs lists kernel boot arguments (e.g. console=ttyS0,
+	// hugepages=16). An entry prefixed with "-" removes an argument added by
+	// a lower-priority MachineConfig: "-name" removes name and any
+	// name=value, "-name=value" only that exact argument. Any kernel
+	// arguments make the config require a reboot.
+	// +kubebuilder:validation:items:Pattern=`^[^\s"'$\\]+$`
+	// +kubebuilder:validation:items:MaxLength=256
+	// +optional
+	KernelArgs []string `json:"kernelArgs,omitempty"`
+
+	// ExclusiveGroup marks MachineConfigs that must never be combined, e.g.
+	// two network plugins sharing the group "network-plugin". A pool that
+	// selects more than one MachineConfig of the same group is not
+	// rendered and reports the conflict in its Degraded condition.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	ExclusiveGroup string `json:"exclusiveGroup,omitempty"`
+}
+
+// GetPriority returns the merge priority: Priority when set, otherwise
+// DefaultPriority.
+func (s *MachineConfigSpec) GetPriority() int {
+	if s.Priority == nil {
+		return DefaultPriority
+	}
+	return *s.Priority
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,119 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MachineConfigFleetName is the name of the single MachineConfigFleet the
+// controller maintains.
+const MachineConfigFleetName = "cluster"
+
+// PoolSummary is the rollout summary of one MachineConfigPool.
+type PoolSummary struct {
+	// Name is the name of the pool.
+	Name string `json:"name"`
+
+	// TargetRevision is the pool's target RenderedMachineConfig.
+	// +optional
+	TargetRevision string `json:"targetRevision,omitempty"`
+
+	// LastSuccessfulRevision is the last revision the whole pool reached.
+	// +optional
+	LastSuccessfulRevision string `json:"lastSuccessfulRevision,omitempty"`
+
+	// MachineCount is the total number of nodes in the pool.
+	MachineCount int `json:"machineCount"`
+
+	// UpdatedMachineCount is the number of nodes running the target revision.
+	UpdatedMachineCount int `json:"updatedMachineCount"`
+
+	// DegradedMachineCount is the number of nodes in the error state.
+	DegradedMachineCount int `json:"degradedMachineCount"`
+
+	// Ready mirrors the pool's Ready condition.
+	Ready bool `json:"ready"`
+
+	// Updating mirrors the pool's Updating condition.
+	Updating bool `json:"updating"`
+
+	// Degraded mirrors the pool's Degraded condition.
+	Degraded bool `json:"degraded"`
+}
+
+// MachineConfigFleetStatus summarizes the rollout state of all pools.
+type MachineConfigFleetStatus struct {
+	// Pools holds one summary per MachineConfigPool, sorted by name.
+	// +optional
+	Pools []PoolSummary `json:"pools,omitempty"`
+
+	// PoolCount is the number of MachineConfigPools.
+	PoolCount int `json:"poolCount"`
+
+	// ReadyPoolCount is the number of pools whose Ready condition is True.
+	ReadyPoolCount int `json:"readyPoolCount"`
+
+	// UpdatingPoolCount is the number of pools whose Updating condition is True.
+	UpdatingPoolCount int `json:"updatingPoolCount"`
+
+	// DegradedPoolCount is the number of pools whose Degraded condition is True.
+	DegradedPoolCount int `json:"degradedPoolCount"`
+
+	// MachineCount is the total number of nodes across all pools.
+	MachineCount int `json:"machineCount"`
+
+	// UpdatedMachineCount is the number of nodes running their pool's target.
+	UpdatedMachineCount int `json:"updatedMachineCount"`
+
+	// DegradedMachineCount is the number of nodes in the error state.
+	DegradedMachineCount int `json:"degradedMachineCount"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=mcf
+// +kubebuilder:printcolumn:name="Pools",type=integer,JSONPath=`.status.poolCount`
+// +kubebuilder:printcolumn:name="Ready",type=integer,JSONPath=`.status.readyPoolCount`
+// +kubebuilder:printcolumn:name="Updating",type=integer,JSONPath=`.status.updatingPoolCount`
+// +kubebuilder:printcolumn:name="Degraded",type=integer,JSONPath=`.status.degradedPoolCount`
+// +kubebuilder:printcolumn:name="Machines",type=integer,JSONPath=`.status.machineCount`
+// +kubebuilder:printcolumn:name="Updated",type=integer,JSONPath=`.status.updatedMachineCount`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// MachineConfigFleet aggregates the rollout status of every
+// MachineConfigPool. The controller creates and maintains a single object
+// named "cluster"; it has no spec.
+type MachineConfigFleet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status MachineConfigFleetStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MachineConfigFleetList contains a list of MachineConfigFleet.
+type MachineConfigFleetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MachineConfigFleet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MachineConfigFleet{}, &MachineConfigFleetList{})
+}
@@ -21,6 +21,13 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// MachineConfigReference refers to a MachineConfig by name.
+type MachineConfigReference struct {
+	// Name of the MachineConfig.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
 // RolloutConfig defines rollout behavior for the pool.
 type RolloutConfig struct {
 	// DebounceSeconds is the time to wait after a config change before
@@ -40,12 +47,92 @@ type RolloutConfig struct {
 	// +optional
 	ApplyTimeoutSeconds int `json:"applyTimeoutSeconds,omitempty"`
 
+	// NotReadyToleranceSeconds is how long a node may be NotReady before it
+	// counts as degraded. Short NotReady periods (e.g. a kubelet restart or
+	// reboot) do not flap the Degraded condition.
+	// +kubebuilder:validation:Minimum=30
+	// +kubebuilder:validation:Maximum=3600
+	// +kubebuilder:default=300
+	// +optional
+	NotReadyToleranceSeconds int `json:"notReadyToleranceSeconds,omitempty"`
+
+	// NewNodeGraceSeconds is how long after a node registers MCO waits
+	// before starting its first update, so the node's initial workloads can
+	// settle before it is cordoned. Only nodes MCO has never configured are
+	// held back. 0 disables the grace period.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	NewNodeGraceSeconds int `json:"newNodeGraceSeconds,omitempty"`
+
+	// HoldCordonOnError keeps nodes whose agent reported an error cordoned,
+	// so workloads don't land on them while they are investigated, even
+	// when UncordonOnCordonTimeout would release them. Use the retry-failed
+	// pool annotation or uncordon manually once resolved.
+	// +kubebuilder:default=false
+	// +optional
+	HoldCordonOnError bool `json:"holdCordonOnError,omitempty"`
+
+	// AnnotateSkipReasons records on each node that needs the target
+	// revision but is not being updated why it was skipped, in the
+	// mco.in-cloud.io/skip-reason annotation.
+	// +kubebuilder:default=false
+	// +optional
+	AnnotateSkipReasons bool `json:"annotateSkipReasons,omitempty"`
+
+	// UpdateStrategy controls which nodes are picked for update.
+	// - RollingUpdate: nodes are updated automatically within maxUnavailable.
+	// - OnDelete: only nodes the operator asks for, with the
+	//   mco.in-cloud.io/update-requested annotation or a manual cordon, are
+	//   updated. Nodes MCO has never configured still get their first config.
+	// +kubebuilder:validation:Enum=RollingUpdate;OnDelete
+	// +kubebuilder:default="RollingUpdate"
+	// +optional
+	UpdateStrategy string `json:"updateStrategy,omitempty"`
+
+	// PoolTransferPolicy controls nodes relabeled into this pool from
+	// another pool, possibly in the middle of that pool's rollout.
+	// - Release: the cordon, drain state and not yet applied desired
+	//   revision left by the previous pool are cleared, and the node is
+	//   then updated like any other node of this pool.
+	// - Adopt: a node the previous pool cordoned stays cordoned and this
+	//   pool continues its update towards its own target revision.
+	// +kubebuilder:validation:Enum=Release;Adopt
+	// +kubebuilder:default="Release"
+	// +optional
+	PoolTransferPolicy string `json:"poolTransferPolicy,omitempty"`
+
 	// MaxUnavailable is the maximum number of nodes that can be unavailable
 	// during an update. Value can be an absolute number (ex: 5) or a percentage
 	// of total nodes (ex: "10%"). Defaults to 1.
 	// +optional
 	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 
+	// MaxSurge is how many nodes may be updated on top of MaxUnavailable,
+	// for pools backing workloads that tolerate bringing replacement
+	// capacity up first. Value can be an absolute number (ex: 2) or a
+	// percentage of total nodes (ex: "25%"). When set, up to
+	// MaxUnavailable + MaxSurge nodes update at once, and MaxUnavailable
+	// may be 0. Defaults to 0.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// MinHealthyNodes is how many nodes of the pool must stay healthy
+	// (Ready, schedulable, not updating and without agent errors). No new
+	// node is started if that would leave fewer healthy nodes. Value can
+	// be an absolute number (ex: 3) or a percentage of total nodes
+	// (ex: "80%"). Unset means no minimum.
+	// +optional
+	MinHealthyNodes *intstr.IntOrString `json:"minHealthyNodes,omitempty"`
+
+	// Canary is how many nodes update first when a new target revision is
+	// selected. The rest of the pool waits until every canary runs the
+	// target with agent state done; a failed or timed-out canary holds the
+	// rollout. Value can be an absolute number (ex: 1) or a percentage of
+	// total nodes (ex: "10%"). Unset disables the canary phase.
+	// +optional
+	Canary *intstr.IntOrString `json:"canary,omitempty"`
+
 	// DrainTimeoutSeconds is the maximum time in seconds to wait for a node drain
 	// to complete before marking it as stuck. The drain will continue retrying.
 	// Defaults to 3600 (1 hour).
@@ -55,6 +142,30 @@ type RolloutConfig struct {
 	// +optional
 	DrainTimeoutSeconds int `json:"drainTimeoutSeconds,omitempty"`
 
+	// ForceDrainAfterStuck deletes the pods still blocking a drain with a
+	// zero grace period once the node is drain-stuck (DrainTimeoutSeconds
+	// exceeded). MCO, DaemonSet and excluded pods are left alone. This
+	// bypasses PodDisruptionBudgets and graceful termination.
+	// +kubebuilder:default=false
+	// +optional
+	ForceDrainAfterStuck bool `json:"forceDrainAfterStuck,omitempty"`
+
+	// CordonTimeoutSeconds is how long a node may stay cordoned by MCO
+	// before its drain starts or it is handed its desired revision. Past it,
+	// the CordonStuck condition is set. 0 means no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=86400
+	// +optional
+	CordonTimeoutSeconds int `json:"cordonTimeoutSeconds,omitempty"`
+
+	// UncordonOnCordonTimeout uncordons a node stuck past
+	// CordonTimeoutSeconds so it is not left unschedulable. It is cordoned
+	// again when next selected for update. Failed nodes stay cordoned when
+	// HoldCordonOnError is set.
+	// +kubebuilder:default=false
+	// +optional
+	UncordonOnCordonTimeout bool `json:"uncordonOnCordonTimeout,omitempty"`
+
 	// DrainRetrySeconds is the interval between drain retry attempts.
 	// If not specified, calculated as max(30, drainTimeoutSeconds/12).
 	// This allows approximately 12 retry attempts before timeout.
@@ -62,6 +173,130 @@ type RolloutConfig struct {
 	// +kubebuilder:validation:Maximum=1800
 	// +optional
 	DrainRetrySeconds int `json:"drainRetrySeconds,omitempty"`
+
+	// SkipDrainWithoutReboot applies changes that need no reboot and
+	// stop, restart or mask no unit without draining the node. The node is
+	// still cordoned while the agent applies them.
+	// +kubebuilder:default=false
+	// +optional
+	SkipDrainWithoutReboot bool `json:"skipDrainWithoutReboot,omitempty"`
+
+	// DisableEviction makes drain delete pods directly instead of using the
+	// eviction API, bypassing PodDisruptionBudgets. Equivalent to
+	// `kubectl drain --disable-eviction`. Use only where PDBs are known to be
+	// broken. DaemonSet, mirror and MCO pods are still skipped.
+	// +kubebuilder:default=false
+	// +optional
+	DisableEviction bool `json:"disableEviction,omitempty"`
+
+	// DrainIgnoreDaemonSets leaves DaemonSet pods running during drain,
+	// like `kubectl drain --ignore-daemonsets`. When false they are evicted
+	// too; their DaemonSet usually recreates them on the cordoned node.
+	// Defaults to true.
+	// +optional
+	DrainIgnoreDaemonSets *bool `json:"drainIgnoreDaemonSets,omitempty"`
+
+	// DrainDeleteOrphans evicts pods that have no controller. They are not
+	// recreated elsewhere. When false such pods are left on the node.
+	// Defaults to true.
+	// +optional
+	DrainDeleteOrphans *bool `json:"drainDeleteOrphans,omitempty"`
+
+	// DrainDeleteEmptyDirData allows drain to evict pods with emptyDir
+	// volumes, losing that data, like `kubectl drain --delete-emptydir-data`.
+	// When false such pods block the drain, so the node is never updated
+	// or rebooted under them, until they are moved or this is set.
+	// Defaults to false.
+	// +optional
+	DrainDeleteEmptyDirData *bool `json:"drainDeleteEmptyDirData,omitempty"`
+
+	// DrainEvictionConcurrency is how many pods of one node are evicted (or
+	// deleted) in parallel during drain. Evictions still go through the
+	// controller's cluster-wide write rate limit. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=50
+	// +optional
+	DrainEvictionConcurrency int `json:"drainEvictionConcurrency,omitempty"`
+
+	// MaxPodEvictions caps the number of pods drains may evict (or delete)
+	// while rolling out one revision. Once reached, drains pause and no new
+	// nodes are started until the cap is raised or a new revision begins.
+	// 0 means no cap.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxPodEvictions int `json:"maxPodEvictions,omitempty"`
+
+	// MaxRolloutDurationSeconds is how long a rollout may run, from when the
+	// target revision changed until every node is updated, before the
+	// RolloutTimeout condition is set. 0 means no limit.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxRolloutDurationSeconds int `json:"maxRolloutDurationSeconds,omitempty"`
+
+	// HaltOnRolloutTimeout stops starting new nodes once the rollout ran
+	// past MaxRolloutDurationSeconds. Nodes already updating are finished.
+	// +kubebuilder:default=false
+	// +optional
+	HaltOnRolloutTimeout bool `json:"haltOnRolloutTimeout,omitempty"`
+
+	// SoakSeconds is how long to wait after a node finishes updating before
+	// the next node is started, giving monitoring time to catch regressions.
+	// 0 disables the soak period.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=86400
+	// +optional
+	SoakSeconds int `json:"soakSeconds,omitempty"`
+
+	// MaintenanceWindows restrict when new nodes are started. Outside every
+	// window nodes already updating finish, but no further node begins.
+	// Empty means rollouts may start at any time.
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
+	// AutoRollback rolls nodes back to status.lastSuccessfulRevision when
+	// the pool stays Degraded for AutoRollbackAfterSeconds while nodes fail
+	// on the new target revision. The failed revision is not rolled out
+	// again until the pool's MachineConfigs change.
+	// +kubebuilder:default=false
+	// +optional
+	AutoRollback bool `json:"autoRollback,omitempty"`
+
+	// AutoRollbackAfterSeconds is how long the pool must be Degraded before
+	// AutoRollback kicks in. Defaults to 600.
+	// +kubebuilder:validation:Minimum=60
+	// +kubebuilder:validation:Maximum=86400
+	// +optional
+	AutoRollbackAfterSeconds int `json:"autoRollbackAfterSeconds,omitempty"`
+
+	// OnUpdateLabels are set on a node once it runs the pool's target
+	// revision and its agent is done, and removed again while it does not,
+	// e.g. to signal readiness to other systems.
+	// +optional
+	OnUpdateLabels map[string]string `json:"onUpdateLabels,omitempty"`
+}
+
+// MaintenanceWindow is a weekly recurring period in which a rollout may
+// start new nodes. A window may extend past midnight into the next day.
+type MaintenanceWindow struct {
+	// Days are the days of the week the window opens on.
+	// Empty means every day.
+	// +kubebuilder:validation:items:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+	// +optional
+	Days []string `json:"days,omitempty"`
+
+	// Start is the time of day the window opens, as HH:MM in 24h format.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// DurationMinutes is how long the window stays open.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10080
+	DurationMinutes int `json:"durationMinutes"`
+
+	// TimeZone is the IANA time zone Start is given in, e.g.
+	// "Europe/Moscow". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
 }
 
 // RebootPolicy defines the reboot behavior for nodes in the pool.
@@ -69,7 +304,10 @@ type RebootPolicy struct {
 	// Strategy determines when nodes are allowed to reboot.
 	// - Never: Nodes never reboot automatically (manual intervention required)
 	// - IfRequired: Nodes reboot when a MachineConfig requires it
-	// +kubebuilder:validation:Enum=Never;IfRequired
+	// - Kexec: Like IfRequired, but the agent kexecs into the kernel instead
+	//   of a full reboot, skipping firmware init. Falls back to a full
+	//   reboot when kexec is unavailable on the host.
+	// +kubebuilder:validation:Enum=Never;IfRequired;Kexec
 	// +kubebuilder:default="Never"
 	// +optional
 	Strategy string `json:"strategy,omitempty"`
@@ -82,6 +320,40 @@ type RebootPolicy struct {
 	MinIntervalSeconds int `json:"minIntervalSeconds,omitempty"`
 }
 
+// CandidateConfig renders a second, candidate configuration for a labeled
+// subset of the pool's nodes, so it can be compared with the rest of the
+// pool before it is promoted.
+type CandidateConfig struct {
+	// NodeSelector selects the pool nodes that run the candidate revision.
+	// All other pool nodes stay on the regular target revision.
+	// +required
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector"`
+
+	// MachineConfigSelector selects additional MachineConfigs merged on top of
+	// the pool's MachineConfigs to render the candidate revision.
+	// +required
+	MachineConfigSelector *metav1.LabelSelector `json:"machineConfigSelector"`
+}
+
+// UnitRestartPolicy controls how the agent cycles units whose state is
+// "restarted" or "reloaded" when no reboot is involved.
+type UnitRestartPolicy struct {
+	// DelaySeconds is the pause between consecutive unit restarts on a node.
+	// When set (or when Order is set), restarts are deferred until all other
+	// unit changes are applied and then performed one by one.
+	// 0 restarts units inline as they are applied.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=300
+	// +optional
+	DelaySeconds int `json:"delaySeconds,omitempty"`
+
+	// Order lists unit names to restart first, in the given order.
+	// Remaining units are restarted afterwards, sorted by name.
+	// +optional
+	// +listType=atomic
+	Order []string `json:"order,omitempty"`
+}
+
 // RevisionHistoryConfig defines how many old RenderedMachineConfigs to keep.
 type RevisionHistoryConfig struct {
 	// Limit is the maximum number of old RenderedMachineConfigs to retain.
@@ -114,6 +386,65 @@ type MachineConfigPoolSpec struct {
 	// +optional
 	RevisionHistory RevisionHistoryConfig `json:"revisionHistory,omitempty"`
 
+	// BaseConfigRef names a MachineConfig that is always merged into this
+	// pool's configuration below every selected MachineConfig, whatever its
+	// labels or priority. While it does not exist the pool is not rendered
+	// and reports Degraded with reason RenderFailed.
+	// +optional
+	BaseConfigRef *MachineConfigReference `json:"baseConfigRef,omitempty"`
+
+	// PropagateAnnotations lists MachineConfig annotation keys (e.g. a git
+	// commit annotation) copied onto the RenderedMachineConfig. When several
+	// MachineConfigs set a key, the highest-priority one wins and the key is
+	// listed in the RMC's mco.in-cloud.io/annotation-conflicts annotation.
+	// +optional
+	PropagateAnnotations []string `json:"propagateAnnotations,omitempty"`
+
+	// DefaultMachineConfigPriority is the merge priority used for selected
+	// MachineConfigs that leave priority unset. An explicit priority,
+	// including 0, is kept. When nil, such configs get DefaultPriority (50).
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=99999
+	// +optional
+	DefaultMachineConfigPriority *int `json:"defaultMachineConfigPriority,omitempty"`
+
+	// RevertWhenEmpty rolls out a revert of the last target revision once
+	// every MachineConfig of the pool is deleted: files the agent created
+	// are removed, files that were on the host before keep their last
+	// content, masks are lifted and kernel arguments dropped. Units it
+	// enabled or started are left running. When false, nodes keep the last
+	// applied config.
+	// +kubebuilder:default=false
+	// +optional
+	RevertWhenEmpty bool `json:"revertWhenEmpty,omitempty"`
+
+	// UnitRestart staggers unit restarts on each node to reduce disruption.
+	// +optional
+	UnitRestart UnitRestartPolicy `json:"unitRestart,omitempty"`
+
+	// MirrorNodeLabels mirrors pool membership and the node's current
+	// revision into node labels (mco.in-cloud.io/pool, mco.in-cloud.io/revision)
+	// so external tooling can select nodes by config state.
+	// +kubebuilder:default=false
+	// +optional
+	MirrorNodeLabels bool `json:"mirrorNodeLabels,omitempty"`
+
+	// EventVerbosity controls which lifecycle events are emitted for this pool.
+	// - All: every event, including per-node cordon/drain/uncordon
+	// - Reduced: rollout batches, completion and problems only
+	// - Errors: only events reporting problems (drain stuck/failed, timeouts, overlap)
+	// +kubebuilder:validation:Enum=All;Reduced;Errors
+	// +kubebuilder:default="All"
+	// +optional
+	EventVerbosity string `json:"eventVerbosity,omitempty"`
+
+	// Candidate, when set, routes the nodes it selects to a candidate
+	// revision rendered with extra MachineConfigs (blue/green testing).
+	// To promote, label the candidate MachineConfigs for the pool and
+	// remove this field.
+	// +optional
+	Candidate *CandidateConfig `json:"candidate,omitempty"`
+
 	// Paused stops all reconciliation for this pool when set to true.
 	// No new RenderedMachineConfigs will be created and no nodes will be updated.
 	// +kubebuilder:default=false
@@ -138,6 +469,20 @@ type MachineConfigPoolStatus struct {
 	// +optional
 	LastSuccessfulRevision string `json:"lastSuccessfulRevision,omitempty"`
 
+	// CandidateRevision is the RenderedMachineConfig candidate nodes are
+	// converging to. Empty when the pool has no candidate.
+	// +optional
+	CandidateRevision string `json:"candidateRevision,omitempty"`
+
+	// CandidateMachineCount is the number of nodes routed to the candidate.
+	// +optional
+	CandidateMachineCount int `json:"candidateMachineCount,omitempty"`
+
+	// CandidateUpdatedMachineCount is the number of candidate nodes running
+	// the candidate revision.
+	// +optional
+	CandidateUpdatedMachineCount int `json:"candidateUpdatedMachineCount,omitempty"`
+
 	// MachineCount is the total number of nodes in this pool.
 	MachineCount int `json:"machineCount"`
 
@@ -165,6 +510,105 @@ type MachineConfigPoolStatus struct {
 	// DrainingMachineCount is the number of nodes that are being drained.
 	DrainingMachineCount int `json:"drainingMachineCount"`
 
+	// MaintenanceMachineCount is the number of nodes annotated for
+	// maintenance. MCO takes no action on them; they are not counted as
+	// degraded, unavailable, updating, cordoned or draining.
+	// +optional
+	MaintenanceMachineCount int `json:"maintenanceMachineCount"`
+
+	// UnacknowledgedMachineCount is the number of nodes that were given the
+	// target revision but whose agent has not started applying it within the
+	// acknowledgement grace period (e.g. the agent is not running).
+	// +optional
+	UnacknowledgedMachineCount int `json:"unacknowledgedMachineCount"`
+
+	// OldestUpdatingAgeSeconds is how long the longest-running node update has
+	// been going, measured from when its desired revision was set.
+	// Zero when no node is updating.
+	// +optional
+	OldestUpdatingAgeSeconds int64 `json:"oldestUpdatingAgeSeconds"`
+
+	// DrainDurations records the most recent drain duration of each node,
+	// measured from drain start to drain completion.
+	// +optional
+	// +listType=map
+	// +listMapKey=node
+	DrainDurations []NodeDrainDuration `json:"drainDurations,omitempty"`
+
+	// NodeStatuses lists the rollout state of the pool's nodes, sorted by
+	// node name. Large pools list at most 100 nodes, preferring nodes that
+	// are not updated and ready; NodeStatusesTruncated is then set.
+	// +optional
+	// +listType=map
+	// +listMapKey=node
+	NodeStatuses []NodeStatus `json:"nodeStatuses,omitempty"`
+
+	// NodeStatusesTruncated is true when NodeStatuses omits some nodes.
+	// +optional
+	NodeStatusesTruncated bool `json:"nodeStatusesTruncated,omitempty"`
+
+	// SkippedMachines counts nodes that need the target revision but were
+	// not selected for update in the last reconcile, by reason
+	// (paused, maintenance, overlap, unavailable, frozen, halted,
+	// eviction-limit, soaking, drain-config-invalid, outside-window,
+	// min-healthy, not-requested, pdb-blocked, new-node-grace, budget).
+	// +optional
+	// +listType=map
+	// +listMapKey=reason
+	SkippedMachines []SkippedMachineCount `json:"skippedMachines,omitempty"`
+
+	// PodEvictions counts pods removed by drains while rolling out the
+	// current target revision.
+	// +optional
+	PodEvictions *PodEvictionCount `json:"podEvictions,omitempty"`
+
+	// RolloutStartedAt is when the rollout of the current target revision
+	// started. Cleared once every node is updated.
+	// +optional
+	RolloutStartedAt *metav1.Time `json:"rolloutStartedAt,omitempty"`
+
+	// RolledBackRevision is the target revision AutoRollback abandoned in
+	// favor of LastSuccessfulRevision. Cleared once the MachineConfigs
+	// render a different revision.
+	// +optional
+	RolledBackRevision string `json:"rolledBackRevision,omitempty"`
+
+	// LastNodeCompletedAt is when a node of the pool last finished updating
+	// and was uncordoned. rollout.soakSeconds is measured from it.
+	// +optional
+	LastNodeCompletedAt *metav1.Time `json:"lastNodeCompletedAt,omitempty"`
+
+	// RolloutFailures lists the nodes that failed while rolling out the
+	// current target revision, for the rollout report. Cleared once every
+	// node is updated.
+	// +optional
+	// +listType=map
+	// +listMapKey=node
+	RolloutFailures []NodeRolloutFailure `json:"rolloutFailures,omitempty"`
+
+	// RenderValidationFailures lists the files and units that failed
+	// validation in the latest render. Such a render is never created as a
+	// RenderedMachineConfig, so its results are kept here, next to the
+	// Degraded condition with reason RenderFailed. Cleared once a render
+	// passes validation.
+	// +optional
+	// +listType=atomic
+	RenderValidationFailures []ValidationResult `json:"renderValidationFailures,omitempty"`
+
+	// ReferencedRevisions lists the RenderedMachineConfigs named by at least
+	// one node's current or desired revision. The cleaner never deletes them.
+	// +optional
+	// +listType=set
+	ReferencedRevisions []string `json:"referencedRevisions,omitempty"`
+
+	// ManagedFileCount is the number of files in the target RenderedMachineConfig.
+	// +optional
+	ManagedFileCount int `json:"managedFileCount"`
+
+	// ManagedUnitCount is the number of systemd units in the target RenderedMachineConfig.
+	// +optional
+	ManagedUnitCount int `json:"managedUnitCount"`
+
 	// Conditions represent the latest available observations of the pool's state.
 	// +optional
 	// +patchMergeKey=type
@@ -174,6 +618,89 @@ type MachineConfigPoolStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
+// NodeDrainDuration is the measured duration of a node's last drain.
+type NodeDrainDuration struct {
+	// Node is the node name.
+	Node string `json:"node"`
+
+	// DurationSeconds is the time from drain start to drain completion.
+	DurationSeconds int64 `json:"durationSeconds"`
+
+	// CompletedAt is when the drain finished.
+	CompletedAt metav1.Time `json:"completedAt"`
+}
+
+// NodeStatus is the rollout state of a single node.
+type NodeStatus struct {
+	// Node is the node name.
+	Node string `json:"node"`
+
+	// CurrentRevision is the revision the agent last applied.
+	// +optional
+	CurrentRevision string `json:"currentRevision,omitempty"`
+
+	// DesiredRevision is the revision the controller handed the node.
+	// +optional
+	DesiredRevision string `json:"desiredRevision,omitempty"`
+
+	// AgentState is the agent's reported state (idle, applying, done, error).
+	// +optional
+	AgentState string `json:"agentState,omitempty"`
+
+	// Cordoned is true while the node is unschedulable.
+	// +optional
+	Cordoned bool `json:"cordoned,omitempty"`
+
+	// Draining is true between drain start and drain completion.
+	// +optional
+	Draining bool `json:"draining,omitempty"`
+
+	// TimedOut is true when the node has been applying for longer than
+	// rollout.applyTimeoutSeconds.
+	// +optional
+	TimedOut bool `json:"timedOut,omitempty"`
+
+	// RebootPendingReason says why a reboot the node needs has not run:
+	// StrategyNever (suppressed by the reboot strategy), MinInterval
+	// (deferred until the minimum interval has elapsed) or Unknown (not
+	// reported by the agent). Empty when no reboot is pending.
+	// +optional
+	RebootPendingReason string `json:"rebootPendingReason,omitempty"`
+}
+
+// NodeRolloutFailure is a failure seen on a node during a rollout.
+type NodeRolloutFailure struct {
+	// Node is the node name.
+	Node string `json:"node"`
+
+	// Revision is the target revision the node failed to roll out.
+	Revision string `json:"revision"`
+
+	// Message is the latest error reported for the node.
+	Message string `json:"message"`
+
+	// FirstSeenAt is when the failure was first observed.
+	FirstSeenAt metav1.Time `json:"firstSeenAt"`
+}
+
+// SkippedMachineCount is the number of nodes skipped for one reason.
+type SkippedMachineCount struct {
+	// Reason is why the nodes were not selected for update.
+	Reason string `json:"reason"`
+
+	// Count is the number of nodes skipped for this reason.
+	Count int `json:"count"`
+}
+
+// PodEvictionCount is the number of pods drained during one rollout.
+type PodEvictionCount struct {
+	// Revision is the target revision the count belongs to.
+	Revision string `json:"revision"`
+
+	// Count is the number of pods evicted or deleted by drains.
+	Count int `json:"count"`
+}
+
 // Condition types for MachineConfigPool.
 const (
 	// ConditionReady indicates the pool is fully updated and healthy.
@@ -199,6 +726,74 @@ const (
 
 	// ConditionDrainStuck indicates drain has been stuck for longer than timeout.
 	ConditionDrainStuck string = "DrainStuck"
+
+	// ConditionNodeUpdateFailed indicates the controller failed to advance
+	// one or more nodes through the update lifecycle in the last reconcile.
+	// Other nodes in the batch keep progressing.
+	// Reasons: APIError, AllNodesProcessed
+	ConditionNodeUpdateFailed string = "NodeUpdateFailed"
+
+	// ConditionNodesUnacknowledged indicates some nodes have not picked up
+	// their desired revision within the acknowledgement grace period.
+	// Distinct from a slow apply: the agent never moved to applying.
+	// Reasons: AgentNotResponding, AllAcknowledged
+	ConditionNodesUnacknowledged string = "NodesUnacknowledged"
+
+	// ConditionFrozen indicates a ConfigFreeze is active: no new revision is
+	// rendered and no new nodes are started until it is lifted.
+	// Reasons: ConfigFreezeActive, ConfigFreezeLifted
+	ConditionFrozen string = "Frozen"
+
+	// ConditionCanaryComplete indicates the canary nodes run the target
+	// revision and the rest of the pool may update. Only set when
+	// rollout.canary is configured.
+	// Reasons: CanaryNodesUpdated, CanaryInProgress, CanaryFailed
+	ConditionCanaryComplete string = "CanaryComplete"
+
+	// ConditionEvictionLimitReached indicates the rollout evicted
+	// rollout.maxPodEvictions pods and further drains are paused.
+	// Reasons: MaxPodEvictionsReached, WithinLimit
+	ConditionEvictionLimitReached string = "EvictionLimitReached"
+
+	// ConditionRolloutComplete is True only when every node runs its target
+	// revision, is ready, and no node is degraded or waiting to reboot.
+	// A single condition for CI to wait on.
+	// Reasons: RolloutSucceeded, NodesNotUpdated, NodesNotReady, NodesDegraded,
+	// RebootPending, NoNodes
+	ConditionRolloutComplete string = "RolloutComplete"
+
+	// ConditionRolloutTimeout indicates the rollout has been running longer
+	// than rollout.maxRolloutDurationSeconds.
+	// Reasons: MaxRolloutDurationExceeded, WithinLimit
+	ConditionRolloutTimeout string = "RolloutTimeout"
+
+	// ConditionCordonStuck indicates nodes stayed cordoned past
+	// rollout.cordonTimeoutSeconds without their drain starting.
+	// Reasons: CordonTimeoutExceeded, NoCordonStuck
+	ConditionCordonStuck string = "CordonStuck"
+
+	// ConditionDebounceActive indicates a MachineConfig change is staged
+	// but not rendered yet because rollout.debounceSeconds has not elapsed.
+	// The message names the pending revision and the remaining wait.
+	// Reasons: ChangeStaged, NoPendingChange
+	ConditionDebounceActive string = "DebounceActive"
+
+	// ConditionDrainNoProgress indicates drains whose count of pods left to
+	// evict has not dropped over several checks, e.g. because something
+	// keeps recreating pods on the node. Unlike DrainStuck it does not wait
+	// for the drain timeout.
+	// Reasons: PodCountNotDecreasing, DrainProgressing
+	ConditionDrainNoProgress string = "DrainNoProgress"
+
+	// ConditionRolloutPausedWindow indicates the pool is outside all of
+	// its rollout.maintenanceWindows, so no new nodes are started.
+	// Reasons: OutsideMaintenanceWindow, InsideMaintenanceWindow
+	ConditionRolloutPausedWindow string = "RolloutPausedWindow"
+
+	// ConditionRolledBack indicates AutoRollback replaced a failing target
+	// revision with the last successful one.
+	// Reasons: DegradedTooLong, TargetChanged
+	ConditionRolledBack string = "RolledBack"
 )
 
 // +kubebuilder:object:root=true
@@ -31,6 +31,15 @@ type RenderedConfig struct {
 	// Units are deduplicated by name (higher priority wins).
 	// +optional
 	Systemd SystemdSpec `json:"systemd,omitempty"`
+
+	// PrepullImages is the union of images to pull before reboot, sorted.
+	// +optional
+	PrepullImages []string `json:"prepullImages,omitempty"`
+
+	// KernelArgs is the merged list of kernel boot arguments, in the order
+	// they were first added, with removals applied.
+	// +optional
+	KernelArgs []string `json:"kernelArgs,omitempty"`
 }
 
 // ConfigSource identifies a MachineConfig that contributed to this render.
@@ -49,7 +58,7 @@ type RenderedRebootSpec struct {
 	Required bool `json:"required"`
 
 	// Strategy is the reboot strategy from the MachineConfigPool.
-	// +kubebuilder:validation:Enum=Never;IfRequired
+	// +kubebuilder:validation:Enum=Never;IfRequired;Kexec
 	Strategy string `json:"strategy"`
 
 	// MinIntervalSeconds is the minimum time between reboots from the pool.
@@ -72,6 +81,16 @@ type RebootRequirements struct {
 	// Value: true if reboot is required when this unit changes
 	// +optional
 	Units map[string]bool `json:"units,omitempty"`
+
+	// FileSources maps file paths to the MachineConfig that contributed them.
+	// Used to explain reboot decisions; it does not affect the revision.
+	// +optional
+	FileSources map[string]string `json:"fileSources,omitempty"`
+
+	// UnitSources maps systemd unit names to the MachineConfig that
+	// contributed them.
+	// +optional
+	UnitSources map[string]string `json:"unitSources,omitempty"`
 }
 
 // RenderedMachineConfigSpec defines the desired state of RenderedMachineConfig.
@@ -109,9 +128,50 @@ type RenderedMachineConfigSpec struct {
 	// This is the OR of all source MachineConfigs' reboot.required fields.
 	// Used for first apply and fallback scenarios.
 	Reboot RenderedRebootSpec `json:"reboot"`
+
+	// UnitRestart is the unit restart policy from the MachineConfigPool.
+	// Like the reboot strategy, it is synced from the pool without creating
+	// a new revision.
+	// +optional
+	UnitRestart UnitRestartPolicy `json:"unitRestart,omitempty"`
+}
+
+// Validation result kinds.
+const (
+	// ValidationKindFile identifies a file entry.
+	ValidationKindFile = "File"
+	// ValidationKindUnit identifies a systemd unit entry.
+	ValidationKindUnit = "Unit"
+)
+
+// ValidationResult records the validation outcome of a single rendered entry.
+type ValidationResult struct {
+	// Kind is the type of the entry.
+	// +kubebuilder:validation:Enum=File;Unit
+	Kind string `json:"kind"`
+
+	// Name is the file path or unit name.
+	Name string `json:"name"`
+
+	// Valid is true when the entry passed validation.
+	Valid bool `json:"valid"`
+
+	// Message explains why validation failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// RenderedMachineConfigStatus defines the observed state of RenderedMachineConfig.
+type RenderedMachineConfigStatus struct {
+	// ValidationResults lists the validation outcome of every file and unit
+	// in this render, files first, in spec order.
+	// +optional
+	// +listType=atomic
+	ValidationResults []ValidationResult `json:"validationResults,omitempty"`
 }
 
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,shortName=rmc
 // +kubebuilder:printcolumn:name="Pool",type=string,JSONPath=`.spec.poolName`
 // +kubebuilder:printcolumn:name="Revision",type=string,JSONPath=`.spec.revision`
@@ -126,7 +186,8 @@ type RenderedMachineConfig struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec RenderedMachineConfigSpec `json:"spec,omitempty"`
+	Spec   RenderedMachineConfigSpec   `json:"spec,omitempty"`
+	Status RenderedMachineConfigStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
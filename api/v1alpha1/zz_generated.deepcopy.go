@@ -26,6 +26,104 @@ import (
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CandidateConfig) DeepCopyInto(out *CandidateConfig) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MachineConfigSelector != nil {
+		in, out := &in.MachineConfigSelector, &out.MachineConfigSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CandidateConfig.
+func (in *CandidateConfig) DeepCopy() *CandidateConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CandidateConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigFreeze) DeepCopyInto(out *ConfigFreeze) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigFreeze.
+func (in *ConfigFreeze) DeepCopy() *ConfigFreeze {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigFreeze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConfigFreeze) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigFreezeList) DeepCopyInto(out *ConfigFreezeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ConfigFreeze, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigFreezeList.
+func (in *ConfigFreezeList) DeepCopy() *ConfigFreezeList {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigFreezeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConfigFreezeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigFreezeSpec) DeepCopyInto(out *ConfigFreezeSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigFreezeSpec.
+func (in *ConfigFreezeSpec) DeepCopy() *ConfigFreezeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigFreezeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigSource) DeepCopyInto(out *ConfigSource) {
 	*out = *in
@@ -82,6 +180,84 @@ func (in *MachineConfig) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineConfigFleet) DeepCopyInto(out *MachineConfigFleet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineConfigFleet.
+func (in *MachineConfigFleet) DeepCopy() *MachineConfigFleet {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineConfigFleet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MachineConfigFleet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineConfigFleetList) DeepCopyInto(out *MachineConfigFleetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MachineConfigFleet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineConfigFleetList.
+func (in *MachineConfigFleetList) DeepCopy() *MachineConfigFleetList {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineConfigFleetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MachineConfigFleetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineConfigFleetStatus) DeepCopyInto(out *MachineConfigFleetStatus) {
+	*out = *in
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]PoolSummary, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineConfigFleetStatus.
+func (in *MachineConfigFleetStatus) DeepCopy() *MachineConfigFleetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineConfigFleetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineConfigList) DeepCopyInto(out *MachineConfigList) {
 	*out = *in
@@ -189,6 +365,27 @@ func (in *MachineConfigPoolSpec) DeepCopyInto(out *MachineConfigPoolSpec) {
 	in.Rollout.DeepCopyInto(&out.Rollout)
 	out.Reboot = in.Reboot
 	out.RevisionHistory = in.RevisionHistory
+	if in.BaseConfigRef != nil {
+		in, out := &in.BaseConfigRef, &out.BaseConfigRef
+		*out = new(MachineConfigReference)
+		**out = **in
+	}
+	if in.PropagateAnnotations != nil {
+		in, out := &in.PropagateAnnotations, &out.PropagateAnnotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultMachineConfigPriority != nil {
+		in, out := &in.DefaultMachineConfigPriority, &out.DefaultMachineConfigPriority
+		*out = new(int)
+		**out = **in
+	}
+	in.UnitRestart.DeepCopyInto(&out.UnitRestart)
+	if in.Candidate != nil {
+		in, out := &in.Candidate, &out.Candidate
+		*out = new(CandidateConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineConfigPoolSpec.
@@ -204,6 +401,53 @@ func (in *MachineConfigPoolSpec) DeepCopy() *MachineConfigPoolSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineConfigPoolStatus) DeepCopyInto(out *MachineConfigPoolStatus) {
 	*out = *in
+	if in.DrainDurations != nil {
+		in, out := &in.DrainDurations, &out.DrainDurations
+		*out = make([]NodeDrainDuration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeStatuses != nil {
+		in, out := &in.NodeStatuses, &out.NodeStatuses
+		*out = make([]NodeStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.SkippedMachines != nil {
+		in, out := &in.SkippedMachines, &out.SkippedMachines
+		*out = make([]SkippedMachineCount, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodEvictions != nil {
+		in, out := &in.PodEvictions, &out.PodEvictions
+		*out = new(PodEvictionCount)
+		**out = **in
+	}
+	if in.RolloutStartedAt != nil {
+		in, out := &in.RolloutStartedAt, &out.RolloutStartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.LastNodeCompletedAt != nil {
+		in, out := &in.LastNodeCompletedAt, &out.LastNodeCompletedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.RolloutFailures != nil {
+		in, out := &in.RolloutFailures, &out.RolloutFailures
+		*out = make([]NodeRolloutFailure, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RenderValidationFailures != nil {
+		in, out := &in.RenderValidationFailures, &out.RenderValidationFailures
+		*out = make([]ValidationResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReferencedRevisions != nil {
+		in, out := &in.ReferencedRevisions, &out.ReferencedRevisions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -223,9 +467,29 @@ func (in *MachineConfigPoolStatus) DeepCopy() *MachineConfigPoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineConfigReference) DeepCopyInto(out *MachineConfigReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineConfigReference.
+func (in *MachineConfigReference) DeepCopy() *MachineConfigReference {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineConfigReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineConfigSpec) DeepCopyInto(out *MachineConfigSpec) {
 	*out = *in
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int)
+		**out = **in
+	}
 	if in.Files != nil {
 		in, out := &in.Files, &out.Files
 		*out = make([]FileSpec, len(*in))
@@ -233,6 +497,16 @@ func (in *MachineConfigSpec) DeepCopyInto(out *MachineConfigSpec) {
 	}
 	in.Systemd.DeepCopyInto(&out.Systemd)
 	out.Reboot = in.Reboot
+	if in.PrepullImages != nil {
+		in, out := &in.PrepullImages, &out.PrepullImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KernelArgs != nil {
+		in, out := &in.KernelArgs, &out.KernelArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineConfigSpec.
@@ -245,6 +519,103 @@ func (in *MachineConfigSpec) DeepCopy() *MachineConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeDrainDuration) DeepCopyInto(out *NodeDrainDuration) {
+	*out = *in
+	in.CompletedAt.DeepCopyInto(&out.CompletedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeDrainDuration.
+func (in *NodeDrainDuration) DeepCopy() *NodeDrainDuration {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeDrainDuration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeRolloutFailure) DeepCopyInto(out *NodeRolloutFailure) {
+	*out = *in
+	in.FirstSeenAt.DeepCopyInto(&out.FirstSeenAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRolloutFailure.
+func (in *NodeRolloutFailure) DeepCopy() *NodeRolloutFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeRolloutFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeStatus) DeepCopyInto(out *NodeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeStatus.
+func (in *NodeStatus) DeepCopy() *NodeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodEvictionCount) DeepCopyInto(out *PodEvictionCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodEvictionCount.
+func (in *PodEvictionCount) DeepCopy() *PodEvictionCount {
+	if in == nil {
+		return nil
+	}
+	out := new(PodEvictionCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PoolSummary) DeepCopyInto(out *PoolSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PoolSummary.
+func (in *PoolSummary) DeepCopy() *PoolSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(PoolSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RebootPolicy) DeepCopyInto(out *RebootPolicy) {
 	*out = *in
@@ -292,6 +663,20 @@ func (in *RebootRequirements) DeepCopyInto(out *RebootRequirements) {
 			(*out)[key] = val
 		}
 	}
+	if in.FileSources != nil {
+		in, out := &in.FileSources, &out.FileSources
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.UnitSources != nil {
+		in, out := &in.UnitSources, &out.UnitSources
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RebootRequirements.
@@ -313,6 +698,16 @@ func (in *RenderedConfig) DeepCopyInto(out *RenderedConfig) {
 		copy(*out, *in)
 	}
 	in.Systemd.DeepCopyInto(&out.Systemd)
+	if in.PrepullImages != nil {
+		in, out := &in.PrepullImages, &out.PrepullImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KernelArgs != nil {
+		in, out := &in.KernelArgs, &out.KernelArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenderedConfig.
@@ -331,6 +726,7 @@ func (in *RenderedMachineConfig) DeepCopyInto(out *RenderedMachineConfig) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenderedMachineConfig.
@@ -394,6 +790,7 @@ func (in *RenderedMachineConfigSpec) DeepCopyInto(out *RenderedMachineConfigSpec
 	}
 	in.RebootRequirements.DeepCopyInto(&out.RebootRequirements)
 	out.Reboot = in.Reboot
+	in.UnitRestart.DeepCopyInto(&out.UnitRestart)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenderedMachineConfigSpec.
@@ -406,6 +803,26 @@ func (in *RenderedMachineConfigSpec) DeepCopy() *RenderedMachineConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RenderedMachineConfigStatus) DeepCopyInto(out *RenderedMachineConfigStatus) {
+	*out = *in
+	if in.ValidationResults != nil {
+		in, out := &in.ValidationResults, &out.ValidationResults
+		*out = make([]ValidationResult, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenderedMachineConfigStatus.
+func (in *RenderedMachineConfigStatus) DeepCopy() *RenderedMachineConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RenderedMachineConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RenderedRebootSpec) DeepCopyInto(out *RenderedRebootSpec) {
 	*out = *in
@@ -444,6 +861,50 @@ func (in *RolloutConfig) DeepCopyInto(out *RolloutConfig) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MinHealthyNodes != nil {
+		in, out := &in.MinHealthyNodes, &out.MinHealthyNodes
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.Canary != nil {
+		in, out := &in.Canary, &out.Canary
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.DrainIgnoreDaemonSets != nil {
+		in, out := &in.DrainIgnoreDaemonSets, &out.DrainIgnoreDaemonSets
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DrainDeleteOrphans != nil {
+		in, out := &in.DrainDeleteOrphans, &out.DrainDeleteOrphans
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DrainDeleteEmptyDirData != nil {
+		in, out := &in.DrainDeleteEmptyDirData, &out.DrainDeleteEmptyDirData
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OnUpdateLabels != nil {
+		in, out := &in.OnUpdateLabels, &out.OnUpdateLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutConfig.
@@ -456,6 +917,21 @@ func (in *RolloutConfig) DeepCopy() *RolloutConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SkippedMachineCount) DeepCopyInto(out *SkippedMachineCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SkippedMachineCount.
+func (in *SkippedMachineCount) DeepCopy() *SkippedMachineCount {
+	if in == nil {
+		return nil
+	}
+	out := new(SkippedMachineCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SystemdSpec) DeepCopyInto(out *SystemdSpec) {
 	*out = *in
@@ -478,6 +954,26 @@ func (in *SystemdSpec) DeepCopy() *SystemdSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UnitRestartPolicy) DeepCopyInto(out *UnitRestartPolicy) {
+	*out = *in
+	if in.Order != nil {
+		in, out := &in.Order, &out.Order
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UnitRestartPolicy.
+func (in *UnitRestartPolicy) DeepCopy() *UnitRestartPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(UnitRestartPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UnitSpec) DeepCopyInto(out *UnitSpec) {
 	*out = *in
@@ -497,3 +993,18 @@ func (in *UnitSpec) DeepCopy() *UnitSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValidationResult) DeepCopyInto(out *ValidationResult) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValidationResult.
+func (in *ValidationResult) DeepCopy() *ValidationResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ValidationResult)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -21,6 +21,8 @@ package main
 import (
 	"flag"
 	"os"
+	"strings"
+	"time"
 
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
@@ -31,6 +33,7 @@ import (
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
 	"in-cloud.io/machine-config/internal/agent"
+	"in-cloud.io/machine-config/internal/agent/reboot"
 	mcoclient "in-cloud.io/machine-config/pkg/client"
 )
 
@@ -43,10 +46,25 @@ func main() {
 	var hostRoot string
 	var skipSystemd bool
 	var noReboot bool
+	var rebootExecutor string
+	var verifyApply bool
+	var driftCheckInterval time.Duration
+	var postRebootVerifyCommand string
+	var allowDebugApplyPaths bool
 	flag.StringVar(&nodeName, "node-name", os.Getenv("NODE_NAME"), "Name of the node this agent runs on")
 	flag.StringVar(&hostRoot, "host-root", "/host", "Path prefix for host filesystem")
 	flag.BoolVar(&skipSystemd, "skip-systemd", false, "Skip systemd (for envs without systemd)")
 	flag.BoolVar(&noReboot, "no-reboot", false, "Disable actual reboots (use NoOpExecutor for testing)")
+	flag.StringVar(&rebootExecutor, "reboot-executor", reboot.ExecutorSystemd,
+		"How to reboot the node: "+strings.Join(reboot.ExecutorNames(), ", "))
+	flag.BoolVar(&verifyApply, "verify-apply", false,
+		"Re-read managed files after apply and only report the revision once their content matches")
+	flag.DurationVar(&driftCheckInterval, "drift-check-interval", 0,
+		"How often to restore hand-edited managed unit files of the current revision. 0 disables the check.")
+	flag.StringVar(&postRebootVerifyCommand, "post-reboot-verify-command", "",
+		"Shell command run on the host after a reboot; the node is only reported done once it succeeds")
+	flag.BoolVar(&allowDebugApplyPaths, "allow-debug-apply-paths", false,
+		"Honor the debug-apply-paths node annotation and apply only the listed files and units (debugging only)")
 
 	opts := zap.Options{
 		Development: true,
@@ -92,12 +110,17 @@ func main() {
 	}
 
 	agentInstance, err := agent.NewWithContext(ctx, agent.Config{
-		NodeName:    nodeName,
-		K8sClient:   k8sClient,
-		MCOClient:   mcoClient,
-		HostRoot:    hostRoot,
-		SystemdConn: systemdConn,
-		NoReboot:    noReboot,
+		NodeName:                nodeName,
+		K8sClient:               k8sClient,
+		MCOClient:               mcoClient,
+		HostRoot:                hostRoot,
+		SystemdConn:             systemdConn,
+		NoReboot:                noReboot,
+		RebootExecutor:          rebootExecutor,
+		VerifyApply:             verifyApply,
+		DriftCheckInterval:      driftCheckInterval,
+		PostRebootVerifyCommand: postRebootVerifyCommand,
+		AllowDebugApplyPaths:    allowDebugApplyPaths,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to create agent")
@@ -0,0 +1,85 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command checksum prints the content checksum of each MachineConfig in a
+// manifest, the value for its mco.in-cloud.io/content-checksum annotation.
+// It reads YAML or JSON, multi-document, from a file or stdin, and needs no
+// cluster access.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/checksum"
+)
+
+func main() {
+	var file string
+	flag.StringVar(&file, "f", "-", "File with MachineConfigs (YAML/JSON, multi-document); - for stdin")
+	flag.Parse()
+
+	if err := run(file, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run prints "<name> <checksum>" for every MachineConfig in file.
+func run(file string, out io.Writer) error {
+	var r io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+
+	found := false
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var mc mcov1alpha1.MachineConfig
+		if err := decoder.Decode(&mc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return fmt.Errorf("decode %s: %w", file, err)
+		}
+		if mc.Name == "" || (mc.Kind != "" && mc.Kind != "MachineConfig") {
+			continue
+		}
+		sum, err := checksum.MachineConfig(&mc)
+		if err != nil {
+			return fmt.Errorf("%s: %w", mc.Name, err)
+		}
+		if _, err := fmt.Fprintf(out, "%s %s\n", mc.Name, sum); err != nil {
+			return err
+		}
+		found = true
+	}
+	if !found {
+		return fmt.Errorf("no MachineConfigs in %s", file)
+	}
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 	"crypto/tls"
 	"flag"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -31,15 +32,18 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
 	"in-cloud.io/machine-config/internal/controller"
+	webhookv1alpha1 "in-cloud.io/machine-config/internal/webhook/v1alpha1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -63,6 +67,14 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var enableWebhooks bool
+	var maxReconcileDuration time.Duration
+	var reconcileFairness string
+	var reconcileStuckThreshold time.Duration
+	var ignoreSelfNodeUpdates bool
+	var statusConfigMap string
+	var nodeWriteQPS float64
+	var nodeWriteBurst int
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -81,6 +93,23 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"If set, the admission webhooks are served. Requires webhook certificates.")
+	flag.DurationVar(&maxReconcileDuration, "max-reconcile-duration", controller.DefaultMaxReconcileDuration,
+		"Maximum time a single pool reconcile spends processing nodes before requeuing the rest.")
+	flag.StringVar(&reconcileFairness, "reconcile-fairness", "",
+		"Order of pending pool reconciles: round-robin or oldest-pending-first. Empty keeps FIFO.")
+	flag.DurationVar(&reconcileStuckThreshold, "reconcile-stuck-threshold", controller.DefaultReconcileStuckThreshold,
+		"mco_pool_reconcile_stuck reports a pool that goes this long without a successful reconcile, "+
+			"and readiness fails when no pool reconcile returns for this long. 0 disables both.")
+	flag.BoolVar(&ignoreSelfNodeUpdates, "ignore-self-node-updates", true,
+		"Do not re-enqueue a pool for node updates that only change annotations the controller writes itself.")
+	flag.StringVar(&statusConfigMap, "status-configmap", "",
+		"Name of a ConfigMap in "+controller.MCONamespace+" to mirror pool status into, one <pool>.json key per pool. Empty disables it.")
+	flag.Float64Var(&nodeWriteQPS, "node-write-qps", 0,
+		"Maximum node writes, pod deletions and evictions per second during rollouts. 0 disables the limit.")
+	flag.IntVar(&nodeWriteBurst, "node-write-burst", 10,
+		"Burst allowed above --node-write-qps.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -149,6 +178,12 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "mco-controller.in-cloud.io",
+		// The controller only reads its own drain ConfigMap; don't cache every ConfigMap in the cluster.
+		Cache: cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				&corev1.ConfigMap{}: {Namespaces: map[string]cache.Config{controller.MCONamespace: {}}},
+			},
+		},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -177,14 +212,33 @@ func main() {
 	}
 
 	// Register MachineConfigPoolReconciler
-	if err := controller.NewMachineConfigPoolReconciler(
-		mgr.GetClient(),
-		mgr.GetScheme(),
-	).SetupWithManager(mgr); err != nil {
+	reconciler := controller.NewMachineConfigPoolReconciler(
+		controller.LimitNodeWrites(mgr.GetClient(), float32(nodeWriteQPS), nodeWriteBurst), mgr.GetScheme())
+	reconciler.MaxReconcileDuration = maxReconcileDuration
+	reconciler.Liveness = controller.NewReconcileLiveness(reconcileStuckThreshold)
+	metrics.Registry.MustRegister(reconciler.Liveness)
+	reconciler.IgnoreSelfNodeUpdates = ignoreSelfNodeUpdates
+	reconciler.StatusConfigMap = statusConfigMap
+	reconciler.Fairness, err = controller.ParseReconcileFairness(reconcileFairness)
+	if err != nil {
+		setupLog.Error(err, "invalid --reconcile-fairness")
+		os.Exit(1)
+	}
+	if err := reconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MachineConfigPool")
 		os.Exit(1)
 	}
 
+	if enableWebhooks {
+		if err := webhookv1alpha1.SetupMachineConfigWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "MachineConfig")
+			os.Exit(1)
+		}
+		if err := webhookv1alpha1.SetupRenderedMachineConfigWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "RenderedMachineConfig")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -195,6 +249,10 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if err := mgr.AddReadyzCheck("reconcile", reconciler.Liveness.Check); err != nil {
+		setupLog.Error(err, "unable to set up reconcile ready check")
+		os.Exit(1)
+	}
 
 	setupLog.Info("starting mco-controller")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
@@ -0,0 +1,186 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command simulate reports what rolling out proposed MachineConfigs to a
+// pool would do: the rendered config, which nodes would be cordoned and
+// drained, which would reboot, and the ordered actions each node's update
+// would take. It only reads from the cluster.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/yaml"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/internal/controller"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(mcov1alpha1.AddToScheme(scheme))
+}
+
+func main() {
+	var poolName string
+	var file string
+	var replace bool
+	var output string
+	flag.StringVar(&poolName, "pool", "", "Name of the MachineConfigPool to simulate")
+	flag.StringVar(&file, "f", "", "File with proposed MachineConfigs (YAML/JSON, multi-document); - for stdin")
+	flag.BoolVar(&replace, "replace", false,
+		"Treat the file as the complete set of MachineConfigs instead of overlaying it on the cluster's")
+	flag.StringVar(&output, "o", "yaml", "Output format: yaml or json")
+
+	opts := zap.Options{}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	if poolName == "" || file == "" {
+		fmt.Fprintln(os.Stderr, "--pool and -f are required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if output != "yaml" && output != "json" {
+		fmt.Fprintf(os.Stderr, "unsupported output format %q\n", output)
+		os.Exit(2)
+	}
+
+	if err := run(context.Background(), poolName, file, replace, output); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, poolName, file string, replace bool, output string) error {
+	proposed, err := readMachineConfigs(file)
+	if err != nil {
+		return err
+	}
+
+	c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("create client: %w", err)
+	}
+
+	pool := &mcov1alpha1.MachineConfigPool{}
+	if err := c.Get(ctx, types.NamespacedName{Name: poolName}, pool); err != nil {
+		return fmt.Errorf("get pool %s: %w", poolName, err)
+	}
+
+	configs := proposed
+	if !replace {
+		list := &mcov1alpha1.MachineConfigList{}
+		if err := c.List(ctx, list); err != nil {
+			return fmt.Errorf("list MachineConfigs: %w", err)
+		}
+		configs = overlay(list.Items, proposed)
+	}
+
+	sim, err := controller.SimulateRollout(ctx, c, pool, configs)
+	if err != nil {
+		return err
+	}
+
+	var out []byte
+	if output == "json" {
+		out, err = json.MarshalIndent(sim, "", "  ")
+	} else {
+		out, err = yaml.Marshal(sim)
+	}
+	if err != nil {
+		return fmt.Errorf("encode result: %w", err)
+	}
+	fmt.Println(string(out))
+
+	fmt.Fprintf(os.Stderr, "pool %s -> %s: drain %v, reboot %v\n",
+		sim.Pool, sim.RMC.Name, sim.NodesToDrain(), sim.NodesToReboot())
+	return nil
+}
+
+// readMachineConfigs decodes every MachineConfig document in file.
+func readMachineConfigs(file string) ([]mcov1alpha1.MachineConfig, error) {
+	var r io.Reader = os.Stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = f.Close() }()
+		r = f
+	}
+
+	var configs []mcov1alpha1.MachineConfig
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	for {
+		var mc mcov1alpha1.MachineConfig
+		if err := decoder.Decode(&mc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("decode %s: %w", file, err)
+		}
+		if mc.Name == "" {
+			continue
+		}
+		if mc.Kind != "" && mc.Kind != "MachineConfig" {
+			return nil, fmt.Errorf("%s: unexpected kind %s", mc.Name, mc.Kind)
+		}
+		configs = append(configs, mc)
+	}
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("no MachineConfigs in %s", file)
+	}
+	return configs, nil
+}
+
+// overlay replaces existing configs by name with proposed ones and appends
+// proposed configs that don't exist yet.
+func overlay(existing, proposed []mcov1alpha1.MachineConfig) []mcov1alpha1.MachineConfig {
+	byName := make(map[string]int, len(existing))
+	result := make([]mcov1alpha1.MachineConfig, len(existing))
+	copy(result, existing)
+	for i, mc := range result {
+		byName[mc.Name] = i
+	}
+	for _, mc := range proposed {
+		if i, ok := byName[mc.Name]; ok {
+			result[i] = mc
+			continue
+		}
+		result = append(result, mc)
+	}
+	return result
+}
@@ -34,6 +34,7 @@ import (
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
 	"in-cloud.io/machine-config/internal/agent/reboot"
+	"in-cloud.io/machine-config/internal/configdiff"
 	"in-cloud.io/machine-config/pkg/annotations"
 	mcoclient "in-cloud.io/machine-config/pkg/client"
 )
@@ -59,6 +60,36 @@ type Config struct {
 
 	// NoReboot disables actual reboots (uses NoOpExecutor for testing).
 	NoReboot bool
+
+	// RebootExecutor names the registered reboot executor to use
+	// (see reboot.ExecutorNames). Empty means systemd. Ignored with NoReboot.
+	RebootExecutor string
+
+	// VerifyApply re-reads managed files after apply and refuses to mark
+	// the revision current unless their content matches the RMC.
+	VerifyApply bool
+
+	// DriftCheckInterval is how often the agent compares managed unit files
+	// of the current revision with the host and restores hand edits.
+	// Zero disables the check.
+	DriftCheckInterval time.Duration
+
+	// ImagePuller pulls images listed in the RMC before reboot.
+	// If nil, images are pulled with crictl on the host.
+	ImagePuller ImagePuller
+
+	// PostRebootVerifyCommand is run on the host after the node returns
+	// from a reboot; the revision is only marked done once it succeeds.
+	// Empty disables verification.
+	PostRebootVerifyCommand string
+
+	// AllowDebugApplyPaths honors the debug-apply-paths node annotation,
+	// applying only part of a revision. For debugging only.
+	AllowDebugApplyPaths bool
+
+	// CommandRunner runs PostRebootVerifyCommand.
+	// If nil, commands run on the host via chroot.
+	CommandRunner reboot.CommandRunner
 }
 
 // Agent manages configuration on a single node.
@@ -70,13 +101,17 @@ type Agent struct {
 	applier       *Applier
 	writer        *NodeWriter
 	rebootHandler *reboot.Handler
+	imagePuller   ImagePuller
 	hostRoot      string
+	verifyApply   bool
+	driftInterval time.Duration
+	debugApply    bool
 
 	// rmcCache caches RenderedMachineConfigs for diff-based reboot determination.
 	rmcCache *RMCCache
 
 	// rebootDeterminer handles diff-based reboot logic.
-	rebootDeterminer *RebootDeterminer
+	rebootDeterminer *configdiff.RebootDeterminer
 
 	// pendingRebootRevision tracks which revision we've applied and are waiting
 	// for reboot. This prevents re-applying the same config on every watch event
@@ -113,7 +148,7 @@ func NewWithContext(ctx context.Context, cfg Config) (*Agent, error) {
 	conn := cfg.SystemdConn
 	if conn == nil {
 		var err error
-		conn, err = NewDBusConnection(ctx)
+		conn, err = NewDBusConnection(ctx, cfg.HostRoot)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create systemd connection: %w", err)
 		}
@@ -121,15 +156,31 @@ func NewWithContext(ctx context.Context, cfg Config) (*Agent, error) {
 
 	writer := NewNodeWriter(cfg.K8sClient, cfg.NodeName)
 
-	var executor reboot.RebootExecutor
+	executorName := cfg.RebootExecutor
+	if executorName == "" {
+		executorName = reboot.ExecutorSystemd
+	}
 	if cfg.NoReboot {
 		agentLog.Info("using no-op reboot executor (reboots disabled)")
-		executor = &reboot.NoOpExecutor{}
-	} else {
-		executor = reboot.NewSystemdExecutor(cfg.HostRoot)
+		executorName = reboot.ExecutorNoOp
+	}
+	executor, err := reboot.NewExecutor(executorName, cfg.HostRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	puller := cfg.ImagePuller
+	if puller == nil {
+		puller = NewCrictlPuller(cfg.HostRoot)
+	}
+
+	runner := cfg.CommandRunner
+	if runner == nil {
+		runner = reboot.NewHostCommandRunner(cfg.HostRoot)
 	}
 
 	rebootHandler := reboot.NewHandler(cfg.HostRoot, writer, executor)
+	rebootHandler.SetVerifyCommand(cfg.PostRebootVerifyCommand, runner)
 	rmcCache := NewRMCCache(DefaultRMCCacheTTL)
 	agent := &Agent{
 		nodeName:      cfg.NodeName,
@@ -138,11 +189,15 @@ func NewWithContext(ctx context.Context, cfg Config) (*Agent, error) {
 		applier:       NewApplier(cfg.HostRoot, conn),
 		writer:        writer,
 		rebootHandler: rebootHandler,
+		imagePuller:   puller,
 		hostRoot:      cfg.HostRoot,
+		verifyApply:   cfg.VerifyApply,
+		driftInterval: cfg.DriftCheckInterval,
+		debugApply:    cfg.AllowDebugApplyPaths,
 		rmcCache:      rmcCache,
 	}
 
-	agent.rebootDeterminer = NewRebootDeterminer(agent)
+	agent.rebootDeterminer = configdiff.NewRebootDeterminer(agent)
 
 	return agent, nil
 }
@@ -171,6 +226,12 @@ func (a *Agent) Run(ctx context.Context) error {
 		}
 	}
 
+	// Report usable reboot methods so the controller doesn't assign a
+	// strategy this host can't honor.
+	if err := a.writer.SetRebootMethods(ctx, a.rebootHandler.SupportedMethods()); err != nil {
+		log.Error(err, "failed to report reboot methods")
+	}
+
 	// Set initial state to idle
 	if err := a.writer.SetState(ctx, annotations.StateIdle); err != nil {
 		log.Error(err, "failed to set initial state")
@@ -216,11 +277,28 @@ func (a *Agent) watchNode(ctx context.Context) error {
 
 	log.V(1).Info("watching node for annotation changes")
 
+	// Drift checks run on this goroutine so they never overlap an apply.
+	var driftTick <-chan time.Time
+	if a.driftInterval > 0 {
+		ticker := time.NewTicker(a.driftInterval)
+		defer ticker.Stop()
+		driftTick = ticker.C
+	}
+	var lastNode *corev1.Node
+
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 
+		case <-driftTick:
+			if lastNode == nil {
+				continue
+			}
+			if err := a.checkUnitDrift(ctx, lastNode); err != nil {
+				log.Error(err, "unit drift check failed")
+			}
+
 		case event, ok := <-watcher.ResultChan():
 			if !ok {
 				// Watch closed, will reconnect
@@ -240,6 +318,7 @@ func (a *Agent) watchNode(ctx context.Context) error {
 				log.V(1).Info("unexpected object type in watch")
 				continue
 			}
+			lastNode = node
 
 			// Process update with cancellation support for stale updates.
 			// If desired-revision changed, cancel any in-flight update for old revision.
@@ -302,6 +381,11 @@ func (a *Agent) handleNodeUpdate(ctx context.Context, node *corev1.Node) error {
 		return nil
 	}
 
+	if annotations.IsNodeInMaintenance(ann) {
+		log.V(1).Info("node is in maintenance, skipping")
+		return nil
+	}
+
 	desired := annotations.GetAnnotation(ann, annotations.DesiredRevision)
 	current := annotations.GetAnnotation(ann, annotations.CurrentRevision)
 
@@ -386,7 +470,7 @@ func (a *Agent) fetchRMCWithRetry(ctx context.Context, name string) (*mcov1alpha
 }
 
 // FetchRMC fetches an RMC by name, using cache when available.
-// This method implements the RMCFetcher interface for use by RebootDeterminer.
+// This method implements configdiff.RMCFetcher for use by the reboot determiner.
 func (a *Agent) FetchRMC(ctx context.Context, name string) (*mcov1alpha1.RenderedMachineConfig, error) {
 	if cached := a.rmcCache.Get(name); cached != nil {
 		agentLog.V(1).Info("RMC cache hit", "name", name)
@@ -420,39 +504,104 @@ func (a *Agent) applyConfig(ctx context.Context, rmc *mcov1alpha1.RenderedMachin
 		log.V(1).Info("failed to clear last error", "error", err)
 	}
 
-	log.Info("applying configuration")
-	result, err := a.applier.ApplySpec(ctx, &rmc.Spec)
+	spec := &rmc.Spec
+	debugEntries := debugApplyEntries(node)
+	if len(debugEntries) > 0 && !a.debugApply {
+		log.Info("ignoring debug-apply-paths annotation, agent was not started with --allow-debug-apply-paths")
+		debugEntries = nil
+	}
+	if len(debugEntries) > 0 {
+		spec = filterSpecForDebug(spec, debugEntries)
+		log.Info("debug apply filter active, applying part of the revision", "entries", debugEntries)
+	}
+
+	// Apply on top of the revision the node runs, so files it dropped are
+	// removed and unchanged units are not cycled. A partial debug apply
+	// has no such baseline.
+	var previous *mcov1alpha1.RenderedMachineConfigSpec
+	currentRevision := annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision)
+	if len(debugEntries) == 0 && currentRevision != "" && currentRevision != rmc.Name {
+		if current, err := a.FetchRMC(ctx, currentRevision); err != nil {
+			log.Info("current revision unavailable, applying in full", "current", currentRevision, "error", err)
+		} else {
+			previous = &current.Spec
+		}
+	}
+
+	log.Info("applying configuration", "incremental", previous != nil)
+	result, err := a.applier.ApplySpecIncremental(ctx, previous, spec)
 	if err != nil {
 		log.Error(err, "apply failed")
 		_ = a.writer.SetStateWithError(ctx, annotations.StateError, err.Error())
 		return err
 	}
 
+	// A partial apply is not the revision: never report it done or reboot.
+	if len(debugEntries) > 0 {
+		msg := fmt.Sprintf("debug apply: applied %d of %d files and %d of %d units; remove %s to apply the full revision",
+			len(spec.Config.Files), len(rmc.Spec.Config.Files),
+			len(spec.Config.Systemd.Units), len(rmc.Spec.Config.Systemd.Units),
+			annotations.DebugApplyPaths)
+		log.Info(msg)
+		_ = a.writer.SetStateWithError(ctx, annotations.StateError, msg)
+		return nil
+	}
+
+	if a.verifyApply {
+		if err := VerifyFiles(a.hostRoot, rmc.Spec.Config.Files); err != nil {
+			log.Error(err, "apply verification failed")
+			_ = a.writer.SetStateWithError(ctx, annotations.StateError, err.Error())
+			return err
+		}
+	}
+
 	log.Info("apply successful",
 		"filesApplied", result.FilesApplied,
 		"filesSkipped", result.FilesSkipped,
 		"unitsApplied", result.UnitsApplied,
 		"unitsSkipped", result.UnitsSkipped)
 
+	if value, err := BuildLastApplied(rmc).Encode(); err != nil {
+		log.Error(err, "failed to encode last-applied summary")
+	} else if err := a.writer.SetLastApplied(ctx, value); err != nil {
+		log.V(1).Info("failed to set last-applied annotation", "error", err)
+	}
+
 	// If no changes were applied, skip reboot check entirely.
 	// This prevents unnecessary reboots when files already exist on host.
-	if result.FilesApplied == 0 && result.UnitsApplied == 0 {
+	if result.FilesApplied == 0 && result.UnitsApplied == 0 && !result.KernelArgsChanged {
 		log.Info("no changes applied, skipping reboot check")
 		a.pendingRebootRevision = ""
-		if err := a.writer.SetDone(ctx, rmc.Name); err != nil {
-			return fmt.Errorf("set done state: %w", err)
-		}
-		return nil
+		return a.markDone(ctx, rmc.Name)
 	}
 
-	currentRevision := annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision)
-	decision := a.rebootDeterminer.DetermineReboot(ctx, currentRevision, rmc)
+	decision := a.rebootDeterminer.DetermineRebootAfterApply(ctx, currentRevision, rmc, result.Changes())
 
 	log.Info("reboot decision",
 		"required", decision.Required,
 		"method", decision.Method,
 		"reasons", decision.Reasons)
 
+	// Pull images before any reboot so workloads don't wait on pulls afterwards.
+	if decision.Required && len(rmc.Spec.Config.PrepullImages) > 0 {
+		log.Info("prepulling images before reboot", "count", len(rmc.Spec.Config.PrepullImages))
+		if err := PrepullImages(ctx, a.imagePuller, rmc.Spec.Config.PrepullImages); err != nil {
+			log.Error(err, "image prepull failed")
+			_ = a.writer.SetStateWithError(ctx, annotations.StateError, err.Error())
+			return err
+		}
+	}
+
+	// The reason always describes this revision's decision, so a revision
+	// that needs no reboot clears the one left by an earlier revision.
+	rebootReason := ""
+	if decision.Required {
+		rebootReason = decision.Summary()
+	}
+	if err := a.writer.SetRebootReason(ctx, rebootReason); err != nil {
+		log.Error(err, "failed to record reboot reason")
+	}
+
 	originalRequired := rmc.Spec.Reboot.Required
 	rmc.Spec.Reboot.Required = decision.Required
 	if err := a.rebootHandler.HandleReboot(ctx, rmc, node); err != nil {
@@ -468,10 +617,20 @@ func (a *Agent) applyConfig(ctx context.Context, rmc *mcov1alpha1.RenderedMachin
 	}
 
 	a.pendingRebootRevision = ""
-	if err := a.writer.SetDone(ctx, rmc.Name); err != nil {
+	return a.markDone(ctx, rmc.Name)
+}
+
+// markDone reports revision applied. A pending post-reboot verification
+// must pass first, also when the re-apply after the reboot changed nothing.
+func (a *Agent) markDone(ctx context.Context, revision string) error {
+	if err := a.rebootHandler.VerifyAfterReboot(ctx); err != nil {
+		agentLog.Error(err, "node is not healthy after reboot", "node", a.nodeName, "revision", revision)
+		_ = a.writer.SetStateWithError(ctx, annotations.StateError, err.Error())
+		return err
+	}
+	if err := a.writer.SetDone(ctx, revision); err != nil {
 		return fmt.Errorf("set done state: %w", err)
 	}
-
 	return nil
 }
 
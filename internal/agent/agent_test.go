@@ -32,6 +32,7 @@ import (
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
 	"in-cloud.io/machine-config/internal/agent/reboot"
+	"in-cloud.io/machine-config/internal/configdiff"
 	"in-cloud.io/machine-config/pkg/annotations"
 	mcoclient "in-cloud.io/machine-config/pkg/client"
 )
@@ -95,10 +96,11 @@ func newTestAgent(nodeName string, k8sClient kubernetes.Interface, mcoClient mco
 		writer:        writer,
 		applier:       NewApplierWithOptions("", NewMockConnection(), true),
 		rebootHandler: rebootHandler,
+		imagePuller:   &NoOpPuller{},
 		rmcCache:      rmcCache,
 	}
 	// Initialize rebootDeterminer with agent as the RMCFetcher
-	agent.rebootDeterminer = NewRebootDeterminer(agent)
+	agent.rebootDeterminer = configdiff.NewRebootDeterminer(agent)
 	return agent
 }
 
@@ -147,6 +149,33 @@ func TestNew_Validation(t *testing.T) {
 	}
 }
 
+func TestAgent_HandleNodeUpdate_Maintenance(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+			Annotations: map[string]string{
+				annotations.Maintenance:     "true",
+				annotations.DesiredRevision: "new-rev",
+				annotations.CurrentRevision: "old-rev",
+			},
+		},
+	}
+	k8sClient := fake.NewSimpleClientset(node)
+	mcoClient := newMockMCOClient()
+	mcoClient.addRMC(&mcov1alpha1.RenderedMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "new-rev"},
+	})
+
+	agent := newTestAgent("test-node", k8sClient, mcoClient)
+
+	if err := agent.handleNodeUpdate(context.Background(), node); err != nil {
+		t.Fatalf("handleNodeUpdate() error = %v", err)
+	}
+	if mcoClient.rmcGetter.callCount != 0 {
+		t.Errorf("RMC Get called %d times, want 0 (maintenance)", mcoClient.rmcGetter.callCount)
+	}
+}
+
 func TestAgent_HandleNodeUpdate_Paused(t *testing.T) {
 	node := &corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
@@ -354,6 +383,13 @@ func TestAgent_HandleNodeUpdate_NewRevision(t *testing.T) {
 	if got := updated.Annotations[annotations.CurrentRevision]; got != "new-rev" {
 		t.Errorf("CurrentRevision = %q, want %q", got, "new-rev")
 	}
+	la, err := ParseLastApplied(updated.Annotations[annotations.LastApplied])
+	if err != nil {
+		t.Fatalf("last-applied annotation: %v", err)
+	}
+	if la.Revision != "new-rev" {
+		t.Errorf("last-applied revision = %q, want %q", la.Revision, "new-rev")
+	}
 }
 
 func TestAgent_HandleNodeUpdate_RMCNotFound(t *testing.T) {
@@ -536,6 +572,59 @@ func TestAgent_HandleNodeUpdate_NoChangesNoReboot(t *testing.T) {
 	}
 }
 
+// failingRunner is a reboot.CommandRunner whose command always fails.
+type failingRunner struct{}
+
+func (failingRunner) Run(context.Context, string) error { return errors.New("exit status 1") }
+
+// TestAgent_HandleNodeUpdate_NoChangesVerifiesAfterReboot verifies that a
+// re-apply after a reboot that changes nothing still waits for the
+// post-reboot verification before reporting the revision done.
+func TestAgent_HandleNodeUpdate_NoChangesVerifiesAfterReboot(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-node",
+			Annotations: map[string]string{annotations.DesiredRevision: "new-rev"},
+		},
+	}
+	k8sClient := fake.NewSimpleClientset(node)
+	mcoClient := newMockMCOClient()
+
+	tmpDir := t.TempDir()
+	os.MkdirAll(tmpDir+"/etc", 0755)
+	os.WriteFile(tmpDir+"/etc/already-exists.conf", []byte("existing-content"), 0644)
+	mcoClient.addRMC(&mcov1alpha1.RenderedMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "new-rev"},
+		Spec: mcov1alpha1.RenderedMachineConfigSpec{
+			Config: mcov1alpha1.RenderedConfig{
+				Files: []mcov1alpha1.FileSpec{
+					{Path: "/etc/already-exists.conf", Content: "existing-content", State: "present"},
+				},
+			},
+		},
+	})
+
+	agent := newTestAgent("test-node", k8sClient, mcoClient)
+	agent.applier = NewApplierWithOptions(tmpDir, NewMockConnection(), true)
+	agent.rebootHandler = reboot.NewHandler(tmpDir, agent.writer, &reboot.NoOpExecutor{})
+	agent.rebootHandler.SetVerifyCommand("systemctl is-active kubelet", failingRunner{})
+	if err := reboot.NewStateManager(tmpDir).WriteVerifyPending(); err != nil {
+		t.Fatalf("WriteVerifyPending() error = %v", err)
+	}
+
+	if err := agent.handleNodeUpdate(context.Background(), node); err == nil {
+		t.Fatal("handleNodeUpdate() error = nil, want verification failure")
+	}
+
+	updated, _ := k8sClient.CoreV1().Nodes().Get(context.Background(), "test-node", metav1.GetOptions{})
+	if got := updated.Annotations[annotations.AgentState]; got == annotations.StateDone {
+		t.Errorf("AgentState = %q, want not done after failed verification", got)
+	}
+	if got := updated.Annotations[annotations.CurrentRevision]; got == "new-rev" {
+		t.Errorf("CurrentRevision = %q, want it not advanced after failed verification", got)
+	}
+}
+
 // TestAgent_HandleNodeUpdate_WithChangesReboot verifies that when files/units
 // ARE changed, reboot IS triggered if reboot.required=true.
 func TestAgent_HandleNodeUpdate_WithChangesReboot(t *testing.T) {
@@ -771,3 +860,79 @@ func TestAgent_FetchRMCWithRetry_ContextCanceled(t *testing.T) {
 		t.Errorf("Expected context.Canceled error, got: %v", err)
 	}
 }
+
+// TestAgent_HandleNodeUpdate_PrepullBeforeReboot verifies images are pulled
+// before the reboot decision is acted on, and a pull failure blocks the reboot.
+func TestAgent_HandleNodeUpdate_PrepullBeforeReboot(t *testing.T) {
+	newNode := func() *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-node",
+				Annotations: map[string]string{
+					annotations.DesiredRevision: "new-rev",
+					annotations.CurrentRevision: "old-rev",
+				},
+			},
+		}
+	}
+	rmc := &mcov1alpha1.RenderedMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "new-rev"},
+		Spec: mcov1alpha1.RenderedMachineConfigSpec{
+			Config: mcov1alpha1.RenderedConfig{
+				Files: []mcov1alpha1.FileSpec{
+					{Path: "/etc/static-pod.yaml", Content: "v2", State: "present"},
+				},
+				PrepullImages: []string{"registry.example/app:v2", "registry.example/sidecar:v2"},
+			},
+			Reboot: mcov1alpha1.RenderedRebootSpec{Required: true},
+		},
+	}
+
+	t.Run("images pulled", func(t *testing.T) {
+		node := newNode()
+		k8sClient := fake.NewSimpleClientset(node)
+		mcoClient := newMockMCOClient()
+		mcoClient.addRMC(rmc.DeepCopy())
+
+		puller := &NoOpPuller{}
+		agent := newTestAgent("test-node", k8sClient, mcoClient)
+		agent.applier = NewApplierWithOptions(t.TempDir(), NewMockConnection(), true)
+		agent.imagePuller = puller
+
+		if err := agent.handleNodeUpdate(context.Background(), node); err != nil {
+			t.Fatalf("handleNodeUpdate() error = %v", err)
+		}
+		if len(puller.Pulled) != 2 {
+			t.Errorf("pulled %v, want both images", puller.Pulled)
+		}
+	})
+
+	t.Run("pull failure blocks reboot", func(t *testing.T) {
+		node := newNode()
+		k8sClient := fake.NewSimpleClientset(node)
+		mcoClient := newMockMCOClient()
+		mcoClient.addRMC(rmc.DeepCopy())
+
+		agent := newTestAgent("test-node", k8sClient, mcoClient)
+		agent.applier = NewApplierWithOptions(t.TempDir(), NewMockConnection(), true)
+		agent.imagePuller = failingPuller{}
+
+		if err := agent.handleNodeUpdate(context.Background(), node); err == nil {
+			t.Fatal("handleNodeUpdate() error = nil, want prepull failure")
+		}
+
+		updated, _ := k8sClient.CoreV1().Nodes().Get(context.Background(), "test-node", metav1.GetOptions{})
+		if got := updated.Annotations[annotations.AgentState]; got != annotations.StateError {
+			t.Errorf("AgentState = %q, want %q", got, annotations.StateError)
+		}
+		if got := updated.Annotations[annotations.RebootPending]; got == "true" {
+			t.Error("RebootPending should not be set when prepull fails")
+		}
+	})
+}
+
+type failingPuller struct{}
+
+func (failingPuller) Pull(_ context.Context, image string) error {
+	return errors.New("registry unreachable")
+}
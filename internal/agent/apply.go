@@ -19,9 +19,15 @@ package agent
 import (
 	"context"
 	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
 	"sort"
+	"strings"
+	"time"
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/internal/configdiff"
 )
 
 // ApplyResult contains the result of a full config apply operation.
@@ -32,6 +38,24 @@ type ApplyResult struct {
 	FilesSkipped int
 	UnitsApplied int
 	UnitsSkipped int
+
+	// ChangedFiles and ChangedUnits name the files and units the apply
+	// actually changed on the host.
+	ChangedFiles []string
+	ChangedUnits []string
+
+	// KernelArgsChanged is true if the apply changed the kernel arguments
+	// in the bootloader config.
+	KernelArgsChanged bool
+}
+
+// Changes returns what the apply changed on the host, for reboot decisions.
+func (r *ApplyResult) Changes() *configdiff.AppliedChanges {
+	return &configdiff.AppliedChanges{
+		ChangedFiles:      r.ChangedFiles,
+		ChangedUnits:      r.ChangedUnits,
+		KernelArgsChanged: r.KernelArgsChanged,
+	}
 }
 
 // Applier orchestrates the application of rendered configurations.
@@ -40,6 +64,16 @@ type ApplyResult struct {
 type Applier struct {
 	files   FileOperations
 	systemd *SystemdApplier
+	// kernelArgs is nil for appliers without a host root; kernel
+	// arguments are then left alone.
+	kernelArgs *KernelArgsApplier
+	// staging enables validating changed files below hostRoot before
+	// the live config is replaced; see stageAndValidate.
+	staging bool
+	// trackCreated records the files the applier creates below hostRoot,
+	// so only those are deleted when a later revision drops them.
+	trackCreated bool
+	hostRoot     string
 }
 
 // NewApplier creates a new configuration applier.
@@ -47,16 +81,24 @@ type Applier struct {
 // conn is the systemd connection to use.
 func NewApplier(hostRoot string, conn SystemdConnection) *Applier {
 	return &Applier{
-		files:   NewFileApplier(hostRoot),
-		systemd: NewSystemdApplier(conn),
+		files:        NewFileApplier(hostRoot),
+		systemd:      NewSystemdApplier(conn),
+		kernelArgs:   NewKernelArgsApplier(hostRoot),
+		staging:      true,
+		trackCreated: true,
+		hostRoot:     hostRoot,
 	}
 }
 
 // NewApplierWithOptions creates an applier with configurable options.
 func NewApplierWithOptions(hostRoot string, conn SystemdConnection, skipOwnership bool) *Applier {
 	return &Applier{
-		files:   NewFileApplierWithOptions(hostRoot, skipOwnership),
-		systemd: NewSystemdApplier(conn),
+		files:        NewFileApplierWithOptions(hostRoot, skipOwnership),
+		systemd:      NewSystemdApplier(conn),
+		kernelArgs:   NewKernelArgsApplier(hostRoot),
+		staging:      true,
+		trackCreated: true,
+		hostRoot:     hostRoot,
 	}
 }
 
@@ -79,57 +121,372 @@ func (a *Applier) Close() {
 // Files are applied first (sorted by path), then systemd units (sorted by name).
 // Stops on first error.
 func (a *Applier) Apply(ctx context.Context, config *mcov1alpha1.RenderedConfig) (*ApplyResult, error) {
+	return a.apply(ctx, nil, &mcov1alpha1.RenderedMachineConfigSpec{Config: *config})
+}
+
+// apply applies spec to the host. previous is the spec the host was last
+// brought to; when set, files it had that spec dropped are removed if the
+// applier created them, and units are only restarted or reloaded if they
+// or their config changed since. A nil previous applies spec in full.
+func (a *Applier) apply(ctx context.Context, previous, spec *mcov1alpha1.RenderedMachineConfigSpec) (*ApplyResult, error) {
 	result := &ApplyResult{}
+	config, restart := &spec.Config, spec.UnitRestart
+
+	var created *createdFiles
+	if a.trackCreated {
+		var err error
+		if created, err = loadCreatedFiles(a.hostRoot); err != nil {
+			result.Error = err
+			return result, result.Error
+		}
+		defer func() {
+			if err := created.Save(); err != nil {
+				agentLog.Error(err, "failed to record created files")
+			}
+		}()
+	}
 
 	files := sortFilesByPath(config.Files)
+	if previous != nil {
+		files = append(files, removedFiles(previous.Config.Files, config.Files, created)...)
+	}
+
+	// Validate the new revision while the old config is still live, so a
+	// bad file fails the apply before anything is replaced.
+	var verified map[string]bool
+	if a.staging {
+		var err error
+		if verified, err = a.stageAndValidate(ctx, files); err != nil {
+			result.Error = fmt.Errorf("validate staged revision: %w", err)
+			return result, result.Error
+		}
+	}
+
+	// Host appliers snapshot each file before writing it, so a failure
+	// partway through puts back the files already written instead of
+	// leaving the host with half of the new revision.
+	var snapshots []*fileSnapshot
+	var createdBefore map[string]bool
+	if created != nil {
+		createdBefore = maps.Clone(created.paths)
+	}
+	// Kernel arguments take effect on the next boot, whatever causes it,
+	// so they are put back when a later step of the apply fails.
+	var kernelArgsBefore []string
+	restoreKernelArgs := func(err error) error {
+		if !result.KernelArgsChanged {
+			return err
+		}
+		if _, restoreErr := a.kernelArgs.Apply(context.WithoutCancel(ctx), kernelArgsBefore); restoreErr != nil {
+			return fmt.Errorf("%w (restore kernel args failed: %v)", err, restoreErr)
+		}
+		result.KernelArgsChanged = false
+		return fmt.Errorf("%w (restored kernel args)", err)
+	}
+	rollback := func(err error) (*ApplyResult, error) {
+		err = restoreKernelArgs(err)
+		if a.staging && len(snapshots) > 0 {
+			if failed := rollbackFiles(snapshots); failed > 0 {
+				err = fmt.Errorf("%w (rollback failed for %d files)", err, failed)
+			} else {
+				err = fmt.Errorf("%w (rolled back %d files)", err, len(snapshots))
+			}
+			if created != nil {
+				created.paths, created.dirty = createdBefore, true
+			}
+			result.FilesApplied, result.ChangedFiles = 0, nil
+		}
+		result.Error = err
+		return result, result.Error
+	}
+
 	for _, f := range files {
 		select {
 		case <-ctx.Done():
-			result.Error = ctx.Err()
-			return result, result.Error
+			return rollback(ctx.Err())
 		default:
 		}
 
+		if a.staging {
+			snapshot, err := snapshotFile(filepath.Join(a.hostRoot, f.Path))
+			if err != nil {
+				return rollback(fmt.Errorf("file %s: snapshot: %w", f.Path, err))
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+
+		existed := true
+		if created != nil && f.State != FileStateAbsent {
+			_, err := os.Lstat(filepath.Join(a.hostRoot, f.Path))
+			existed = err == nil
+		}
+
 		fileResult := a.files.Apply(f)
 		if fileResult.Error != nil {
-			result.Error = fmt.Errorf("file %s: %w", f.Path, fileResult.Error)
-			return result, result.Error
+			return rollback(fmt.Errorf("file %s: %w", f.Path, fileResult.Error))
+		}
+		if created != nil && fileResult.Applied {
+			if f.State == FileStateAbsent {
+				created.Remove(f.Path)
+			} else if !existed {
+				created.Add(f.Path)
+			}
 		}
 		if fileResult.Applied {
+			if f.State != "absent" && isUnitFilePath(f.Path) && !verified[f.Path] {
+				// Catch broken units before anything activates them.
+				if err := a.systemd.conn.VerifyUnitFile(ctx, f.Path); err != nil {
+					return rollback(fmt.Errorf("verify unit file %s: %w", f.Path, err))
+				}
+			}
 			result.FilesApplied++
+			result.ChangedFiles = append(result.ChangedFiles, f.Path)
 		} else {
 			result.FilesSkipped++
+			if a.staging {
+				snapshots = snapshots[:len(snapshots)-1]
+			}
 		}
 	}
 
+	if a.kernelArgs != nil {
+		var err error
+		if kernelArgsBefore, err = a.kernelArgs.Current(); err != nil {
+			return rollback(fmt.Errorf("kernel args: %w", err))
+		}
+		changed, err := a.kernelArgs.Apply(ctx, config.KernelArgs)
+		if err != nil {
+			return rollback(fmt.Errorf("kernel args: %w", err))
+		}
+		result.KernelArgsChanged = changed
+	}
+
+	// Unit failures leave the files written, but not the kernel arguments.
+	fail := func(err error) (*ApplyResult, error) {
+		result.Error = restoreKernelArgs(err)
+		return result, result.Error
+	}
+
+	staggered := restart.DelaySeconds > 0 || len(restart.Order) > 0
+
+	var cycle map[string]bool
+	if previous != nil {
+		cycle = unitsToCycle(previous, spec, result.ChangedFiles)
+	}
+
 	units := sortUnitsByName(config.Systemd.Units)
+	var deferred []mcov1alpha1.UnitSpec
 	for _, u := range units {
 		select {
 		case <-ctx.Done():
-			result.Error = ctx.Err()
-			return result, result.Error
+			return fail(ctx.Err())
 		default:
 		}
 
+		if cycle != nil && isRestartState(u.State) && !cycle[u.Name] {
+			// Nothing the unit depends on changed: keep mask/enable
+			// in sync, but leave the running service alone.
+			u.State = ""
+		}
+
+		if staggered && isRestartState(u.State) {
+			// Apply mask/enable now, cycle the unit later with the others.
+			pre := u
+			pre.State = ""
+			if unitResult := a.systemd.Apply(ctx, pre); unitResult.Error != nil {
+				return fail(fmt.Errorf("unit %s: %w", u.Name, unitResult.Error))
+			}
+			deferred = append(deferred, u)
+			continue
+		}
+
 		unitResult := a.systemd.Apply(ctx, u)
 		if unitResult.Error != nil {
-			result.Error = fmt.Errorf("unit %s: %w", u.Name, unitResult.Error)
-			return result, result.Error
+			return fail(fmt.Errorf("unit %s: %w", u.Name, unitResult.Error))
 		}
 		if unitResult.Applied {
 			result.UnitsApplied++
+			result.ChangedUnits = append(result.ChangedUnits, u.Name)
 		} else {
 			result.UnitsSkipped++
 		}
 	}
 
+	delay := time.Duration(restart.DelaySeconds) * time.Second
+	for i, u := range orderRestarts(deferred, restart.Order) {
+		if i > 0 && delay > 0 {
+			select {
+			case <-ctx.Done():
+				return fail(ctx.Err())
+			case <-time.After(delay):
+			}
+		}
+
+		if _, err := a.systemd.applyState(ctx, u.Name, u.State); err != nil {
+			return fail(fmt.Errorf("unit %s: state: %w", u.Name, err))
+		}
+		result.UnitsApplied++
+		result.ChangedUnits = append(result.ChangedUnits, u.Name)
+	}
+
 	result.Success = true
 	return result, nil
 }
 
-// ApplySpec applies a RenderedMachineConfig spec.
+// ApplySpec applies a RenderedMachineConfig spec, honoring its unit restart policy.
 func (a *Applier) ApplySpec(ctx context.Context, spec *mcov1alpha1.RenderedMachineConfigSpec) (*ApplyResult, error) {
-	return a.Apply(ctx, &spec.Config)
+	return a.apply(ctx, nil, spec)
+}
+
+// ApplySpecIncremental applies spec on top of previous, the spec the host
+// currently runs: files previous had and spec dropped are removed from the
+// host if the agent created them, and only units that changed or whose
+// config changed are restarted or reloaded (see unitsToCycle). A nil
+// previous applies spec in full, like ApplySpec.
+func (a *Applier) ApplySpecIncremental(ctx context.Context, previous, spec *mcov1alpha1.RenderedMachineConfigSpec) (*ApplyResult, error) {
+	if previous == nil {
+		return a.ApplySpec(ctx, spec)
+	}
+	return a.apply(ctx, previous, spec)
+}
+
+// removedFiles returns absent specs, sorted by path, for the files
+// DiffFiles reports removed between current and desired that the applier
+// created. Files that existed before it first wrote them are left with
+// their last content, and line-present files are shared with other
+// writers, so dropping the line's spec leaves the file in place. Without a
+// created-files record nothing is removed.
+func removedFiles(current, desired []mcov1alpha1.FileSpec, created *createdFiles) []mcov1alpha1.FileSpec {
+	if created == nil {
+		return nil
+	}
+	previous := make(map[string]mcov1alpha1.FileSpec, len(current))
+	for _, f := range current {
+		previous[f.Path] = f
+	}
+
+	var removed []mcov1alpha1.FileSpec
+	for _, c := range configdiff.DiffFiles(current, desired) {
+		if c.ChangeType != configdiff.ChangeTypeRemoved || !created.Has(c.Path) {
+			continue
+		}
+		switch previous[c.Path].State {
+		case FileStateAbsent, FileStateLinePresent:
+			continue
+		}
+		removed = append(removed, mcov1alpha1.FileSpec{Path: c.Path, State: FileStateAbsent})
+	}
+	return removed
+}
+
+// unitsToCycle returns the units of desired whose restarted or reloaded
+// state should run: those DiffUnits reports added or modified, those whose
+// unit file or drop-in is among changedFiles, and those that come from the
+// same MachineConfig as another changed file, which the unit likely reads
+// (chrony.conf for chronyd.service). A unit or file of unknown origin is
+// cycled on any file change, as before incremental apply.
+func unitsToCycle(current, desired *mcov1alpha1.RenderedMachineConfigSpec, changedFiles []string) map[string]bool {
+	cycle := make(map[string]bool)
+	for _, c := range configdiff.DiffUnits(current.Config.Systemd.Units, desired.Config.Systemd.Units) {
+		if c.ChangeType != configdiff.ChangeTypeRemoved {
+			cycle[c.Name] = true
+		}
+	}
+
+	fileSources := desired.RebootRequirements.FileSources
+	unitSources := desired.RebootRequirements.UnitSources
+	for _, u := range desired.Config.Systemd.Units {
+		unitSource := unitSources[u.Name]
+		for _, path := range changedFiles {
+			fileSource := fileSources[path]
+			if fileSource == "" {
+				// Removed files are only in the previous revision.
+				fileSource = current.RebootRequirements.FileSources[path]
+			}
+			if isUnitConfigPath(path, u.Name) || unitSource == "" || fileSource == "" || unitSource == fileSource {
+				cycle[u.Name] = true
+				break
+			}
+		}
+	}
+	return cycle
+}
+
+// isUnitConfigPath reports whether path is the unit file of unit name, or
+// one of its drop-ins, in one of systemd's unit directories.
+func isUnitConfigPath(path, name string) bool {
+	for _, dir := range unitFileDirs {
+		if path == dir+name || strings.HasPrefix(path, dir+name+".d/") {
+			return true
+		}
+	}
+	return false
+}
+
+// unitFileDirs are the directories systemd loads admin unit files from.
+var unitFileDirs = []string{"/etc/systemd/system/", "/run/systemd/system/"}
+
+// unitFileSuffixes are the unit types checked by systemd-analyze verify.
+var unitFileSuffixes = []string{
+	".service", ".socket", ".timer", ".mount", ".automount",
+	".path", ".target", ".slice", ".swap",
+}
+
+// isUnitFilePath reports whether path is a unit file (not a drop-in or
+// other config) in one of systemd's unit directories.
+func isUnitFilePath(path string) bool {
+	inDir := false
+	for _, dir := range unitFileDirs {
+		if strings.HasPrefix(path, dir) && !strings.Contains(strings.TrimPrefix(path, dir), "/") {
+			inDir = true
+			break
+		}
+	}
+	if !inDir {
+		return false
+	}
+	for _, suffix := range unitFileSuffixes {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRestartState reports whether a unit state cycles a running service.
+func isRestartState(state string) bool {
+	return state == "restarted" || state == "reloaded"
+}
+
+// orderRestarts puts units named in order first, in that order, followed by
+// the rest in their existing (name-sorted) order.
+func orderRestarts(units []mcov1alpha1.UnitSpec, order []string) []mcov1alpha1.UnitSpec {
+	if len(order) == 0 {
+		return units
+	}
+
+	rank := make(map[string]int, len(order))
+	for i, name := range order {
+		if _, ok := rank[name]; !ok {
+			rank[name] = i
+		}
+	}
+
+	sorted := make([]mcov1alpha1.UnitSpec, len(units))
+	copy(sorted, units)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ri, iOK := rank[sorted[i].Name]
+		rj, jOK := rank[sorted[j].Name]
+		switch {
+		case iOK && jOK:
+			return ri < rj
+		case iOK != jOK:
+			return iOK
+		default:
+			return false
+		}
+	})
+	return sorted
 }
 
 func sortFilesByPath(files []mcov1alpha1.FileSpec) []mcov1alpha1.FileSpec {
@@ -172,16 +529,25 @@ func (a *Applier) DryRun(ctx context.Context, config *mcov1alpha1.RenderedConfig
 		result.UnitsToChange = append(result.UnitsToChange, u.Name)
 	}
 
+	if a.kernelArgs != nil {
+		needs, err := a.kernelArgs.NeedsUpdate(config.KernelArgs)
+		if err != nil {
+			return nil, fmt.Errorf("check kernel args: %w", err)
+		}
+		result.KernelArgsToChange = needs
+	}
+
 	return result, nil
 }
 
 // DryRunResult contains the result of a dry run.
 type DryRunResult struct {
-	FilesToChange []string
-	UnitsToChange []string
+	FilesToChange      []string
+	UnitsToChange      []string
+	KernelArgsToChange bool
 }
 
 // HasChanges returns true if any changes would be made.
 func (r *DryRunResult) HasChanges() bool {
-	return len(r.FilesToChange) > 0 || len(r.UnitsToChange) > 0
+	return len(r.FilesToChange) > 0 || len(r.UnitsToChange) > 0 || r.KernelArgsToChange
 }
@@ -18,11 +18,18 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"k8s.io/utils/ptr"
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/internal/configdiff"
+	"in-cloud.io/machine-config/internal/renderer"
 )
 
 func TestNewApplier(t *testing.T) {
@@ -232,10 +239,15 @@ func TestApply_FileErrorStops(t *testing.T) {
 		t.Error("Apply() should not succeed")
 	}
 
-	// After sorting: /aaa (applied), /zzz (applied), relative (error)
-	// 2 files applied before the relative path error
-	if result.FilesApplied != 2 {
-		t.Errorf("FilesApplied = %d, want 2", result.FilesApplied)
+	// After sorting: /aaa (applied), /zzz (applied), relative (error).
+	// The two files written before the error are rolled back.
+	if result.FilesApplied != 0 {
+		t.Errorf("FilesApplied = %d, want 0 after rollback", result.FilesApplied)
+	}
+	for _, path := range []string{"/aaa/first.conf", "/zzz/good.conf"} {
+		if _, err := os.Stat(filepath.Join(dir, path)); !os.IsNotExist(err) {
+			t.Errorf("%s stat error = %v, want not exist", path, err)
+		}
 	}
 	if result.UnitsApplied != 0 {
 		t.Errorf("UnitsApplied = %d, want 0", result.UnitsApplied)
@@ -279,6 +291,35 @@ func TestApply_UnitErrorStops(t *testing.T) {
 	}
 }
 
+func TestApply_UnitErrorRestoresKernelArgs(t *testing.T) {
+	dir := t.TempDir()
+	mock := NewMockConnection()
+	a := NewApplierWithOptions(dir, mock, true)
+	a.kernelArgs = NewKernelArgsApplierWithUpdater(dir, func(context.Context, string) error { return nil })
+	ctx := context.Background()
+
+	if _, err := a.kernelArgs.Apply(ctx, []string{"quiet"}); err != nil {
+		t.Fatal(err)
+	}
+
+	config := &mcov1alpha1.RenderedConfig{
+		KernelArgs: []string{"quiet", "iommu=pt"},
+		Systemd: mcov1alpha1.SystemdSpec{
+			Units: []mcov1alpha1.UnitSpec{{Name: "bad.service", State: "invalid"}},
+		},
+	}
+	result, err := a.Apply(ctx, config)
+	if err == nil {
+		t.Fatal("Apply() should error")
+	}
+	if result.KernelArgsChanged {
+		t.Error("KernelArgsChanged = true after the failed apply restored them")
+	}
+	if needs, err := a.kernelArgs.NeedsUpdate([]string{"quiet"}); err != nil || needs {
+		t.Errorf("NeedsUpdate(previous) = %v, %v; want previous kernel args restored", needs, err)
+	}
+}
+
 func TestApplier_Idempotent(t *testing.T) {
 	dir := t.TempDir()
 	mock := NewMockConnection()
@@ -393,6 +434,322 @@ func TestApplySpec(t *testing.T) {
 	}
 }
 
+func TestApplySpec_UnitRestartOrder(t *testing.T) {
+	mock := NewMockConnection()
+	a := NewApplier("", mock)
+	enabled := true
+
+	spec := &mcov1alpha1.RenderedMachineConfigSpec{
+		Config: mcov1alpha1.RenderedConfig{
+			Systemd: mcov1alpha1.SystemdSpec{
+				Units: []mcov1alpha1.UnitSpec{
+					{Name: "a.service", State: "restarted", Enabled: &enabled},
+					{Name: "m.service", State: "started"},
+					{Name: "r.service", State: "reloaded"},
+					{Name: "z.service", State: "restarted"},
+				},
+			},
+		},
+		UnitRestart: mcov1alpha1.UnitRestartPolicy{Order: []string{"z.service"}},
+	}
+
+	result, err := a.ApplySpec(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("ApplySpec() error = %v", err)
+	}
+	if result.UnitsApplied != 4 {
+		t.Errorf("UnitsApplied = %d, want 4", result.UnitsApplied)
+	}
+
+	wantRestarts := []string{"z.service", "a.service"}
+	if len(mock.RestartCalls) != len(wantRestarts) {
+		t.Fatalf("RestartCalls = %v, want %v", mock.RestartCalls, wantRestarts)
+	}
+	for i, name := range wantRestarts {
+		if mock.RestartCalls[i] != name {
+			t.Errorf("RestartCalls[%d] = %s, want %s", i, mock.RestartCalls[i], name)
+		}
+	}
+	if len(mock.EnableCalls) != 1 || len(mock.StartCalls) != 1 || len(mock.ReloadCalls) != 1 {
+		t.Errorf("enable/start/reload calls = %v/%v/%v, want one each",
+			mock.EnableCalls, mock.StartCalls, mock.ReloadCalls)
+	}
+}
+
+func TestApplySpec_UnitRestartDelay(t *testing.T) {
+	mock := NewMockConnection()
+	a := NewApplier("", mock)
+
+	spec := &mcov1alpha1.RenderedMachineConfigSpec{
+		Config: mcov1alpha1.RenderedConfig{
+			Systemd: mcov1alpha1.SystemdSpec{
+				Units: []mcov1alpha1.UnitSpec{
+					{Name: "a.service", State: "restarted"},
+					{Name: "b.service", State: "restarted"},
+				},
+			},
+		},
+		UnitRestart: mcov1alpha1.UnitRestartPolicy{DelaySeconds: 300},
+	}
+
+	// The second restart waits out the delay, so a short deadline stops it.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := a.ApplySpec(ctx, spec); err == nil {
+		t.Fatal("ApplySpec() error = nil, want context deadline while waiting between restarts")
+	}
+	if len(mock.RestartCalls) != 1 || mock.RestartCalls[0] != "a.service" {
+		t.Errorf("RestartCalls = %v, want only [a.service] before the delay", mock.RestartCalls)
+	}
+}
+
+func TestApplySpecIncremental_UnchangedUnitsNotCycled(t *testing.T) {
+	mock := NewMockConnection()
+	a := NewApplierWithOptions(t.TempDir(), mock, true)
+
+	units := []mcov1alpha1.UnitSpec{
+		{Name: "a.service", State: "restarted"},
+		{Name: "b.service", State: "reloaded"},
+	}
+	// The changed file belongs to a MachineConfig neither unit comes from.
+	sources := mcov1alpha1.RebootRequirements{
+		FileSources: map[string]string{"/etc/app.conf": "app"},
+		UnitSources: map[string]string{"a.service": "a", "b.service": "b"},
+	}
+	previous := &mcov1alpha1.RenderedMachineConfigSpec{
+		Config:             mcov1alpha1.RenderedConfig{Systemd: mcov1alpha1.SystemdSpec{Units: units}},
+		RebootRequirements: sources,
+	}
+	spec := &mcov1alpha1.RenderedMachineConfigSpec{
+		Config: mcov1alpha1.RenderedConfig{
+			Files:   []mcov1alpha1.FileSpec{{Path: "/etc/app.conf", Content: "v2", State: "present"}},
+			Systemd: mcov1alpha1.SystemdSpec{Units: units},
+		},
+		RebootRequirements: sources,
+	}
+
+	if len(configdiff.DiffUnits(previous.Config.Systemd.Units, spec.Config.Systemd.Units)) != 0 {
+		t.Fatal("DiffUnits() should report no changes")
+	}
+	result, err := a.ApplySpecIncremental(context.Background(), previous, spec)
+	if err != nil {
+		t.Fatalf("ApplySpecIncremental() error = %v", err)
+	}
+	if len(mock.RestartCalls) != 0 || len(mock.ReloadCalls) != 0 {
+		t.Errorf("restart/reload calls = %v/%v, want none", mock.RestartCalls, mock.ReloadCalls)
+	}
+	if result.UnitsApplied != 0 {
+		t.Errorf("UnitsApplied = %d, want 0", result.UnitsApplied)
+	}
+
+	// The same spec with a restart policy takes the staggered path.
+	spec.UnitRestart = mcov1alpha1.UnitRestartPolicy{Order: []string{"b.service"}}
+	if _, err := a.ApplySpecIncremental(context.Background(), previous, spec); err != nil {
+		t.Fatalf("staggered ApplySpecIncremental() error = %v", err)
+	}
+	if len(mock.RestartCalls) != 0 || len(mock.ReloadCalls) != 0 {
+		t.Errorf("staggered restart/reload calls = %v/%v, want none", mock.RestartCalls, mock.ReloadCalls)
+	}
+}
+
+func TestApplySpecIncremental_ChangedUnitsCycled(t *testing.T) {
+	dir := t.TempDir()
+	mock := NewMockConnection()
+	a := NewApplierWithOptions(dir, mock, true)
+
+	units := func(enabled *bool) []mcov1alpha1.UnitSpec {
+		return []mcov1alpha1.UnitSpec{
+			{Name: "a.service", State: "restarted", Enabled: enabled},
+			{Name: "b.service", State: "restarted"},
+			{Name: "c.service", State: "restarted"},
+			{Name: "chronyd.service", State: "restarted"},
+			{Name: "d.service", State: "reloaded"},
+		}
+	}
+	sources := mcov1alpha1.RebootRequirements{
+		FileSources: map[string]string{
+			"/etc/systemd/system/b.service.d/override.conf": "b",
+			"/etc/chrony.conf": "chrony",
+		},
+		UnitSources: map[string]string{
+			"a.service": "a", "b.service": "b", "c.service": "c",
+			"chronyd.service": "chrony", "d.service": "d",
+		},
+	}
+	previous := &mcov1alpha1.RenderedMachineConfigSpec{
+		Config: mcov1alpha1.RenderedConfig{
+			Files: []mcov1alpha1.FileSpec{
+				{Path: "/etc/chrony.conf", Content: "server a", State: "present"},
+			},
+			Systemd: mcov1alpha1.SystemdSpec{Units: units(nil)},
+		},
+		RebootRequirements: sources,
+	}
+	if _, err := a.ApplySpec(context.Background(), previous); err != nil {
+		t.Fatalf("ApplySpec() error = %v", err)
+	}
+	mock.RestartCalls, mock.ReloadCalls = nil, nil
+
+	enabled := true
+	spec := &mcov1alpha1.RenderedMachineConfigSpec{
+		Config: mcov1alpha1.RenderedConfig{
+			Files: []mcov1alpha1.FileSpec{
+				{Path: "/etc/chrony.conf", Content: "server b", State: "present"},
+				{Path: "/etc/systemd/system/b.service.d/override.conf", Content: "[Service]\n", State: "present"},
+			},
+			Systemd: mcov1alpha1.SystemdSpec{Units: units(&enabled)},
+		},
+		RebootRequirements: sources,
+	}
+
+	if _, err := a.ApplySpecIncremental(context.Background(), previous, spec); err != nil {
+		t.Fatalf("ApplySpecIncremental() error = %v", err)
+	}
+	// a.service changed in the spec, b.service got a new drop-in and
+	// chronyd.service's MachineConfig changed the config file it reads.
+	want := []string{"a.service", "b.service", "chronyd.service"}
+	if strings.Join(mock.RestartCalls, ",") != strings.Join(want, ",") {
+		t.Errorf("RestartCalls = %v, want %v", mock.RestartCalls, want)
+	}
+	if len(mock.ReloadCalls) != 0 {
+		t.Errorf("ReloadCalls = %v, want none", mock.ReloadCalls)
+	}
+}
+
+func TestApplySpecIncremental_UnknownSourcesCycleOnFileChange(t *testing.T) {
+	dir := t.TempDir()
+	mock := NewMockConnection()
+	a := NewApplierWithOptions(dir, mock, true)
+
+	units := []mcov1alpha1.UnitSpec{{Name: "sshd.service", State: "reloaded"}}
+	previous := &mcov1alpha1.RenderedMachineConfigSpec{
+		Config: mcov1alpha1.RenderedConfig{Systemd: mcov1alpha1.SystemdSpec{Units: units}},
+	}
+	spec := &mcov1alpha1.RenderedMachineConfigSpec{
+		Config: mcov1alpha1.RenderedConfig{
+			Files:   []mcov1alpha1.FileSpec{{Path: "/etc/ssh/sshd_config", Content: "PermitRootLogin no", State: "present"}},
+			Systemd: mcov1alpha1.SystemdSpec{Units: units},
+		},
+	}
+
+	if _, err := a.ApplySpecIncremental(context.Background(), previous, spec); err != nil {
+		t.Fatalf("ApplySpecIncremental() error = %v", err)
+	}
+	if len(mock.ReloadCalls) != 1 || mock.ReloadCalls[0] != "sshd.service" {
+		t.Errorf("ReloadCalls = %v, want [sshd.service]", mock.ReloadCalls)
+	}
+}
+
+func TestApplySpecIncremental_RemovesDroppedFiles(t *testing.T) {
+	dir := t.TempDir()
+	a := NewApplierWithOptions(dir, NewMockConnection(), true)
+	ctx := context.Background()
+
+	// resolv.conf is a host file the revision took over, not one it created.
+	if err := os.MkdirAll(filepath.Join(dir, "/etc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "/etc/resolv.conf"), []byte("nameserver 1.1.1.1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	previous := &mcov1alpha1.RenderedMachineConfigSpec{
+		Config: mcov1alpha1.RenderedConfig{
+			Files: []mcov1alpha1.FileSpec{
+				{Path: "/etc/keep.conf", Content: "keep", State: "present"},
+				{Path: "/etc/old.conf", Content: "old", State: "present"},
+				{Path: "/etc/resolv.conf", Content: "nameserver 10.0.0.1\n", State: "present"},
+				{Path: "/etc/hosts", Content: "10.0.0.1 node", State: "line-present"},
+			},
+		},
+	}
+	if _, err := a.ApplySpec(ctx, previous); err != nil {
+		t.Fatalf("ApplySpec() error = %v", err)
+	}
+
+	spec := &mcov1alpha1.RenderedMachineConfigSpec{
+		Config: mcov1alpha1.RenderedConfig{
+			Files: []mcov1alpha1.FileSpec{
+				{Path: "/etc/keep.conf", Content: "keep", State: "present"},
+			},
+		},
+	}
+	result, err := a.ApplySpecIncremental(ctx, previous, spec)
+	if err != nil {
+		t.Fatalf("ApplySpecIncremental() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "/etc/old.conf")); !os.IsNotExist(err) {
+		t.Errorf("/etc/old.conf stat error = %v, want not exist", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "/etc/resolv.conf")); err != nil {
+		t.Errorf("pre-existing /etc/resolv.conf should be left in place: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "/etc/hosts")); err != nil {
+		t.Errorf("line-present /etc/hosts should be left in place: %v", err)
+	}
+	if len(result.ChangedFiles) != 1 || result.ChangedFiles[0] != "/etc/old.conf" {
+		t.Errorf("ChangedFiles = %v, want [/etc/old.conf]", result.ChangedFiles)
+	}
+
+	record, err := os.ReadFile(filepath.Join(dir, CreatedFilesPath))
+	if err != nil {
+		t.Fatalf("read created-files record: %v", err)
+	}
+	if got, want := string(record), "/etc/hosts\n/etc/keep.conf\n"; got != want {
+		t.Errorf("created-files record = %q, want %q", got, want)
+	}
+}
+
+func TestApplySpecIncremental_RevertKeepsPreexistingFilesAndUnits(t *testing.T) {
+	dir := t.TempDir()
+	mock := NewMockConnection()
+	a := NewApplierWithOptions(dir, mock, true)
+	ctx := context.Background()
+
+	if err := os.MkdirAll(filepath.Join(dir, "/etc"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "/etc/resolv.conf"), []byte("nameserver 1.1.1.1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	enabled := true
+	previous := &mcov1alpha1.RenderedMachineConfigSpec{
+		Config: mcov1alpha1.RenderedConfig{
+			Files: []mcov1alpha1.FileSpec{
+				{Path: "/etc/app.conf", Content: "app", State: "present"},
+				{Path: "/etc/resolv.conf", Content: "nameserver 10.0.0.1\n", State: "present"},
+			},
+			Systemd: mcov1alpha1.SystemdSpec{Units: []mcov1alpha1.UnitSpec{
+				{Name: "kubelet.service", Enabled: &enabled, State: "restarted"},
+			}},
+		},
+	}
+	if _, err := a.ApplySpec(ctx, previous); err != nil {
+		t.Fatalf("ApplySpec() error = %v", err)
+	}
+	mock.StopCalls, mock.DisableCalls = nil, nil
+
+	revert := renderer.BuildRMC("worker", renderer.RevertConfig(previous.Config), nil)
+	if _, err := a.ApplySpecIncremental(ctx, previous, &revert.Spec); err != nil {
+		t.Fatalf("ApplySpecIncremental() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "/etc/app.conf")); !os.IsNotExist(err) {
+		t.Errorf("/etc/app.conf stat error = %v, want the created file removed", err)
+	}
+	if got, err := os.ReadFile(filepath.Join(dir, "/etc/resolv.conf")); err != nil {
+		t.Errorf("pre-existing /etc/resolv.conf removed by the revert: %v", err)
+	} else if string(got) != "nameserver 10.0.0.1\n" {
+		t.Errorf("/etc/resolv.conf = %q, want its last content kept", got)
+	}
+	if len(mock.StopCalls) != 0 || len(mock.DisableCalls) != 0 {
+		t.Errorf("revert stopped %v, disabled %v; want kubelet.service left alone", mock.StopCalls, mock.DisableCalls)
+	}
+}
+
 func TestDryRun(t *testing.T) {
 	dir := t.TempDir()
 	mock := NewMockConnection()
@@ -511,3 +868,168 @@ func TestSortUnitsByName(t *testing.T) {
 		t.Error("Original slice was modified")
 	}
 }
+
+func TestApply_VerifyUnitFileFailureStops(t *testing.T) {
+	dir := t.TempDir()
+	mock := NewMockConnection()
+	mock.VerifyErrors = map[string]error{
+		StagingDir + "/etc/systemd/system/broken.service": errors.New("Unknown section 'Srvice'"),
+	}
+	a := NewApplierWithOptions(dir, mock, true)
+
+	config := &mcov1alpha1.RenderedConfig{
+		Files: []mcov1alpha1.FileSpec{
+			{Path: "/etc/systemd/system/broken.service", Content: "[Srvice]\n", State: "present"},
+			{Path: "/etc/systemd/system/broken.service.d/10-env.conf", Content: "[Service]\n", State: "present"},
+		},
+		Systemd: mcov1alpha1.SystemdSpec{
+			Units: []mcov1alpha1.UnitSpec{
+				{Name: "broken.service", Enabled: ptr.To(true), State: "restarted"},
+			},
+		},
+	}
+
+	result, err := a.Apply(context.Background(), config)
+	if err == nil {
+		t.Fatal("Apply() should error for an invalid unit file")
+	}
+	if !strings.Contains(err.Error(), "Unknown section 'Srvice'") {
+		t.Errorf("error = %q, want verifier output", err)
+	}
+	if result.Success {
+		t.Error("Apply() should not succeed")
+	}
+	if len(mock.EnableCalls) != 0 || len(mock.RestartCalls) != 0 {
+		t.Errorf("unit activated despite failed verify: enable=%v restart=%v", mock.EnableCalls, mock.RestartCalls)
+	}
+	// The staged copy failed, so the live config was never replaced.
+	for _, f := range config.Files {
+		if _, err := os.Stat(filepath.Join(dir, f.Path)); !os.IsNotExist(err) {
+			t.Errorf("%s written despite failed staged verify", f.Path)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, StagingDir)); !os.IsNotExist(err) {
+		t.Error("staging directory should be removed")
+	}
+}
+
+func TestApply_StagedValidationKeepsLiveFile(t *testing.T) {
+	dir := t.TempDir()
+	a := NewApplierWithOptions(dir, NewMockConnection(), true)
+	ctx := context.Background()
+
+	good := &mcov1alpha1.RenderedConfig{
+		Files: []mcov1alpha1.FileSpec{
+			{Path: "/etc/app.conf", Content: "v2", State: "present"},
+			{Path: "/etc/resolv.conf", Content: "nameserver 10.0.0.1\n", State: "present"},
+		},
+	}
+	if _, err := a.Apply(ctx, good); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	bad := &mcov1alpha1.RenderedConfig{
+		Files: []mcov1alpha1.FileSpec{
+			{Path: "/etc/app.conf", Content: "v3", State: "present"},
+			{Path: "/etc/resolv.conf", Content: "nameserver not-an-ip\n", State: "present"},
+		},
+	}
+	if _, err := a.Apply(ctx, bad); err == nil {
+		t.Fatal("Apply() should fail validation of the staged revision")
+	}
+	// /etc/app.conf sorts before /etc/resolv.conf, yet it is left alone.
+	if got, _ := os.ReadFile(filepath.Join(dir, "/etc/app.conf")); string(got) != "v2" {
+		t.Errorf("/etc/app.conf = %q, want the live v2 kept", got)
+	}
+}
+
+func TestApply_FailurePartwayRollsBackWrittenFiles(t *testing.T) {
+	dir := t.TempDir()
+	a := NewApplierWithOptions(dir, NewMockConnection(), true)
+	ctx := context.Background()
+
+	if _, err := a.Apply(ctx, &mcov1alpha1.RenderedConfig{
+		Files: []mcov1alpha1.FileSpec{{Path: "/etc/a.conf", Content: "v1", State: "present"}},
+	}); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	// A regular file where the revision needs a directory makes the last
+	// write fail after the others are already on disk.
+	if err := os.WriteFile(filepath.Join(dir, "/etc/zblocked"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := a.Apply(ctx, &mcov1alpha1.RenderedConfig{
+		Files: []mcov1alpha1.FileSpec{
+			{Path: "/etc/a.conf", Content: "v2", State: "present"},
+			{Path: "/etc/new.conf", Content: "new", State: "present"},
+			{Path: "/etc/zblocked/b.conf", Content: "b", State: "present"},
+		},
+	})
+	if err == nil {
+		t.Fatal("Apply() should fail writing /etc/zblocked/b.conf")
+	}
+
+	if got, _ := os.ReadFile(filepath.Join(dir, "/etc/a.conf")); string(got) != "v1" {
+		t.Errorf("/etc/a.conf = %q, want v1 restored", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "/etc/new.conf")); !os.IsNotExist(err) {
+		t.Errorf("/etc/new.conf stat error = %v, want not exist", err)
+	}
+	record, err := os.ReadFile(filepath.Join(dir, CreatedFilesPath))
+	if err != nil {
+		t.Fatalf("read created-files record: %v", err)
+	}
+	if got, want := string(record), "/etc/a.conf\n"; got != want {
+		t.Errorf("created-files record = %q, want %q", got, want)
+	}
+}
+
+func TestApply_VerifiesOnlyChangedUnitFiles(t *testing.T) {
+	dir := t.TempDir()
+	mock := NewMockConnection()
+	a := NewApplierWithOptions(dir, mock, true)
+	ctx := context.Background()
+
+	config := &mcov1alpha1.RenderedConfig{
+		Files: []mcov1alpha1.FileSpec{
+			{Path: "/etc/systemd/system/app.service", Content: "[Service]\nExecStart=/bin/true\n", State: "present"},
+			{Path: "/etc/systemd/system/app.service.d/override.conf", Content: "[Service]\n", State: "present"},
+			{Path: "/etc/app.conf", Content: "x", State: "present"},
+		},
+	}
+
+	if _, err := a.Apply(ctx, config); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if want := StagingDir + "/etc/systemd/system/app.service"; len(mock.VerifyCalls) != 1 || mock.VerifyCalls[0] != want {
+		t.Errorf("VerifyCalls = %v, want [%s]", mock.VerifyCalls, want)
+	}
+
+	// Unchanged files are not re-verified.
+	if _, err := a.Apply(ctx, config); err != nil {
+		t.Fatalf("second Apply() error = %v", err)
+	}
+	if len(mock.VerifyCalls) != 1 {
+		t.Errorf("VerifyCalls after re-apply = %v, want 1 call", mock.VerifyCalls)
+	}
+}
+
+func TestIsUnitFilePath(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"/etc/systemd/system/app.service", true},
+		{"/run/systemd/system/app.timer", true},
+		{"/etc/systemd/system/app.service.d/override.conf", false},
+		{"/etc/systemd/system/multi-user.target.wants/app.service", false},
+		{"/etc/app.service", false},
+		{"/etc/systemd/system/notes.txt", false},
+	}
+	for _, tt := range tests {
+		if got := isUnitFilePath(tt.path); got != tt.want {
+			t.Errorf("isUnitFilePath(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
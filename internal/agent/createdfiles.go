@@ -0,0 +1,109 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/renameio/v2"
+)
+
+// CreatedFilesPath is the host file listing the paths the agent created,
+// one per line. Only these are deleted when a revision drops them; files
+// that existed before the agent first wrote them, such as /etc/resolv.conf,
+// are left with their last content.
+const CreatedFilesPath = "/var/lib/mco/created-files"
+
+// createdFiles is the set of paths recorded in CreatedFilesPath.
+type createdFiles struct {
+	path  string
+	paths map[string]bool
+	dirty bool
+}
+
+// loadCreatedFiles reads the created-files record below hostRoot. A missing
+// record is an empty set.
+func loadCreatedFiles(hostRoot string) (*createdFiles, error) {
+	c := &createdFiles{
+		path:  filepath.Join(hostRoot, CreatedFilesPath),
+		paths: make(map[string]bool),
+	}
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", CreatedFilesPath, err)
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line != "" {
+			c.paths[line] = true
+		}
+	}
+	return c, nil
+}
+
+// Has reports whether the agent created path.
+func (c *createdFiles) Has(path string) bool {
+	return c.paths[path]
+}
+
+// Add records that the agent created path.
+func (c *createdFiles) Add(path string) {
+	if !c.paths[path] {
+		c.paths[path] = true
+		c.dirty = true
+	}
+}
+
+// Remove forgets path once the file is gone.
+func (c *createdFiles) Remove(path string) {
+	if c.paths[path] {
+		delete(c.paths, path)
+		c.dirty = true
+	}
+}
+
+// Save writes the record back if it changed.
+func (c *createdFiles) Save() error {
+	if !c.dirty {
+		return nil
+	}
+	paths := make([]string, 0, len(c.paths))
+	for p := range c.paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	content := strings.Join(paths, "\n")
+	if content != "" {
+		content += "\n"
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return fmt.Errorf("write %s: %w", CreatedFilesPath, err)
+	}
+	if err := renameio.WriteFile(c.path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("write %s: %w", CreatedFilesPath, err)
+	}
+	c.dirty = false
+	return nil
+}
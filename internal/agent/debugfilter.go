@@ -0,0 +1,69 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+// debugApplyEntries returns the entries of the debug-apply-paths annotation,
+// or nil when it is absent or empty.
+func debugApplyEntries(node *corev1.Node) []string {
+	var entries []string
+	for _, e := range strings.Split(annotations.GetAnnotation(node.Annotations, annotations.DebugApplyPaths), ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// filterSpecForDebug returns a copy of spec that only keeps the files under
+// one of the path prefixes in entries and the units named in entries.
+// Entries starting with "/" are path prefixes, the rest unit names.
+func filterSpecForDebug(spec *mcov1alpha1.RenderedMachineConfigSpec, entries []string) *mcov1alpha1.RenderedMachineConfigSpec {
+	filtered := spec.DeepCopy()
+
+	files := filtered.Config.Files[:0]
+	for _, f := range filtered.Config.Files {
+		for _, e := range entries {
+			if strings.HasPrefix(e, "/") && strings.HasPrefix(f.Path, e) {
+				files = append(files, f)
+				break
+			}
+		}
+	}
+	filtered.Config.Files = files
+
+	units := filtered.Config.Systemd.Units[:0]
+	for _, u := range filtered.Config.Systemd.Units {
+		for _, e := range entries {
+			if e == u.Name {
+				units = append(units, u)
+				break
+			}
+		}
+	}
+	filtered.Config.Systemd.Units = units
+
+	return filtered
+}
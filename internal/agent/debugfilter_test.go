@@ -0,0 +1,162 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+func TestFilterSpecForDebug(t *testing.T) {
+	spec := &mcov1alpha1.RenderedMachineConfigSpec{
+		Config: mcov1alpha1.RenderedConfig{
+			Files: []mcov1alpha1.FileSpec{
+				{Path: "/etc/sysctl.d/90-app.conf"},
+				{Path: "/etc/chrony.conf"},
+				{Path: "/etc/systemd/system/app.service"},
+			},
+			Systemd: mcov1alpha1.SystemdSpec{
+				Units: []mcov1alpha1.UnitSpec{{Name: "app.service"}, {Name: "chronyd.service"}},
+			},
+		},
+	}
+
+	got := filterSpecForDebug(spec, []string{"/etc/sysctl.d/", "app.service"})
+
+	if len(got.Config.Files) != 1 || got.Config.Files[0].Path != "/etc/sysctl.d/90-app.conf" {
+		t.Errorf("Files = %v, want only /etc/sysctl.d/90-app.conf", got.Config.Files)
+	}
+	if len(got.Config.Systemd.Units) != 1 || got.Config.Systemd.Units[0].Name != "app.service" {
+		t.Errorf("Units = %v, want only app.service", got.Config.Systemd.Units)
+	}
+	if len(spec.Config.Files) != 3 || len(spec.Config.Systemd.Units) != 2 {
+		t.Error("filterSpecForDebug modified the original spec")
+	}
+}
+
+func TestDebugApplyEntries(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+		annotations.DebugApplyPaths: " /etc/a, ,app.service ",
+	}}}
+	got := debugApplyEntries(node)
+	if len(got) != 2 || got[0] != "/etc/a" || got[1] != "app.service" {
+		t.Errorf("debugApplyEntries() = %q", got)
+	}
+	if got := debugApplyEntries(&corev1.Node{}); got != nil {
+		t.Errorf("debugApplyEntries() without annotation = %q, want nil", got)
+	}
+}
+
+func TestAgent_ApplyConfig_DebugApplyPaths(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+			Annotations: map[string]string{
+				annotations.DesiredRevision: "rev-1",
+				annotations.DebugApplyPaths: "/etc/a.conf",
+			},
+		},
+	}
+	rmc := &mcov1alpha1.RenderedMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "rev-1"},
+		Spec: mcov1alpha1.RenderedMachineConfigSpec{
+			Config: mcov1alpha1.RenderedConfig{
+				Files: []mcov1alpha1.FileSpec{
+					{Path: "/etc/a.conf", Content: "a"},
+					{Path: "/etc/b.conf", Content: "b"},
+				},
+			},
+		},
+	}
+	k8sClient := fake.NewSimpleClientset(node)
+	dir := t.TempDir()
+	agent := newTestAgent("test-node", k8sClient, newMockMCOClient())
+	agent.applier = NewApplierWithOptions(dir, NewMockConnection(), true)
+	agent.debugApply = true
+
+	ctx := context.Background()
+	if err := agent.applyConfig(ctx, rmc, node); err != nil {
+		t.Fatalf("applyConfig() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "/etc/a.conf")); err != nil {
+		t.Errorf("selected file not applied: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "/etc/b.conf")); !os.IsNotExist(err) {
+		t.Error("unselected file was applied")
+	}
+
+	updated, _ := k8sClient.CoreV1().Nodes().Get(ctx, "test-node", metav1.GetOptions{})
+	if got := updated.Annotations[annotations.AgentState]; got != annotations.StateError {
+		t.Errorf("state = %q, want %q", got, annotations.StateError)
+	}
+	if _, ok := updated.Annotations[annotations.CurrentRevision]; ok {
+		t.Error("partial apply must not set current-revision")
+	}
+	if msg := updated.Annotations[annotations.LastError]; !strings.Contains(msg, "applied 1 of 2 files") {
+		t.Errorf("last-error = %q", msg)
+	}
+}
+
+func TestAgent_ApplyConfig_DebugApplyPathsNotAllowed(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+			Annotations: map[string]string{
+				annotations.DesiredRevision: "rev-1",
+				annotations.DebugApplyPaths: "/etc/a.conf",
+			},
+		},
+	}
+	rmc := &mcov1alpha1.RenderedMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "rev-1"},
+		Spec: mcov1alpha1.RenderedMachineConfigSpec{
+			Config: mcov1alpha1.RenderedConfig{
+				Files: []mcov1alpha1.FileSpec{
+					{Path: "/etc/a.conf", Content: "a"},
+					{Path: "/etc/b.conf", Content: "b"},
+				},
+			},
+		},
+	}
+	k8sClient := fake.NewSimpleClientset(node)
+	dir := t.TempDir()
+	agent := newTestAgent("test-node", k8sClient, newMockMCOClient())
+	agent.applier = NewApplierWithOptions(dir, NewMockConnection(), true)
+
+	ctx := context.Background()
+	if err := agent.applyConfig(ctx, rmc, node); err != nil {
+		t.Fatalf("applyConfig() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "/etc/b.conf")); err != nil {
+		t.Errorf("annotation must be ignored without --allow-debug-apply-paths: %v", err)
+	}
+	updated, _ := k8sClient.CoreV1().Nodes().Get(ctx, "test-node", metav1.GetOptions{})
+	if got := updated.Annotations[annotations.CurrentRevision]; got != "rev-1" {
+		t.Errorf("current-revision = %q, want rev-1", got)
+	}
+}
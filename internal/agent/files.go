@@ -18,6 +18,7 @@ package agent
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"os"
@@ -25,18 +26,36 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/google/renameio/v2"
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/filecontent"
 )
 
 // File state constants.
 const (
-	FileStatePresent = "present"
-	FileStateAbsent  = "absent"
+	FileStatePresent     = "present"
+	FileStateAbsent      = "absent"
+	FileStateLinePresent = "line-present"
 )
 
+// Per-file retry defaults. A file whose write fails with a transient error
+// (busy file, permission flapping while another process holds it) is tried
+// up to DefaultFileApplyAttempts times, waiting DefaultFileRetryDelay before
+// the second attempt and twice as long before each further one.
+const (
+	DefaultFileApplyAttempts = 3
+	DefaultFileRetryDelay    = 200 * time.Millisecond
+)
+
+// ErrChecksumMismatch is returned when a file read back after writing does
+// not match the content the agent meant to write, e.g. a short write on a
+// full disk. The previous file is left in place.
+var ErrChecksumMismatch = errors.New("written content does not match expected checksum")
+
 // FileApplyResult contains the result of a file apply operation.
 type FileApplyResult struct {
 	Path    string
@@ -63,13 +82,19 @@ var _ FileOperations = (*FileApplier)(nil)
 type FileApplier struct {
 	hostRoot      string // e.g., "/host" for container, "" for direct
 	skipOwnership bool   // skip chown (for testing as non-root)
+
+	attempts   int                 // tries per file on transient errors
+	retryDelay time.Duration       // wait before the first retry, doubled after
+	sleep      func(time.Duration) // replaced in tests
+
+	write func(f *os.File, content []byte) (int, error) // replaced in tests
 }
 
 // NewFileApplier creates a new file applier.
 // hostRoot is the path prefix for all file operations (e.g., "/host" when
 // running as a container with the host filesystem mounted).
 func NewFileApplier(hostRoot string) *FileApplier {
-	return &FileApplier{hostRoot: hostRoot}
+	return NewFileApplierWithOptions(hostRoot, false)
 }
 
 // NewFileApplierWithOptions creates a file applier with options.
@@ -77,14 +102,56 @@ func NewFileApplierWithOptions(hostRoot string, skipOwnership bool) *FileApplier
 	return &FileApplier{
 		hostRoot:      hostRoot,
 		skipOwnership: skipOwnership,
+		attempts:      DefaultFileApplyAttempts,
+		retryDelay:    DefaultFileRetryDelay,
+		sleep:         time.Sleep,
+		write:         (*os.File).Write,
 	}
 }
 
 // Apply applies a single file spec.
 // For state=absent, the file is deleted if it exists.
 // For state=present (default), the file is written atomically.
+// For state=line-present, the line in Content is appended if missing.
 // Returns a result indicating whether the file was modified.
+// Transient failures are retried with backoff before the error is returned.
 func (a *FileApplier) Apply(f mcov1alpha1.FileSpec) FileApplyResult {
+	return a.withRetries(f.Path, func() FileApplyResult { return a.applyOnce(f) })
+}
+
+// withRetries runs apply until it succeeds, fails permanently or runs out
+// of attempts. A failure after the file was already changed (e.g. chown
+// after the write) is not retried: the next attempt would see the new
+// content and skip the file.
+func (a *FileApplier) withRetries(path string, apply func() FileApplyResult) FileApplyResult {
+	delay := a.retryDelay
+	for attempt := 1; ; attempt++ {
+		result := apply()
+		if result.Error == nil || result.Applied || attempt >= a.attempts || !isTransientFileError(result.Error) {
+			return result
+		}
+		agentLog.Info("transient file apply error, retrying",
+			"path", path, "attempt", attempt, "after", delay, "error", result.Error)
+		a.sleep(delay)
+		delay *= 2
+	}
+}
+
+// isTransientFileError reports whether err may go away on its own, such as
+// a busy file or a permission error while another process holds the file.
+func isTransientFileError(err error) bool {
+	for _, errno := range []syscall.Errno{
+		syscall.EBUSY, syscall.ETXTBSY, syscall.EAGAIN, syscall.EINTR, syscall.EACCES, syscall.EPERM,
+	} {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyOnce makes a single attempt at applying f.
+func (a *FileApplier) applyOnce(f mcov1alpha1.FileSpec) FileApplyResult {
 	result := FileApplyResult{Path: f.Path}
 
 	if !filepath.IsAbs(f.Path) {
@@ -105,9 +172,19 @@ func (a *FileApplier) Apply(f mcov1alpha1.FileSpec) FileApplyResult {
 		result.Applied = applied
 		result.Error = err
 	case FileStatePresent:
+		if _, ok := hostFileValidators[f.Path]; ok {
+			applied, err := a.writeValidatedFile(path, f)
+			result.Applied = applied
+			result.Error = err
+			break
+		}
 		applied, err := a.writeFile(path, f)
 		result.Applied = applied
 		result.Error = err
+	case FileStateLinePresent:
+		applied, err := a.ensureLine(path, f)
+		result.Applied = applied
+		result.Error = err
 	default:
 		result.Error = fmt.Errorf("unknown state: %s", state)
 	}
@@ -142,10 +219,13 @@ func (a *FileApplier) deleteFile(path string) (bool, error) {
 }
 
 func (a *FileApplier) writeFile(path string, f mcov1alpha1.FileSpec) (bool, error) {
-	content := []byte(f.Content)
+	content, err := filecontent.Decode(f)
+	if err != nil {
+		return false, err
+	}
 
 	if !a.needsUpdate(path, content) {
-		return false, nil
+		return a.fixMetadata(path, f)
 	}
 
 	dir := filepath.Dir(path)
@@ -153,10 +233,7 @@ func (a *FileApplier) writeFile(path string, f mcov1alpha1.FileSpec) (bool, erro
 		return false, fmt.Errorf("create directory %s: %w", dir, err)
 	}
 
-	mode := os.FileMode(f.Mode)
-	if mode == 0 {
-		mode = 0644
-	}
+	mode := fileMode(f)
 
 	t, err := renameio.TempFile(dir, path)
 	if err != nil {
@@ -164,8 +241,8 @@ func (a *FileApplier) writeFile(path string, f mcov1alpha1.FileSpec) (bool, erro
 	}
 	defer func() { _ = t.Cleanup() }()
 
-	if _, err := t.Write(content); err != nil {
-		return false, fmt.Errorf("write content: %w", err)
+	if err := a.writeVerified(t, content); err != nil {
+		return false, err
 	}
 
 	if err := t.Chmod(mode); err != nil {
@@ -185,6 +262,43 @@ func (a *FileApplier) writeFile(path string, f mcov1alpha1.FileSpec) (bool, erro
 	return true, nil
 }
 
+// writeValidatedFile writes a file that has a validator, such as
+// /etc/resolv.conf. Invalid content is rejected before anything is
+// written; the current file is kept as a known-good copy and restored if
+// the written file does not validate.
+func (a *FileApplier) writeValidatedFile(path string, f mcov1alpha1.FileSpec) (bool, error) {
+	content, err := filecontent.Decode(f)
+	if err != nil {
+		return false, err
+	}
+	if !a.needsUpdate(path, content) {
+		return a.fixMetadata(path, f)
+	}
+	if err := ValidateHostFile(f.Path, content); err != nil {
+		return false, fmt.Errorf("rejected: %w", err)
+	}
+	if err := saveKnownGood(f.Path, path); err != nil {
+		return false, fmt.Errorf("save known-good copy: %w", err)
+	}
+
+	applied, err := a.writeFile(path, f)
+	if err != nil {
+		return applied, err
+	}
+
+	written, err := os.ReadFile(path)
+	if err == nil {
+		err = ValidateHostFile(f.Path, written)
+	}
+	if err != nil {
+		if restoreErr := restoreKnownGood(path); restoreErr != nil {
+			return true, fmt.Errorf("written file invalid (%v), restore failed: %w", err, restoreErr)
+		}
+		return true, fmt.Errorf("written file invalid, restored known-good copy: %w", err)
+	}
+	return applied, nil
+}
+
 func (a *FileApplier) needsUpdate(path string, content []byte) bool {
 	existing, err := os.ReadFile(path)
 	if err != nil {
@@ -193,26 +307,109 @@ func (a *FileApplier) needsUpdate(path string, content []byte) bool {
 	return !bytes.Equal(existing, content)
 }
 
+// writeVerified writes content to the pending temp file, syncs it and reads
+// it back, comparing SHA-256 sums. A mismatch fails with ErrChecksumMismatch
+// before the temp file replaces anything, so the previous file stays in
+// place for the next attempt.
+func (a *FileApplier) writeVerified(t *renameio.PendingFile, content []byte) error {
+	if _, err := a.write(t.File, content); err != nil {
+		return fmt.Errorf("write content: %w", err)
+	}
+	if err := t.Sync(); err != nil {
+		return fmt.Errorf("sync: %w", err)
+	}
+	written, err := os.ReadFile(t.Name())
+	if err != nil {
+		return fmt.Errorf("read back: %w", err)
+	}
+	if sha256.Sum256(written) != sha256.Sum256(content) {
+		return fmt.Errorf("%w: read back %d of %d bytes", ErrChecksumMismatch, len(written), len(content))
+	}
+	return nil
+}
+
+// fileMode returns the permission bits f asks for, 0644 when unset.
+func fileMode(f mcov1alpha1.FileSpec) os.FileMode {
+	mode := os.FileMode(f.Mode)
+	if mode == 0 {
+		mode = 0644
+	}
+	return mode
+}
+
+// fixMetadata corrects the mode and ownership of a file whose content
+// already matches f with chmod and chown, without rewriting it. It reports
+// whether anything was changed.
+func (a *FileApplier) fixMetadata(path string, f mcov1alpha1.FileSpec) (bool, error) {
+	modeDrift, ownerDrift, err := a.metadataDrift(path, f)
+	if err != nil {
+		return false, err
+	}
+
+	if modeDrift {
+		if err := os.Chmod(path, fileMode(f)); err != nil {
+			return false, fmt.Errorf("set mode: %w", err)
+		}
+	}
+	if ownerDrift {
+		if err := a.setOwnership(path, f.Owner); err != nil {
+			return modeDrift, fmt.Errorf("set ownership: %w", err)
+		}
+	}
+	return modeDrift || ownerDrift, nil
+}
+
+// metadataDrift reports whether the mode or, unless ownership is skipped,
+// the owner of the file at path differ from f.
+func (a *FileApplier) metadataDrift(path string, f mcov1alpha1.FileSpec) (mode, owner bool, err error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, false, fmt.Errorf("stat: %w", err)
+	}
+	mode = info.Mode().Perm() != fileMode(f).Perm()
+
+	if a.skipOwnership {
+		return mode, false, nil
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return mode, false, nil
+	}
+	uid, gid, err := a.resolveOwner(f.Owner)
+	if err != nil {
+		return mode, false, fmt.Errorf("resolve owner: %w", err)
+	}
+	return mode, int(stat.Uid) != uid || int(stat.Gid) != gid, nil
+}
+
 func (a *FileApplier) setOwnership(path, owner string) error {
+	uid, gid, err := a.resolveOwner(owner)
+	if err != nil {
+		return err
+	}
+	return os.Chown(path, uid, gid)
+}
+
+// resolveOwner turns a user:group owner, root:root when empty, into ids.
+func (a *FileApplier) resolveOwner(owner string) (int, int, error) {
 	if owner == "" {
 		owner = "root:root"
 	}
 
 	parts := strings.Split(owner, ":")
 	if len(parts) != 2 {
-		return fmt.Errorf("invalid owner format (expected user:group): %s", owner)
+		return 0, 0, fmt.Errorf("invalid owner format (expected user:group): %s", owner)
 	}
 
 	uid, err := a.lookupUID(parts[0])
 	if err != nil {
-		return fmt.Errorf("lookup user %s: %w", parts[0], err)
+		return 0, 0, fmt.Errorf("lookup user %s: %w", parts[0], err)
 	}
 	gid, err := a.lookupGID(parts[1])
 	if err != nil {
-		return fmt.Errorf("lookup group %s: %w", parts[1], err)
+		return 0, 0, fmt.Errorf("lookup group %s: %w", parts[1], err)
 	}
-
-	return os.Chown(path, uid, gid)
+	return uid, gid, nil
 }
 
 func (a *FileApplier) lookupUID(s string) (int, error) {
@@ -255,11 +452,11 @@ func (a *FileApplier) NeedsUpdate(f mcov1alpha1.FileSpec) (bool, error) {
 	path := filepath.Join(a.hostRoot, f.Path)
 	state := f.State
 	if state == "" {
-		state = "present"
+		state = FileStatePresent
 	}
 
 	switch state {
-	case "absent":
+	case FileStateAbsent:
 		_, err := os.Stat(path)
 		if errors.Is(err, os.ErrNotExist) {
 			return false, nil
@@ -268,8 +465,21 @@ func (a *FileApplier) NeedsUpdate(f mcov1alpha1.FileSpec) (bool, error) {
 			return false, err
 		}
 		return true, nil
-	case "present":
-		return a.needsUpdate(path, []byte(f.Content)), nil
+	case FileStatePresent:
+		content, err := filecontent.Decode(f)
+		if err != nil {
+			return false, err
+		}
+		if a.needsUpdate(path, content) {
+			return true, nil
+		}
+		mode, owner, err := a.metadataDrift(path, f)
+		if err != nil {
+			return false, err
+		}
+		return mode || owner, nil
+	case FileStateLinePresent:
+		return a.lineMissing(path, f.Content)
 	default:
 		return false, fmt.Errorf("unknown state: %s", state)
 	}
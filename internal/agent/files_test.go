@@ -17,9 +17,14 @@ limitations under the License.
 package agent
 
 import (
+	"encoding/base64"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"syscall"
 	"testing"
+	"time"
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
 )
@@ -64,6 +69,53 @@ func TestApply_CreateFile(t *testing.T) {
 	}
 }
 
+func TestApply_Base64Content(t *testing.T) {
+	dir := t.TempDir()
+	a := NewFileApplierWithOptions(dir, true)
+
+	payload := []byte("\x00\x01binary")
+	f := mcov1alpha1.FileSpec{
+		Path:     "/etc/blob.bin",
+		Content:  base64.StdEncoding.EncodeToString(payload),
+		Encoding: "base64",
+		State:    "present",
+	}
+
+	if result := a.Apply(f); result.Error != nil || !result.Applied {
+		t.Fatalf("Apply() = %+v, want applied", result)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, f.Path))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(content) != string(payload) {
+		t.Errorf("content = %q, want decoded %q", content, payload)
+	}
+
+	if result := a.Apply(f); result.Error != nil || result.Applied {
+		t.Errorf("second Apply() = %+v, want no change", result)
+	}
+}
+
+func TestApply_InvalidEncodedContent(t *testing.T) {
+	dir := t.TempDir()
+	a := NewFileApplierWithOptions(dir, true)
+
+	f := mcov1alpha1.FileSpec{
+		Path:     "/etc/blob.bin",
+		Content:  base64.StdEncoding.EncodeToString([]byte("not gzip")),
+		Encoding: "gzip+base64",
+		State:    "present",
+	}
+
+	if result := a.Apply(f); result.Error == nil {
+		t.Fatal("Apply() should fail for a corrupt gzip stream")
+	}
+	if _, err := os.Stat(filepath.Join(dir, f.Path)); !os.IsNotExist(err) {
+		t.Errorf("file should not be written, stat error = %v", err)
+	}
+}
+
 func TestApply_CreateFileCreatesDirectory(t *testing.T) {
 	dir := t.TempDir()
 	a := NewFileApplierWithOptions(dir, true)
@@ -267,6 +319,78 @@ func TestApply_CustomMode(t *testing.T) {
 	}
 }
 
+// inode returns the inode number of path, which changes when the file is
+// rewritten through a temp file and rename.
+func inode(t *testing.T, path string) uint64 {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat: %v", err)
+	}
+	return info.Sys().(*syscall.Stat_t).Ino
+}
+
+func TestApply_ModeDriftFixedInPlace(t *testing.T) {
+	dir := t.TempDir()
+	a := NewFileApplierWithOptions(dir, true)
+	f := mcov1alpha1.FileSpec{Path: "/etc/test.conf", Content: "same", Mode: 0600, State: "present"}
+	if result := a.Apply(f); result.Error != nil {
+		t.Fatalf("Apply() error = %v", result.Error)
+	}
+	path := filepath.Join(dir, f.Path)
+	if err := os.Chmod(path, 0666); err != nil {
+		t.Fatal(err)
+	}
+	before := inode(t, path)
+
+	if needs, err := a.NeedsUpdate(f); err != nil || !needs {
+		t.Fatalf("NeedsUpdate() = %v, %v, want true", needs, err)
+	}
+	result := a.Apply(f)
+	if result.Error != nil || !result.Applied {
+		t.Fatalf("Apply() = %+v, want applied", result)
+	}
+	info, _ := os.Stat(path)
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %o, want 0600", info.Mode().Perm())
+	}
+	if inode(t, path) != before {
+		t.Error("file was rewritten, want chmod in place")
+	}
+	if result := a.Apply(f); result.Applied {
+		t.Error("second Apply() reported a change")
+	}
+}
+
+func TestApply_OwnerDriftFixedInPlace(t *testing.T) {
+	if os.Geteuid() != 0 {
+		t.Skip("chown needs root")
+	}
+	dir := t.TempDir()
+	a := NewFileApplier(dir)
+	f := mcov1alpha1.FileSpec{Path: "/etc/test.conf", Content: "same", Owner: "0:0", State: "present"}
+	if result := a.Apply(f); result.Error != nil {
+		t.Fatalf("Apply() error = %v", result.Error)
+	}
+	path := filepath.Join(dir, f.Path)
+	if err := os.Chown(path, 1000, 1000); err != nil {
+		t.Fatal(err)
+	}
+	before := inode(t, path)
+
+	result := a.Apply(f)
+	if result.Error != nil || !result.Applied {
+		t.Fatalf("Apply() = %+v, want applied", result)
+	}
+	info, _ := os.Stat(path)
+	if stat := info.Sys().(*syscall.Stat_t); stat.Uid != 0 || stat.Gid != 0 {
+		t.Errorf("owner = %d:%d, want 0:0", stat.Uid, stat.Gid)
+	}
+	if inode(t, path) != before {
+		t.Error("file was rewritten, want chown in place")
+	}
+}
+
 func TestApply_RelativePathError(t *testing.T) {
 	a := NewFileApplier("")
 
@@ -530,3 +654,113 @@ func TestApply_OwnerFormat(t *testing.T) {
 		t.Error("Apply() should error on invalid owner format")
 	}
 }
+
+func TestApply_RetriesTransientErrors(t *testing.T) {
+	busy := fmt.Errorf("atomic replace: %w", &os.PathError{Op: "rename", Path: "/etc/app.conf", Err: syscall.EBUSY})
+
+	tests := []struct {
+		name       string
+		results    []FileApplyResult
+		wantCalls  int
+		wantErr    bool
+		wantSleeps []time.Duration
+	}{
+		{
+			name:       "transient failure then success",
+			results:    []FileApplyResult{{Error: busy}, {Error: busy}, {Applied: true}},
+			wantCalls:  3,
+			wantSleeps: []time.Duration{DefaultFileRetryDelay, 2 * DefaultFileRetryDelay},
+		},
+		{
+			name:       "gives up after the last attempt",
+			results:    []FileApplyResult{{Error: busy}, {Error: busy}, {Error: busy}, {Applied: true}},
+			wantCalls:  DefaultFileApplyAttempts,
+			wantErr:    true,
+			wantSleeps: []time.Duration{DefaultFileRetryDelay, 2 * DefaultFileRetryDelay},
+		},
+		{
+			name:      "permanent error is not retried",
+			results:   []FileApplyResult{{Error: errors.New("unknown state: bogus")}, {Applied: true}},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "failure after the file changed is not retried",
+			results:   []FileApplyResult{{Applied: true, Error: busy}, {Applied: true}},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewFileApplierWithOptions(t.TempDir(), true)
+			var sleeps []time.Duration
+			a.sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+
+			calls := 0
+			result := a.withRetries("/etc/app.conf", func() FileApplyResult {
+				r := tt.results[calls]
+				calls++
+				return r
+			})
+
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if (result.Error != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", result.Error, tt.wantErr)
+			}
+			if fmt.Sprint(sleeps) != fmt.Sprint(tt.wantSleeps) {
+				t.Errorf("sleeps = %v, want %v", sleeps, tt.wantSleeps)
+			}
+		})
+	}
+}
+
+func TestIsTransientFileError(t *testing.T) {
+	if !isTransientFileError(fmt.Errorf("write: %w", &os.PathError{Op: "open", Path: "/x", Err: syscall.EACCES})) {
+		t.Error("EACCES should be transient")
+	}
+	if isTransientFileError(fmt.Errorf("write: %w", &os.PathError{Op: "open", Path: "/x", Err: syscall.EROFS})) {
+		t.Error("EROFS should not be transient")
+	}
+	if isTransientFileError(errors.New("path must be absolute: x")) {
+		t.Error("plain error should not be transient")
+	}
+}
+
+func TestApply_ShortWriteKeepsPreviousFile(t *testing.T) {
+	dir := t.TempDir()
+	a := NewFileApplierWithOptions(dir, true)
+	f := mcov1alpha1.FileSpec{Path: "/etc/sysctl.d/99-mco.conf", Content: "vm.swappiness = 10\n", State: "present"}
+	if result := a.Apply(f); result.Error != nil {
+		t.Fatalf("Apply() error = %v", result.Error)
+	}
+
+	// A full disk can truncate a write that still reports success.
+	a.write = func(file *os.File, content []byte) (int, error) {
+		_, err := file.Write(content[:len(content)/2])
+		return len(content), err
+	}
+	f.Content = "vm.swappiness = 60\nkernel.panic = 10\n"
+
+	result := a.Apply(f)
+	if !errors.Is(result.Error, ErrChecksumMismatch) {
+		t.Fatalf("Apply() error = %v, want ErrChecksumMismatch", result.Error)
+	}
+	if result.Applied {
+		t.Error("Applied = true for a failed write")
+	}
+	got, err := os.ReadFile(filepath.Join(dir, f.Path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "vm.swappiness = 10\n" {
+		t.Errorf("content = %q, want previous content kept", got)
+	}
+
+	a.write = (*os.File).Write
+	if result := a.Apply(f); result.Error != nil || !result.Applied {
+		t.Fatalf("retry Apply() = %+v, want applied", result)
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// ImagePuller pulls container images into the node's container runtime.
+type ImagePuller interface {
+	Pull(ctx context.Context, image string) error
+}
+
+// CrictlPuller pulls images with crictl on the host via chroot.
+type CrictlPuller struct {
+	hostRoot string
+}
+
+// NewCrictlPuller creates a new crictl-based image puller.
+func NewCrictlPuller(hostRoot string) *CrictlPuller {
+	return &CrictlPuller{hostRoot: hostRoot}
+}
+
+// Pull pulls a single image using the host's crictl.
+func (p *CrictlPuller) Pull(ctx context.Context, image string) error {
+	cmd := exec.CommandContext(ctx, "chroot", p.hostRoot, "crictl", "pull", image)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("crictl pull %s: %w: %s", image, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// NoOpPuller records pulled images without contacting a runtime.
+// Useful for testing and when the agent runs without host access.
+type NoOpPuller struct {
+	Pulled []string
+}
+
+// Pull records the image.
+func (p *NoOpPuller) Pull(_ context.Context, image string) error {
+	p.Pulled = append(p.Pulled, image)
+	return nil
+}
+
+// PrepullImages pulls every image in order and stops at the first failure.
+func PrepullImages(ctx context.Context, puller ImagePuller, images []string) error {
+	for _, image := range images {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := puller.Pull(ctx, image); err != nil {
+			return fmt.Errorf("prepull image %s: %w", image, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPrepullImages_PullsAllInOrder(t *testing.T) {
+	puller := &NoOpPuller{}
+	images := []string{"a:1", "b:2", "c:3"}
+
+	if err := PrepullImages(context.Background(), puller, images); err != nil {
+		t.Fatalf("PrepullImages() error = %v", err)
+	}
+	if strings.Join(puller.Pulled, ",") != "a:1,b:2,c:3" {
+		t.Errorf("pulled = %v, want %v", puller.Pulled, images)
+	}
+}
+
+func TestPrepullImages_StopsOnError(t *testing.T) {
+	err := PrepullImages(context.Background(), failingPuller{}, []string{"a:1", "b:2"})
+	if err == nil {
+		t.Fatal("PrepullImages() error = nil, want error")
+	}
+	if !strings.Contains(err.Error(), "a:1") {
+		t.Errorf("error = %v, want failing image name", err)
+	}
+}
+
+func TestPrepullImages_CanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	puller := &NoOpPuller{}
+	err := PrepullImages(ctx, puller, []string{"a:1"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("PrepullImages() error = %v, want context.Canceled", err)
+	}
+	if len(puller.Pulled) != 0 {
+		t.Errorf("pulled = %v, want none", puller.Pulled)
+	}
+}
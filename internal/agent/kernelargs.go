@@ -0,0 +1,317 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/renameio/v2"
+)
+
+// KernelArgsFile is the GRUB drop-in holding the managed kernel arguments
+// on hosts whose update-grub reads /etc/default/grub.d (Debian, Ubuntu).
+// GRUB sources it after /etc/default/grub, so the arguments are appended
+// to the host's own GRUB_CMDLINE_LINUX.
+const KernelArgsFile = "/etc/default/grub.d/99-mco-kernel-args.cfg"
+
+// KernelArgsStateFile records the managed kernel arguments on BLS hosts
+// (RHEL 8+, Fedora), where grubby edits the boot entries directly.
+const KernelArgsStateFile = "/var/lib/mco/kernel-args"
+
+const kernelArgsHeader = "# Managed by machine-config-operator. Do not edit.\n"
+
+// updateGrubPath is the only GRUB generator that reads KernelArgsFile;
+// grub-mkconfig and grub2-mkconfig ignore /etc/default/grub.d.
+const updateGrubPath = "/usr/sbin/update-grub"
+
+// grubbyPaths are where grubby is looked up on the host, in order.
+var grubbyPaths = []string{"/usr/sbin/grubby", "/usr/bin/grubby"}
+
+// errNoBootloaderTooling is returned when kernel arguments must change but
+// the host has neither update-grub nor grubby.
+var errNoBootloaderTooling = errors.New(
+	"no supported bootloader tooling on the host: kernel arguments need update-grub (GRUB with /etc/default/grub.d) or grubby (BLS)")
+
+// BootloaderUpdater regenerates the bootloader config on the host after
+// the kernel argument drop-in changed.
+type BootloaderUpdater func(ctx context.Context, hostRoot string) error
+
+// GrubbyRunner runs grubby on the host with args.
+type GrubbyRunner func(ctx context.Context, hostRoot string, args ...string) error
+
+// kernelArgsBackend installs the managed kernel arguments on the host.
+type kernelArgsBackend interface {
+	// read returns the managed arguments currently installed.
+	read() ([]string, error)
+	// write replaces the managed arguments current with desired.
+	write(ctx context.Context, current, desired []string) error
+}
+
+// KernelArgsApplier writes the managed kernel arguments to the bootloader
+// config under hostRoot. They take effect on the next boot.
+type KernelArgsApplier struct {
+	hostRoot string
+	// backend is nil when it is detected from the host on each use.
+	backend kernelArgsBackend
+}
+
+// NewKernelArgsApplier creates a KernelArgsApplier that uses the host's own
+// tooling: the GRUB drop-in with update-grub where available, otherwise
+// grubby.
+func NewKernelArgsApplier(hostRoot string) *KernelArgsApplier {
+	return &KernelArgsApplier{hostRoot: hostRoot}
+}
+
+// NewKernelArgsApplierWithUpdater creates a KernelArgsApplier that writes
+// the GRUB drop-in and regenerates the config with a custom updater.
+func NewKernelArgsApplierWithUpdater(hostRoot string, update BootloaderUpdater) *KernelArgsApplier {
+	return &KernelArgsApplier{hostRoot: hostRoot, backend: &grubDropIn{hostRoot: hostRoot, update: update}}
+}
+
+// NewKernelArgsApplierWithGrubby creates a KernelArgsApplier that edits the
+// BLS entries with a custom grubby runner.
+func NewKernelArgsApplierWithGrubby(hostRoot string, run GrubbyRunner) *KernelArgsApplier {
+	return &KernelArgsApplier{hostRoot: hostRoot, backend: &grubbyBLS{hostRoot: hostRoot, run: run}}
+}
+
+// Apply makes the managed kernel arguments equal to args. It reports
+// whether anything changed. It fails without changing anything when args
+// differ from the installed ones and the host has no supported tooling.
+func (k *KernelArgsApplier) Apply(ctx context.Context, args []string) (bool, error) {
+	backend, err := k.resolve()
+	if err != nil {
+		if len(args) == 0 {
+			return false, nil
+		}
+		return false, err
+	}
+	current, err := backend.read()
+	if err != nil {
+		return false, err
+	}
+	if slices.Equal(current, args) {
+		return false, nil
+	}
+	added, removed := DiffKernelArgs(current, args)
+	agentLog.Info("updating kernel arguments", "added", added, "removed", removed)
+
+	if err := backend.write(ctx, current, args); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Current returns the managed kernel arguments installed on the host.
+func (k *KernelArgsApplier) Current() ([]string, error) {
+	backend, err := k.resolve()
+	if err != nil {
+		// Nothing can have been installed without the tooling.
+		return nil, nil
+	}
+	return backend.read()
+}
+
+// NeedsUpdate reports whether the managed kernel arguments differ from args.
+func (k *KernelArgsApplier) NeedsUpdate(args []string) (bool, error) {
+	current, err := k.Current()
+	if err != nil {
+		return false, err
+	}
+	return !slices.Equal(current, args), nil
+}
+
+// resolve returns the configured backend or detects one from the host.
+func (k *KernelArgsApplier) resolve() (kernelArgsBackend, error) {
+	if k.backend != nil {
+		return k.backend, nil
+	}
+	if hostHasFile(k.hostRoot, updateGrubPath) {
+		return &grubDropIn{hostRoot: k.hostRoot, update: runUpdateGrub}, nil
+	}
+	for _, path := range grubbyPaths {
+		if hostHasFile(k.hostRoot, path) {
+			return &grubbyBLS{hostRoot: k.hostRoot, run: grubbyAt(path)}, nil
+		}
+	}
+	return nil, errNoBootloaderTooling
+}
+
+func hostHasFile(hostRoot, path string) bool {
+	_, err := os.Stat(filepath.Join(hostRoot, path))
+	return err == nil
+}
+
+// grubDropIn installs the arguments as KernelArgsFile and regenerates the
+// GRUB config. If regenerating fails, the previous drop-in is restored so
+// the next apply retries.
+type grubDropIn struct {
+	hostRoot string
+	update   BootloaderUpdater
+}
+
+func (g *grubDropIn) read() ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(g.hostRoot, KernelArgsFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read %s: %w", KernelArgsFile, err)
+	}
+	return parseKernelArgsFile(data), nil
+}
+
+func (g *grubDropIn) write(ctx context.Context, _, desired []string) error {
+	path := filepath.Join(g.hostRoot, KernelArgsFile)
+	previous, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("read %s: %w", KernelArgsFile, err)
+	}
+	existed := err == nil
+
+	if len(desired) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove %s: %w", KernelArgsFile, err)
+		}
+	} else if err := writeKernelArgsFile(path, KernelArgsFile, formatKernelArgsFile(desired)); err != nil {
+		return err
+	}
+
+	if err := g.update(ctx, g.hostRoot); err != nil {
+		var restoreErr error
+		if existed {
+			restoreErr = writeKernelArgsFile(path, KernelArgsFile, previous)
+		} else {
+			restoreErr = os.Remove(path)
+		}
+		if restoreErr != nil && !errors.Is(restoreErr, os.ErrNotExist) {
+			return fmt.Errorf("update bootloader (%v), restore %s failed: %w", err, KernelArgsFile, restoreErr)
+		}
+		return fmt.Errorf("update bootloader: %w", err)
+	}
+	return nil
+}
+
+// grubbyBLS edits every boot entry with grubby and records the managed
+// arguments in KernelArgsStateFile, so later revisions remove only those.
+type grubbyBLS struct {
+	hostRoot string
+	run      GrubbyRunner
+}
+
+func (g *grubbyBLS) read() ([]string, error) {
+	data, err := os.ReadFile(filepath.Join(g.hostRoot, KernelArgsStateFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read %s: %w", KernelArgsStateFile, err)
+	}
+	return strings.Fields(string(data)), nil
+}
+
+func (g *grubbyBLS) write(ctx context.Context, current, desired []string) error {
+	added, removed := DiffKernelArgs(current, desired)
+	if len(removed) > 0 {
+		if err := g.run(ctx, g.hostRoot, "--update-kernel=ALL", "--remove-args="+strings.Join(removed, " ")); err != nil {
+			return fmt.Errorf("update bootloader: %w", err)
+		}
+	}
+	if len(added) > 0 {
+		if err := g.run(ctx, g.hostRoot, "--update-kernel=ALL", "--args="+strings.Join(added, " ")); err != nil {
+			if len(removed) > 0 {
+				if restoreErr := g.run(ctx, g.hostRoot, "--update-kernel=ALL", "--args="+strings.Join(removed, " ")); restoreErr != nil {
+					return fmt.Errorf("update bootloader (%v), restore removed arguments failed: %w", err, restoreErr)
+				}
+			}
+			return fmt.Errorf("update bootloader: %w", err)
+		}
+	}
+
+	path := filepath.Join(g.hostRoot, KernelArgsStateFile)
+	if len(desired) == 0 {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("remove %s: %w", KernelArgsStateFile, err)
+		}
+		return nil
+	}
+	return writeKernelArgsFile(path, KernelArgsStateFile, []byte(strings.Join(desired, "\n")+"\n"))
+}
+
+// DiffKernelArgs returns the arguments in desired but not in current, and
+// those in current but not in desired, each in list order.
+func DiffKernelArgs(current, desired []string) (added, removed []string) {
+	for _, arg := range desired {
+		if !slices.Contains(current, arg) {
+			added = append(added, arg)
+		}
+	}
+	for _, arg := range current {
+		if !slices.Contains(desired, arg) {
+			removed = append(removed, arg)
+		}
+	}
+	return added, removed
+}
+
+func formatKernelArgsFile(args []string) []byte {
+	return []byte(kernelArgsHeader +
+		`GRUB_CMDLINE_LINUX="$GRUB_CMDLINE_LINUX ` + strings.Join(args, " ") + "\"\n")
+}
+
+// parseKernelArgsFile extracts the arguments from a drop-in written by
+// formatKernelArgsFile.
+func parseKernelArgsFile(data []byte) []string {
+	for _, line := range strings.Split(string(data), "\n") {
+		value, ok := strings.CutPrefix(line, `GRUB_CMDLINE_LINUX="$GRUB_CMDLINE_LINUX`)
+		if !ok {
+			continue
+		}
+		return strings.Fields(strings.TrimSuffix(value, `"`))
+	}
+	return nil
+}
+
+func writeKernelArgsFile(path, name string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", name, err)
+	}
+	if err := renameio.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+	return nil
+}
+
+// runUpdateGrub runs the host's update-grub in a chroot.
+func runUpdateGrub(ctx context.Context, hostRoot string) error {
+	return runInHost(ctx, hostRoot, updateGrubPath)
+}
+
+// grubbyAt returns a GrubbyRunner for the grubby binary at path.
+func grubbyAt(path string) GrubbyRunner {
+	return func(ctx context.Context, hostRoot string, args ...string) error {
+		return runInHost(ctx, hostRoot, path, args...)
+	}
+}
+
+func runInHost(ctx context.Context, hostRoot, path string, args ...string) error {
+	out, err := exec.CommandContext(ctx, "chroot", append([]string{hostRoot, path}, args...)...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w: %s", filepath.Base(path), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
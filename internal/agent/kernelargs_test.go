@@ -0,0 +1,177 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestKernelArgsApplier_Apply(t *testing.T) {
+	dir := t.TempDir()
+	updates := 0
+	k := NewKernelArgsApplierWithUpdater(dir, func(context.Context, string) error {
+		updates++
+		return nil
+	})
+	ctx := context.Background()
+	path := filepath.Join(dir, KernelArgsFile)
+
+	args := []string{"console=ttyS0", "hugepages=16"}
+	changed, err := k.Apply(ctx, args)
+	if err != nil || !changed {
+		t.Fatalf("Apply() = %v, %v; want changed", changed, err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if got := parseKernelArgsFile(data); !reflect.DeepEqual(got, args) {
+		t.Errorf("written args = %v, want %v", got, args)
+	}
+
+	if changed, err := k.Apply(ctx, args); err != nil || changed {
+		t.Errorf("second Apply() = %v, %v; want unchanged", changed, err)
+	}
+	if updates != 1 {
+		t.Errorf("bootloader updates = %d, want 1", updates)
+	}
+
+	if changed, err := k.Apply(ctx, nil); err != nil || !changed {
+		t.Fatalf("Apply(nil) = %v, %v; want changed", changed, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("drop-in should be removed, stat error = %v", err)
+	}
+	if updates != 2 {
+		t.Errorf("bootloader updates = %d, want 2", updates)
+	}
+}
+
+func TestKernelArgsApplier_UpdateFailureRestoresDropIn(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	ok := NewKernelArgsApplierWithUpdater(dir, func(context.Context, string) error { return nil })
+	if _, err := ok.Apply(ctx, []string{"quiet"}); err != nil {
+		t.Fatal(err)
+	}
+
+	failing := NewKernelArgsApplierWithUpdater(dir, func(context.Context, string) error {
+		return errors.New("grub-mkconfig failed")
+	})
+	if _, err := failing.Apply(ctx, []string{"quiet", "iommu=pt"}); err == nil {
+		t.Fatal("Apply() error = nil, want bootloader update error")
+	}
+
+	needs, err := ok.NeedsUpdate([]string{"quiet"})
+	if err != nil || needs {
+		t.Errorf("NeedsUpdate(previous) = %v, %v; want previous drop-in restored", needs, err)
+	}
+}
+
+func TestKernelArgsApplier_Grubby(t *testing.T) {
+	dir := t.TempDir()
+	var calls [][]string
+	k := NewKernelArgsApplierWithGrubby(dir, func(_ context.Context, _ string, args ...string) error {
+		calls = append(calls, args)
+		return nil
+	})
+	ctx := context.Background()
+
+	if changed, err := k.Apply(ctx, []string{"quiet", "hugepages=16"}); err != nil || !changed {
+		t.Fatalf("Apply() = %v, %v; want changed", changed, err)
+	}
+	if changed, err := k.Apply(ctx, []string{"quiet", "hugepages=32"}); err != nil || !changed {
+		t.Fatalf("Apply() = %v, %v; want changed", changed, err)
+	}
+	want := [][]string{
+		{"--update-kernel=ALL", "--args=quiet hugepages=16"},
+		{"--update-kernel=ALL", "--remove-args=hugepages=16"},
+		{"--update-kernel=ALL", "--args=hugepages=32"},
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("grubby calls = %v, want %v", calls, want)
+	}
+	if current, err := k.Current(); err != nil || !reflect.DeepEqual(current, []string{"quiet", "hugepages=32"}) {
+		t.Errorf("Current() = %v, %v", current, err)
+	}
+
+	if changed, err := k.Apply(ctx, nil); err != nil || !changed {
+		t.Fatalf("Apply(nil) = %v, %v; want changed", changed, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, KernelArgsStateFile)); !os.IsNotExist(err) {
+		t.Errorf("state file should be removed, stat error = %v", err)
+	}
+}
+
+func TestKernelArgsApplier_DetectsHostTooling(t *testing.T) {
+	touch := func(t *testing.T, root, path string) {
+		t.Helper()
+		full := filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, nil, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	debian := t.TempDir()
+	touch(t, debian, updateGrubPath)
+	touch(t, debian, grubbyPaths[0])
+	if b, err := NewKernelArgsApplier(debian).resolve(); err != nil {
+		t.Errorf("update-grub host: resolve() error = %v", err)
+	} else if _, ok := b.(*grubDropIn); !ok {
+		t.Errorf("update-grub host: backend = %T, want the GRUB drop-in", b)
+	}
+
+	bls := t.TempDir()
+	touch(t, bls, grubbyPaths[1])
+	if b, err := NewKernelArgsApplier(bls).resolve(); err != nil {
+		t.Errorf("BLS host: resolve() error = %v", err)
+	} else if _, ok := b.(*grubbyBLS); !ok {
+		t.Errorf("BLS host: backend = %T, want grubby", b)
+	}
+
+	// grub2-mkconfig alone would ignore the drop-in.
+	other := t.TempDir()
+	touch(t, other, "/usr/sbin/grub2-mkconfig")
+	k := NewKernelArgsApplier(other)
+	if changed, err := k.Apply(context.Background(), nil); err != nil || changed {
+		t.Errorf("Apply(nil) = %v, %v; want unchanged without tooling", changed, err)
+	}
+	if changed, err := k.Apply(context.Background(), []string{"quiet"}); !errors.Is(err, errNoBootloaderTooling) || changed {
+		t.Errorf("Apply() = %v, %v; want errNoBootloaderTooling", changed, err)
+	}
+	if _, err := os.Stat(filepath.Join(other, KernelArgsFile)); !os.IsNotExist(err) {
+		t.Errorf("drop-in written without tooling, stat error = %v", err)
+	}
+}
+
+func TestDiffKernelArgs(t *testing.T) {
+	added, removed := DiffKernelArgs([]string{"quiet", "hugepages=16"}, []string{"quiet", "console=ttyS0"})
+	if !reflect.DeepEqual(added, []string{"console=ttyS0"}) {
+		t.Errorf("added = %v, want [console=ttyS0]", added)
+	}
+	if !reflect.DeepEqual(removed, []string{"hugepages=16"}) {
+		t.Errorf("removed = %v, want [hugepages=16]", removed)
+	}
+}
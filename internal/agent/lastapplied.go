@@ -0,0 +1,168 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/filecontent"
+)
+
+// MaxLastAppliedSize bounds the encoded last-applied annotation. When the
+// per-entry summary would exceed it, only the revision and config hash
+// are kept.
+const MaxLastAppliedSize = 32 * 1024
+
+// LastApplied summarizes the config the agent last wrote to the host.
+// Files and units are recorded as short digests rather than content so
+// the annotation stays small while still allowing a per-entry diff.
+type LastApplied struct {
+	Revision   string `json:"revision"`
+	ConfigHash string `json:"configHash"`
+
+	// Files maps file path to "<mode> <owner> sha256:<digest>", "absent" or
+	// "line-present sha256:<digest>".
+	Files map[string]string `json:"files,omitempty"`
+
+	// Units maps unit name to its desired enabled/state/mask settings.
+	Units map[string]string `json:"units,omitempty"`
+
+	// Truncated is true when Files and Units were dropped to fit
+	// MaxLastAppliedSize; only the config hash can be compared then.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// BuildLastApplied summarizes rmc for the last-applied annotation.
+func BuildLastApplied(rmc *mcov1alpha1.RenderedMachineConfig) *LastApplied {
+	la := &LastApplied{
+		Revision:   rmc.Name,
+		ConfigHash: rmc.Spec.ConfigHash,
+		Files:      make(map[string]string, len(rmc.Spec.Config.Files)),
+		Units:      make(map[string]string, len(rmc.Spec.Config.Systemd.Units)),
+	}
+	for _, f := range rmc.Spec.Config.Files {
+		la.Files[f.Path] = fileSummary(f)
+	}
+	for _, u := range rmc.Spec.Config.Systemd.Units {
+		la.Units[u.Name] = unitSummary(u)
+	}
+	return la
+}
+
+// Encode returns the annotation value, dropping per-entry summaries if
+// they don't fit MaxLastAppliedSize.
+func (la *LastApplied) Encode() (string, error) {
+	data, err := json.Marshal(la)
+	if err != nil {
+		return "", err
+	}
+	if len(data) <= MaxLastAppliedSize {
+		return string(data), nil
+	}
+
+	short := LastApplied{Revision: la.Revision, ConfigHash: la.ConfigHash, Truncated: true}
+	data, err = json.Marshal(short)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ParseLastApplied decodes a last-applied annotation value.
+func ParseLastApplied(value string) (*LastApplied, error) {
+	la := &LastApplied{}
+	if err := json.Unmarshal([]byte(value), la); err != nil {
+		return nil, fmt.Errorf("parse last-applied: %w", err)
+	}
+	return la, nil
+}
+
+// DiffLastApplied compares the last applied summary with rmc and returns
+// one line per differing entry, sorted. An empty result means the node
+// has rmc's config applied.
+func DiffLastApplied(la *LastApplied, rmc *mcov1alpha1.RenderedMachineConfig) []string {
+	if la.ConfigHash == rmc.Spec.ConfigHash {
+		return nil
+	}
+	if la.Truncated {
+		return []string{fmt.Sprintf("config hash differs: applied %s, desired %s",
+			la.Revision, rmc.Name)}
+	}
+
+	desired := BuildLastApplied(rmc)
+	var diff []string
+	diff = append(diff, diffEntries("file", la.Files, desired.Files)...)
+	diff = append(diff, diffEntries("unit", la.Units, desired.Units)...)
+	sort.Strings(diff)
+	return diff
+}
+
+func diffEntries(kind string, applied, desired map[string]string) []string {
+	var diff []string
+	for name, want := range desired {
+		got, ok := applied[name]
+		switch {
+		case !ok:
+			diff = append(diff, fmt.Sprintf("+ %s %s: %s", kind, name, want))
+		case got != want:
+			diff = append(diff, fmt.Sprintf("~ %s %s: %s -> %s", kind, name, got, want))
+		}
+	}
+	for name, got := range applied {
+		if _, ok := desired[name]; !ok {
+			diff = append(diff, fmt.Sprintf("- %s %s: %s", kind, name, got))
+		}
+	}
+	return diff
+}
+
+func fileSummary(f mcov1alpha1.FileSpec) string {
+	if f.State == "absent" {
+		return "absent"
+	}
+	if f.State == FileStateLinePresent {
+		sum := sha256.Sum256([]byte(f.Content))
+		return "line-present sha256:" + hex.EncodeToString(sum[:8])
+	}
+	mode := f.Mode
+	if mode == 0 {
+		mode = 0644
+	}
+	owner := f.Owner
+	if owner == "" {
+		owner = "root:root"
+	}
+	content, err := filecontent.Decode(f)
+	if err != nil {
+		content = []byte(f.Content)
+	}
+	sum := sha256.Sum256(content)
+	return fmt.Sprintf("%04o %s sha256:%s", mode, owner, hex.EncodeToString(sum[:8]))
+}
+
+func unitSummary(u mcov1alpha1.UnitSpec) string {
+	enabled := "-"
+	if u.Enabled != nil {
+		enabled = fmt.Sprintf("%t", *u.Enabled)
+	}
+	return fmt.Sprintf("enabled=%s state=%s mask=%t", enabled, u.State, u.Mask)
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+func lastAppliedRMC(name, hash string, files []mcov1alpha1.FileSpec, units []mcov1alpha1.UnitSpec) *mcov1alpha1.RenderedMachineConfig {
+	return &mcov1alpha1.RenderedMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: mcov1alpha1.RenderedMachineConfigSpec{
+			ConfigHash: hash,
+			Config: mcov1alpha1.RenderedConfig{
+				Files:   files,
+				Systemd: mcov1alpha1.SystemdSpec{Units: units},
+			},
+		},
+	}
+}
+
+func TestLastApplied_RoundTripAndDiff(t *testing.T) {
+	applied := lastAppliedRMC("worker-aaa", "aaa", []mcov1alpha1.FileSpec{
+		{Path: "/etc/a.conf", Content: "a"},
+		{Path: "/etc/b.conf", Content: "b"},
+	}, []mcov1alpha1.UnitSpec{{Name: "app.service", State: "started"}})
+
+	value, err := BuildLastApplied(applied).Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	la, err := ParseLastApplied(value)
+	if err != nil {
+		t.Fatalf("ParseLastApplied() error = %v", err)
+	}
+
+	if diff := DiffLastApplied(la, applied); len(diff) != 0 {
+		t.Errorf("diff against same RMC = %v, want none", diff)
+	}
+
+	desired := lastAppliedRMC("worker-bbb", "bbb", []mcov1alpha1.FileSpec{
+		{Path: "/etc/a.conf", Content: "a2"},
+		{Path: "/etc/c.conf", Content: "c"},
+	}, []mcov1alpha1.UnitSpec{{Name: "app.service", State: "started"}})
+
+	diff := DiffLastApplied(la, desired)
+	if len(diff) != 3 {
+		t.Fatalf("diff = %v, want 3 entries", diff)
+	}
+	for i, prefix := range []string{"+ file /etc/c.conf", "- file /etc/b.conf", "~ file /etc/a.conf"} {
+		if !strings.HasPrefix(diff[i], prefix) {
+			t.Errorf("diff[%d] = %q, want prefix %q", i, diff[i], prefix)
+		}
+	}
+}
+
+func TestLastApplied_Truncated(t *testing.T) {
+	var files []mcov1alpha1.FileSpec
+	for i := 0; i < 1000; i++ {
+		files = append(files, mcov1alpha1.FileSpec{Path: fmt.Sprintf("/etc/generated/file-%04d.conf", i), Content: "data"})
+	}
+	rmc := lastAppliedRMC("worker-big", "big", files, nil)
+
+	value, err := BuildLastApplied(rmc).Encode()
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+	if len(value) > MaxLastAppliedSize {
+		t.Errorf("encoded size = %d, want <= %d", len(value), MaxLastAppliedSize)
+	}
+	la, err := ParseLastApplied(value)
+	if err != nil {
+		t.Fatalf("ParseLastApplied() error = %v", err)
+	}
+	if !la.Truncated || la.Files != nil {
+		t.Errorf("la = %+v, want truncated without files", la)
+	}
+
+	other := lastAppliedRMC("worker-new", "new", nil, nil)
+	if diff := DiffLastApplied(la, other); len(diff) != 1 {
+		t.Errorf("diff = %v, want a single config hash line", diff)
+	}
+}
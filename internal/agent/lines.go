@@ -0,0 +1,107 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/google/renameio/v2"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+// ensureLine makes sure f.Content is a line of the file at path. A missing
+// file is created with the spec's mode and owner; an existing file keeps its
+// content, mode and owner and only gets the line appended.
+func (a *FileApplier) ensureLine(path string, f mcov1alpha1.FileSpec) (bool, error) {
+	line := strings.TrimSuffix(f.Content, "\n")
+
+	existing, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		created := f
+		created.Content = line + "\n"
+		return a.writeFile(path, created)
+	}
+	if err != nil {
+		return false, fmt.Errorf("read file: %w", err)
+	}
+	if hasLine(existing, line) {
+		return false, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, fmt.Errorf("stat file: %w", err)
+	}
+
+	content := existing
+	if len(content) > 0 && content[len(content)-1] != '\n' {
+		content = append(content, '\n')
+	}
+	content = append(content, line+"\n"...)
+
+	t, err := renameio.TempFile(filepath.Dir(path), path)
+	if err != nil {
+		return false, fmt.Errorf("create temp file: %w", err)
+	}
+	defer func() { _ = t.Cleanup() }()
+
+	if err := a.writeVerified(t, content); err != nil {
+		return false, err
+	}
+	if err := t.Chmod(info.Mode().Perm()); err != nil {
+		return false, fmt.Errorf("set mode: %w", err)
+	}
+	if st, ok := info.Sys().(*syscall.Stat_t); ok && !a.skipOwnership {
+		if err := t.Chown(int(st.Uid), int(st.Gid)); err != nil {
+			return false, fmt.Errorf("keep ownership: %w", err)
+		}
+	}
+	if err := t.CloseAtomicallyReplace(); err != nil {
+		return false, fmt.Errorf("atomic replace: %w", err)
+	}
+	return true, nil
+}
+
+// lineMissing reports whether the line in content is absent from the file
+// at path. A missing file counts as missing the line.
+func (a *FileApplier) lineMissing(path, content string) (bool, error) {
+	existing, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return !hasLine(existing, strings.TrimSuffix(content, "\n")), nil
+}
+
+// hasLine reports whether data contains line as a whole line.
+func hasLine(data []byte, line string) bool {
+	for _, l := range bytes.Split(data, []byte("\n")) {
+		if string(bytes.TrimSuffix(l, []byte("\r"))) == line {
+			return true
+		}
+	}
+	return false
+}
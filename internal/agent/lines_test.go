@@ -0,0 +1,117 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+func TestApply_LinePresent(t *testing.T) {
+	const line = "tmpfs /scratch tmpfs defaults 0 0"
+
+	tests := []struct {
+		name        string
+		existing    string
+		want        string
+		wantApplied bool
+	}{
+		{
+			name:        "missing file is created",
+			want:        line + "\n",
+			wantApplied: true,
+		},
+		{
+			name:        "line appended",
+			existing:    "/dev/sda1 / ext4 defaults 0 1\n",
+			want:        "/dev/sda1 / ext4 defaults 0 1\n" + line + "\n",
+			wantApplied: true,
+		},
+		{
+			name:        "newline added before line",
+			existing:    "/dev/sda1 / ext4 defaults 0 1",
+			want:        "/dev/sda1 / ext4 defaults 0 1\n" + line + "\n",
+			wantApplied: true,
+		},
+		{
+			name:        "line already present",
+			existing:    "# fstab\n" + line + "\n/dev/sdb1 /data xfs defaults 0 2\n",
+			want:        "# fstab\n" + line + "\n/dev/sdb1 /data xfs defaults 0 2\n",
+			wantApplied: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			a := NewFileApplierWithOptions(dir, true)
+			path := filepath.Join(dir, "etc", "fstab")
+			if tt.existing != "" {
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(tt.existing), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			f := mcov1alpha1.FileSpec{Path: "/etc/fstab", Content: line, Mode: 0644, State: FileStateLinePresent}
+			needs, err := a.NeedsUpdate(f)
+			if err != nil {
+				t.Fatalf("NeedsUpdate() error = %v", err)
+			}
+			if needs != tt.wantApplied {
+				t.Errorf("NeedsUpdate() = %v, want %v", needs, tt.wantApplied)
+			}
+
+			result := a.Apply(f)
+			if result.Error != nil {
+				t.Fatalf("Apply() error = %v", result.Error)
+			}
+			if result.Applied != tt.wantApplied {
+				t.Errorf("Applied = %v, want %v", result.Applied, tt.wantApplied)
+			}
+
+			got, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("content = %q, want %q", got, tt.want)
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantMode := os.FileMode(0644)
+			if tt.existing != "" {
+				wantMode = 0600
+			}
+			if info.Mode().Perm() != wantMode {
+				t.Errorf("mode = %o, want %o", info.Mode().Perm(), wantMode)
+			}
+
+			if err := VerifyFiles(dir, []mcov1alpha1.FileSpec{f}); err != nil {
+				t.Errorf("VerifyFiles() error = %v", err)
+			}
+		})
+	}
+}
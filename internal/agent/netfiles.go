@@ -0,0 +1,171 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/google/renameio/v2"
+)
+
+// KnownGoodSuffix is appended to a validated host file's path to keep the
+// last known-good copy before it is overwritten.
+const KnownGoodSuffix = ".mco-known-good"
+
+// hostFileValidators checks files whose corruption breaks name resolution
+// on the node. Content for these paths is validated before it is written.
+var hostFileValidators = map[string]func([]byte) error{
+	"/etc/hosts":       validateHosts,
+	"/etc/resolv.conf": validateResolvConf,
+}
+
+// ValidateHostFile checks content destined for path. Paths without a
+// validator are always accepted.
+func ValidateHostFile(path string, content []byte) error {
+	validate, ok := hostFileValidators[path]
+	if !ok {
+		return nil
+	}
+	if err := validate(content); err != nil {
+		return fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return nil
+}
+
+// saveKnownGood copies the current file at hostPath next to it when it
+// passes validation, so a later bad write can be reverted.
+func saveKnownGood(specPath, hostPath string) error {
+	current, err := os.ReadFile(hostPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if ValidateHostFile(specPath, current) != nil {
+		return nil
+	}
+	info, err := os.Stat(hostPath)
+	if err != nil {
+		return err
+	}
+	return renameio.WriteFile(hostPath+KnownGoodSuffix, current, info.Mode().Perm())
+}
+
+// restoreKnownGood puts the known-good copy back at hostPath.
+func restoreKnownGood(hostPath string) error {
+	good, err := os.ReadFile(hostPath + KnownGoodSuffix)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(hostPath + KnownGoodSuffix)
+	if err != nil {
+		return err
+	}
+	return renameio.WriteFile(hostPath, good, info.Mode().Perm())
+}
+
+// validateHosts accepts lines of "<ip> <hostname> [aliases...]", blank
+// lines and comments.
+func validateHosts(content []byte) error {
+	return forEachLine(content, "#", func(n int, fields []string) error {
+		if len(fields) < 2 {
+			return fmt.Errorf("line %d: expected address and hostname", n)
+		}
+		if net.ParseIP(strings.SplitN(fields[0], "%", 2)[0]) == nil {
+			return fmt.Errorf("line %d: invalid address %q", n, fields[0])
+		}
+		for _, name := range fields[1:] {
+			if !validHostname(name) {
+				return fmt.Errorf("line %d: invalid hostname %q", n, name)
+			}
+		}
+		return nil
+	})
+}
+
+// validateResolvConf accepts the resolv.conf(5) keywords and requires at
+// least one nameserver so a write cannot leave the node without DNS.
+func validateResolvConf(content []byte) error {
+	nameservers := 0
+	err := forEachLine(content, "#;", func(n int, fields []string) error {
+		switch fields[0] {
+		case "nameserver":
+			if len(fields) != 2 || net.ParseIP(strings.SplitN(fields[1], "%", 2)[0]) == nil {
+				return fmt.Errorf("line %d: nameserver needs one IP address", n)
+			}
+			nameservers++
+		case "search", "domain", "options", "sortlist":
+			if len(fields) < 2 {
+				return fmt.Errorf("line %d: %s needs a value", n, fields[0])
+			}
+		default:
+			return fmt.Errorf("line %d: unknown keyword %q", n, fields[0])
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if nameservers == 0 {
+		return errors.New("no nameserver")
+	}
+	return nil
+}
+
+// forEachLine calls fn with the fields of every non-blank line, after
+// stripping comments starting with any of commentChars.
+func forEachLine(content []byte, commentChars string, fn func(n int, fields []string) error) error {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if i := strings.IndexAny(line, commentChars); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if err := fn(n, fields); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func validHostname(name string) bool {
+	if name == "" || len(name) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+				return false
+			}
+		}
+	}
+	return true
+}
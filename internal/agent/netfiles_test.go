@@ -0,0 +1,108 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+func TestValidateHostFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		wantErr bool
+	}{
+		{"hosts ok", "/etc/hosts", "# local\n127.0.0.1 localhost\n::1 localhost ip6-localhost\n10.0.0.5 node-1.example.com node-1\n", false},
+		{"hosts missing name", "/etc/hosts", "127.0.0.1\n", true},
+		{"hosts bad address", "/etc/hosts", "300.0.0.1 localhost\n", true},
+		{"hosts bad name", "/etc/hosts", "127.0.0.1 -bad\n", true},
+		{"resolv ok", "/etc/resolv.conf", "; comment\nsearch example.com\nnameserver 10.0.0.2\noptions ndots:5\n", false},
+		{"resolv no nameserver", "/etc/resolv.conf", "search example.com\n", true},
+		{"resolv bad nameserver", "/etc/resolv.conf", "nameserver dns.example.com\n", true},
+		{"resolv unknown keyword", "/etc/resolv.conf", "nameserver 10.0.0.2\nnamesever 10.0.0.3\n", true},
+		{"other file unchecked", "/etc/other.conf", "anything", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHostFile(tt.path, []byte(tt.content))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateHostFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApply_RejectsInvalidResolvConf(t *testing.T) {
+	dir := t.TempDir()
+	a := NewFileApplierWithOptions(dir, true)
+	path := filepath.Join(dir, "/etc/resolv.conf")
+
+	good := "nameserver 10.0.0.2\n"
+	if result := a.Apply(mcov1alpha1.FileSpec{Path: "/etc/resolv.conf", Content: good}); result.Error != nil {
+		t.Fatalf("Apply() valid content error = %v", result.Error)
+	}
+
+	result := a.Apply(mcov1alpha1.FileSpec{Path: "/etc/resolv.conf", Content: "nameserver\n"})
+	if result.Error == nil {
+		t.Fatal("Apply() should reject malformed resolv.conf")
+	}
+	if result.Applied {
+		t.Error("rejected content should not be reported as applied")
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(content) != good {
+		t.Errorf("resolv.conf = %q, want previous content %q", content, good)
+	}
+
+	next := "nameserver 10.0.0.3\n"
+	if result := a.Apply(mcov1alpha1.FileSpec{Path: "/etc/resolv.conf", Content: next}); result.Error != nil {
+		t.Fatalf("Apply() error = %v", result.Error)
+	}
+	backup, err := os.ReadFile(path + KnownGoodSuffix)
+	if err != nil {
+		t.Fatalf("known-good copy missing: %v", err)
+	}
+	if string(backup) != good {
+		t.Errorf("known-good = %q, want %q", backup, good)
+	}
+}
+
+func TestRestoreKnownGood(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hosts")
+	if err := os.WriteFile(path+KnownGoodSuffix, []byte("127.0.0.1 localhost\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("garbage"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := restoreKnownGood(path); err != nil {
+		t.Fatalf("restoreKnownGood() error = %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	if string(content) != "127.0.0.1 localhost\n" {
+		t.Errorf("content = %q, want known-good", content)
+	}
+}
@@ -19,6 +19,7 @@ package agent
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -53,6 +54,42 @@ func (w *NodeWriter) SetCurrentRevision(ctx context.Context, revision string) er
 	return w.patchAnnotation(ctx, annotations.CurrentRevision, revision)
 }
 
+// SetLastApplied sets the last-applied annotation.
+func (w *NodeWriter) SetLastApplied(ctx context.Context, value string) error {
+	return w.patchAnnotation(ctx, annotations.LastApplied, value)
+}
+
+// SetRebootMethods sets the reboot-methods annotation.
+func (w *NodeWriter) SetRebootMethods(ctx context.Context, methods []string) error {
+	return w.patchAnnotation(ctx, annotations.RebootMethods, strings.Join(methods, ","))
+}
+
+// SetUnitDrift sets the unit-drift annotation, or removes it when paths is empty.
+func (w *NodeWriter) SetUnitDrift(ctx context.Context, paths []string) error {
+	if len(paths) == 0 {
+		return w.removeAnnotation(ctx, annotations.UnitDrift)
+	}
+	return w.patchAnnotation(ctx, annotations.UnitDrift, strings.Join(paths, ","))
+}
+
+// SetUnitStateDrift sets the unit-state-drift annotation, or removes it
+// when summary is empty.
+func (w *NodeWriter) SetUnitStateDrift(ctx context.Context, summary string) error {
+	if summary == "" {
+		return w.removeAnnotation(ctx, annotations.UnitStateDrift)
+	}
+	return w.patchAnnotation(ctx, annotations.UnitStateDrift, summary)
+}
+
+// SetRebootReason sets the reboot-reason annotation, or removes it when
+// reason is empty.
+func (w *NodeWriter) SetRebootReason(ctx context.Context, reason string) error {
+	if reason == "" {
+		return w.removeAnnotation(ctx, annotations.RebootReason)
+	}
+	return w.patchAnnotation(ctx, annotations.RebootReason, reason)
+}
+
 // SetLastError sets the last-error annotation.
 func (w *NodeWriter) SetLastError(ctx context.Context, errMsg string) error {
 	return w.patchAnnotation(ctx, annotations.LastError, errMsg)
@@ -63,12 +100,29 @@ func (w *NodeWriter) ClearLastError(ctx context.Context) error {
 	return w.removeAnnotation(ctx, annotations.LastError)
 }
 
-// SetRebootPending sets or clears the reboot-pending annotation.
+// SetRebootPending sets or clears the reboot-pending annotation. Clearing
+// also removes the reboot-pending-reason annotation.
 func (w *NodeWriter) SetRebootPending(ctx context.Context, pending bool) error {
 	if pending {
 		return w.patchAnnotation(ctx, annotations.RebootPending, "true")
 	}
-	return w.removeAnnotation(ctx, annotations.RebootPending)
+	patch := fmt.Sprintf(
+		`{"metadata":{"annotations":{%q:null,%q:null}}}`,
+		annotations.RebootPending,
+		annotations.RebootPendingReason,
+	)
+	return w.patch(ctx, patch)
+}
+
+// SetRebootPendingReason sets reboot-pending together with the reason the
+// reboot is held back in a single patch.
+func (w *NodeWriter) SetRebootPendingReason(ctx context.Context, reason string) error {
+	patch := fmt.Sprintf(
+		`{"metadata":{"annotations":{%q:"true",%q:%q}}}`,
+		annotations.RebootPending,
+		annotations.RebootPendingReason, reason,
+	)
+	return w.patch(ctx, patch)
 }
 
 // ClearForceReboot removes the force-reboot annotation.
@@ -122,6 +122,29 @@ func TestNodeWriter_ClearLastError(t *testing.T) {
 	}
 }
 
+func TestNodeWriter_SetRebootReason_EmptyRemoves(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-node",
+			Annotations: map[string]string{annotations.RebootReason: "kernel args changed"},
+		},
+	}
+	client := fake.NewSimpleClientset(node)
+	writer := NewNodeWriter(client, "test-node")
+
+	if err := writer.SetRebootReason(context.Background(), ""); err != nil {
+		t.Fatalf("SetRebootReason() error = %v", err)
+	}
+
+	updated, err := client.CoreV1().Nodes().Get(context.Background(), "test-node", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get node error = %v", err)
+	}
+	if _, exists := updated.Annotations[annotations.RebootReason]; exists {
+		t.Error("RebootReason annotation should be removed")
+	}
+}
+
 func TestNodeWriter_SetRebootPending(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -175,6 +198,33 @@ func TestNodeWriter_SetRebootPending(t *testing.T) {
 	}
 }
 
+func TestNodeWriter_SetRebootPendingReason(t *testing.T) {
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+	client := fake.NewSimpleClientset(node)
+	writer := NewNodeWriter(client, "test-node")
+	ctx := context.Background()
+
+	if err := writer.SetRebootPendingReason(ctx, annotations.RebootPendingMinInterval); err != nil {
+		t.Fatalf("SetRebootPendingReason() error = %v", err)
+	}
+	updated, _ := client.CoreV1().Nodes().Get(ctx, "test-node", metav1.GetOptions{})
+	if updated.Annotations[annotations.RebootPending] != "true" {
+		t.Errorf("RebootPending = %q, want true", updated.Annotations[annotations.RebootPending])
+	}
+	if updated.Annotations[annotations.RebootPendingReason] != annotations.RebootPendingMinInterval {
+		t.Errorf("RebootPendingReason = %q, want %q",
+			updated.Annotations[annotations.RebootPendingReason], annotations.RebootPendingMinInterval)
+	}
+
+	if err := writer.SetRebootPending(ctx, false); err != nil {
+		t.Fatalf("SetRebootPending() error = %v", err)
+	}
+	updated, _ = client.CoreV1().Nodes().Get(ctx, "test-node", metav1.GetOptions{})
+	if _, ok := updated.Annotations[annotations.RebootPendingReason]; ok {
+		t.Error("RebootPendingReason should be removed with RebootPending")
+	}
+}
+
 func TestNodeWriter_SetStateWithError(t *testing.T) {
 	node := &corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
@@ -18,7 +18,9 @@ package reboot
 
 import (
 	"context"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"syscall"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -49,10 +51,57 @@ func (e *SystemdExecutor) Execute(ctx context.Context) error {
 	return cmd.Run()
 }
 
+// kexecBinaries are the host paths where kexec-tools installs kexec.
+var kexecBinaries = []string{"/usr/sbin/kexec", "/sbin/kexec", "/usr/bin/kexec"}
+
+// ExecuteKexec reboots into the default boot entry via systemctl kexec,
+// skipping firmware initialization. If kexec-tools is not installed or
+// systemctl kexec fails, it falls back to a full reboot.
+func (e *SystemdExecutor) ExecuteKexec(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	if !e.kexecAvailable() {
+		logger.Info("kexec is not available on the host, falling back to full reboot")
+		return e.Execute(ctx)
+	}
+
+	logger.Info("syncing filesystems")
+	syscall.Sync()
+
+	logger.Info("executing systemctl kexec")
+	out, err := exec.CommandContext(ctx, "chroot", e.hostRoot, "systemctl", "kexec").CombinedOutput()
+	if err != nil {
+		logger.Info("systemctl kexec failed, falling back to full reboot",
+			"error", err, "output", string(out))
+		return e.Execute(ctx)
+	}
+	return nil
+}
+
+// SupportedMethods returns reboot, plus kexec when kexec-tools is installed.
+func (e *SystemdExecutor) SupportedMethods() []string {
+	if e.kexecAvailable() {
+		return []string{MethodReboot, MethodKexec}
+	}
+	return []string{MethodReboot}
+}
+
+// kexecAvailable reports whether the kexec binary exists on the host.
+func (e *SystemdExecutor) kexecAvailable() bool {
+	for _, path := range kexecBinaries {
+		if _, err := os.Stat(filepath.Join(e.hostRoot, path)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // NoOpExecutor is a reboot executor that does nothing.
 // Useful for testing and dry-run scenarios.
 type NoOpExecutor struct {
 	Called bool
+	// Kexec is true when the last call was ExecuteKexec.
+	Kexec bool
 }
 
 // Execute records that it was called but doesn't reboot.
@@ -61,3 +110,74 @@ func (e *NoOpExecutor) Execute(ctx context.Context) error {
 	log.FromContext(ctx).Info("NoOpExecutor: reboot would be executed here")
 	return nil
 }
+
+// ExecuteKexec records that it was called but doesn't reboot.
+func (e *NoOpExecutor) ExecuteKexec(ctx context.Context) error {
+	e.Called = true
+	e.Kexec = true
+	log.FromContext(ctx).Info("NoOpExecutor: kexec reboot would be executed here")
+	return nil
+}
+
+// SupportedMethods reports every method, since nothing is executed.
+func (e *NoOpExecutor) SupportedMethods() []string {
+	return []string{MethodReboot, MethodKexec}
+}
+
+// KexecExecutor reboots via kexec for every reboot, whatever the pool's
+// reboot strategy asks for. Like SystemdExecutor.ExecuteKexec it falls back
+// to a full reboot when kexec is not usable.
+type KexecExecutor struct {
+	*SystemdExecutor
+}
+
+// NewKexecExecutor creates a kexec reboot executor.
+func NewKexecExecutor(hostRoot string) *KexecExecutor {
+	return &KexecExecutor{SystemdExecutor: NewSystemdExecutor(hostRoot)}
+}
+
+// Execute reboots via kexec.
+func (e *KexecExecutor) Execute(ctx context.Context) error {
+	return e.ExecuteKexec(ctx)
+}
+
+// RebootRequiredFile is the host sentinel file external reboot coordinators
+// (e.g. kured) watch for.
+const RebootRequiredFile = "/var/run/reboot-required"
+
+// SignalExternalExecutor does not reboot itself: it creates
+// RebootRequiredFile on the host and leaves the reboot to an external
+// coordinator. The node stays in the rebooting state until it comes back.
+type SignalExternalExecutor struct {
+	hostRoot string
+}
+
+// NewSignalExternalExecutor creates a reboot executor that signals an
+// external coordinator.
+func NewSignalExternalExecutor(hostRoot string) *SignalExternalExecutor {
+	return &SignalExternalExecutor{hostRoot: hostRoot}
+}
+
+// Execute creates the reboot-required sentinel file.
+func (e *SignalExternalExecutor) Execute(ctx context.Context) error {
+	path := filepath.Join(e.hostRoot, RebootRequiredFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, nil, 0644); err != nil {
+		return err
+	}
+	log.FromContext(ctx).Info("signaled external reboot coordinator", "file", RebootRequiredFile)
+	return nil
+}
+
+// ExecuteKexec signals a reboot like Execute; the coordinator decides how
+// the node is rebooted.
+func (e *SignalExternalExecutor) ExecuteKexec(ctx context.Context) error {
+	return e.Execute(ctx)
+}
+
+// SupportedMethods returns reboot: kexec is up to the coordinator.
+func (e *SignalExternalExecutor) SupportedMethods() []string {
+	return []string{MethodReboot}
+}
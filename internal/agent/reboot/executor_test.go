@@ -18,6 +18,8 @@ package reboot
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -49,5 +51,63 @@ func TestNoOpExecutor(t *testing.T) {
 	}
 }
 
+func TestSystemdExecutor_KexecAvailable(t *testing.T) {
+	root := t.TempDir()
+	executor := NewSystemdExecutor(root)
+	if executor.kexecAvailable() {
+		t.Error("kexecAvailable() = true on empty host root")
+	}
+	if got := executor.SupportedMethods(); len(got) != 1 || got[0] != MethodReboot {
+		t.Errorf("SupportedMethods() = %v, want [reboot]", got)
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, "usr/sbin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "usr/sbin/kexec"), nil, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if !executor.kexecAvailable() {
+		t.Error("kexecAvailable() = false with /usr/sbin/kexec present")
+	}
+	if got := executor.SupportedMethods(); len(got) != 2 || got[1] != MethodKexec {
+		t.Errorf("SupportedMethods() = %v, want [reboot kexec]", got)
+	}
+}
+
+func TestSignalExternalExecutor(t *testing.T) {
+	root := t.TempDir()
+	executor := NewSignalExternalExecutor(root)
+
+	if err := executor.Execute(context.Background()); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, RebootRequiredFile)); err != nil {
+		t.Errorf("reboot-required sentinel not created: %v", err)
+	}
+}
+
+func TestNewExecutor(t *testing.T) {
+	for _, name := range []string{ExecutorSystemd, ExecutorKexec, ExecutorNoOp, ExecutorSignalExternal} {
+		executor, err := NewExecutor(name, "/host")
+		if err != nil || executor == nil {
+			t.Errorf("NewExecutor(%q) = %v, %v; want executor", name, executor, err)
+		}
+	}
+	if _, err := NewExecutor("bogus", "/host"); err == nil {
+		t.Error("NewExecutor(bogus) should fail")
+	}
+
+	RegisterExecutor("custom", func(string) RebootExecutor { return &NoOpExecutor{} })
+	defer func() {
+		executorsMu.Lock()
+		delete(executors, "custom")
+		executorsMu.Unlock()
+	}()
+	if _, err := NewExecutor("custom", "/host"); err != nil {
+		t.Errorf("NewExecutor(custom) after RegisterExecutor error = %v", err)
+	}
+}
+
 // Note: We don't test SystemdExecutor.Execute() because it would actually
 // attempt to reboot the system. The NoOpExecutor is used for testing.
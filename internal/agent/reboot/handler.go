@@ -18,9 +18,13 @@ limitations under the License.
 //
 // The reboot handler orchestrates reboot decisions based on:
 //   - RMC reboot requirements (required field)
-//   - Reboot strategy (Never, IfRequired)
+//   - Reboot strategy (Never, IfRequired, Kexec)
 //   - Minimum interval between reboots
 //   - Force-reboot annotation
+//   - Reboots already issued during the current boot, which satisfy any
+//     further reboot request until the node is back
+//   - An optional verification command that must pass after the reboot
+//     before the revision is marked done
 //
 // Example usage:
 //
@@ -44,6 +48,9 @@ import (
 type NodeAnnotationWriter interface {
 	SetState(ctx context.Context, state string) error
 	SetRebootPending(ctx context.Context, pending bool) error
+	// SetRebootPendingReason marks a reboot pending and records why it is
+	// held back.
+	SetRebootPendingReason(ctx context.Context, reason string) error
 	SetCurrentRevision(ctx context.Context, revision string) error
 	SetDone(ctx context.Context, revision string) error
 	ClearForceReboot(ctx context.Context) error
@@ -53,14 +60,30 @@ type NodeAnnotationWriter interface {
 // This interface enables testing without triggering real reboots.
 type RebootExecutor interface {
 	Execute(ctx context.Context) error
+	// ExecuteKexec reboots via kexec, falling back to a full reboot when
+	// kexec is not available.
+	ExecuteKexec(ctx context.Context) error
+	// SupportedMethods returns the reboot methods usable on this host.
+	SupportedMethods() []string
 }
 
+// Reboot methods reported in the reboot-methods node annotation.
+const (
+	MethodReboot = "reboot"
+	MethodKexec  = "kexec"
+)
+
 // Handler handles reboot decision logic.
 type Handler struct {
 	hostRoot string
 	writer   NodeAnnotationWriter
 	executor RebootExecutor
 	state    *StateManager
+
+	// verifyCommand, when set, must pass after a reboot before the
+	// revision is marked done.
+	verifyCommand string
+	verifier      CommandRunner
 }
 
 // NewHandler creates a new reboot handler.
@@ -91,37 +114,56 @@ func (h *Handler) HandleReboot(ctx context.Context, rmc *mcov1alpha1.RenderedMac
 		return nil
 	}
 
-	logger.Info("reboot required, checking policy")
-
-	// Check force-reboot annotation (bypasses strategy and interval)
-	if annotations.GetBoolAnnotation(node.Annotations, annotations.ForceReboot) {
-		logger.Info("force-reboot annotation set, proceeding with reboot")
-		return h.executeReboot(ctx)
+	// Requests from several revisions or a force-reboot arriving while a
+	// reboot is already under way are folded into that one reboot.
+	if h.state.RebootIssued() {
+		logger.Info("reboot already issued during this boot, not rebooting again")
+		h.clearRebootRequests(ctx)
+		return nil
 	}
 
+	logger.Info("reboot required, checking policy")
+
 	// Get strategy (default to Never)
 	strategy := rmc.Spec.Reboot.Strategy
 	if strategy == "" {
 		strategy = "Never"
 	}
+	// The controller downgrades the strategy on nodes that lack support for it.
+	if override := annotations.GetAnnotation(node.Annotations, annotations.RebootStrategy); override != "" {
+		logger.Info("using node reboot strategy override", "strategy", override, "rmcStrategy", strategy)
+		strategy = override
+	}
+	kexec := strategy == "Kexec"
+
+	// Check force-reboot annotation (bypasses strategy and interval)
+	if annotations.GetBoolAnnotation(node.Annotations, annotations.ForceReboot) {
+		logger.Info("force-reboot annotation set, proceeding with reboot")
+		return h.executeReboot(ctx, kexec)
+	}
 
 	switch strategy {
 	case "Never":
 		logger.Info("reboot strategy is Never, setting pending")
-		return h.setPending(ctx)
+		return h.setPending(ctx, annotations.RebootPendingStrategyNever)
 
-	case "IfRequired":
-		return h.handleIfRequired(ctx, rmc.Spec.Reboot.MinIntervalSeconds)
+	case "IfRequired", "Kexec":
+		return h.handleIfRequired(ctx, rmc.Spec.Reboot.MinIntervalSeconds, kexec)
 
 	default:
 		logger.Info("unknown reboot strategy, treating as Never", "strategy", strategy)
-		return h.setPending(ctx)
+		return h.setPending(ctx, annotations.RebootPendingStrategyNever)
 	}
 }
 
-// handleIfRequired handles the IfRequired strategy.
+// SupportedMethods returns the reboot methods the executor can use.
+func (h *Handler) SupportedMethods() []string {
+	return h.executor.SupportedMethods()
+}
+
+// handleIfRequired handles the IfRequired and Kexec strategies.
 // It checks the minimum interval and either reboots or sets pending.
-func (h *Handler) handleIfRequired(ctx context.Context, minIntervalSeconds int) error {
+func (h *Handler) handleIfRequired(ctx context.Context, minIntervalSeconds int, kexec bool) error {
 	logger := log.FromContext(ctx)
 
 	// Read last reboot time
@@ -129,13 +171,13 @@ func (h *Handler) handleIfRequired(ctx context.Context, minIntervalSeconds int)
 	if err != nil {
 		// No last reboot time - first boot, proceed with reboot
 		logger.V(1).Info("no last reboot time found, proceeding with reboot")
-		return h.executeReboot(ctx)
+		return h.executeReboot(ctx, kexec)
 	}
 
 	// Check if minInterval is 0 (disabled)
 	if minIntervalSeconds <= 0 {
 		logger.V(1).Info("minInterval is 0, proceeding with reboot")
-		return h.executeReboot(ctx)
+		return h.executeReboot(ctx, kexec)
 	}
 
 	// Check interval
@@ -148,22 +190,23 @@ func (h *Handler) handleIfRequired(ctx context.Context, minIntervalSeconds int)
 			"elapsed", elapsed.Round(time.Second),
 			"required", required,
 			"remaining", remaining.Round(time.Second))
-		return h.setPending(ctx)
+		return h.setPending(ctx, annotations.RebootPendingMinInterval)
 	}
 
 	logger.Info("min interval elapsed, proceeding with reboot",
 		"elapsed", elapsed.Round(time.Second),
 		"required", required)
-	return h.executeReboot(ctx)
+	return h.executeReboot(ctx, kexec)
 }
 
-// setPending sets the reboot-pending annotation.
-func (h *Handler) setPending(ctx context.Context) error {
-	return h.writer.SetRebootPending(ctx, true)
+// setPending sets the reboot-pending annotation and the reason the reboot
+// is held back.
+func (h *Handler) setPending(ctx context.Context, reason string) error {
+	return h.writer.SetRebootPendingReason(ctx, reason)
 }
 
-// executeReboot executes the reboot sequence.
-func (h *Handler) executeReboot(ctx context.Context) error {
+// executeReboot executes the reboot sequence, via kexec if requested.
+func (h *Handler) executeReboot(ctx context.Context, kexec bool) error {
 	logger := log.FromContext(ctx)
 
 	// Write last reboot time (before reboot, as we may not return)
@@ -178,21 +221,44 @@ func (h *Handler) executeReboot(ctx context.Context) error {
 		// Continue with reboot despite this error
 	}
 
-	// Clear force-reboot annotation
-	if err := h.writer.ClearForceReboot(ctx); err != nil {
-		logger.Error(err, "failed to clear force-reboot annotation")
+	// Clear force-reboot and reboot-pending annotations
+	h.clearRebootRequests(ctx)
+
+	if err := h.state.WriteRebootIssued(); err != nil {
+		logger.Error(err, "failed to record issued reboot, continuing anyway")
 		// Continue with reboot despite this error
 	}
 
-	// Clear reboot-pending annotation
+	// Execute the reboot
+	var err error
+	if kexec {
+		logger.Info("executing kexec reboot")
+		err = h.executor.ExecuteKexec(ctx)
+	} else {
+		logger.Info("executing reboot")
+		err = h.executor.Execute(ctx)
+	}
+	if err != nil {
+		// Nothing is going to reboot the node: let the next request try again.
+		if clearErr := h.state.ClearRebootIssued(); clearErr != nil {
+			logger.Error(clearErr, "failed to clear issued reboot marker")
+		}
+	}
+	return err
+}
+
+// clearRebootRequests removes the force-reboot and reboot-pending
+// annotations: the reboot that satisfies them has been issued. Errors are
+// logged, the reboot goes ahead regardless.
+func (h *Handler) clearRebootRequests(ctx context.Context) {
+	logger := log.FromContext(ctx)
+
+	if err := h.writer.ClearForceReboot(ctx); err != nil {
+		logger.Error(err, "failed to clear force-reboot annotation")
+	}
 	if err := h.writer.SetRebootPending(ctx, false); err != nil {
 		logger.Error(err, "failed to clear reboot-pending annotation")
-		// Continue with reboot despite this error
 	}
-
-	// Execute the reboot
-	logger.Info("executing reboot")
-	return h.executor.Execute(ctx)
 }
 
 // CheckRebootPendingOnStartup clears reboot-pending if reboot actually occurred.
@@ -207,6 +273,8 @@ func (h *Handler) executeReboot(ctx context.Context) error {
 func (h *Handler) CheckRebootPendingOnStartup(ctx context.Context, node *corev1.Node) error {
 	logger := log.FromContext(ctx)
 
+	h.markVerifyPending(ctx)
+
 	// Always write boot marker at end (deferred)
 	defer func() {
 		if err := h.state.WriteBootMarker(); err != nil {
@@ -238,6 +306,11 @@ func (h *Handler) CheckRebootPendingOnStartup(ctx context.Context, node *corev1.
 		// This enables ShouldUncordon() to return true and uncordon the node.
 		desiredRevision := annotations.GetAnnotation(node.Annotations, annotations.DesiredRevision)
 		if desiredRevision != "" {
+			// Leave the revision unfinished; the agent re-applies it and
+			// verifies again before marking it done.
+			if err := h.VerifyAfterReboot(ctx); err != nil {
+				return err
+			}
 			logger.Info("setting done state after reboot", "revision", desiredRevision)
 			if err := h.writer.SetDone(ctx, desiredRevision); err != nil {
 				return err
@@ -33,6 +33,7 @@ import (
 type mockNodeWriter struct {
 	state           string
 	rebootPending   *bool
+	pendingReason   string
 	forceCleared    bool
 	currentRevision string
 	setStateErr     error
@@ -54,6 +55,19 @@ func (m *mockNodeWriter) SetRebootPending(ctx context.Context, pending bool) err
 		return m.setPendingErr
 	}
 	m.rebootPending = &pending
+	if !pending {
+		m.pendingReason = ""
+	}
+	return nil
+}
+
+func (m *mockNodeWriter) SetRebootPendingReason(ctx context.Context, reason string) error {
+	if m.setPendingErr != nil {
+		return m.setPendingErr
+	}
+	pending := true
+	m.rebootPending = &pending
+	m.pendingReason = reason
 	return nil
 }
 
@@ -85,6 +99,7 @@ func (m *mockNodeWriter) SetDone(ctx context.Context, revision string) error {
 // mockExecutor is a mock reboot executor.
 type mockExecutor struct {
 	called bool
+	kexec  bool
 	err    error
 }
 
@@ -93,6 +108,16 @@ func (m *mockExecutor) Execute(ctx context.Context) error {
 	return m.err
 }
 
+func (m *mockExecutor) ExecuteKexec(ctx context.Context) error {
+	m.called = true
+	m.kexec = true
+	return m.err
+}
+
+func (m *mockExecutor) SupportedMethods() []string {
+	return []string{MethodReboot, MethodKexec}
+}
+
 func TestNewHandler(t *testing.T) {
 	writer := &mockNodeWriter{}
 	executor := &mockExecutor{}
@@ -200,6 +225,9 @@ func TestHandleReboot_StrategyNever(t *testing.T) {
 	if writer.rebootPending == nil || !*writer.rebootPending {
 		t.Error("reboot-pending was not set to true")
 	}
+	if writer.pendingReason != annotations.RebootPendingStrategyNever {
+		t.Errorf("pending reason = %q, want %q", writer.pendingReason, annotations.RebootPendingStrategyNever)
+	}
 }
 
 func TestHandleReboot_StrategyEmpty(t *testing.T) {
@@ -285,6 +313,53 @@ func TestHandleReboot_IfRequired_NoLastReboot(t *testing.T) {
 	}
 }
 
+func TestHandleReboot_Kexec(t *testing.T) {
+	writer := &mockNodeWriter{}
+	executor := &mockExecutor{}
+	handler := NewHandler(t.TempDir(), writer, executor)
+
+	rmc := &mcov1alpha1.RenderedMachineConfig{
+		Spec: mcov1alpha1.RenderedMachineConfigSpec{
+			Reboot: mcov1alpha1.RenderedRebootSpec{
+				Required:           true,
+				Strategy:           "Kexec",
+				MinIntervalSeconds: 1800,
+			},
+		},
+	}
+
+	if err := handler.HandleReboot(context.Background(), rmc, &corev1.Node{}); err != nil {
+		t.Fatalf("HandleReboot() error = %v", err)
+	}
+	if !executor.called || !executor.kexec {
+		t.Errorf("called = %v, kexec = %v, want kexec reboot", executor.called, executor.kexec)
+	}
+}
+
+func TestHandleReboot_StrategyOverride(t *testing.T) {
+	writer := &mockNodeWriter{}
+	executor := &mockExecutor{}
+	handler := NewHandler(t.TempDir(), writer, executor)
+
+	rmc := &mcov1alpha1.RenderedMachineConfig{
+		Spec: mcov1alpha1.RenderedMachineConfigSpec{
+			Reboot: mcov1alpha1.RenderedRebootSpec{
+				Required: true,
+				Strategy: "Kexec",
+			},
+		},
+	}
+	node := &corev1.Node{}
+	node.Annotations = map[string]string{annotations.RebootStrategy: "IfRequired"}
+
+	if err := handler.HandleReboot(context.Background(), rmc, node); err != nil {
+		t.Fatalf("HandleReboot() error = %v", err)
+	}
+	if !executor.called || executor.kexec {
+		t.Errorf("called = %v, kexec = %v, want full reboot", executor.called, executor.kexec)
+	}
+}
+
 func TestHandleReboot_IfRequired_IntervalElapsed(t *testing.T) {
 	writer := &mockNodeWriter{}
 	executor := &mockExecutor{}
@@ -346,6 +421,9 @@ func TestHandleReboot_IfRequired_IntervalNotElapsed(t *testing.T) {
 	if writer.rebootPending == nil || !*writer.rebootPending {
 		t.Error("reboot-pending was not set to true")
 	}
+	if writer.pendingReason != annotations.RebootPendingMinInterval {
+		t.Errorf("pending reason = %q, want %q", writer.pendingReason, annotations.RebootPendingMinInterval)
+	}
 }
 
 func TestHandleReboot_IfRequired_ZeroInterval(t *testing.T) {
@@ -383,7 +461,7 @@ func TestExecuteReboot_SetsState(t *testing.T) {
 	executor := &mockExecutor{}
 	handler := NewHandler(t.TempDir(), writer, executor)
 
-	err := handler.executeReboot(context.Background())
+	err := handler.executeReboot(context.Background(), false)
 
 	if err != nil {
 		t.Fatalf("executeReboot() error = %v", err)
@@ -398,7 +476,7 @@ func TestExecuteReboot_ClearsAnnotations(t *testing.T) {
 	executor := &mockExecutor{}
 	handler := NewHandler(t.TempDir(), writer, executor)
 
-	err := handler.executeReboot(context.Background())
+	err := handler.executeReboot(context.Background(), false)
 
 	if err != nil {
 		t.Fatalf("executeReboot() error = %v", err)
@@ -418,7 +496,7 @@ func TestExecuteReboot_WritesLastRebootTime(t *testing.T) {
 	handler := NewHandler(hostRoot, writer, executor)
 
 	before := time.Now().Add(-1 * time.Second)
-	err := handler.executeReboot(context.Background())
+	err := handler.executeReboot(context.Background(), false)
 	after := time.Now().Add(1 * time.Second)
 
 	if err != nil {
@@ -564,7 +642,7 @@ func TestExecuteReboot_ContinuesOnErrors(t *testing.T) {
 	executor := &mockExecutor{}
 	handler := NewHandler(t.TempDir(), writer, executor)
 
-	err := handler.executeReboot(context.Background())
+	err := handler.executeReboot(context.Background(), false)
 
 	// Should still call executor despite writer errors
 	if err != nil {
@@ -574,3 +652,53 @@ func TestExecuteReboot_ContinuesOnErrors(t *testing.T) {
 		t.Error("executor was not called despite writer errors")
 	}
 }
+
+func TestHandleReboot_CoalescesWithIssuedReboot(t *testing.T) {
+	writer := &mockNodeWriter{}
+	executor := &mockExecutor{}
+	handler := NewHandler(t.TempDir(), writer, executor)
+
+	rmc := &mcov1alpha1.RenderedMachineConfig{
+		Spec: mcov1alpha1.RenderedMachineConfigSpec{
+			Reboot: mcov1alpha1.RenderedRebootSpec{Required: true, Strategy: "IfRequired"},
+		},
+	}
+	node := &corev1.Node{}
+
+	if err := handler.HandleReboot(context.Background(), rmc, node); err != nil {
+		t.Fatalf("first HandleReboot() error = %v", err)
+	}
+	if !executor.called {
+		t.Fatal("first request should reboot")
+	}
+
+	// A second revision and a force-reboot arrive before the node is down.
+	executor.called = false
+	writer.forceCleared = false
+	node.Annotations = map[string]string{annotations.ForceReboot: "true"}
+	if err := handler.HandleReboot(context.Background(), rmc, node); err != nil {
+		t.Fatalf("second HandleReboot() error = %v", err)
+	}
+	if executor.called {
+		t.Error("second request must not reboot again during the same boot")
+	}
+	if !writer.forceCleared {
+		t.Error("force-reboot should be cleared, the issued reboot satisfies it")
+	}
+	if writer.rebootPending == nil || *writer.rebootPending {
+		t.Error("reboot-pending should be cleared, the issued reboot satisfies it")
+	}
+}
+
+func TestExecuteReboot_FailureAllowsRetry(t *testing.T) {
+	writer := &mockNodeWriter{}
+	executor := &mockExecutor{err: fmt.Errorf("systemctl failed")}
+	handler := NewHandler(t.TempDir(), writer, executor)
+
+	if err := handler.executeReboot(context.Background(), false); err == nil {
+		t.Fatal("executeReboot() should return the executor error")
+	}
+	if handler.state.RebootIssued() {
+		t.Error("a failed reboot must not block the next attempt")
+	}
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reboot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Names of the built-in reboot executors.
+const (
+	ExecutorSystemd        = "systemd"
+	ExecutorKexec          = "kexec"
+	ExecutorNoOp           = "noop"
+	ExecutorSignalExternal = "signal-external"
+)
+
+// ExecutorFactory creates a reboot executor for the host mounted at hostRoot.
+type ExecutorFactory func(hostRoot string) RebootExecutor
+
+var (
+	executorsMu sync.RWMutex
+	executors   = map[string]ExecutorFactory{
+		ExecutorSystemd:        func(hostRoot string) RebootExecutor { return NewSystemdExecutor(hostRoot) },
+		ExecutorKexec:          func(hostRoot string) RebootExecutor { return NewKexecExecutor(hostRoot) },
+		ExecutorNoOp:           func(string) RebootExecutor { return &NoOpExecutor{} },
+		ExecutorSignalExternal: func(hostRoot string) RebootExecutor { return NewSignalExternalExecutor(hostRoot) },
+	}
+)
+
+// RegisterExecutor makes a reboot executor selectable by name, replacing
+// any executor registered under the same name.
+func RegisterExecutor(name string, factory ExecutorFactory) {
+	executorsMu.Lock()
+	defer executorsMu.Unlock()
+	executors[name] = factory
+}
+
+// NewExecutor creates the reboot executor registered under name.
+func NewExecutor(name, hostRoot string) (RebootExecutor, error) {
+	executorsMu.RLock()
+	factory, ok := executors[name]
+	executorsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown reboot executor %q (available: %s)", name, strings.Join(ExecutorNames(), ", "))
+	}
+	return factory(hostRoot), nil
+}
+
+// ExecutorNames returns the names of the registered reboot executors, sorted.
+func ExecutorNames() []string {
+	executorsMu.RLock()
+	defer executorsMu.RUnlock()
+	names := make([]string, 0, len(executors))
+	for name := range executors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
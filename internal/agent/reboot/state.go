@@ -34,6 +34,12 @@ const (
 	bootMarkerDir = "/run/mco"
 	// bootMarkerFile indicates the agent has started since last reboot.
 	bootMarkerFile = "boot-marker"
+	// rebootIssuedFile records that a reboot was issued during this boot.
+	// It lives next to the boot marker, so the reboot itself removes it.
+	rebootIssuedFile = "reboot-issued"
+	// verifyPendingFile records that the post-reboot verification command
+	// has not yet passed during this boot.
+	verifyPendingFile = "verify-pending"
 )
 
 // StateManager manages local state persistence for reboot handling.
@@ -154,3 +160,69 @@ func (s *StateManager) WriteBootMarker() error {
 
 	return nil
 }
+
+// RebootIssued reports whether a reboot was already issued during the
+// current boot.
+func (s *StateManager) RebootIssued() bool {
+	_, err := os.Stat(filepath.Join(s.hostRoot, bootMarkerDir, rebootIssuedFile))
+	return err == nil
+}
+
+// WriteRebootIssued records that a reboot is being issued.
+func (s *StateManager) WriteRebootIssued() error {
+	dir := filepath.Join(s.hostRoot, bootMarkerDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create boot marker directory: %w", err)
+	}
+
+	path := filepath.Join(dir, rebootIssuedFile)
+	content := time.Now().Format(time.RFC3339)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write reboot-issued marker: %w", err)
+	}
+
+	return nil
+}
+
+// ClearRebootIssued removes the reboot-issued marker, e.g. when the reboot
+// could not be started.
+func (s *StateManager) ClearRebootIssued() error {
+	err := os.Remove(filepath.Join(s.hostRoot, bootMarkerDir, rebootIssuedFile))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove reboot-issued marker: %w", err)
+	}
+	return nil
+}
+
+// VerifyPending reports whether post-reboot verification is still due
+// during the current boot.
+func (s *StateManager) VerifyPending() bool {
+	_, err := os.Stat(filepath.Join(s.hostRoot, bootMarkerDir, verifyPendingFile))
+	return err == nil
+}
+
+// WriteVerifyPending records that post-reboot verification is due.
+func (s *StateManager) WriteVerifyPending() error {
+	dir := filepath.Join(s.hostRoot, bootMarkerDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create boot marker directory: %w", err)
+	}
+
+	path := filepath.Join(dir, verifyPendingFile)
+	content := time.Now().Format(time.RFC3339)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write verify-pending marker: %w", err)
+	}
+
+	return nil
+}
+
+// ClearVerifyPending removes the verify-pending marker once verification
+// has passed.
+func (s *StateManager) ClearVerifyPending() error {
+	err := os.Remove(filepath.Join(s.hostRoot, bootMarkerDir, verifyPendingFile))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove verify-pending marker: %w", err)
+	}
+	return nil
+}
@@ -268,3 +268,26 @@ func TestStatePath(t *testing.T) {
 		})
 	}
 }
+
+func TestStateManager_RebootIssued(t *testing.T) {
+	sm := NewStateManager(t.TempDir())
+
+	if sm.RebootIssued() {
+		t.Error("RebootIssued() = true before any reboot")
+	}
+	if err := sm.WriteRebootIssued(); err != nil {
+		t.Fatalf("WriteRebootIssued() error = %v", err)
+	}
+	if !sm.RebootIssued() {
+		t.Error("RebootIssued() = false after WriteRebootIssued()")
+	}
+	if err := sm.ClearRebootIssued(); err != nil {
+		t.Fatalf("ClearRebootIssued() error = %v", err)
+	}
+	if sm.RebootIssued() {
+		t.Error("RebootIssued() = true after ClearRebootIssued()")
+	}
+	if err := sm.ClearRebootIssued(); err != nil {
+		t.Errorf("ClearRebootIssued() on missing marker error = %v", err)
+	}
+}
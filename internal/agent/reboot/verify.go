@@ -0,0 +1,107 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reboot
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// DefaultVerifyTimeout bounds a single run of the post-reboot verification
+// command.
+const DefaultVerifyTimeout = 5 * time.Minute
+
+// CommandRunner runs a shell command on the host.
+// This interface enables testing without executing host commands.
+type CommandRunner interface {
+	Run(ctx context.Context, command string) error
+}
+
+// HostCommandRunner runs commands with the host's /bin/sh via chroot.
+type HostCommandRunner struct {
+	hostRoot string
+}
+
+// NewHostCommandRunner creates a new chroot-based command runner.
+func NewHostCommandRunner(hostRoot string) *HostCommandRunner {
+	return &HostCommandRunner{hostRoot: hostRoot}
+}
+
+// Run executes command and returns its output in the error on failure.
+func (r *HostCommandRunner) Run(ctx context.Context, command string) error {
+	root := r.hostRoot
+	if root == "" {
+		root = "/"
+	}
+	cmd := exec.CommandContext(ctx, "chroot", root, "/bin/sh", "-c", command)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// SetVerifyCommand configures a command that must succeed after the node
+// comes back from a reboot before the revision is marked done. An empty
+// command disables verification.
+func (h *Handler) SetVerifyCommand(command string, runner CommandRunner) {
+	h.verifyCommand = command
+	h.verifier = runner
+}
+
+// VerifyAfterReboot runs the verification command if the node rebooted and
+// it has not passed yet during this boot. It returns nil when there is
+// nothing to verify.
+func (h *Handler) VerifyAfterReboot(ctx context.Context) error {
+	if h.verifyCommand == "" || !h.state.VerifyPending() {
+		return nil
+	}
+	logger := log.FromContext(ctx)
+
+	runCtx, cancel := context.WithTimeout(ctx, DefaultVerifyTimeout)
+	defer cancel()
+
+	logger.Info("running post-reboot verification", "command", h.verifyCommand)
+	if err := h.verifier.Run(runCtx, h.verifyCommand); err != nil {
+		return fmt.Errorf("post-reboot verification failed: %w", err)
+	}
+	logger.Info("post-reboot verification passed")
+
+	if err := h.state.ClearVerifyPending(); err != nil {
+		logger.Error(err, "failed to clear verify-pending marker")
+	}
+	return nil
+}
+
+// markVerifyPending records that verification is due when the node has
+// rebooted since the agent last ran. It must be called before the boot
+// marker is written.
+func (h *Handler) markVerifyPending(ctx context.Context) {
+	if h.verifyCommand == "" || h.state.BootMarkerExists() {
+		return
+	}
+	if _, err := h.state.ReadLastRebootTime(); err != nil {
+		return
+	}
+	if err := h.state.WriteVerifyPending(); err != nil {
+		log.FromContext(ctx).Error(err, "failed to record pending post-reboot verification")
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reboot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+// mockRunner is a mock command runner.
+type mockRunner struct {
+	commands []string
+	err      error
+}
+
+func (m *mockRunner) Run(ctx context.Context, command string) error {
+	m.commands = append(m.commands, command)
+	return m.err
+}
+
+func rebootedNode() *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				annotations.RebootPending:   "true",
+				annotations.DesiredRevision: "worker-abc123",
+			},
+		},
+	}
+}
+
+func TestCheckRebootPendingOnStartup_VerifyFails(t *testing.T) {
+	writer := &mockNodeWriter{}
+	runner := &mockRunner{err: errors.New("exit status 1")}
+	handler := NewHandler(t.TempDir(), writer, &mockExecutor{})
+	handler.SetVerifyCommand("systemctl is-active kubelet", runner)
+	handler.state.WriteLastRebootTime(time.Now())
+
+	if err := handler.CheckRebootPendingOnStartup(context.Background(), rebootedNode()); err == nil {
+		t.Fatal("CheckRebootPendingOnStartup() error = nil, want verification failure")
+	}
+	if writer.state == "done" || writer.currentRevision != "" {
+		t.Errorf("revision marked done despite failed verification: state=%q current=%q",
+			writer.state, writer.currentRevision)
+	}
+	if !handler.state.VerifyPending() {
+		t.Error("verify-pending cleared after failed verification")
+	}
+
+	// The gate survives an agent restart within the same boot.
+	if err := handler.VerifyAfterReboot(context.Background()); err == nil {
+		t.Error("VerifyAfterReboot() error = nil, want failure while command fails")
+	}
+
+	runner.err = nil
+	if err := handler.VerifyAfterReboot(context.Background()); err != nil {
+		t.Fatalf("VerifyAfterReboot() error = %v", err)
+	}
+	if handler.state.VerifyPending() {
+		t.Error("verify-pending not cleared after verification passed")
+	}
+	if len(runner.commands) != 3 || runner.commands[0] != "systemctl is-active kubelet" {
+		t.Errorf("commands = %v, want three runs of the verify command", runner.commands)
+	}
+}
+
+func TestCheckRebootPendingOnStartup_VerifyPasses(t *testing.T) {
+	writer := &mockNodeWriter{}
+	runner := &mockRunner{}
+	handler := NewHandler(t.TempDir(), writer, &mockExecutor{})
+	handler.SetVerifyCommand("true", runner)
+	handler.state.WriteLastRebootTime(time.Now())
+
+	if err := handler.CheckRebootPendingOnStartup(context.Background(), rebootedNode()); err != nil {
+		t.Fatalf("CheckRebootPendingOnStartup() error = %v", err)
+	}
+	if writer.state != "done" {
+		t.Errorf("state = %q, want done", writer.state)
+	}
+	if len(runner.commands) != 1 {
+		t.Errorf("verify command ran %d times, want 1", len(runner.commands))
+	}
+}
+
+func TestVerifyAfterReboot_NoReboot(t *testing.T) {
+	runner := &mockRunner{err: errors.New("should not run")}
+	handler := NewHandler(t.TempDir(), &mockNodeWriter{}, &mockExecutor{})
+	handler.SetVerifyCommand("false", runner)
+	handler.state.WriteLastRebootTime(time.Now())
+	if err := handler.state.WriteBootMarker(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Agent restart without a reboot: nothing to verify.
+	if err := handler.CheckRebootPendingOnStartup(context.Background(), &corev1.Node{}); err != nil {
+		t.Fatalf("CheckRebootPendingOnStartup() error = %v", err)
+	}
+	if err := handler.VerifyAfterReboot(context.Background()); err != nil {
+		t.Errorf("VerifyAfterReboot() error = %v, want nil", err)
+	}
+	if len(runner.commands) != 0 {
+		t.Errorf("verify command ran without a reboot: %v", runner.commands)
+	}
+}
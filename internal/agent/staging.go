@@ -0,0 +1,155 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/google/renameio/v2"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/filecontent"
+)
+
+// StagingDir is the host directory a revision's changed unit files are
+// staged in, so they can be verified while the old config is still live.
+const StagingDir = "/var/lib/mco/staging"
+
+// stageAndValidate validates the files of a revision that apply would
+// change before any of them is written: host files with a validator are
+// checked in memory, and unit files are written below StagingDir and
+// verified there. It returns the unit file paths it verified. The
+// staging directory is removed afterwards.
+func (a *Applier) stageAndValidate(ctx context.Context, files []mcov1alpha1.FileSpec) (map[string]bool, error) {
+	stagingRoot := filepath.Join(a.hostRoot, StagingDir)
+	defer func() { _ = os.RemoveAll(stagingRoot) }()
+
+	verified := make(map[string]bool)
+	for _, f := range files {
+		if f.State != "" && f.State != FileStatePresent {
+			continue
+		}
+		// Errors checking the live file are left for the apply to report.
+		if needs, err := a.files.NeedsUpdate(f); err == nil && !needs {
+			continue
+		}
+		content, err := filecontent.Decode(f)
+		if err != nil {
+			return nil, fmt.Errorf("file %s: %w", f.Path, err)
+		}
+		if err := ValidateHostFile(f.Path, content); err != nil {
+			return nil, fmt.Errorf("file %s: %w", f.Path, err)
+		}
+		if !isUnitFilePath(f.Path) {
+			continue
+		}
+
+		staged := filepath.Join(stagingRoot, f.Path)
+		if err := os.MkdirAll(filepath.Dir(staged), 0755); err != nil {
+			return nil, fmt.Errorf("stage %s: %w", f.Path, err)
+		}
+		if err := os.WriteFile(staged, content, 0644); err != nil {
+			return nil, fmt.Errorf("stage %s: %w", f.Path, err)
+		}
+		if err := a.systemd.conn.VerifyUnitFile(ctx, filepath.Join(StagingDir, f.Path)); err != nil {
+			return nil, fmt.Errorf("verify unit file %s: %w", f.Path, err)
+		}
+		verified[f.Path] = true
+	}
+	return verified, nil
+}
+
+// fileSnapshot is the state of a host file before apply changed it, kept
+// so a failed apply can put the file back.
+type fileSnapshot struct {
+	path    string // below hostRoot
+	existed bool
+	// regular is false for directories, symlinks and other special
+	// files, which restore leaves alone.
+	regular  bool
+	content  []byte
+	mode     os.FileMode
+	uid, gid int
+}
+
+// snapshotFile records the current state of the host file at path.
+func snapshotFile(path string) (*fileSnapshot, error) {
+	s := &fileSnapshot{path: path}
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.existed = true
+	if !info.Mode().IsRegular() {
+		return s, nil
+	}
+	if s.content, err = os.ReadFile(path); err != nil {
+		return nil, err
+	}
+	s.regular = true
+	s.mode = info.Mode().Perm()
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		s.uid, s.gid = int(st.Uid), int(st.Gid)
+	}
+	return s, nil
+}
+
+// restore puts the file back the way it was snapshotted: a file that did
+// not exist is removed, a regular file gets its content, mode and owner
+// back.
+func (s *fileSnapshot) restore() error {
+	if !s.existed {
+		if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	if !s.regular {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	if err := renameio.WriteFile(s.path, s.content, s.mode); err != nil {
+		return err
+	}
+	if err := os.Chmod(s.path, s.mode); err != nil {
+		return err
+	}
+	return os.Lchown(s.path, s.uid, s.gid)
+}
+
+// rollbackFiles restores snapshots newest first and returns how many
+// files it could not restore.
+func rollbackFiles(snapshots []*fileSnapshot) int {
+	failed := 0
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if err := snapshots[i].restore(); err != nil {
+			agentLog.Error(err, "failed to roll back file", "path", snapshots[i].path)
+			failed++
+		}
+	}
+	return failed
+}
@@ -54,6 +54,10 @@ type SystemdConnection interface {
 
 	// ReloadUnit reloads a unit's configuration.
 	ReloadUnit(ctx context.Context, name string) error
+
+	// VerifyUnitFile checks the syntax of a unit file on the host
+	// (systemd-analyze verify). The returned error carries the verifier output.
+	VerifyUnitFile(ctx context.Context, path string) error
 }
 
 // UnitApplyResult contains the result of a unit apply operation.
@@ -22,22 +22,27 @@ package agent
 import (
 	"context"
 	"fmt"
+	"os/exec"
+	"strings"
 
 	"github.com/coreos/go-systemd/v22/dbus"
 )
 
 // DBusConnection implements SystemdConnection using go-systemd/dbus.
 type DBusConnection struct {
-	conn *dbus.Conn
+	conn     *dbus.Conn
+	hostRoot string
 }
 
 // NewDBusConnection creates a new D-Bus connection to systemd.
-func NewDBusConnection(ctx context.Context) (*DBusConnection, error) {
+// hostRoot is where the host filesystem is mounted; it is used to run
+// host tools such as systemd-analyze.
+func NewDBusConnection(ctx context.Context, hostRoot string) (*DBusConnection, error) {
 	conn, err := dbus.NewSystemConnectionContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("connect to systemd: %w", err)
 	}
-	return &DBusConnection{conn: conn}, nil
+	return &DBusConnection{conn: conn, hostRoot: hostRoot}, nil
 }
 
 // Close closes the D-Bus connection.
@@ -123,3 +128,17 @@ func (c *DBusConnection) ReloadUnit(ctx context.Context, name string) error {
 	<-ch
 	return nil
 }
+
+// VerifyUnitFile runs systemd-analyze verify on the host for a unit file.
+// D-Bus has no verify call, so the host binary is used via chroot.
+func (c *DBusConnection) VerifyUnitFile(ctx context.Context, path string) error {
+	root := c.hostRoot
+	if root == "" {
+		root = "/"
+	}
+	cmd := exec.CommandContext(ctx, "chroot", root, "systemd-analyze", "verify", path)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("systemd-analyze verify %s: %w: %s", path, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -85,3 +85,8 @@ func (n *NoOpSystemdConnection) ReloadUnit(_ context.Context, name string) error
 	noopLog.Info("no-op: ReloadUnit", "unit", name)
 	return nil
 }
+
+func (n *NoOpSystemdConnection) VerifyUnitFile(_ context.Context, path string) error {
+	noopLog.Info("no-op: VerifyUnitFile", "path", path)
+	return nil
+}
@@ -26,6 +26,6 @@ import (
 
 // NewDBusConnection returns an error on non-Linux platforms.
 // Systemd D-Bus is only available on Linux.
-func NewDBusConnection(ctx context.Context) (SystemdConnection, error) {
+func NewDBusConnection(ctx context.Context, hostRoot string) (SystemdConnection, error) {
 	return nil, fmt.Errorf("systemd D-Bus is only available on Linux")
 }
@@ -35,6 +35,8 @@ type MockSystemdConnection struct {
 	StopCalls    []string
 	RestartCalls []string
 	ReloadCalls  []string
+	VerifyCalls  []string
+	VerifyErrors map[string]error // path -> error returned by VerifyUnitFile
 	Closed       bool
 	Error        error // Error to return for all operations
 }
@@ -134,6 +136,14 @@ func (m *MockSystemdConnection) ReloadUnit(ctx context.Context, name string) err
 	return m.Error
 }
 
+func (m *MockSystemdConnection) VerifyUnitFile(ctx context.Context, path string) error {
+	m.VerifyCalls = append(m.VerifyCalls, path)
+	if err, ok := m.VerifyErrors[path]; ok {
+		return err
+	}
+	return m.Error
+}
+
 func TestNewSystemdApplier(t *testing.T) {
 	mock := NewMockConnection()
 	a := NewSystemdApplier(mock)
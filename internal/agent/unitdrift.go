@@ -0,0 +1,207 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+// CorrectUnitDrift restores managed unit files whose on-disk state no
+// longer matches config, e.g. after a hand edit, and returns their paths.
+// Only unit files are checked; other managed files are left to the next
+// apply.
+func (a *Applier) CorrectUnitDrift(ctx context.Context, config *mcov1alpha1.RenderedConfig) ([]string, error) {
+	var drifted []string
+	for _, f := range sortFilesByPath(config.Files) {
+		if !isUnitFilePath(f.Path) {
+			continue
+		}
+		needs, err := a.files.NeedsUpdate(f)
+		if err != nil {
+			return drifted, fmt.Errorf("check %s: %w", f.Path, err)
+		}
+		if !needs {
+			continue
+		}
+		drifted = append(drifted, f.Path)
+
+		if result := a.files.Apply(f); result.Error != nil {
+			return drifted, fmt.Errorf("file %s: %w", f.Path, result.Error)
+		}
+		if f.State != FileStateAbsent {
+			if err := a.systemd.conn.VerifyUnitFile(ctx, f.Path); err != nil {
+				return drifted, fmt.Errorf("verify unit file %s: %w", f.Path, err)
+			}
+		}
+	}
+	return drifted, nil
+}
+
+// UnitStateDrift is a unit whose runtime state differs from its spec.
+type UnitStateDrift struct {
+	Unit string
+	// Property is "masked", "enabled" or "active".
+	Property string
+	Actual   string
+	Desired  string
+}
+
+func (d UnitStateDrift) String() string {
+	return fmt.Sprintf("%s %s=%s (want %s)", d.Unit, d.Property, d.Actual, d.Desired)
+}
+
+// UnitStateDriftSummary joins drifts for the unit-state-drift annotation.
+func UnitStateDriftSummary(drifts []UnitStateDrift) string {
+	entries := make([]string, len(drifts))
+	for i, d := range drifts {
+		entries[i] = d.String()
+	}
+	return strings.Join(entries, "; ")
+}
+
+// DiffUnitState compares the runtime state of units, read through systemd,
+// with their spec. Only what the spec declares is checked: mask always,
+// enablement when Enabled is set, and activity when State is set.
+// Restarted and reloaded units are expected to be running. Nothing is
+// changed on the host.
+func (a *Applier) DiffUnitState(ctx context.Context, units []mcov1alpha1.UnitSpec) ([]UnitStateDrift, error) {
+	var drifts []UnitStateDrift
+	for _, u := range sortUnitsByName(units) {
+		fileState, err := a.systemd.getUnitFileState(ctx, u.Name)
+		if err != nil {
+			return drifts, fmt.Errorf("unit %s: %w", u.Name, err)
+		}
+		masked := fileState == "masked"
+		if masked != u.Mask {
+			drifts = append(drifts, UnitStateDrift{
+				Unit: u.Name, Property: "masked",
+				Actual: fmt.Sprint(masked), Desired: fmt.Sprint(u.Mask),
+			})
+		}
+		if u.Mask {
+			continue
+		}
+
+		if u.Enabled != nil {
+			enabled := fileState == "enabled" || fileState == "enabled-runtime"
+			disabled := fileState == "disabled" || fileState == ""
+			if (*u.Enabled && !enabled) || (!*u.Enabled && !disabled) {
+				drifts = append(drifts, UnitStateDrift{
+					Unit: u.Name, Property: "enabled",
+					Actual: orUnknown(fileState), Desired: enabledState(*u.Enabled),
+				})
+			}
+		}
+
+		if u.State != "" {
+			active, err := a.systemd.getActiveState(ctx, u.Name)
+			if err != nil {
+				return drifts, fmt.Errorf("unit %s: %w", u.Name, err)
+			}
+			if desired, ok := activeStateMatches(u.State, active); !ok {
+				drifts = append(drifts, UnitStateDrift{
+					Unit: u.Name, Property: "active",
+					Actual: orUnknown(active), Desired: desired,
+				})
+			}
+		}
+	}
+	return drifts, nil
+}
+
+// activeStateMatches reports whether active is the ActiveState expected
+// for a unit with the given spec state, and names the expected state.
+func activeStateMatches(state, active string) (string, bool) {
+	if state == "stopped" {
+		return "inactive", active == "inactive" || active == "failed" || active == ""
+	}
+	return "active", slices.Contains([]string{"active", "activating", "reloading"}, active)
+}
+
+func enabledState(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
+
+// checkUnitDrift restores hand-edited unit files of the revision the node
+// runs and reports them in the unit-drift annotation. Units whose runtime
+// state differs from the spec are reported, not corrected, in the
+// unit-state-drift annotation. It does nothing while the node is paused,
+// in maintenance, or between revisions.
+func (a *Agent) checkUnitDrift(ctx context.Context, node *corev1.Node) error {
+	ann := node.Annotations
+	if annotations.IsNodePaused(ann) || annotations.IsNodeInMaintenance(ann) {
+		return nil
+	}
+	current := annotations.GetAnnotation(ann, annotations.CurrentRevision)
+	if current == "" || !annotations.IsReady(ann) || a.pendingRebootRevision != "" {
+		return nil
+	}
+
+	rmc, err := a.FetchRMC(ctx, current)
+	if err != nil {
+		return err
+	}
+
+	drifted, err := a.applier.CorrectUnitDrift(ctx, &rmc.Spec.Config)
+	if len(drifted) > 0 {
+		agentLog.Info("restored hand-edited unit files", "node", a.nodeName, "revision", current, "paths", drifted)
+		if werr := a.writer.SetUnitDrift(ctx, drifted); werr != nil {
+			agentLog.V(1).Info("failed to set unit-drift annotation", "error", werr)
+		}
+	} else if err == nil && annotations.GetAnnotation(ann, annotations.UnitDrift) != "" {
+		if werr := a.writer.SetUnitDrift(ctx, nil); werr != nil {
+			agentLog.V(1).Info("failed to clear unit-drift annotation", "error", werr)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	drifts, err := a.applier.DiffUnitState(ctx, rmc.Spec.Config.Systemd.Units)
+	if err != nil {
+		return err
+	}
+	summary := UnitStateDriftSummary(drifts)
+	if summary == annotations.GetAnnotation(ann, annotations.UnitStateDrift) {
+		return nil
+	}
+	if summary != "" {
+		agentLog.Info("unit runtime state differs from spec", "node", a.nodeName, "revision", current, "units", summary)
+	}
+	if werr := a.writer.SetUnitStateDrift(ctx, summary); werr != nil {
+		agentLog.V(1).Info("failed to set unit-state-drift annotation", "error", werr)
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+const driftUnitPath = "/etc/systemd/system/app.service"
+
+func writeHostFile(t *testing.T, root, path, content string) {
+	t.Helper()
+	full := filepath.Join(root, path)
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCorrectUnitDrift(t *testing.T) {
+	dir := t.TempDir()
+	conn := NewMockConnection()
+	applier := NewApplierWithOptions(dir, conn, true)
+
+	config := &mcov1alpha1.RenderedConfig{
+		Files: []mcov1alpha1.FileSpec{
+			{Path: driftUnitPath, Content: "[Service]\nExecStart=/usr/bin/app\n"},
+			{Path: "/etc/systemd/system/other.service", Content: "[Service]\nExecStart=/usr/bin/other\n"},
+			{Path: "/etc/systemd/system/removed.service", State: FileStateAbsent},
+			{Path: "/etc/app.conf", Content: "managed"},
+		},
+	}
+	writeHostFile(t, dir, driftUnitPath, "[Service]\nExecStart=/usr/bin/app --debug\n")
+	writeHostFile(t, dir, "/etc/systemd/system/other.service", "[Service]\nExecStart=/usr/bin/other\n")
+	writeHostFile(t, dir, "/etc/systemd/system/removed.service", "[Service]\n")
+	writeHostFile(t, dir, "/etc/app.conf", "hand edited")
+
+	drifted, err := applier.CorrectUnitDrift(context.Background(), config)
+	if err != nil {
+		t.Fatalf("CorrectUnitDrift() error = %v", err)
+	}
+	want := []string{driftUnitPath, "/etc/systemd/system/removed.service"}
+	if len(drifted) != len(want) || drifted[0] != want[0] || drifted[1] != want[1] {
+		t.Errorf("drifted = %v, want %v", drifted, want)
+	}
+
+	got, _ := os.ReadFile(filepath.Join(dir, driftUnitPath))
+	if string(got) != config.Files[0].Content {
+		t.Errorf("unit content = %q, want restored", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "/etc/systemd/system/removed.service")); !os.IsNotExist(err) {
+		t.Error("absent unit should be removed again")
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, "/etc/app.conf")); string(got) != "hand edited" {
+		t.Error("non-unit files must be left to the next apply")
+	}
+	if len(conn.VerifyCalls) != 1 || conn.VerifyCalls[0] != driftUnitPath {
+		t.Errorf("VerifyCalls = %v, want [%s]", conn.VerifyCalls, driftUnitPath)
+	}
+
+	drifted, err = applier.CorrectUnitDrift(context.Background(), config)
+	if err != nil || len(drifted) != 0 {
+		t.Errorf("second check = %v, %v; want no drift", drifted, err)
+	}
+}
+
+func TestAgent_CheckUnitDrift(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+			Annotations: map[string]string{
+				annotations.DesiredRevision: "rev-1",
+				annotations.CurrentRevision: "rev-1",
+				annotations.AgentState:      annotations.StateDone,
+			},
+		},
+	}
+	k8sClient := fake.NewSimpleClientset(node)
+	mcoClient := newMockMCOClient()
+	mcoClient.addRMC(&mcov1alpha1.RenderedMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "rev-1"},
+		Spec: mcov1alpha1.RenderedMachineConfigSpec{
+			Config: mcov1alpha1.RenderedConfig{
+				Files: []mcov1alpha1.FileSpec{
+					{Path: driftUnitPath, Content: "[Service]\nExecStart=/usr/bin/app\n"},
+				},
+			},
+		},
+	})
+
+	dir := t.TempDir()
+	writeHostFile(t, dir, driftUnitPath, "[Service]\nExecStart=/bin/true\n")
+
+	agent := newTestAgent("test-node", k8sClient, mcoClient)
+	agent.applier = NewApplierWithOptions(dir, NewMockConnection(), true)
+
+	ctx := context.Background()
+	if err := agent.checkUnitDrift(ctx, node); err != nil {
+		t.Fatalf("checkUnitDrift() error = %v", err)
+	}
+	updated, _ := k8sClient.CoreV1().Nodes().Get(ctx, "test-node", metav1.GetOptions{})
+	if got := updated.Annotations[annotations.UnitDrift]; got != driftUnitPath {
+		t.Errorf("UnitDrift = %q, want %q", got, driftUnitPath)
+	}
+
+	// A clean check clears the report.
+	if err := agent.checkUnitDrift(ctx, updated); err != nil {
+		t.Fatalf("checkUnitDrift() error = %v", err)
+	}
+	updated, _ = k8sClient.CoreV1().Nodes().Get(ctx, "test-node", metav1.GetOptions{})
+	if _, ok := updated.Annotations[annotations.UnitDrift]; ok {
+		t.Error("UnitDrift should be removed when no drift is found")
+	}
+
+	// Nodes between revisions are left alone.
+	writeHostFile(t, dir, driftUnitPath, "edited")
+	updated.Annotations[annotations.DesiredRevision] = "rev-2"
+	if err := agent.checkUnitDrift(ctx, updated); err != nil {
+		t.Fatalf("checkUnitDrift() error = %v", err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, driftUnitPath)); string(got) != "edited" {
+		t.Error("drift check must not touch a node that is mid-update")
+	}
+}
+
+func TestDiffUnitState(t *testing.T) {
+	conn := NewMockConnection()
+	conn.SetProperty("app.service", "UnitFileState", "enabled")
+	conn.SetProperty("app.service", "ActiveState", "active")
+	conn.SetProperty("crashed.service", "UnitFileState", "enabled")
+	conn.SetProperty("crashed.service", "ActiveState", "failed")
+	conn.SetProperty("legacy.service", "UnitFileState", "disabled")
+	conn.SetProperty("legacy.service", "ActiveState", "active")
+	conn.SetProperty("unmasked.service", "UnitFileState", "static")
+	applier := NewApplierWithOptions(t.TempDir(), conn, true)
+
+	enabled, disabled := true, false
+	units := []mcov1alpha1.UnitSpec{
+		{Name: "app.service", Enabled: &enabled, State: "started"},
+		{Name: "crashed.service", Enabled: &enabled, State: "started"},
+		{Name: "legacy.service", Enabled: &disabled, State: "stopped"},
+		{Name: "unmasked.service", Mask: true},
+		{Name: "unmanaged.service"},
+	}
+
+	drifts, err := applier.DiffUnitState(context.Background(), units)
+	if err != nil {
+		t.Fatalf("DiffUnitState() error = %v", err)
+	}
+	want := "crashed.service active=failed (want active); " +
+		"legacy.service active=active (want inactive); " +
+		"unmasked.service masked=false (want true)"
+	if got := UnitStateDriftSummary(drifts); got != want {
+		t.Errorf("summary = %q, want %q", got, want)
+	}
+}
+
+func TestCheckUnitDrift_ReportsUnitStateDrift(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-node",
+			Annotations: map[string]string{
+				annotations.DesiredRevision: "rev-1",
+				annotations.CurrentRevision: "rev-1",
+				annotations.AgentState:      annotations.StateDone,
+			},
+		},
+	}
+	k8sClient := fake.NewSimpleClientset(node)
+	mcoClient := newMockMCOClient()
+	mcoClient.addRMC(&mcov1alpha1.RenderedMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "rev-1"},
+		Spec: mcov1alpha1.RenderedMachineConfigSpec{
+			Config: mcov1alpha1.RenderedConfig{
+				Systemd: mcov1alpha1.SystemdSpec{
+					Units: []mcov1alpha1.UnitSpec{{Name: "app.service", State: "started"}},
+				},
+			},
+		},
+	})
+
+	conn := NewMockConnection()
+	conn.SetProperty("app.service", "ActiveState", "failed")
+	agent := newTestAgent("test-node", k8sClient, mcoClient)
+	agent.applier = NewApplierWithOptions(t.TempDir(), conn, true)
+
+	ctx := context.Background()
+	if err := agent.checkUnitDrift(ctx, node); err != nil {
+		t.Fatalf("checkUnitDrift() error = %v", err)
+	}
+	updated, _ := k8sClient.CoreV1().Nodes().Get(ctx, "test-node", metav1.GetOptions{})
+	if got, want := updated.Annotations[annotations.UnitStateDrift], "app.service active=failed (want active)"; got != want {
+		t.Errorf("UnitStateDrift = %q, want %q", got, want)
+	}
+
+	conn.SetProperty("app.service", "ActiveState", "active")
+	if err := agent.checkUnitDrift(ctx, updated); err != nil {
+		t.Fatalf("checkUnitDrift() error = %v", err)
+	}
+	updated, _ = k8sClient.CoreV1().Nodes().Get(ctx, "test-node", metav1.GetOptions{})
+	if _, ok := updated.Annotations[annotations.UnitStateDrift]; ok {
+		t.Error("UnitStateDrift should be removed once the unit runs again")
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/filecontent"
+)
+
+// ManagedFilesDigest returns a digest of the managed file set as
+// described by files: each path with its state and, for present files,
+// the sha256 of its content.
+func ManagedFilesDigest(files []mcov1alpha1.FileSpec) string {
+	entries := make(map[string]string, len(files))
+	for _, f := range files {
+		entries[f.Path] = expectedEntry(f)
+	}
+	return digestEntries(entries)
+}
+
+// expectedEntry is the digest entry a file should have on disk. For
+// line-present files only the presence of the line is checked.
+func expectedEntry(f mcov1alpha1.FileSpec) string {
+	switch f.State {
+	case FileStateAbsent:
+		return FileStateAbsent
+	case FileStateLinePresent:
+		return FileStateLinePresent
+	default:
+		content, err := filecontent.Decode(f)
+		if err != nil {
+			content = []byte(f.Content)
+		}
+		return contentDigest(content)
+	}
+}
+
+// VerifyFiles checks that the on-disk state of files matches the spec.
+// It is run after apply to catch partial or overwritten writes before
+// the revision is reported as current.
+func VerifyFiles(hostRoot string, files []mcov1alpha1.FileSpec) error {
+	expected := ManagedFilesDigest(files)
+	entries, err := hostEntries(hostRoot, files)
+	if err != nil {
+		return err
+	}
+	if digestEntries(entries) == expected {
+		return nil
+	}
+
+	var mismatched []string
+	for _, f := range files {
+		if entries[f.Path] != expectedEntry(f) {
+			mismatched = append(mismatched, f.Path)
+		}
+	}
+	sort.Strings(mismatched)
+	return fmt.Errorf("on-disk files do not match expected content: %v", mismatched)
+}
+
+func hostEntries(hostRoot string, files []mcov1alpha1.FileSpec) (map[string]string, error) {
+	entries := make(map[string]string, len(files))
+	for _, f := range files {
+		data, err := os.ReadFile(filepath.Join(hostRoot, f.Path))
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			entries[f.Path] = FileStateAbsent
+		case err != nil:
+			return nil, fmt.Errorf("read %s: %w", f.Path, err)
+		case f.State == FileStateLinePresent:
+			entries[f.Path] = "line-missing"
+			if hasLine(data, strings.TrimSuffix(f.Content, "\n")) {
+				entries[f.Path] = FileStateLinePresent
+			}
+		default:
+			entries[f.Path] = contentDigest(data)
+		}
+	}
+	return entries, nil
+}
+
+func contentDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+func digestEntries(entries map[string]string) string {
+	paths := make([]string, 0, len(entries))
+	for p := range entries {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	for _, p := range paths {
+		fmt.Fprintf(h, "%s\x00%s\n", p, entries[p])
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
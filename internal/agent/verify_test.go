@@ -0,0 +1,74 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+func TestVerifyFiles(t *testing.T) {
+	dir := t.TempDir()
+	a := NewFileApplierWithOptions(dir, true)
+	files := []mcov1alpha1.FileSpec{
+		{Path: "/etc/a.conf", Content: "a", Mode: 0644, State: "present"},
+		{Path: "/etc/b.conf", Content: "b", Mode: 0644, State: "present"},
+		{Path: "/etc/gone.conf", State: "absent"},
+	}
+	if _, err := a.ApplyAll(files); err != nil {
+		t.Fatalf("ApplyAll() error = %v", err)
+	}
+
+	if err := VerifyFiles(dir, files); err != nil {
+		t.Fatalf("VerifyFiles() after apply error = %v", err)
+	}
+
+	// Simulate a partial apply: one file was truncated, one leftover exists.
+	if err := os.WriteFile(filepath.Join(dir, "/etc/b.conf"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "/etc/gone.conf"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := VerifyFiles(dir, files)
+	if err == nil {
+		t.Fatal("VerifyFiles() should fail on mismatched content")
+	}
+	if !strings.Contains(err.Error(), "/etc/b.conf") || !strings.Contains(err.Error(), "/etc/gone.conf") {
+		t.Errorf("error should name mismatched paths, got %v", err)
+	}
+	if strings.Contains(err.Error(), "/etc/a.conf") {
+		t.Errorf("error should not name matching path, got %v", err)
+	}
+}
+
+func TestManagedFilesDigest_OrderIndependent(t *testing.T) {
+	a := mcov1alpha1.FileSpec{Path: "/etc/a", Content: "1"}
+	b := mcov1alpha1.FileSpec{Path: "/etc/b", Content: "2"}
+	if ManagedFilesDigest([]mcov1alpha1.FileSpec{a, b}) != ManagedFilesDigest([]mcov1alpha1.FileSpec{b, a}) {
+		t.Error("digest should not depend on file order")
+	}
+	b.Content = "3"
+	if ManagedFilesDigest([]mcov1alpha1.FileSpec{a}) == ManagedFilesDigest([]mcov1alpha1.FileSpec{a, b}) {
+		t.Error("digest should change with the file set")
+	}
+}
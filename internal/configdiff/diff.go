@@ -14,12 +14,19 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package agent
+// Package configdiff compares rendered configurations: which files and
+// units change between two revisions, and whether moving a node from one
+// to the other requires a reboot. Both the agent and the controller use
+// it, so it must not depend on host tooling.
+package configdiff
 
 import (
+	"bytes"
 	"sort"
+	"unicode/utf8"
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/filecontent"
 )
 
 // ChangeType describes the type of change between two configs.
@@ -40,6 +47,22 @@ type FileChange struct {
 	Path string
 	// ChangeType is the type of change (added, modified, removed).
 	ChangeType ChangeType
+	// Binary is true when the file content involved in the change is binary,
+	// so a line-based content diff is not meaningful.
+	Binary bool
+	// MetadataOnly is true for a modification that changes only the mode
+	// or owner; the content is the same, so the agent fixes it with
+	// chmod/chown instead of rewriting the file.
+	MetadataOnly bool
+}
+
+// Summary describes the change for logs and reboot reasons,
+// e.g. "modified" or "modified, binary content".
+func (c FileChange) Summary() string {
+	if c.Binary {
+		return string(c.ChangeType) + ", binary content"
+	}
+	return string(c.ChangeType)
 }
 
 // UnitChange describes a change to a systemd unit.
@@ -78,11 +101,15 @@ func DiffFiles(current, new []mcov1alpha1.FileSpec) []FileChange {
 			changes = append(changes, FileChange{
 				Path:       f.Path,
 				ChangeType: ChangeTypeAdded,
+				Binary:     isBinaryFile(f),
 			})
 		} else if !filesEqual(curr, f) {
+			contentEqual := filecontent.Equal(curr, f)
 			changes = append(changes, FileChange{
-				Path:       f.Path,
-				ChangeType: ChangeTypeModified,
+				Path:         f.Path,
+				ChangeType:   ChangeTypeModified,
+				Binary:       !contentEqual && (isBinaryFile(curr) || isBinaryFile(f)),
+				MetadataOnly: contentEqual && curr.State == f.State,
 			})
 		}
 	}
@@ -92,6 +119,7 @@ func DiffFiles(current, new []mcov1alpha1.FileSpec) []FileChange {
 			changes = append(changes, FileChange{
 				Path:       f.Path,
 				ChangeType: ChangeTypeRemoved,
+				Binary:     isBinaryFile(f),
 			})
 		}
 	}
@@ -156,8 +184,27 @@ func DiffUnits(current, new []mcov1alpha1.UnitSpec) []UnitChange {
 	return changes
 }
 
+// IsBinaryContent reports whether file content looks binary: it contains a
+// NUL byte or is not valid UTF-8, the same heuristic diff tools use.
+func IsBinaryContent(content string) bool {
+	if content == "" {
+		return false
+	}
+	return bytes.IndexByte([]byte(content), 0) >= 0 || !utf8.ValidString(content)
+}
+
+// isBinaryFile reports whether the decoded content of f looks binary.
+// Content that does not decode is judged as written.
+func isBinaryFile(f mcov1alpha1.FileSpec) bool {
+	data, err := filecontent.Decode(f)
+	if err != nil {
+		return IsBinaryContent(f.Content)
+	}
+	return IsBinaryContent(string(data))
+}
+
 func filesEqual(a, b mcov1alpha1.FileSpec) bool {
-	return a.Content == b.Content &&
+	return filecontent.Equal(a, b) &&
 		a.Mode == b.Mode &&
 		a.Owner == b.Owner &&
 		a.State == b.State
@@ -14,9 +14,10 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package agent
+package configdiff
 
 import (
+	"encoding/base64"
 	"testing"
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
@@ -104,6 +105,80 @@ func TestDiffFiles_ModifyContent(t *testing.T) {
 	}
 }
 
+// TestDiffFiles_ReencodedContent verifies that re-encoding the same payload
+// is not reported as a modification.
+func TestDiffFiles_ReencodedContent(t *testing.T) {
+	payload := []byte("\x00\x01payload")
+	currentList := []mcov1alpha1.FileSpec{
+		{Path: "/etc/blob.bin", Content: base64.StdEncoding.EncodeToString(payload), Encoding: "base64"},
+		{Path: "/etc/text.conf", Content: "hello"},
+	}
+	newList := []mcov1alpha1.FileSpec{
+		{Path: "/etc/blob.bin", Content: base64.StdEncoding.EncodeToString(payload), Encoding: "base64"},
+		{Path: "/etc/text.conf", Content: base64.StdEncoding.EncodeToString([]byte("hello")), Encoding: "base64"},
+	}
+
+	if changes := DiffFiles(currentList, newList); len(changes) != 0 {
+		t.Fatalf("Expected no changes, got %+v", changes)
+	}
+
+	newList[0].Content = base64.StdEncoding.EncodeToString([]byte("\x00\x01other"))
+	changes := DiffFiles(currentList, newList)
+	if len(changes) != 1 || changes[0].Summary() != "modified, binary content" {
+		t.Fatalf("Expected /etc/blob.bin modified, binary content, got %+v", changes)
+	}
+}
+
+// TestDiffFiles_BinaryContent verifies binary content changes are labeled.
+func TestDiffFiles_BinaryContent(t *testing.T) {
+	currentList := []mcov1alpha1.FileSpec{
+		{Path: "/etc/blob.bin", Content: "\x00\x01v1"},
+		{Path: "/etc/text.conf", Content: "v1"},
+		{Path: "/etc/mode.bin", Content: "\x00same", Mode: 0644},
+	}
+	newList := []mcov1alpha1.FileSpec{
+		{Path: "/etc/blob.bin", Content: "\x00\x01v2"},
+		{Path: "/etc/text.conf", Content: "v2"},
+		{Path: "/etc/mode.bin", Content: "\x00same", Mode: 0600},
+		{Path: "/etc/new.bin", Content: "\xff\xfe"},
+	}
+
+	changes := DiffFiles(currentList, newList)
+
+	want := map[string]string{
+		"/etc/blob.bin":  "modified, binary content",
+		"/etc/mode.bin":  "modified",
+		"/etc/new.bin":   "added, binary content",
+		"/etc/text.conf": "modified",
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("Expected %d changes, got %d: %+v", len(want), len(changes), changes)
+	}
+	for _, c := range changes {
+		if got := c.Summary(); got != want[c.Path] {
+			t.Errorf("%s: Summary() = %q, want %q", c.Path, got, want[c.Path])
+		}
+	}
+}
+
+func TestIsBinaryContent(t *testing.T) {
+	tests := []struct {
+		content string
+		want    bool
+	}{
+		{"", false},
+		{"plain text\n", false},
+		{"unicode ✓", false},
+		{"nul\x00byte", true},
+		{"\xff\xfe", true},
+	}
+	for _, tt := range tests {
+		if got := IsBinaryContent(tt.content); got != tt.want {
+			t.Errorf("IsBinaryContent(%q) = %v, want %v", tt.content, got, tt.want)
+		}
+	}
+}
+
 // TestDiffFiles_ModifyMode verifies detection of mode changes.
 func TestDiffFiles_ModifyMode(t *testing.T) {
 	currentList := []mcov1alpha1.FileSpec{
@@ -121,6 +196,9 @@ func TestDiffFiles_ModifyMode(t *testing.T) {
 	if changes[0].ChangeType != ChangeTypeModified {
 		t.Errorf("Expected modified, got %s", changes[0].ChangeType)
 	}
+	if !changes[0].MetadataOnly {
+		t.Error("Expected a metadata-only change")
+	}
 }
 
 // TestDiffFiles_ModifyOwner verifies detection of owner changes.
@@ -140,6 +218,9 @@ func TestDiffFiles_ModifyOwner(t *testing.T) {
 	if changes[0].ChangeType != ChangeTypeModified {
 		t.Errorf("Expected modified, got %s", changes[0].ChangeType)
 	}
+	if !changes[0].MetadataOnly {
+		t.Error("Expected a metadata-only change")
+	}
 }
 
 // TestDiffFiles_ModifyState verifies detection of state changes.
@@ -159,6 +240,9 @@ func TestDiffFiles_ModifyState(t *testing.T) {
 	if changes[0].ChangeType != ChangeTypeModified {
 		t.Errorf("Expected modified, got %s", changes[0].ChangeType)
 	}
+	if changes[0].MetadataOnly {
+		t.Error("State change must not be metadata-only")
+	}
 }
 
 // TestDiffFiles_MultipleChanges verifies handling of multiple changes.
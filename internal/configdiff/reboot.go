@@ -0,0 +1,300 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package configdiff
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+var log = ctrl.Log.WithName("reboot-decision")
+
+// AppliedChanges is what an apply actually changed on the host.
+type AppliedChanges struct {
+	// ChangedFiles and ChangedUnits name the files and units written.
+	ChangedFiles []string
+	ChangedUnits []string
+	// KernelArgsChanged is true if the kernel arguments were changed.
+	KernelArgsChanged bool
+}
+
+// RebootDecision contains the result of reboot determination.
+type RebootDecision struct {
+	// Required is true if reboot is needed.
+	Required bool
+
+	// Reasons lists why reboot is required (empty if not required).
+	Reasons []string
+
+	// Method describes how the decision was made.
+	// Values: "diff-based", "applied-changes", "legacy-first-apply",
+	// "legacy-fallback", "same-revision"
+	Method string
+}
+
+// Summary describes the decision in one line: the first reason, with a
+// count of any others.
+func (d RebootDecision) Summary() string {
+	switch len(d.Reasons) {
+	case 0:
+		return d.Method
+	case 1:
+		return d.Reasons[0]
+	default:
+		return fmt.Sprintf("%s (and %d more)", d.Reasons[0], len(d.Reasons)-1)
+	}
+}
+
+// Reboot determination methods.
+const (
+	MethodDiffBased        = "diff-based"
+	MethodAppliedChanges   = "applied-changes"
+	MethodLegacyFirstApply = "legacy-first-apply"
+	MethodLegacyFallback   = "legacy-fallback"
+	MethodSameRevision     = "same-revision"
+)
+
+// RMCFetcher is an interface for fetching RenderedMachineConfigs.
+// This allows for easy testing without requiring a full client.
+type RMCFetcher interface {
+	FetchRMC(ctx context.Context, name string) (*mcov1alpha1.RenderedMachineConfig, error)
+}
+
+// RebootDeterminer determines if reboot is required when transitioning
+// between configurations. It uses diff-based logic when possible,
+// falling back to legacy OR-based logic when necessary.
+type RebootDeterminer struct {
+	fetcher RMCFetcher
+}
+
+// NewRebootDeterminer creates a new RebootDeterminer with the given fetcher.
+func NewRebootDeterminer(fetcher RMCFetcher) *RebootDeterminer {
+	return &RebootDeterminer{
+		fetcher: fetcher,
+	}
+}
+
+// DetermineReboot determines if reboot is needed when transitioning
+// from currentRevision to newRMC.
+//
+// Decision logic:
+//  1. First apply (currentRevision == ""): Use legacy (OR of all MCs)
+//  2. Same revision: No reboot needed
+//  3. Current RMC not available: Fallback to legacy
+//  4. RebootRequirements not populated: Fallback to legacy
+//  5. Normal transition: Use diff-based logic
+func (d *RebootDeterminer) DetermineReboot(ctx context.Context, currentRevision string, newRMC *mcov1alpha1.RenderedMachineConfig) RebootDecision {
+	return d.determine(ctx, currentRevision, newRMC, nil)
+}
+
+// DetermineRebootAfterApply is DetermineReboot narrowed to what the apply
+// actually changed on the host: an added or modified file or unit only
+// requires a reboot if the apply wrote it and newRMC marks it
+// reboot-required. Without per-file requirements in newRMC it is the same
+// as DetermineReboot.
+func (d *RebootDeterminer) DetermineRebootAfterApply(ctx context.Context, currentRevision string, newRMC *mcov1alpha1.RenderedMachineConfig, applied *AppliedChanges) RebootDecision {
+	return d.determine(ctx, currentRevision, newRMC, applied)
+}
+
+func (d *RebootDeterminer) determine(ctx context.Context, currentRevision string, newRMC *mcov1alpha1.RenderedMachineConfig, applied *AppliedChanges) RebootDecision {
+	// The host-side changes are known and each path carries its own
+	// requirement, so the OR across MachineConfigs is not needed.
+	useApplied := applied != nil && hasRebootRequirements(newRMC)
+
+	if currentRevision == "" {
+		if useApplied {
+			return appliedChangesReboot(newRMC, applied)
+		}
+		return RebootDecision{
+			Required: newRMC.Spec.Reboot.Required,
+			Reasons:  []string{"first apply"},
+			Method:   MethodLegacyFirstApply,
+		}
+	}
+
+	if currentRevision == newRMC.Name {
+		return RebootDecision{
+			Required: false,
+			Method:   MethodSameRevision,
+		}
+	}
+
+	currentRMC, err := d.fetcher.FetchRMC(ctx, currentRevision)
+	if err != nil && useApplied {
+		return appliedChangesReboot(newRMC, applied)
+	}
+	if err != nil {
+		log.Info("cannot fetch current RMC, using legacy reboot check",
+			"currentRevision", currentRevision,
+			"error", err)
+		return RebootDecision{
+			Required: newRMC.Spec.Reboot.Required,
+			Reasons:  []string{"fallback: current RMC not available"},
+			Method:   MethodLegacyFallback,
+		}
+	}
+
+	if !hasRebootRequirements(newRMC) && !hasRebootRequirements(currentRMC) {
+		log.Info("neither RMC has RebootRequirements populated, using legacy check")
+		return RebootDecision{
+			Required: newRMC.Spec.Reboot.Required,
+			Reasons:  []string{"fallback: RebootRequirements not populated"},
+			Method:   MethodLegacyFallback,
+		}
+	}
+
+	return diffBasedReboot(currentRMC, newRMC, applied)
+}
+
+// appliedChangesReboot requires a reboot when a file or unit the apply
+// changed is reboot-required in rmc.
+func appliedChangesReboot(rmc *mcov1alpha1.RenderedMachineConfig, applied *AppliedChanges) RebootDecision {
+	var reasons []string
+	if applied.KernelArgsChanged {
+		reasons = append(reasons, kernelArgsRebootReason)
+	}
+	for _, path := range applied.ChangedFiles {
+		if rmc.Spec.RebootRequirements.Files[path] {
+			reasons = append(reasons, fmt.Sprintf("file %s (changed) requires reboot%s",
+				path, fromSource(rmc.Spec.RebootRequirements.FileSources[path])))
+		}
+	}
+	for _, name := range applied.ChangedUnits {
+		if rmc.Spec.RebootRequirements.Units[name] {
+			reasons = append(reasons, fmt.Sprintf("unit %s (changed) requires reboot%s",
+				name, fromSource(rmc.Spec.RebootRequirements.UnitSources[name])))
+		}
+	}
+	return RebootDecision{
+		Required: len(reasons) > 0,
+		Reasons:  reasons,
+		Method:   MethodAppliedChanges,
+	}
+}
+
+func hasRebootRequirements(rmc *mcov1alpha1.RenderedMachineConfig) bool {
+	return len(rmc.Spec.RebootRequirements.Files) > 0 ||
+		len(rmc.Spec.RebootRequirements.Units) > 0
+}
+
+// diffBasedReboot requires a reboot for reboot-required changes between
+// current and new. When applied is set, added and modified entries only
+// count if the apply changed them on the host.
+func diffBasedReboot(current, new *mcov1alpha1.RenderedMachineConfig, applied *AppliedChanges) RebootDecision {
+	var reasons []string
+
+	changedFiles, changedUnits := appliedSets(applied)
+
+	if !slices.Equal(current.Spec.Config.KernelArgs, new.Spec.Config.KernelArgs) &&
+		(applied == nil || applied.KernelArgsChanged) {
+		reasons = append(reasons, kernelArgsRebootReason)
+	}
+
+	fileChanges := DiffFiles(current.Spec.Config.Files, new.Spec.Config.Files)
+	for _, change := range fileChanges {
+		var requiresReboot bool
+		var source string
+
+		switch change.ChangeType {
+		case ChangeTypeAdded, ChangeTypeModified:
+			// For added/modified: check new RMC's requirements
+			// A mode or owner change is fixed in place and takes
+			// effect without a reboot.
+			requiresReboot = new.Spec.RebootRequirements.Files[change.Path] &&
+				!change.MetadataOnly &&
+				(changedFiles == nil || changedFiles[change.Path])
+			source = new.Spec.RebootRequirements.FileSources[change.Path]
+		case ChangeTypeRemoved:
+			// For removed: check current RMC's requirements
+			// (removing a reboot-requiring file also requires reboot)
+			requiresReboot = current.Spec.RebootRequirements.Files[change.Path]
+			source = current.Spec.RebootRequirements.FileSources[change.Path]
+		}
+
+		if requiresReboot {
+			reasons = append(reasons, fmt.Sprintf(
+				"file %s (%s) requires reboot%s", change.Path, change.Summary(), fromSource(source)))
+		}
+	}
+
+	unitChanges := DiffUnits(
+		current.Spec.Config.Systemd.Units,
+		new.Spec.Config.Systemd.Units,
+	)
+	for _, change := range unitChanges {
+		var requiresReboot bool
+		var source string
+
+		switch change.ChangeType {
+		case ChangeTypeAdded, ChangeTypeModified:
+			// For added/modified: check new RMC's requirements
+			requiresReboot = new.Spec.RebootRequirements.Units[change.Name] &&
+				(changedUnits == nil || changedUnits[change.Name])
+			source = new.Spec.RebootRequirements.UnitSources[change.Name]
+		case ChangeTypeRemoved:
+			// For removed: check current RMC's requirements
+			requiresReboot = current.Spec.RebootRequirements.Units[change.Name]
+			source = current.Spec.RebootRequirements.UnitSources[change.Name]
+		}
+
+		if requiresReboot {
+			reasons = append(reasons, fmt.Sprintf(
+				"unit %s (%s) requires reboot%s", change.Name, change.ChangeType, fromSource(source)))
+		}
+	}
+
+	return RebootDecision{
+		Required: len(reasons) > 0,
+		Reasons:  reasons,
+		Method:   MethodDiffBased,
+	}
+}
+
+// kernelArgsRebootReason is the reboot reason for changed kernel
+// arguments, which only take effect on the next boot.
+const kernelArgsRebootReason = "kernel args changed"
+
+// fromSource names the MachineConfig a reboot reason comes from. RMCs
+// rendered before sources were recorded yield an empty string.
+func fromSource(source string) string {
+	if source == "" {
+		return ""
+	}
+	return " (from MachineConfig " + source + ")"
+}
+
+// appliedSets indexes the changed files and units of applied. Both are nil
+// when applied is nil, meaning every change counts.
+func appliedSets(applied *AppliedChanges) (files, units map[string]bool) {
+	if applied == nil {
+		return nil, nil
+	}
+	files = make(map[string]bool, len(applied.ChangedFiles))
+	for _, path := range applied.ChangedFiles {
+		files[path] = true
+	}
+	units = make(map[string]bool, len(applied.ChangedUnits))
+	for _, name := range applied.ChangedUnits {
+		units[name] = true
+	}
+	return files, units
+}
@@ -14,11 +14,12 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package agent
+package configdiff
 
 import (
 	"context"
 	"errors"
+	"reflect"
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -240,6 +241,39 @@ func TestDetermineReboot_ModifyRebootFile(t *testing.T) {
 	}
 }
 
+// TestDetermineReboot_ModeOnlyChange verifies a mode change to a
+// reboot-requiring file does not require a reboot.
+func TestDetermineReboot_ModeOnlyChange(t *testing.T) {
+	currentRMC := makeRMC("workers-old123",
+		[]mcov1alpha1.FileSpec{
+			{Path: "/etc/sysctl.conf", Content: "same", Mode: 0644, Owner: "root:root", State: "present"},
+		},
+		nil, true,
+		map[string]bool{"/etc/sysctl.conf": true},
+		nil)
+
+	newRMC := makeRMC("workers-new123",
+		[]mcov1alpha1.FileSpec{
+			{Path: "/etc/sysctl.conf", Content: "same", Mode: 0600, Owner: "root:root", State: "present"},
+		},
+		nil, true,
+		map[string]bool{"/etc/sysctl.conf": true},
+		nil)
+
+	fetcher := &mockRMCFetcher{
+		rmcs: map[string]*mcov1alpha1.RenderedMachineConfig{
+			"workers-old123": currentRMC,
+		},
+	}
+	determiner := NewRebootDeterminer(fetcher)
+
+	decision := determiner.DetermineReboot(context.Background(), "workers-old123", newRMC)
+
+	if decision.Required {
+		t.Errorf("Expected Required=false, got reasons %v", decision.Reasons)
+	}
+}
+
 // TestDetermineReboot_RemoveRebootFile verifies reboot when removing reboot-requiring file.
 func TestDetermineReboot_RemoveRebootFile(t *testing.T) {
 	currentRMC := makeRMC("workers-old123",
@@ -319,6 +353,32 @@ func TestDetermineReboot_ModifyNonRebootFile(t *testing.T) {
 	}
 }
 
+// TestDetermineReboot_KernelArgsChanged verifies a kernel argument change
+// requires a reboot even when no file or unit does.
+func TestDetermineReboot_KernelArgsChanged(t *testing.T) {
+	files := []mcov1alpha1.FileSpec{
+		{Path: "/etc/app.conf", Content: "v1", Mode: 0644, Owner: "root:root", State: "present"},
+	}
+	reqs := map[string]bool{"/etc/app.conf": false}
+	currentRMC := makeRMC("workers-old123", files, nil, true, reqs, nil)
+	newRMC := makeRMC("workers-new123", files, nil, true, reqs, nil)
+	newRMC.Spec.Config.KernelArgs = []string{"console=ttyS0"}
+
+	determiner := NewRebootDeterminer(&mockRMCFetcher{
+		rmcs: map[string]*mcov1alpha1.RenderedMachineConfig{"workers-old123": currentRMC},
+	})
+
+	decision := determiner.DetermineReboot(context.Background(), "workers-old123", newRMC)
+	if !decision.Required || !reflect.DeepEqual(decision.Reasons, []string{kernelArgsRebootReason}) {
+		t.Errorf("decision = %+v, want reboot for kernel args", decision)
+	}
+
+	decision = determiner.DetermineRebootAfterApply(context.Background(), "workers-old123", newRMC, &AppliedChanges{})
+	if decision.Required {
+		t.Errorf("decision = %+v, want no reboot when the apply left kernel args unchanged", decision)
+	}
+}
+
 // TestDetermineReboot_AddRebootUnit verifies reboot when adding reboot-requiring unit.
 func TestDetermineReboot_AddRebootUnit(t *testing.T) {
 	currentRMC := makeRMC("workers-old123",
@@ -545,7 +605,7 @@ func TestDiffBasedReboot_EmptyConfigs(t *testing.T) {
 	currentRMC := makeRMC("workers-old123", nil, nil, false, map[string]bool{}, map[string]bool{})
 	newRMC := makeRMC("workers-new123", nil, nil, false, map[string]bool{}, map[string]bool{})
 
-	decision := diffBasedReboot(currentRMC, newRMC)
+	decision := diffBasedReboot(currentRMC, newRMC, nil)
 
 	if decision.Required {
 		t.Error("Expected Required=false for empty configs")
@@ -576,3 +636,141 @@ func TestDetermineReboot_LegacyFallbackWithNoReboot(t *testing.T) {
 		t.Errorf("Expected Method=%s, got %s", MethodLegacyFallback, decision.Method)
 	}
 }
+
+// TestDetermineRebootAfterApply verifies that only reboot-required entries
+// the apply actually changed trigger a reboot.
+func TestDetermineRebootAfterApply(t *testing.T) {
+	fileReqs := map[string]bool{"/etc/kernel.conf": true, "/etc/app.conf": false}
+	files := []mcov1alpha1.FileSpec{
+		{Path: "/etc/kernel.conf", Content: "same"},
+		{Path: "/etc/app.conf", Content: "v2"},
+	}
+	currentRMC := makeRMC("workers-old", []mcov1alpha1.FileSpec{
+		{Path: "/etc/kernel.conf", Content: "same"},
+		{Path: "/etc/app.conf", Content: "v1"},
+	}, nil, true, fileReqs, nil)
+	newRMC := makeRMC("workers-new", files, nil, true, fileReqs, nil)
+
+	tests := []struct {
+		name           string
+		current        string
+		newRMC         *mcov1alpha1.RenderedMachineConfig
+		applied        *AppliedChanges
+		expectRequired bool
+		expectMethod   string
+	}{
+		{
+			name:           "only non-reboot file changed",
+			current:        "workers-old",
+			newRMC:         newRMC,
+			applied:        &AppliedChanges{ChangedFiles: []string{"/etc/app.conf"}},
+			expectRequired: false,
+			expectMethod:   MethodDiffBased,
+		},
+		{
+			name:    "reboot file modified in spec but already on disk",
+			current: "workers-old",
+			newRMC: makeRMC("workers-new", []mcov1alpha1.FileSpec{
+				{Path: "/etc/kernel.conf", Content: "tuned"},
+			}, nil, true, fileReqs, nil),
+			applied:        &AppliedChanges{},
+			expectRequired: false,
+			expectMethod:   MethodDiffBased,
+		},
+		{
+			name:           "first apply writes only non-reboot file",
+			current:        "",
+			newRMC:         newRMC,
+			applied:        &AppliedChanges{ChangedFiles: []string{"/etc/app.conf"}},
+			expectRequired: false,
+			expectMethod:   MethodAppliedChanges,
+		},
+		{
+			name:           "first apply writes reboot file",
+			current:        "",
+			newRMC:         newRMC,
+			applied:        &AppliedChanges{ChangedFiles: []string{"/etc/kernel.conf"}},
+			expectRequired: true,
+			expectMethod:   MethodAppliedChanges,
+		},
+		{
+			name:           "current RMC gone",
+			current:        "workers-gc",
+			newRMC:         newRMC,
+			applied:        &AppliedChanges{ChangedFiles: []string{"/etc/app.conf"}},
+			expectRequired: false,
+			expectMethod:   MethodAppliedChanges,
+		},
+		{
+			name:           "no per-file requirements keeps legacy",
+			current:        "",
+			newRMC:         makeRMC("workers-legacy", files, nil, true, nil, nil),
+			applied:        &AppliedChanges{ChangedFiles: []string{"/etc/app.conf"}},
+			expectRequired: true,
+			expectMethod:   MethodLegacyFirstApply,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher := &mockRMCFetcher{rmcs: map[string]*mcov1alpha1.RenderedMachineConfig{
+				"workers-old": currentRMC,
+			}}
+			determiner := NewRebootDeterminer(fetcher)
+
+			decision := determiner.DetermineRebootAfterApply(context.Background(), tt.current, tt.newRMC, tt.applied)
+
+			if decision.Required != tt.expectRequired {
+				t.Errorf("Required = %v, want %v (reasons %v)", decision.Required, tt.expectRequired, decision.Reasons)
+			}
+			if decision.Method != tt.expectMethod {
+				t.Errorf("Method = %s, want %s", decision.Method, tt.expectMethod)
+			}
+		})
+	}
+}
+
+func TestDetermineReboot_ReasonNamesSource(t *testing.T) {
+	currentRMC := makeRMC("workers-old123",
+		[]mcov1alpha1.FileSpec{
+			{Path: "/etc/old.conf", Content: "old", Mode: 0644, Owner: "root:root", State: "present"},
+		},
+		nil, true,
+		map[string]bool{"/etc/old.conf": true},
+		nil)
+	currentRMC.Spec.RebootRequirements.FileSources = map[string]string{"/etc/old.conf": "10-old"}
+
+	newRMC := makeRMC("workers-new123",
+		[]mcov1alpha1.FileSpec{
+			{Path: "/etc/new.conf", Content: "new", Mode: 0644, Owner: "root:root", State: "present"},
+		},
+		nil, true,
+		map[string]bool{"/etc/new.conf": true},
+		nil)
+	newRMC.Spec.RebootRequirements.FileSources = map[string]string{"/etc/new.conf": "50-kernel"}
+
+	fetcher := &mockRMCFetcher{
+		rmcs: map[string]*mcov1alpha1.RenderedMachineConfig{"workers-old123": currentRMC},
+	}
+	decision := NewRebootDeterminer(fetcher).DetermineReboot(context.Background(), "workers-old123", newRMC)
+
+	want := []string{
+		"file /etc/new.conf (added) requires reboot (from MachineConfig 50-kernel)",
+		"file /etc/old.conf (removed) requires reboot (from MachineConfig 10-old)",
+	}
+	if !reflect.DeepEqual(decision.Reasons, want) {
+		t.Errorf("Reasons = %v, want %v", decision.Reasons, want)
+	}
+	if got := decision.Summary(); got != want[0]+" (and 1 more)" {
+		t.Errorf("Summary() = %q", got)
+	}
+}
+
+func TestRebootDecisionSummary(t *testing.T) {
+	if got := (RebootDecision{Method: MethodLegacyFallback}).Summary(); got != MethodLegacyFallback {
+		t.Errorf("Summary() without reasons = %q, want %q", got, MethodLegacyFallback)
+	}
+	if got := (RebootDecision{Reasons: []string{"first apply"}}).Summary(); got != "first apply" {
+		t.Errorf("Summary() with one reason = %q, want %q", got, "first apply")
+	}
+}
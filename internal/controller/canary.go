@@ -0,0 +1,146 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+// CalculateCanaryCount returns how many nodes form the canary phase of a
+// rollout. Percentages round up like maxUnavailable; unset, invalid or
+// non-positive values disable the phase. The count never exceeds nodeCount.
+func CalculateCanaryCount(canary *intstr.IntOrString, nodeCount int) int {
+	if canary == nil {
+		return 0
+	}
+	count, ok := scaledValue(canary, nodeCount)
+	if !ok || count < 0 {
+		return 0
+	}
+	return min(count, nodeCount)
+}
+
+// CanaryProgress describes how far the canary phase of a rollout got.
+type CanaryProgress struct {
+	// Count is the configured number of canary nodes; 0 when disabled.
+	Count int
+	// Started is the number of nodes given their target revision.
+	Started int
+	// Done is the number of nodes running their target with state done.
+	Done int
+	// Failed lists started nodes in error or past the apply timeout.
+	Failed []string
+}
+
+// Complete reports whether the rest of the pool may update.
+func (p CanaryProgress) Complete() bool {
+	return p.Done >= p.Count
+}
+
+// canaryProgress counts the nodes working towards their target revision.
+// Paused and maintenance nodes are never picked, so they do not count
+// towards the canary size.
+func canaryProgress(pool *mcov1alpha1.MachineConfigPool, nodes []corev1.Node, targetFor targetFunc) CanaryProgress {
+	timeout := pool.Spec.Rollout.ApplyTimeoutSeconds
+	if timeout <= 0 {
+		timeout = DefaultApplyTimeoutSeconds
+	}
+	timeoutDuration := time.Duration(timeout) * time.Second
+
+	var progress CanaryProgress
+	eligible := 0
+	for i := range nodes {
+		node := &nodes[i]
+		ann := node.Annotations
+		if annotations.IsNodePaused(ann) || annotations.IsNodeInMaintenance(ann) {
+			continue
+		}
+		eligible++
+
+		target := targetFor(node)
+		current := annotations.GetAnnotation(ann, annotations.CurrentRevision)
+		desired := annotations.GetAnnotation(ann, annotations.DesiredRevision)
+		if current != target && desired != target {
+			continue
+		}
+		progress.Started++
+
+		switch state := annotations.GetAnnotation(ann, annotations.AgentState); {
+		case current == target && (state == annotations.StateDone || state == annotations.StateIdle):
+			progress.Done++
+		case state == annotations.StateError:
+			progress.Failed = append(progress.Failed, node.Name)
+		case state == annotations.StateApplying && isApplyTimedOut(ann, timeoutDuration):
+			progress.Failed = append(progress.Failed, node.Name)
+		}
+	}
+	progress.Count = CalculateCanaryCount(pool.Spec.Rollout.Canary, eligible)
+	return progress
+}
+
+// canaryLimit returns how many more nodes may start while the canary phase
+// is running, and false once the phase is complete or disabled. A failed
+// canary holds the rollout rather than being replaced by another node.
+func canaryLimit(pool *mcov1alpha1.MachineConfigPool, nodes []corev1.Node, targetFor targetFunc) (int, bool) {
+	progress := canaryProgress(pool, nodes, targetFor)
+	if progress.Complete() {
+		return 0, false
+	}
+	if len(progress.Failed) > 0 {
+		return 0, true
+	}
+	return max(progress.Count-progress.Started, 0), true
+}
+
+// SetCanaryCondition reports the canary phase in the CanaryComplete
+// condition, or removes it when the pool has no canary configured.
+func SetCanaryCondition(pool *mcov1alpha1.MachineConfigPool, nodes []corev1.Node, targetFor targetFunc) {
+	if pool.Spec.Rollout.Canary == nil {
+		meta.RemoveStatusCondition(&pool.Status.Conditions, mcov1alpha1.ConditionCanaryComplete)
+		return
+	}
+
+	progress := canaryProgress(pool, nodes, targetFor)
+	condition := metav1.Condition{
+		Type:               mcov1alpha1.ConditionCanaryComplete,
+		LastTransitionTime: metav1.Now(),
+	}
+	switch {
+	case progress.Complete():
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = "CanaryNodesUpdated"
+		condition.Message = fmt.Sprintf("%d canary node(s) run %s", progress.Count, pool.Status.TargetRevision)
+	case len(progress.Failed) > 0:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "CanaryFailed"
+		condition.Message = fmt.Sprintf("Rollout held, canary nodes failed: %s", joinNodeNames(progress.Failed))
+	default:
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "CanaryInProgress"
+		condition.Message = fmt.Sprintf("%d/%d canary node(s) updated", progress.Done, progress.Count)
+	}
+	setCondition(pool, condition)
+}
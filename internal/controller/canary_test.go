@@ -0,0 +1,145 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+func TestCalculateCanaryCount(t *testing.T) {
+	one := intstr.FromInt(1)
+	ten := intstr.FromInt(10)
+	pct := intstr.FromString("10%")
+	bad := intstr.FromString("abc")
+	tests := []struct {
+		name   string
+		canary *intstr.IntOrString
+		nodes  int
+		want   int
+	}{
+		{"nil disables", nil, 10, 0},
+		{"absolute", &one, 10, 1},
+		{"capped at node count", &ten, 3, 3},
+		{"percentage rounds up", &pct, 15, 2},
+		{"invalid disables", &bad, 10, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculateCanaryCount(tt.canary, tt.nodes); got != tt.want {
+				t.Errorf("CalculateCanaryCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectNodesForUpdate_Canary(t *testing.T) {
+	canary := intstr.FromInt(1)
+	maxUnavailable := intstr.FromInt(3)
+	pool := &mcov1alpha1.MachineConfigPool{
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			Rollout: mcov1alpha1.RolloutConfig{
+				MaxUnavailable: &maxUnavailable,
+				Canary:         &canary,
+			},
+		},
+	}
+	now := time.Now()
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-1", CreationTimestamp: metav1.Time{Time: now}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-2", CreationTimestamp: metav1.Time{Time: now}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-3", CreationTimestamp: metav1.Time{Time: now}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-4", CreationTimestamp: metav1.Time{Time: now}}},
+	}
+
+	if result := SelectNodesForUpdate(pool, nodes, "rev-1"); len(result) != 1 {
+		t.Fatalf("expected 1 canary node, got %d", len(result))
+	}
+
+	// The canary is applying: the rest of the pool waits.
+	nodes[0].Annotations = map[string]string{
+		annotations.DesiredRevision:      "rev-1",
+		annotations.DesiredRevisionSetAt: now.UTC().Format(time.RFC3339),
+		annotations.AgentState:           annotations.StateApplying,
+	}
+	if result := SelectNodesForUpdate(pool, nodes, "rev-1"); len(result) != 0 {
+		t.Errorf("expected no nodes while the canary applies, got %d", len(result))
+	}
+
+	// A failed canary holds the rollout.
+	nodes[0].Annotations[annotations.AgentState] = annotations.StateError
+	if result := SelectNodesForUpdate(pool, nodes, "rev-1"); len(result) != 0 {
+		t.Errorf("expected no nodes after the canary failed, got %d", len(result))
+	}
+
+	// A timed-out canary holds the rollout too.
+	nodes[0].Annotations[annotations.AgentState] = annotations.StateApplying
+	nodes[0].Annotations[annotations.DesiredRevisionSetAt] = now.Add(-time.Hour).UTC().Format(time.RFC3339)
+	if limit, ok := canaryLimit(pool, nodes, singleTarget("rev-1")); !ok || limit != 0 {
+		t.Errorf("canaryLimit() = %d, %v, want 0, true", limit, ok)
+	}
+
+	// Once the canary is done the full budget applies.
+	nodes[0].Annotations[annotations.CurrentRevision] = "rev-1"
+	nodes[0].Annotations[annotations.AgentState] = annotations.StateDone
+	if result := SelectNodesForUpdate(pool, nodes, "rev-1"); len(result) != 3 {
+		t.Errorf("expected 3 nodes after the canary phase, got %d", len(result))
+	}
+}
+
+func TestSetCanaryCondition(t *testing.T) {
+	canary := intstr.FromInt(1)
+	pool := &mcov1alpha1.MachineConfigPool{
+		Spec:   mcov1alpha1.MachineConfigPoolSpec{Rollout: mcov1alpha1.RolloutConfig{Canary: &canary}},
+		Status: mcov1alpha1.MachineConfigPoolStatus{TargetRevision: "rev-1"},
+	}
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Annotations: map[string]string{
+			annotations.DesiredRevision: "rev-1",
+			annotations.AgentState:      annotations.StateError,
+		}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}},
+	}
+
+	SetCanaryCondition(pool, nodes, singleTarget("rev-1"))
+	cond := meta.FindStatusCondition(pool.Status.Conditions, mcov1alpha1.ConditionCanaryComplete)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "CanaryFailed" {
+		t.Fatalf("CanaryComplete = %+v, want False/CanaryFailed", cond)
+	}
+
+	nodes[0].Annotations[annotations.CurrentRevision] = "rev-1"
+	nodes[0].Annotations[annotations.AgentState] = annotations.StateDone
+	SetCanaryCondition(pool, nodes, singleTarget("rev-1"))
+	cond = meta.FindStatusCondition(pool.Status.Conditions, mcov1alpha1.ConditionCanaryComplete)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Fatalf("CanaryComplete = %+v, want True", cond)
+	}
+
+	pool.Spec.Rollout.Canary = nil
+	SetCanaryCondition(pool, nodes, singleTarget("rev-1"))
+	if meta.FindStatusCondition(pool.Status.Conditions, mcov1alpha1.ConditionCanaryComplete) != nil {
+		t.Error("CanaryComplete kept after canary was disabled")
+	}
+}
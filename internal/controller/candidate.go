@@ -0,0 +1,157 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+// targetFunc returns the revision a node should converge to.
+type targetFunc func(node *corev1.Node) string
+
+// singleTarget routes every node to target.
+func singleTarget(target string) targetFunc {
+	return func(*corev1.Node) string { return target }
+}
+
+// candidateTargets routes pool nodes matched by the candidate node selector
+// to candidate and all others to target. Without a candidate revision every
+// node is routed to target.
+func candidateTargets(pool *mcov1alpha1.MachineConfigPool, target, candidate string) (targetFunc, error) {
+	if candidate == "" || pool.Spec.Candidate == nil || pool.Spec.Candidate.NodeSelector == nil {
+		return singleTarget(target), nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(pool.Spec.Candidate.NodeSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid candidate nodeSelector: %w", err)
+	}
+	if selector.Empty() {
+		return singleTarget(target), nil
+	}
+	return func(node *corev1.Node) string {
+		if selector.Matches(labels.Set(node.Labels)) {
+			return candidate
+		}
+		return target
+	}, nil
+}
+
+// SelectCandidateMachineConfigs returns base plus the MachineConfigs selected
+// by the pool's candidate machineConfigSelector. Configs already in base are
+// not added twice.
+func SelectCandidateMachineConfigs(
+	ctx context.Context,
+	c client.Client,
+	pool *mcov1alpha1.MachineConfigPool,
+	base []mcov1alpha1.MachineConfig,
+) ([]mcov1alpha1.MachineConfig, error) {
+	if pool.Spec.Candidate == nil || pool.Spec.Candidate.MachineConfigSelector == nil {
+		return nil, fmt.Errorf("pool %s has no candidate machineConfigSelector", pool.Name)
+	}
+	selector, err := metav1.LabelSelectorAsSelector(pool.Spec.Candidate.MachineConfigSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid candidate machineConfigSelector: %w", err)
+	}
+	if selector.Empty() {
+		return nil, fmt.Errorf("candidate machineConfigSelector must not be empty")
+	}
+
+	mcList := &mcov1alpha1.MachineConfigList{}
+	if err := c.List(ctx, mcList, &client.ListOptions{LabelSelector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list candidate MachineConfigs: %w", err)
+	}
+
+	seen := make(map[string]bool, len(base))
+	configs := make([]mcov1alpha1.MachineConfig, 0, len(base)+len(mcList.Items))
+	for i := range base {
+		seen[base[i].Name] = true
+		configs = append(configs, base[i])
+	}
+	extra := make([]mcov1alpha1.MachineConfig, 0, len(mcList.Items))
+	for i := range mcList.Items {
+		if !seen[mcList.Items[i].Name] {
+			extra = append(extra, mcList.Items[i])
+		}
+	}
+	ApplyDefaultPriority(pool, extra)
+	return append(configs, extra...), nil
+}
+
+// machineConfigMatchesCandidate reports whether mc is selected by the
+// pool's candidate machineConfigSelector.
+func machineConfigMatchesCandidate(mc *mcov1alpha1.MachineConfig, pool *mcov1alpha1.MachineConfigPool) bool {
+	if pool.Spec.Candidate == nil || pool.Spec.Candidate.MachineConfigSelector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(pool.Spec.Candidate.MachineConfigSelector)
+	if err != nil || selector.Empty() {
+		return false
+	}
+	return selector.Matches(labels.Set(mc.Labels))
+}
+
+// existingRMC returns the named RMC, or nil if name is empty or the RMC
+// no longer exists.
+func existingRMC(ctx context.Context, c client.Client, name string) (*mcov1alpha1.RenderedMachineConfig, error) {
+	if name == "" {
+		return nil, nil
+	}
+	rmc := &mcov1alpha1.RenderedMachineConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, rmc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rmc, nil
+}
+
+// SetCandidateStatus records the candidate revision and how many routed
+// nodes run it. It clears the candidate fields when candidate is empty.
+func SetCandidateStatus(
+	pool *mcov1alpha1.MachineConfigPool,
+	nodes []corev1.Node,
+	targetFor targetFunc,
+	candidate string,
+) {
+	pool.Status.CandidateRevision = candidate
+	pool.Status.CandidateMachineCount = 0
+	pool.Status.CandidateUpdatedMachineCount = 0
+	if candidate == "" {
+		return
+	}
+	for i := range nodes {
+		if targetFor(&nodes[i]) != candidate {
+			continue
+		}
+		pool.Status.CandidateMachineCount++
+		if annotations.GetAnnotation(nodes[i].Annotations, annotations.CurrentRevision) == candidate {
+			pool.Status.CandidateUpdatedMachineCount++
+		}
+	}
+}
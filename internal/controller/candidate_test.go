@@ -0,0 +1,148 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+func candidatePool() *mcov1alpha1.MachineConfigPool {
+	maxUnavailable := intstr.FromInt(2)
+	return &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			NodeSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"role": "worker"},
+			},
+			MachineConfigSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"pool": "worker"},
+			},
+			Rollout: mcov1alpha1.RolloutConfig{MaxUnavailable: &maxUnavailable},
+			Candidate: &mcov1alpha1.CandidateConfig{
+				NodeSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"canary": "true"},
+				},
+				MachineConfigSelector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"candidate": "worker"},
+				},
+			},
+		},
+	}
+}
+
+func TestCandidateTargets(t *testing.T) {
+	pool := candidatePool()
+	canary := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "a", Labels: map[string]string{"canary": "true"}}}
+	regular := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "b"}}
+
+	targetFor, err := candidateTargets(pool, "worker-main", "worker-cand")
+	if err != nil {
+		t.Fatalf("candidateTargets() error = %v", err)
+	}
+	if got := targetFor(canary); got != "worker-cand" {
+		t.Errorf("canary target = %q, want worker-cand", got)
+	}
+	if got := targetFor(regular); got != "worker-main" {
+		t.Errorf("regular target = %q, want worker-main", got)
+	}
+
+	targetFor, err = candidateTargets(pool, "worker-main", "")
+	if err != nil {
+		t.Fatalf("candidateTargets() error = %v", err)
+	}
+	if got := targetFor(canary); got != "worker-main" {
+		t.Errorf("without candidate revision, canary target = %q, want worker-main", got)
+	}
+}
+
+func TestReconcile_CandidateRouting(t *testing.T) {
+	pool := candidatePool()
+	canary := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "worker-1",
+		Labels: map[string]string{"role": "worker", "canary": "true"},
+	}}
+	regular := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "worker-2",
+		Labels: map[string]string{"role": "worker"},
+	}}
+	base := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "base", Labels: map[string]string{"pool": "worker"}},
+		Spec: mcov1alpha1.MachineConfigSpec{
+			Priority: ptr.To(50),
+			Files:    []mcov1alpha1.FileSpec{{Path: "/etc/base", Content: "base"}},
+		},
+	}
+	extra := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "extra", Labels: map[string]string{"candidate": "worker"}},
+		Spec: mcov1alpha1.MachineConfigSpec{
+			Priority: ptr.To(60),
+			Files:    []mcov1alpha1.FileSpec{{Path: "/etc/extra", Content: "extra"}},
+		},
+	}
+
+	r := newReconciler(pool, canary, regular, base, extra)
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "worker"}}
+	for i := 0; i < 6; i++ {
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v at iteration %d", err, i)
+		}
+	}
+
+	updated := &mcov1alpha1.MachineConfigPool{}
+	if err := r.Get(context.Background(), req.NamespacedName, updated); err != nil {
+		t.Fatalf("Failed to get pool: %v", err)
+	}
+	if updated.Status.CandidateRevision == "" || updated.Status.CandidateRevision == updated.Status.TargetRevision {
+		t.Fatalf("CandidateRevision = %q, TargetRevision = %q", updated.Status.CandidateRevision, updated.Status.TargetRevision)
+	}
+	if updated.Status.CandidateMachineCount != 1 {
+		t.Errorf("CandidateMachineCount = %d, want 1", updated.Status.CandidateMachineCount)
+	}
+
+	candidateRMC := &mcov1alpha1.RenderedMachineConfig{}
+	if err := r.Get(context.Background(), client.ObjectKey{Name: updated.Status.CandidateRevision}, candidateRMC); err != nil {
+		t.Fatalf("Failed to get candidate RMC: %v", err)
+	}
+	if len(candidateRMC.Spec.Config.Files) != 2 {
+		t.Errorf("candidate RMC has %d files, want 2", len(candidateRMC.Spec.Config.Files))
+	}
+
+	want := map[string]string{
+		"worker-1": updated.Status.CandidateRevision,
+		"worker-2": updated.Status.TargetRevision,
+	}
+	for name, revision := range want {
+		n := &corev1.Node{}
+		if err := r.Get(context.Background(), client.ObjectKey{Name: name}, n); err != nil {
+			t.Fatalf("Failed to get node: %v", err)
+		}
+		if got := n.Annotations[annotations.DesiredRevision]; got != revision {
+			t.Errorf("%s desired-revision = %q, want %q", name, got, revision)
+		}
+	}
+}
@@ -61,6 +61,13 @@ func (c *RMCCleaner) CleanupOldRMCs(ctx context.Context, pool *mcov1alpha1.Machi
 	}
 
 	inUse := c.getRevisionsInUse(nodes, pool.Status.TargetRevision)
+	if pool.Status.CandidateRevision != "" {
+		inUse[pool.Status.CandidateRevision] = true
+	}
+	// The rolled back revision is still rendered on every reconcile.
+	if pool.Status.RolledBackRevision != "" {
+		inUse[pool.Status.RolledBackRevision] = true
+	}
 	sort.Slice(rmcs, func(i, j int) bool {
 		return rmcs[i].CreationTimestamp.Before(&rmcs[j].CreationTimestamp)
 	})
@@ -101,21 +108,32 @@ func (c *RMCCleaner) getRevisionsInUse(nodes []corev1.Node, target string) map[s
 		inUse[target] = true
 	}
 
-	for _, node := range nodes {
-		current := annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision)
-		if current != "" {
-			inUse[current] = true
-		}
-
-		desired := annotations.GetAnnotation(node.Annotations, annotations.DesiredRevision)
-		if desired != "" {
-			inUse[desired] = true
-		}
+	for _, name := range ReferencedRevisions(nodes) {
+		inUse[name] = true
 	}
 
 	return inUse
 }
 
+// ReferencedRevisions returns the sorted RMC names that at least one node
+// references through its current or desired revision annotation.
+func ReferencedRevisions(nodes []corev1.Node) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, node := range nodes {
+		for _, key := range []string{annotations.CurrentRevision, annotations.DesiredRevision} {
+			name := annotations.GetAnnotation(node.Annotations, key)
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 // CleanupOrphanedRMCs removes RMCs that belong to pools that no longer exist.
 func (c *RMCCleaner) CleanupOrphanedRMCs(ctx context.Context) (int, error) {
 	pools := &mcov1alpha1.MachineConfigPoolList{}
@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"reflect"
 	"testing"
 	"time"
 
@@ -366,6 +367,40 @@ func TestGetRevisionsInUse_EmptyTarget(t *testing.T) {
 	}
 }
 
+func TestReferencedRevisions(t *testing.T) {
+	nodes := []corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					annotations.CurrentRevision: "rev-b",
+					annotations.DesiredRevision: "rev-c",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					annotations.CurrentRevision: "rev-a",
+					annotations.DesiredRevision: "rev-c",
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{}, // no annotations
+		},
+	}
+
+	got := ReferencedRevisions(nodes)
+	want := []string{"rev-a", "rev-b", "rev-c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReferencedRevisions() = %v, want %v", got, want)
+	}
+
+	if got := ReferencedRevisions(nil); len(got) != 0 {
+		t.Errorf("ReferencedRevisions(nil) = %v, want empty", got)
+	}
+}
+
 func TestCleanupOrphanedRMCs(t *testing.T) {
 	scheme := newTestScheme()
 
@@ -3,12 +3,14 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
 	"in-cloud.io/machine-config/pkg/annotations"
 )
 
@@ -30,6 +32,7 @@ func CordonNode(ctx context.Context, c client.Client, node *corev1.Node) error {
 			current.Annotations = make(map[string]string)
 		}
 		current.Annotations[annotations.Cordoned] = annotations.ValueTrue
+		current.Annotations[annotations.CordonedAt] = time.Now().UTC().Format(time.RFC3339)
 
 		if err := c.Update(ctx, current); err != nil {
 			return err
@@ -56,8 +59,11 @@ func UncordonNode(ctx context.Context, c client.Client, node *corev1.Node) error
 		current.Spec.Unschedulable = false
 		if current.Annotations != nil {
 			delete(current.Annotations, annotations.Cordoned)
+			delete(current.Annotations, annotations.CordonedAt)
 			delete(current.Annotations, annotations.DrainStartedAt)
+			delete(current.Annotations, annotations.DrainCompletedAt)
 			delete(current.Annotations, annotations.DrainRetryCount)
+			delete(current.Annotations, annotations.DrainBlockedReason)
 		}
 
 		if err := c.Update(ctx, current); err != nil {
@@ -73,6 +79,27 @@ func IsNodeCordoned(node *corev1.Node) bool {
 	return annotations.GetBoolAnnotation(node.Annotations, annotations.Cordoned)
 }
 
+// CordonStuck reports whether a node cordoned by MCO has sat in the cordon
+// phase, before drain starts or its desired revision is set, for longer
+// than timeout. Nodes cordoned before cordoned-at was recorded are not
+// considered.
+func CordonStuck(node *corev1.Node, targetRevision string, timeout time.Duration, now time.Time) bool {
+	if timeout <= 0 || !IsNodeCordoned(node) {
+		return false
+	}
+	if annotations.GetAnnotation(node.Annotations, annotations.DrainStartedAt) != "" ||
+		annotations.GetAnnotation(node.Annotations, annotations.DesiredRevision) == targetRevision {
+		return false
+	}
+	cordonedAt, err := time.Parse(time.RFC3339, annotations.GetAnnotation(node.Annotations, annotations.CordonedAt))
+	if err != nil {
+		return false
+	}
+	return now.Sub(cordonedAt) > timeout
+}
+
+// ShouldUncordon reports whether a node cordoned by MCO has finished its
+// update. Nodes in the error state never qualify.
 func ShouldUncordon(node *corev1.Node, targetRevision string) bool {
 	if !IsNodeCordoned(node) {
 		return false
@@ -84,6 +111,13 @@ func ShouldUncordon(node *corev1.Node, targetRevision string) bool {
 	return current == targetRevision && state == annotations.StateDone
 }
 
+// HoldCordon reports whether pool keeps node cordoned for investigation:
+// HoldCordonOnError is set and the node's agent reported an error.
+func HoldCordon(pool *mcov1alpha1.MachineConfigPool, node *corev1.Node) bool {
+	return pool.Spec.Rollout.HoldCordonOnError &&
+		annotations.GetAnnotation(node.Annotations, annotations.AgentState) == annotations.StateError
+}
+
 func SetNodeAnnotation(ctx context.Context, c client.Client, node *corev1.Node, key, value string) error {
 	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 		current := &corev1.Node{}
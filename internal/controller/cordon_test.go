@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -41,6 +42,9 @@ func TestCordonNode(t *testing.T) {
 	if updated.Annotations[annotations.Cordoned] != "true" {
 		t.Error("expected cordoned annotation to be true")
 	}
+	if updated.Annotations[annotations.CordonedAt] == "" {
+		t.Error("expected cordoned-at annotation to be set")
+	}
 }
 
 func TestCordonNode_AlreadyCordoned(t *testing.T) {
@@ -223,6 +227,20 @@ func TestShouldUncordon(t *testing.T) {
 			targetRevision: "rev-1",
 			expected:       true,
 		},
+		{
+			name: "error",
+			node: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotations.Cordoned:        "true",
+						annotations.CurrentRevision: "rev-1",
+						annotations.AgentState:      annotations.StateError,
+					},
+				},
+			},
+			targetRevision: "rev-1",
+			expected:       false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -289,3 +307,46 @@ func TestGetIntAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestCordonStuck(t *testing.T) {
+	now := time.Now()
+	stale := now.Add(-20 * time.Minute).UTC().Format(time.RFC3339)
+	fresh := now.Add(-time.Minute).UTC().Format(time.RFC3339)
+	timeout := 10 * time.Minute
+
+	tests := []struct {
+		name string
+		ann  map[string]string
+		want bool
+	}{
+		{"not cordoned", map[string]string{annotations.CordonedAt: stale}, false},
+		{"within timeout", map[string]string{annotations.Cordoned: "true", annotations.CordonedAt: fresh}, false},
+		{"stuck", map[string]string{annotations.Cordoned: "true", annotations.CordonedAt: stale}, true},
+		{"drain started", map[string]string{
+			annotations.Cordoned:       "true",
+			annotations.CordonedAt:     stale,
+			annotations.DrainStartedAt: fresh,
+		}, false},
+		{"desired revision set", map[string]string{
+			annotations.Cordoned:        "true",
+			annotations.CordonedAt:      stale,
+			annotations.DesiredRevision: "rev-1",
+		}, false},
+		{"no timestamp", map[string]string{annotations.Cordoned: "true"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n", Annotations: tt.ann}}
+			if got := CordonStuck(node, "rev-1", timeout, now); got != tt.want {
+				t.Errorf("CordonStuck() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n", Annotations: map[string]string{
+		annotations.Cordoned: "true", annotations.CordonedAt: stale,
+	}}}
+	if CordonStuck(node, "rev-1", 0, now) {
+		t.Error("CordonStuck() with timeout disabled = true, want false")
+	}
+}
@@ -20,9 +20,12 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"fmt"
 	"sync"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
 )
 
@@ -131,3 +134,41 @@ func ComputePoolSpecHash(pool *mcov1alpha1.MachineConfigPool) string {
 	hash := sha256.Sum256(bytes)
 	return hex.EncodeToString(hash[:8])
 }
+
+// SetDebounceActiveCondition sets DebounceActive=True for a pending
+// revision that will be rendered once remaining has elapsed.
+func SetDebounceActiveCondition(pool *mcov1alpha1.MachineConfigPool, pendingRevision string, remaining time.Duration) {
+	setCondition(pool, metav1.Condition{
+		Type:   mcov1alpha1.ConditionDebounceActive,
+		Status: metav1.ConditionTrue,
+		Reason: "ChangeStaged",
+		Message: fmt.Sprintf("revision %s is staged and will be rendered in %s",
+			pendingRevision, remaining.Round(time.Second)),
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// ClearDebounceActiveCondition sets DebounceActive=False if it was previously set.
+func ClearDebounceActiveCondition(pool *mcov1alpha1.MachineConfigPool) {
+	for _, c := range pool.Status.Conditions {
+		if c.Type == mcov1alpha1.ConditionDebounceActive {
+			setCondition(pool, metav1.Condition{
+				Type:               mcov1alpha1.ConditionDebounceActive,
+				Status:             metav1.ConditionFalse,
+				Reason:             "NoPendingChange",
+				LastTransitionTime: metav1.Now(),
+			})
+			return
+		}
+	}
+}
+
+// applyDebounceCondition reports pendingRevision as staged unless it is
+// already the pool's target, as after a controller restart.
+func applyDebounceCondition(pool *mcov1alpha1.MachineConfigPool, pendingRevision string, remaining time.Duration) {
+	if pendingRevision != pool.Status.TargetRevision {
+		SetDebounceActiveCondition(pool, pendingRevision, remaining)
+	} else {
+		ClearDebounceActiveCondition(pool)
+	}
+}
@@ -17,10 +17,18 @@ limitations under the License.
 package controller
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
 
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/internal/renderer"
 )
 
 // TestNewDebounceState verifies that NewDebounceState creates properly initialized state.
@@ -461,3 +469,55 @@ func TestComputePoolSpecHash_Unchanged(t *testing.T) {
 		t.Errorf("Hash should be same for identical pools: %s != %s", hash1, hash2)
 	}
 }
+
+func TestReconcile_DebounceActiveCondition(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			MachineConfigSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"pool": "worker"},
+			},
+			Rollout: mcov1alpha1.RolloutConfig{DebounceSeconds: 60},
+		},
+	}
+	mc := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"pool": "worker"}},
+		Spec:       mcov1alpha1.MachineConfigSpec{Files: []mcov1alpha1.FileSpec{{Path: "/etc/app.conf", Content: "v1"}}},
+	}
+	pending := renderer.BuildRMC("worker", renderer.Merge([]*mcov1alpha1.MachineConfig{mc}), pool).Name
+
+	r := newReconciler(pool, mc)
+	ctx := context.Background()
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Name: "worker"}}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	updated := &mcov1alpha1.MachineConfigPool{}
+	if err := r.Get(ctx, client.ObjectKey{Name: "worker"}, updated); err != nil {
+		t.Fatal(err)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, mcov1alpha1.ConditionDebounceActive)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Fatalf("DebounceActive condition = %v, want True", cond)
+	}
+	if !strings.Contains(cond.Message, pending) || !strings.Contains(cond.Message, "1m0s") {
+		t.Errorf("DebounceActive message = %q, want pending revision %s and remaining time", cond.Message, pending)
+	}
+}
+
+func TestApplyDebounceCondition_AlreadyTarget(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{}
+	pool.Status.TargetRevision = "worker-abc"
+
+	applyDebounceCondition(pool, "worker-abc", time.Minute)
+	if cond := meta.FindStatusCondition(pool.Status.Conditions, mcov1alpha1.ConditionDebounceActive); cond != nil {
+		t.Errorf("DebounceActive set for the current target: %v", cond)
+	}
+
+	applyDebounceCondition(pool, "worker-def", time.Minute)
+	applyDebounceCondition(pool, "worker-abc", time.Minute)
+	cond := meta.FindStatusCondition(pool.Status.Conditions, mcov1alpha1.ConditionDebounceActive)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "NoPendingChange" {
+		t.Errorf("DebounceActive = %v, want False/NoPendingChange", cond)
+	}
+}
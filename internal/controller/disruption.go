@@ -0,0 +1,91 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/internal/configdiff"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+// drainNeededForUpdate reports whether moving node from its current
+// revision to targetRevision disrupts workloads: the agent's diff-based
+// reboot decision requires a reboot, or a changed unit would be stopped,
+// restarted or masked. It answers true whenever it cannot tell, including
+// a node's first config.
+func drainNeededForUpdate(ctx context.Context, c client.Client, node *corev1.Node, targetRevision string) bool {
+	currentRevision := annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision)
+	if currentRevision == targetRevision {
+		return false
+	}
+	if currentRevision == "" {
+		return true
+	}
+
+	target, err := existingRMC(ctx, c, targetRevision)
+	if err != nil || target == nil {
+		return true
+	}
+	return drainNeeded(ctx, c, node, target)
+}
+
+// drainNeeded is drainNeededForUpdate for a target RMC already at hand,
+// which need not exist in the cluster.
+func drainNeeded(ctx context.Context, c client.Client, node *corev1.Node, target *mcov1alpha1.RenderedMachineConfig) bool {
+	currentRevision := annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision)
+	if currentRevision == target.Name {
+		return false
+	}
+	if currentRevision == "" {
+		return true
+	}
+
+	current, err := existingRMC(ctx, c, currentRevision)
+	if err != nil || current == nil {
+		return true
+	}
+
+	decision := configdiff.NewRebootDeterminer(rmcClientFetcher{c: c}).DetermineReboot(ctx, currentRevision, target)
+	if decision.Required {
+		return true
+	}
+	return hasDisruptiveUnitChange(current, target)
+}
+
+// hasDisruptiveUnitChange reports whether an added or modified unit in
+// target would interrupt a running service.
+func hasDisruptiveUnitChange(current, target *mcov1alpha1.RenderedMachineConfig) bool {
+	units := make(map[string]mcov1alpha1.UnitSpec, len(target.Spec.Config.Systemd.Units))
+	for _, u := range target.Spec.Config.Systemd.Units {
+		units[u.Name] = u
+	}
+	for _, change := range configdiff.DiffUnits(current.Spec.Config.Systemd.Units, target.Spec.Config.Systemd.Units) {
+		if change.ChangeType == configdiff.ChangeTypeRemoved {
+			continue
+		}
+		u := units[change.Name]
+		if u.Mask || u.State == "stopped" || u.State == "restarted" {
+			return true
+		}
+	}
+	return false
+}
@@ -2,17 +2,23 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
 	"in-cloud.io/machine-config/pkg/annotations"
 )
 
@@ -20,54 +26,205 @@ type DrainConfig struct {
 	GracePeriod   int64
 	IgnoreDS      bool
 	DeleteOrphans bool
+	// DisableEviction deletes pods directly instead of evicting them,
+	// bypassing PodDisruptionBudgets.
+	DisableEviction bool
+	// EvictionTimeout bounds each eviction or deletion call. Zero means no bound.
+	EvictionTimeout time.Duration
+	// MaxEvictions limits how many pods one DrainNode call removes.
+	// Zero means no limit.
+	MaxEvictions int
+	// EvictCriticalPods allows evicting critical kube-system pods, which are
+	// excluded from drain by default.
+	EvictCriticalPods bool
+	// RespectSafeToEvict leaves pods annotated with the cluster-autoscaler
+	// safe-to-evict=false annotation in place.
+	RespectSafeToEvict bool
+	// DeleteEmptyDirData allows evicting pods with emptyDir volumes. Without
+	// it those pods block the drain, like kubectl drain does.
+	DeleteEmptyDirData bool
+	// EvictionConcurrency is how many pods of the node are removed in
+	// parallel. Zero or one removes them one at a time.
+	EvictionConcurrency int
+	// Exclusions are rules from the drain ConfigMap; matching pods are
+	// left in place.
+	Exclusions []DrainExclusionRule
 }
 
+// PDBBlockedError reports pods whose eviction a PodDisruptionBudget
+// refused. Err is the refusal of the first pod.
 type PDBBlockedError struct {
-	Pod string
-	Err error
+	Pods []string
+	Err  error
 }
 
 func (e *PDBBlockedError) Error() string {
-	return fmt.Sprintf("PDB blocked eviction of pod %s: %v", e.Pod, e.Err)
+	if len(e.Pods) == 1 {
+		return fmt.Sprintf("PDB blocked eviction of pod %s: %v", e.Pods[0], e.Err)
+	}
+	return fmt.Sprintf("PDB blocked eviction of pods %s: %v", strings.Join(e.Pods, ", "), e.Err)
+}
+
+func (e *PDBBlockedError) Unwrap() error {
+	return e.Err
+}
+
+// EmptyDirBlockedError reports pods drain may not remove because they have
+// emptyDir data and the pool does not allow deleting it.
+type EmptyDirBlockedError struct {
+	Pods []string
 }
 
-func DrainNode(ctx context.Context, c client.Client, node *corev1.Node, config DrainConfig) error {
+func (e *EmptyDirBlockedError) Error() string {
+	return fmt.Sprintf("pods with emptyDir data block drain: %s; set rollout.drainDeleteEmptyDirData to evict them",
+		strings.Join(e.Pods, ", "))
+}
+
+// DrainNode evicts (or deletes) the evictable pods on node and returns how
+// many were removed. When config.MaxEvictions is reached it stops early
+// without error; the remaining pods are left for a later call.
+func DrainNode(ctx context.Context, c client.Client, node *corev1.Node, config DrainConfig) (int, error) {
 	logger := log.FromContext(ctx)
 
 	if annotations.GetAnnotation(node.Annotations, annotations.DrainStartedAt) == "" {
 		if err := SetNodeAnnotation(ctx, c, node, annotations.DrainStartedAt,
 			time.Now().Format(time.RFC3339)); err != nil {
-			return err
+			return 0, err
 		}
 	}
 
 	podList := &corev1.PodList{}
 	if err := c.List(ctx, podList, client.MatchingFields{"spec.nodeName": node.Name}); err != nil {
-		return fmt.Errorf("failed to list pods on node %s: %w", node.Name, err)
+		return 0, fmt.Errorf("failed to list pods on node %s: %w", node.Name, err)
 	}
 
-	evictable := FilterEvictablePods(podList.Items, config)
+	evictable, emptyDir := splitEmptyDirPods(FilterEvictablePods(podList.Items, config), config)
+	var emptyDirBlocked error
+	if len(emptyDir) > 0 {
+		emptyDirBlocked = &EmptyDirBlockedError{Pods: emptyDir}
+		logger.Info("pods with emptyDir data block drain", "node", node.Name, "pods", emptyDir)
+	}
 	if len(evictable) == 0 {
+		if emptyDirBlocked != nil {
+			return 0, fmt.Errorf("drain incomplete: %w", emptyDirBlocked)
+		}
 		logger.Info("drain complete, no pods to evict", "node", node.Name)
-		return nil
+		return 0, nil
 	}
 
+	concurrency := max(config.EvictionConcurrency, 1)
+
 	var errs []error
-	for i := range evictable {
-		pod := &evictable[i]
-		if err := EvictPod(ctx, c, pod, config.GracePeriod); err != nil {
-			errs = append(errs, fmt.Errorf("pod %s/%s: %w", pod.Namespace, pod.Name, err))
-		} else {
-			logger.Info("evicted pod", "pod", pod.Namespace+"/"+pod.Name, "node", node.Name)
+	var pdbBlocked *PDBBlockedError
+	removed := 0
+	for i := 0; i < len(evictable); {
+		batch := min(concurrency, len(evictable)-i)
+		if config.MaxEvictions > 0 {
+			if removed >= config.MaxEvictions {
+				logger.Info("eviction limit reached, pausing drain",
+					"node", node.Name, "remaining", len(evictable)-i)
+				return removed, nil
+			}
+			batch = min(batch, config.MaxEvictions-removed)
+		}
+
+		batchErrs := removePods(ctx, c, evictable[i:i+batch], config)
+		for j, err := range batchErrs {
+			pod := &evictable[i+j]
+			if err != nil {
+				var blocked *PDBBlockedError
+				if errors.As(err, &blocked) {
+					if pdbBlocked == nil {
+						pdbBlocked = &PDBBlockedError{Err: blocked.Err}
+					}
+					pdbBlocked.Pods = append(pdbBlocked.Pods, pod.Namespace+"/"+pod.Name)
+				}
+				errs = append(errs, fmt.Errorf("pod %s/%s: %w", pod.Namespace, pod.Name, err))
+				continue
+			}
+			removed++
+			if config.DisableEviction {
+				logger.Info("deleted pod", "pod", pod.Namespace+"/"+pod.Name, "node", node.Name)
+			} else {
+				logger.Info("evicted pod", "pod", pod.Namespace+"/"+pod.Name, "node", node.Name)
+			}
 		}
+		i += batch
 	}
 
 	if len(errs) > 0 {
-		return fmt.Errorf("drain incomplete: %d/%d pods failed: %v", len(errs), len(evictable), errs[0])
+		// When only PDBs refused, report all blocked pods in one error.
+		if pdbBlocked != nil && len(pdbBlocked.Pods) == len(errs) {
+			return removed, fmt.Errorf("drain incomplete: %d/%d pods failed: %w", len(errs), len(evictable), pdbBlocked)
+		}
+		return removed, fmt.Errorf("drain incomplete: %d/%d pods failed: %v", len(errs), len(evictable), errs[0])
+	}
+	if emptyDirBlocked != nil {
+		return removed, fmt.Errorf("drain incomplete: %w", emptyDirBlocked)
 	}
 
 	logger.Info("drain complete", "node", node.Name, "evicted", len(evictable))
-	return nil
+	return removed, nil
+}
+
+// RemainingEvictionBudget returns how many more pods drains may remove
+// while rolling out targetRevision, or -1 when the pool sets no cap.
+func RemainingEvictionBudget(pool *mcov1alpha1.MachineConfigPool, targetRevision string) int {
+	limit := pool.Spec.Rollout.MaxPodEvictions
+	if limit <= 0 {
+		return -1
+	}
+	used := 0
+	if ev := pool.Status.PodEvictions; ev != nil && ev.Revision == targetRevision {
+		used = ev.Count
+	}
+	if used >= limit {
+		return 0
+	}
+	return limit - used
+}
+
+// RecordPodEvictions adds evicted to the pool's per-rollout eviction count,
+// restarting the count when the target revision changed.
+func RecordPodEvictions(pool *mcov1alpha1.MachineConfigPool, targetRevision string, evicted int) {
+	if pool.Status.PodEvictions == nil || pool.Status.PodEvictions.Revision != targetRevision {
+		pool.Status.PodEvictions = &mcov1alpha1.PodEvictionCount{Revision: targetRevision}
+	}
+	pool.Status.PodEvictions.Count += evicted
+}
+
+// removePods removes pods in parallel and returns the error of each, in
+// order. A single pod is removed without starting a goroutine.
+func removePods(ctx context.Context, c client.Client, pods []corev1.Pod, config DrainConfig) []error {
+	errs := make([]error, len(pods))
+	if len(pods) == 1 {
+		errs[0] = removePod(ctx, c, &pods[0], config)
+		return errs
+	}
+	var wg sync.WaitGroup
+	for i := range pods {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = removePod(ctx, c, &pods[i], config)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
+// removePod evicts or deletes a single pod, bounded by config.EvictionTimeout.
+func removePod(ctx context.Context, c client.Client, pod *corev1.Pod, config DrainConfig) error {
+	if config.EvictionTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.EvictionTimeout)
+		defer cancel()
+	}
+
+	if config.DisableEviction {
+		return DeletePod(ctx, c, pod, config.GracePeriod)
+	}
+	return EvictPod(ctx, c, pod, config.GracePeriod)
 }
 
 func FilterEvictablePods(pods []corev1.Pod, config DrainConfig) []corev1.Pod {
@@ -98,16 +255,57 @@ func FilterEvictablePods(pods []corev1.Pod, config DrainConfig) []corev1.Pod {
 			continue
 		}
 
+		if !config.EvictCriticalPods && isCriticalSystemPod(&pod) {
+			continue
+		}
+
+		if config.RespectSafeToEvict && pod.Annotations[SafeToEvictAnnotation] == "false" {
+			continue
+		}
+
 		if !config.DeleteOrphans && !HasController(&pod) {
 			continue
 		}
 
+		if slices.ContainsFunc(config.Exclusions, func(rule DrainExclusionRule) bool { return rule.Matches(&pod) }) {
+			continue
+		}
+
 		result = append(result, pod)
 	}
 
 	return result
 }
 
+// HasEmptyDir reports whether pod mounts an emptyDir volume, whose data is
+// lost when the pod is evicted.
+func HasEmptyDir(pod *corev1.Pod) bool {
+	for _, v := range pod.Spec.Volumes {
+		if v.EmptyDir != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// splitEmptyDirPods separates the pods drain may not remove because of
+// their emptyDir data, returned as namespace/name, from the rest.
+func splitEmptyDirPods(pods []corev1.Pod, config DrainConfig) ([]corev1.Pod, []string) {
+	if config.DeleteEmptyDirData {
+		return pods, nil
+	}
+	var rest []corev1.Pod
+	var blocked []string
+	for _, pod := range pods {
+		if HasEmptyDir(&pod) {
+			blocked = append(blocked, pod.Namespace+"/"+pod.Name)
+			continue
+		}
+		rest = append(rest, pod)
+	}
+	return rest, blocked
+}
+
 func IsDaemonSetPod(pod *corev1.Pod) bool {
 	for _, ref := range pod.OwnerReferences {
 		if ref.Kind == "DaemonSet" {
@@ -143,7 +341,7 @@ func EvictPod(ctx context.Context, c client.Client, pod *corev1.Pod, gracePeriod
 	err := c.SubResource("eviction").Create(ctx, pod, eviction)
 	if err != nil {
 		if apierrors.IsTooManyRequests(err) {
-			return &PDBBlockedError{Pod: pod.Name, Err: err}
+			return &PDBBlockedError{Pods: []string{pod.Name}, Err: err}
 		}
 		if apierrors.IsNotFound(err) {
 			return nil
@@ -154,14 +352,155 @@ func EvictPod(ctx context.Context, c client.Client, pod *corev1.Pod, gracePeriod
 	return nil
 }
 
+// DeletePod deletes a pod directly, bypassing the eviction API and PDBs.
+// A negative gracePeriod uses the pod's own terminationGracePeriodSeconds.
+func DeletePod(ctx context.Context, c client.Client, pod *corev1.Pod, gracePeriod int64) error {
+	var opts []client.DeleteOption
+	if gracePeriod >= 0 {
+		opts = append(opts, client.GracePeriodSeconds(gracePeriod))
+	}
+
+	if err := c.Delete(ctx, pod, opts...); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ForceDeletePods deletes the pods drain would still remove from node with a
+// zero grace period, bypassing eviction and PodDisruptionBudgets. Pods
+// FilterEvictablePods leaves alone (MCO, DaemonSet, excluded, ...) are kept,
+// and so are pods with emptyDir data unless config allows deleting it; those
+// are reported as an EmptyDirBlockedError. It returns the namespace/name of
+// every pod deleted.
+func ForceDeletePods(ctx context.Context, c client.Client, node *corev1.Node, config DrainConfig) ([]string, error) {
+	remaining, err := RemainingEvictablePods(ctx, c, node, config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Forcing a stuck drain does not override the pool keeping emptyDir data.
+	pods, emptyDir := splitEmptyDirPods(remaining, config)
+	var errs []error
+	if len(emptyDir) > 0 {
+		errs = append(errs, &EmptyDirBlockedError{Pods: emptyDir})
+	}
+
+	var deleted []string
+	for i := range pods {
+		pod := &pods[i]
+		if err := DeletePod(ctx, c, pod, 0); err != nil {
+			errs = append(errs, fmt.Errorf("delete pod %s/%s: %w", pod.Namespace, pod.Name, err))
+			continue
+		}
+		deleted = append(deleted, pod.Namespace+"/"+pod.Name)
+	}
+	return deleted, errors.Join(errs...)
+}
+
+// CanDrainNode reports whether at least one pod that drain would remove
+// from node can be evicted right now: it is not selected by a
+// PodDisruptionBudget that allows no disruptions. A node with nothing to
+// evict, or a pool that deletes pods instead of evicting them, can always
+// be drained.
+func CanDrainNode(ctx context.Context, c client.Client, node *corev1.Node, config DrainConfig) (bool, error) {
+	pods, err := RemainingEvictablePods(ctx, c, node, config)
+	if err != nil {
+		return false, err
+	}
+	if len(pods) == 0 || config.DisableEviction {
+		return true, nil
+	}
+
+	pdbs := &policyv1.PodDisruptionBudgetList{}
+	if err := c.List(ctx, pdbs); err != nil {
+		return false, fmt.Errorf("failed to list PodDisruptionBudgets: %w", err)
+	}
+	for i := range pods {
+		if !pdbBlocksPod(pdbs.Items, &pods[i]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// PDBBlockedNodes returns the nodes waiting for an update whose drain
+// PodDisruptionBudgets would block entirely, so they are not cordoned only
+// to sit out the drain timeout. Nodes that are not drained for this update,
+// such as new nodes, are never reported. settings is the drain ConfigMap
+// content the drains will use.
+func PDBBlockedNodes(
+	ctx context.Context,
+	c client.Client,
+	pool *mcov1alpha1.MachineConfigPool,
+	nodes []corev1.Node,
+	targetFor targetFunc,
+	settings DrainSettings,
+) (map[string]bool, error) {
+	config := settings.Apply(poolDrainConfig(pool))
+
+	blocked := make(map[string]bool)
+	for i := range nodes {
+		node := &nodes[i]
+		target := targetFor(node)
+		if annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision) == target ||
+			annotations.IsNodePaused(node.Annotations) || annotations.IsNodeInMaintenance(node.Annotations) ||
+			IsNodeUnavailable(node) || isNewNodeForPool(node, pool) {
+			continue
+		}
+		if pool.Spec.Rollout.SkipDrainWithoutReboot && !drainNeededForUpdate(ctx, c, node, target) {
+			continue
+		}
+		ok, err := CanDrainNode(ctx, c, node, config)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			blocked[node.Name] = true
+		}
+	}
+	return blocked, nil
+}
+
+// pdbBlocksPod reports whether a PodDisruptionBudget in the pod's namespace
+// selects it and allows no further disruptions. As in policy/v1, an empty
+// selector selects every pod in the namespace and a nil one selects none.
+func pdbBlocksPod(pdbs []policyv1.PodDisruptionBudget, pod *corev1.Pod) bool {
+	for i := range pdbs {
+		pdb := &pdbs[i]
+		if pdb.Namespace != pod.Namespace || pdb.Status.DisruptionsAllowed > 0 || pdb.Spec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			return true
+		}
+	}
+	return false
+}
+
 func IsDrainComplete(ctx context.Context, c client.Client, node *corev1.Node, config DrainConfig) (bool, error) {
-	podList := &corev1.PodList{}
-	if err := c.List(ctx, podList, client.MatchingFields{"spec.nodeName": node.Name}); err != nil {
+	remaining, err := RemainingEvictablePods(ctx, c, node, config)
+	if err != nil {
 		return false, err
 	}
+	return len(remaining) == 0, nil
+}
 
-	evictable := FilterEvictablePods(podList.Items, config)
-	return len(evictable) == 0, nil
+// RemainingEvictablePods returns the pods still on node that drain has to
+// remove. While a drain is stuck these are the pods blocking it.
+func RemainingEvictablePods(ctx context.Context, c client.Client, node *corev1.Node, config DrainConfig) ([]corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := c.List(ctx, podList, client.MatchingFields{"spec.nodeName": node.Name}); err != nil {
+		return nil, err
+	}
+	return FilterEvictablePods(podList.Items, config), nil
 }
 
 type DrainRetryResult struct {
@@ -207,12 +546,42 @@ func isMCOPod(pod *corev1.Pod) bool {
 	return false
 }
 
+// SafeToEvictAnnotation is the cluster-autoscaler annotation marking pods
+// that must not be evicted when set to "false".
+const SafeToEvictAnnotation = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+
+// criticalPriorityClasses are the built-in priority classes of core cluster
+// components.
+var criticalPriorityClasses = map[string]bool{
+	"system-cluster-critical": true,
+	"system-node-critical":    true,
+}
+
+// isCriticalSystemPod reports whether pod is a critical kube-system
+// component (kube-proxy, CoreDNS, CNI, ...), identified by its priority class.
+func isCriticalSystemPod(pod *corev1.Pod) bool {
+	return pod.Namespace == metav1.NamespaceSystem && criticalPriorityClasses[pod.Spec.PriorityClassName]
+}
+
 // HandleDrainRetry manages drain retry logic and determines if drain is stuck.
 // drainTimeoutSeconds specifies the maximum time before marking drain as stuck.
 // drainRetrySeconds specifies the interval between retry attempts.
 // If drainTimeoutSeconds is 0, DefaultDrainTimeoutSeconds (3600) is used.
 // If drainRetrySeconds is 0, it is calculated as max(30, drainTimeoutSeconds/12).
-func HandleDrainRetry(ctx context.Context, c client.Client, node *corev1.Node, drainTimeoutSeconds, drainRetrySeconds int) DrainRetryResult {
+// drainErr is the error of the failed drain attempt; whether PDBs caused it
+// is recorded in the drain-blocked-reason annotation.
+func HandleDrainRetry(ctx context.Context, c client.Client, node *corev1.Node, drainTimeoutSeconds, drainRetrySeconds int, drainErr error) DrainRetryResult {
+	if drainErr != nil {
+		reason := annotations.DrainBlockedEvictionFailed
+		var blocked *PDBBlockedError
+		if errors.As(drainErr, &blocked) {
+			reason = annotations.DrainBlockedPDB
+		}
+		if annotations.GetAnnotation(node.Annotations, annotations.DrainBlockedReason) != reason {
+			_ = SetNodeAnnotation(ctx, c, node, annotations.DrainBlockedReason, reason)
+		}
+	}
+
 	drainStartStr := annotations.GetAnnotation(node.Annotations, annotations.DrainStartedAt)
 	if drainStartStr == "" {
 		// First retry - use configured interval or default
@@ -276,9 +645,39 @@ func calculateRetryInterval(drainTimeoutSeconds, drainRetrySeconds int) time.Dur
 	return time.Duration(calculated) * time.Second
 }
 
+// DrainDuration returns how long a node's drain took and when it completed,
+// from its drain-started-at and drain-completed-at annotations. ok is false
+// unless both are set, parse, and are in order.
+func DrainDuration(ann map[string]string) (duration time.Duration, completedAt time.Time, ok bool) {
+	started, err := time.Parse(time.RFC3339, annotations.GetAnnotation(ann, annotations.DrainStartedAt))
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	completedAt, err = time.Parse(time.RFC3339, annotations.GetAnnotation(ann, annotations.DrainCompletedAt))
+	if err != nil || completedAt.Before(started) {
+		return 0, time.Time{}, false
+	}
+	return completedAt.Sub(started), completedAt, true
+}
+
 func ClearDrainAnnotations(ctx context.Context, c client.Client, node *corev1.Node) error {
 	if err := RemoveNodeAnnotation(ctx, c, node, annotations.DrainStartedAt); err != nil {
 		return err
 	}
+	if err := RemoveNodeAnnotation(ctx, c, node, annotations.DrainCompletedAt); err != nil {
+		return err
+	}
+	if err := RemoveNodeAnnotation(ctx, c, node, annotations.DrainLowestRemaining); err != nil {
+		return err
+	}
+	if err := RemoveNodeAnnotation(ctx, c, node, annotations.DrainNoProgressCount); err != nil {
+		return err
+	}
+	if err := RemoveNodeAnnotation(ctx, c, node, annotations.DrainProgressCheckedAt); err != nil {
+		return err
+	}
+	if err := RemoveNodeAnnotation(ctx, c, node, annotations.DrainBlockedReason); err != nil {
+		return err
+	}
 	return RemoveNodeAnnotation(ctx, c, node, annotations.DrainRetryCount)
 }
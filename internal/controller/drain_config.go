@@ -0,0 +1,193 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// DrainConfigMapName is the ConfigMap in MCONamespace holding cluster-wide drain settings.
+const DrainConfigMapName = "mco-drain-config"
+
+// DrainConfigMapKey is the data key holding the YAML drain settings.
+const DrainConfigMapKey = "config.yaml"
+
+// DrainConfigRecheckInterval is how often an invalid drain ConfigMap is
+// read again. Nothing watches it, so a fix is picked up by this requeue.
+const DrainConfigRecheckInterval = 30 * time.Second
+
+// DrainSettings is the content of the drain ConfigMap.
+//
+//	defaults:
+//	  gracePeriodSeconds: 30
+//	  evictionTimeoutSeconds: 60
+//	  evictCriticalPods: false
+//	  respectSafeToEvict: false
+//	exclusions:
+//	  - namespaces: [databases]
+//	    labelSelectors:
+//	      - matchLabels: {app: postgres}
+type DrainSettings struct {
+	Defaults DefaultsDrainSettings `json:"defaults,omitempty"`
+
+	// Exclusions lists pods drain leaves on the node. A pod matching any
+	// rule is not evicted.
+	Exclusions []DrainExclusionRule `json:"exclusions,omitempty"`
+}
+
+// DrainExclusionRule matches pods that drain must not evict. Every field
+// that is set must match; within a field any entry may match. An empty rule
+// is rejected since it would exclude every pod.
+type DrainExclusionRule struct {
+	// Namespaces the pod must be in.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// PodNamePatterns are shell globs (path.Match syntax) for the pod name.
+	PodNamePatterns []string `json:"podNamePatterns,omitempty"`
+
+	// LabelSelectors the pod labels must satisfy.
+	LabelSelectors []metav1.LabelSelector `json:"labelSelectors,omitempty"`
+}
+
+// Matches reports whether pod is covered by the rule. Invalid patterns or
+// selectors never match; ParseDrainSettings rejects them up front.
+func (r DrainExclusionRule) Matches(pod *corev1.Pod) bool {
+	if len(r.Namespaces) == 0 && len(r.PodNamePatterns) == 0 && len(r.LabelSelectors) == 0 {
+		return false
+	}
+	if len(r.Namespaces) > 0 && !slices.Contains(r.Namespaces, pod.Namespace) {
+		return false
+	}
+	if len(r.PodNamePatterns) > 0 && !slices.ContainsFunc(r.PodNamePatterns, func(pattern string) bool {
+		ok, err := path.Match(pattern, pod.Name)
+		return err == nil && ok
+	}) {
+		return false
+	}
+	if len(r.LabelSelectors) > 0 && !slices.ContainsFunc(r.LabelSelectors, func(ls metav1.LabelSelector) bool {
+		selector, err := metav1.LabelSelectorAsSelector(&ls)
+		return err == nil && selector.Matches(labels.Set(pod.Labels))
+	}) {
+		return false
+	}
+	return true
+}
+
+func (r DrainExclusionRule) validate() error {
+	if len(r.Namespaces) == 0 && len(r.PodNamePatterns) == 0 && len(r.LabelSelectors) == 0 {
+		return fmt.Errorf("rule must set namespaces, podNamePatterns or labelSelectors")
+	}
+	for _, pattern := range r.PodNamePatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("podNamePatterns %q: %w", pattern, err)
+		}
+	}
+	for i := range r.LabelSelectors {
+		if _, err := metav1.LabelSelectorAsSelector(&r.LabelSelectors[i]); err != nil {
+			return fmt.Errorf("labelSelectors[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// DefaultsDrainSettings holds cluster-wide defaults applied to every drain.
+type DefaultsDrainSettings struct {
+	// GracePeriodSeconds overrides the pod termination grace period used for
+	// eviction. Unset uses each pod's terminationGracePeriodSeconds.
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+
+	// EvictionTimeoutSeconds bounds a single pod eviction (or deletion) call.
+	// Unset means no per-pod bound beyond the reconcile context.
+	EvictionTimeoutSeconds *int64 `json:"evictionTimeoutSeconds,omitempty"`
+
+	// EvictCriticalPods allows draining kube-system pods with the
+	// system-cluster-critical or system-node-critical priority class.
+	// They are left in place by default.
+	EvictCriticalPods bool `json:"evictCriticalPods,omitempty"`
+
+	// RespectSafeToEvict leaves pods annotated
+	// cluster-autoscaler.kubernetes.io/safe-to-evict=false on the node
+	// during drain.
+	RespectSafeToEvict bool `json:"respectSafeToEvict,omitempty"`
+}
+
+// LoadDrainSettings reads the drain ConfigMap. A missing ConfigMap yields
+// empty settings; malformed content is returned as an error so the caller can
+// report it and fall back to built-in defaults.
+func LoadDrainSettings(ctx context.Context, c client.Client) (DrainSettings, error) {
+	cm := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: MCONamespace, Name: DrainConfigMapName}
+	if err := c.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return DrainSettings{}, nil
+		}
+		return DrainSettings{}, fmt.Errorf("get drain config: %w", err)
+	}
+
+	return ParseDrainSettings(cm.Data[DrainConfigMapKey])
+}
+
+// ParseDrainSettings parses and validates drain settings YAML.
+func ParseDrainSettings(data string) (DrainSettings, error) {
+	var settings DrainSettings
+	if data == "" {
+		return settings, nil
+	}
+	if err := yaml.UnmarshalStrict([]byte(data), &settings); err != nil {
+		return DrainSettings{}, fmt.Errorf("parse drain config: %w", err)
+	}
+
+	d := settings.Defaults
+	if d.GracePeriodSeconds != nil && *d.GracePeriodSeconds < 0 {
+		return DrainSettings{}, fmt.Errorf("defaults.gracePeriodSeconds must be >= 0, got %d", *d.GracePeriodSeconds)
+	}
+	if d.EvictionTimeoutSeconds != nil && *d.EvictionTimeoutSeconds <= 0 {
+		return DrainSettings{}, fmt.Errorf("defaults.evictionTimeoutSeconds must be > 0, got %d", *d.EvictionTimeoutSeconds)
+	}
+	for i, rule := range settings.Exclusions {
+		if err := rule.validate(); err != nil {
+			return DrainSettings{}, fmt.Errorf("exclusions[%d]: %w", i, err)
+		}
+	}
+
+	return settings, nil
+}
+
+// Apply overlays the cluster-wide defaults onto a drain config.
+func (s DrainSettings) Apply(config DrainConfig) DrainConfig {
+	if s.Defaults.GracePeriodSeconds != nil {
+		config.GracePeriod = *s.Defaults.GracePeriodSeconds
+	}
+	if s.Defaults.EvictionTimeoutSeconds != nil {
+		config.EvictionTimeout = time.Duration(*s.Defaults.EvictionTimeoutSeconds) * time.Second
+	}
+	config.EvictCriticalPods = s.Defaults.EvictCriticalPods
+	config.RespectSafeToEvict = s.Defaults.RespectSafeToEvict
+	config.Exclusions = s.Exclusions
+	return config
+}
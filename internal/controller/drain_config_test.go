@@ -0,0 +1,226 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParseDrainSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		wantErr     bool
+		wantGrace   int64
+		wantTimeout time.Duration
+		wantCrit    bool
+		wantSafe    bool
+	}{
+		{
+			name:        "empty keeps built-in defaults",
+			data:        "",
+			wantGrace:   -1,
+			wantTimeout: 0,
+		},
+		{
+			name:        "grace and timeout",
+			data:        "defaults:\n  gracePeriodSeconds: 30\n  evictionTimeoutSeconds: 60\n",
+			wantGrace:   30,
+			wantTimeout: 60 * time.Second,
+		},
+		{
+			name:        "zero grace is allowed",
+			data:        "defaults:\n  gracePeriodSeconds: 0\n",
+			wantGrace:   0,
+			wantTimeout: 0,
+		},
+		{
+			name:        "evict critical pods",
+			data:        "defaults:\n  evictCriticalPods: true\n",
+			wantGrace:   -1,
+			wantTimeout: 0,
+			wantCrit:    true,
+		},
+		{
+			name:        "respect safe-to-evict",
+			data:        "defaults:\n  respectSafeToEvict: true\n",
+			wantGrace:   -1,
+			wantTimeout: 0,
+			wantSafe:    true,
+		},
+		{
+			name:    "negative grace",
+			data:    "defaults:\n  gracePeriodSeconds: -5\n",
+			wantErr: true,
+		},
+		{
+			name:    "zero timeout",
+			data:    "defaults:\n  evictionTimeoutSeconds: 0\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			data:    "defaults:\n  gracePeriod: 30\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings, err := ParseDrainSettings(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDrainSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			config := settings.Apply(DrainConfig{GracePeriod: -1})
+			if config.GracePeriod != tt.wantGrace {
+				t.Errorf("GracePeriod = %d, want %d", config.GracePeriod, tt.wantGrace)
+			}
+			if config.EvictionTimeout != tt.wantTimeout {
+				t.Errorf("EvictionTimeout = %v, want %v", config.EvictionTimeout, tt.wantTimeout)
+			}
+			if config.EvictCriticalPods != tt.wantCrit {
+				t.Errorf("EvictCriticalPods = %v, want %v", config.EvictCriticalPods, tt.wantCrit)
+			}
+			if config.RespectSafeToEvict != tt.wantSafe {
+				t.Errorf("RespectSafeToEvict = %v, want %v", config.RespectSafeToEvict, tt.wantSafe)
+			}
+		})
+	}
+}
+
+func TestLoadDrainSettings(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	ctx := context.Background()
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	settings, err := LoadDrainSettings(ctx, c)
+	if err != nil {
+		t.Fatalf("LoadDrainSettings() without ConfigMap error = %v", err)
+	}
+	if settings.Defaults.GracePeriodSeconds != nil || settings.Defaults.EvictionTimeoutSeconds != nil {
+		t.Errorf("expected empty settings without ConfigMap, got %+v", settings)
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: DrainConfigMapName, Namespace: MCONamespace},
+		Data:       map[string]string{DrainConfigMapKey: "defaults:\n  gracePeriodSeconds: 15\n"},
+	}
+	c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build()
+	settings, err = LoadDrainSettings(ctx, c)
+	if err != nil {
+		t.Fatalf("LoadDrainSettings() error = %v", err)
+	}
+	if settings.Defaults.GracePeriodSeconds == nil || *settings.Defaults.GracePeriodSeconds != 15 {
+		t.Errorf("GracePeriodSeconds = %v, want 15", settings.Defaults.GracePeriodSeconds)
+	}
+}
+
+func TestParseDrainSettings_Exclusions(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "namespace and selector",
+			data: "exclusions:\n  - namespaces: [db]\n    labelSelectors:\n      - matchLabels: {app: postgres}\n",
+		},
+		{
+			name: "pod name pattern",
+			data: "exclusions:\n  - podNamePatterns: [\"etcd-*\"]\n",
+		},
+		{
+			name:    "empty rule",
+			data:    "exclusions:\n  - {}\n",
+			wantErr: true,
+		},
+		{
+			name:    "bad selector operator",
+			data:    "exclusions:\n  - labelSelectors:\n      - matchExpressions:\n          - {key: app, operator: Near, values: [x]}\n",
+			wantErr: true,
+		},
+		{
+			name:    "bad label value",
+			data:    "exclusions:\n  - labelSelectors:\n      - matchLabels: {app: \"not valid!\"}\n",
+			wantErr: true,
+		},
+		{
+			name:    "bad pattern",
+			data:    "exclusions:\n  - podNamePatterns: [\"web-[\"]\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings, err := ParseDrainSettings(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDrainSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(settings.Apply(DrainConfig{}).Exclusions) != 1 {
+				t.Errorf("expected exclusion rule to be carried into DrainConfig")
+			}
+		})
+	}
+}
+
+func TestDrainExclusionRule_Matches(t *testing.T) {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      "postgres-0",
+		Namespace: "db",
+		Labels:    map[string]string{"app": "postgres"},
+	}}
+	selector := func(app string) []metav1.LabelSelector {
+		return []metav1.LabelSelector{{MatchLabels: map[string]string{"app": app}}}
+	}
+
+	tests := []struct {
+		name string
+		rule DrainExclusionRule
+		want bool
+	}{
+		{"empty rule", DrainExclusionRule{}, false},
+		{"namespace", DrainExclusionRule{Namespaces: []string{"other", "db"}}, true},
+		{"other namespace", DrainExclusionRule{Namespaces: []string{"other"}}, false},
+		{"pattern", DrainExclusionRule{PodNamePatterns: []string{"postgres-*"}}, true},
+		{"selector", DrainExclusionRule{LabelSelectors: selector("postgres")}, true},
+		{"any selector", DrainExclusionRule{LabelSelectors: append(selector("redis"), selector("postgres")...)}, true},
+		{"namespace and selector", DrainExclusionRule{Namespaces: []string{"db"}, LabelSelectors: selector("postgres")}, true},
+		{"namespace without selector match", DrainExclusionRule{Namespaces: []string{"db"}, LabelSelectors: selector("redis")}, false},
+		{"selector without namespace match", DrainExclusionRule{Namespaces: []string{"web"}, LabelSelectors: selector("postgres")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rule.Matches(pod); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+// DrainNoProgressChecks is how many consecutive drain checks may pass
+// without fewer pods left on the node before the drain is flagged as not
+// making progress.
+const DrainNoProgressChecks = 10
+
+// TrackDrainProgress records remaining, the number of pods drain still has
+// to remove from node, and returns how many consecutive checks have not
+// brought it below the lowest count seen during this drain. A check is
+// counted at most once per interval, the drain retry interval, so
+// reconciles triggered by other events do not inflate the count.
+func TrackDrainProgress(ctx context.Context, c client.Client, node *corev1.Node, remaining int, interval time.Duration) (int, error) {
+	now := time.Now()
+	lowest := annotations.GetAnnotation(node.Annotations, annotations.DrainLowestRemaining)
+	if lowest == "" || remaining < GetIntAnnotation(node, annotations.DrainLowestRemaining) {
+		if err := SetNodeAnnotation(ctx, c, node, annotations.DrainLowestRemaining, strconv.Itoa(remaining)); err != nil {
+			return 0, err
+		}
+		if annotations.GetAnnotation(node.Annotations, annotations.DrainNoProgressCount) != "" {
+			if err := RemoveNodeAnnotation(ctx, c, node, annotations.DrainNoProgressCount); err != nil {
+				return 0, err
+			}
+		}
+		return 0, SetNodeAnnotation(ctx, c, node, annotations.DrainProgressCheckedAt, now.Format(time.RFC3339))
+	}
+
+	count := GetIntAnnotation(node, annotations.DrainNoProgressCount)
+	checkedAt, err := time.Parse(time.RFC3339, annotations.GetAnnotation(node.Annotations, annotations.DrainProgressCheckedAt))
+	if err == nil && now.Sub(checkedAt) < interval {
+		return count, nil
+	}
+	count++
+	if err := SetNodeAnnotation(ctx, c, node, annotations.DrainNoProgressCount, strconv.Itoa(count)); err != nil {
+		return 0, err
+	}
+	return count, SetNodeAnnotation(ctx, c, node, annotations.DrainProgressCheckedAt, now.Format(time.RFC3339))
+}
+
+// SetDrainNoProgressCondition sets DrainNoProgress=True naming the nodes.
+func SetDrainNoProgressCondition(pool *mcov1alpha1.MachineConfigPool, nodes []string) {
+	setCondition(pool, metav1.Condition{
+		Type:   mcov1alpha1.ConditionDrainNoProgress,
+		Status: metav1.ConditionTrue,
+		Reason: "PodCountNotDecreasing",
+		Message: fmt.Sprintf("Pods left to evict have not decreased over %d drain checks on nodes: %s",
+			DrainNoProgressChecks, joinNodeNames(nodes)),
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// ClearDrainNoProgressCondition sets DrainNoProgress=False if it was previously set.
+func ClearDrainNoProgressCondition(pool *mcov1alpha1.MachineConfigPool) {
+	for _, c := range pool.Status.Conditions {
+		if c.Type == mcov1alpha1.ConditionDrainNoProgress {
+			setCondition(pool, metav1.Condition{
+				Type:               mcov1alpha1.ConditionDrainNoProgress,
+				Status:             metav1.ConditionFalse,
+				Reason:             "DrainProgressing",
+				LastTransitionTime: metav1.Now(),
+			})
+			return
+		}
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+func TestTrackDrainProgress(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(node).Build()
+	ctx := context.Background()
+
+	track := func(remaining int, interval time.Duration) int {
+		t.Helper()
+		current := &corev1.Node{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(node), current); err != nil {
+			t.Fatalf("get node: %v", err)
+		}
+		checks, err := TrackDrainProgress(ctx, c, current, remaining, interval)
+		if err != nil {
+			t.Fatalf("TrackDrainProgress: %v", err)
+		}
+		return checks
+	}
+
+	if got := track(5, 0); got != 0 {
+		t.Errorf("first check: expected 0, got %d", got)
+	}
+	if got := track(5, 0); got != 1 {
+		t.Errorf("same count: expected 1, got %d", got)
+	}
+	if got := track(6, 0); got != 2 {
+		t.Errorf("higher count: expected 2, got %d", got)
+	}
+	for range 5 {
+		if got := track(6, time.Hour); got != 2 {
+			t.Fatalf("check within the retry interval: expected 2, got %d", got)
+		}
+	}
+	if got := track(4, time.Hour); got != 0 {
+		t.Errorf("lower count should reset: expected 0, got %d", got)
+	}
+
+	current := &corev1.Node{}
+	_ = c.Get(ctx, client.ObjectKeyFromObject(node), current)
+	if got := current.Annotations[annotations.DrainLowestRemaining]; got != "4" {
+		t.Errorf("expected lowest remaining 4, got %q", got)
+	}
+	if _, ok := current.Annotations[annotations.DrainNoProgressCount]; ok {
+		t.Error("expected no-progress count to be removed after progress")
+	}
+
+	if err := ClearDrainAnnotations(ctx, c, current); err != nil {
+		t.Fatalf("ClearDrainAnnotations: %v", err)
+	}
+	_ = c.Get(ctx, client.ObjectKeyFromObject(node), current)
+	if _, ok := current.Annotations[annotations.DrainLowestRemaining]; ok {
+		t.Error("expected lowest remaining to be cleared with drain annotations")
+	}
+}
+
+func TestDrainNoProgressCondition(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+
+	ClearDrainNoProgressCondition(pool)
+	if meta.FindStatusCondition(pool.Status.Conditions, mcov1alpha1.ConditionDrainNoProgress) != nil {
+		t.Fatal("clear should not add the condition")
+	}
+
+	SetDrainNoProgressCondition(pool, []string{"node-a", "node-b"})
+	cond := meta.FindStatusCondition(pool.Status.Conditions, mcov1alpha1.ConditionDrainNoProgress)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Fatalf("expected DrainNoProgress=True, got %+v", cond)
+	}
+	if cond.Reason != "PodCountNotDecreasing" {
+		t.Errorf("unexpected reason %q", cond.Reason)
+	}
+
+	ClearDrainNoProgressCondition(pool)
+	cond = meta.FindStatusCondition(pool.Status.Conditions, mcov1alpha1.ConditionDrainNoProgress)
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.Reason != "DrainProgressing" {
+		t.Errorf("expected DrainNoProgress=False/DrainProgressing, got %+v", cond)
+	}
+}
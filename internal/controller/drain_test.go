@@ -2,15 +2,23 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
 	"in-cloud.io/machine-config/pkg/annotations"
 )
 
@@ -256,7 +264,7 @@ func TestHandleDrainRetry_Phase1(t *testing.T) {
 	ctx := context.Background()
 
 	// With default timeout (3600s) and auto-calculated retry (300s = 5min)
-	result := HandleDrainRetry(ctx, c, node, DefaultDrainTimeoutSeconds, 0)
+	result := HandleDrainRetry(ctx, c, node, DefaultDrainTimeoutSeconds, 0, nil)
 
 	// Auto-calculated retry interval: max(30, 3600/12) = 300s = 5min
 	if result.RequeueAfter != 5*time.Minute {
@@ -285,7 +293,7 @@ func TestHandleDrainRetry_Phase2(t *testing.T) {
 	ctx := context.Background()
 
 	// Auto-calculated retry: max(30, 3600/12) = 300s = 5min
-	result := HandleDrainRetry(ctx, c, node, DefaultDrainTimeoutSeconds, 0)
+	result := HandleDrainRetry(ctx, c, node, DefaultDrainTimeoutSeconds, 0, nil)
 
 	// Remaining = 3600-1800 = 1800s = 30min, so requeue = min(300s, 1800s) = 300s = 5min
 	if result.RequeueAfter != 5*time.Minute {
@@ -314,7 +322,7 @@ func TestHandleDrainRetry_Phase3_DrainStuck(t *testing.T) {
 	ctx := context.Background()
 
 	// 65 min > 60 min timeout → drain stuck
-	result := HandleDrainRetry(ctx, c, node, DefaultDrainTimeoutSeconds, 0)
+	result := HandleDrainRetry(ctx, c, node, DefaultDrainTimeoutSeconds, 0, nil)
 
 	// Auto-calculated retry interval: max(30, 3600/12) = 300s = 5min
 	if result.RequeueAfter != 5*time.Minute {
@@ -337,7 +345,7 @@ func TestHandleDrainRetry_NoDrainStarted(t *testing.T) {
 	ctx := context.Background()
 
 	// No drain started yet → first attempt uses auto-calculated interval
-	result := HandleDrainRetry(ctx, c, node, DefaultDrainTimeoutSeconds, 0)
+	result := HandleDrainRetry(ctx, c, node, DefaultDrainTimeoutSeconds, 0, nil)
 
 	// Auto-calculated retry: max(30, 3600/12) = 300s = 5min
 	if result.RequeueAfter != 5*time.Minute {
@@ -367,13 +375,13 @@ func TestHandleDrainRetry_CustomTimeout(t *testing.T) {
 	ctx := context.Background()
 
 	// With default timeout (3600s), should not be stuck
-	result := HandleDrainRetry(ctx, c, node, DefaultDrainTimeoutSeconds, 0)
+	result := HandleDrainRetry(ctx, c, node, DefaultDrainTimeoutSeconds, 0, nil)
 	if result.SetDrainStuck {
 		t.Error("expected SetDrainStuck to be false with default timeout")
 	}
 
 	// With 15 minute timeout, should be stuck (20min > 15min)
-	result = HandleDrainRetry(ctx, c, node, 900, 0) // 15 minutes
+	result = HandleDrainRetry(ctx, c, node, 900, 0, nil) // 15 minutes
 	if !result.SetDrainStuck {
 		t.Error("expected SetDrainStuck to be true with 15 minute timeout")
 	}
@@ -398,14 +406,14 @@ func TestHandleDrainRetry_ZeroTimeoutUsesDefault(t *testing.T) {
 	ctx := context.Background()
 
 	// Pass 0, 0 to use defaults for both timeout and retry
-	result := HandleDrainRetry(ctx, c, node, 0, 0)
+	result := HandleDrainRetry(ctx, c, node, 0, 0, nil)
 	if result.SetDrainStuck {
 		t.Error("expected SetDrainStuck to be false when using default timeout")
 	}
 }
 
 func TestPDBBlockedError(t *testing.T) {
-	err := &PDBBlockedError{Pod: "test-pod", Err: nil}
+	err := &PDBBlockedError{Pods: []string{"test-pod"}, Err: nil}
 	msg := err.Error()
 
 	if msg != "PDB blocked eviction of pod test-pod: <nil>" {
@@ -434,7 +442,7 @@ func TestHandleDrainRetry_ShortTimeout_60Seconds(t *testing.T) {
 	ctx := context.Background()
 
 	// With 60s timeout, should be stuck after 70s
-	result := HandleDrainRetry(ctx, c, node, 60, 0)
+	result := HandleDrainRetry(ctx, c, node, 60, 0, nil)
 	if !result.SetDrainStuck {
 		t.Error("expected SetDrainStuck=true after 70s with 60s timeout")
 	}
@@ -444,7 +452,7 @@ func TestHandleDrainRetry_ShortTimeout_60Seconds(t *testing.T) {
 	node.Annotations[annotations.DrainStartedAt] = drainStart.Format(time.RFC3339)
 	_ = c.Update(ctx, node)
 
-	result = HandleDrainRetry(ctx, c, node, 60, 0)
+	result = HandleDrainRetry(ctx, c, node, 60, 0, nil)
 	if result.SetDrainStuck {
 		t.Error("expected SetDrainStuck=false at 50s with 60s timeout")
 	}
@@ -470,7 +478,7 @@ func TestHandleDrainRetry_ShortTimeout_120Seconds(t *testing.T) {
 	ctx := context.Background()
 
 	// With 120s timeout, should be stuck after 130s
-	result := HandleDrainRetry(ctx, c, node, 120, 0)
+	result := HandleDrainRetry(ctx, c, node, 120, 0, nil)
 	if !result.SetDrainStuck {
 		t.Error("expected SetDrainStuck=true after 130s with 120s timeout")
 	}
@@ -480,7 +488,7 @@ func TestHandleDrainRetry_ShortTimeout_120Seconds(t *testing.T) {
 	node.Annotations[annotations.DrainStartedAt] = drainStart.Format(time.RFC3339)
 	_ = c.Update(ctx, node)
 
-	result = HandleDrainRetry(ctx, c, node, 120, 0)
+	result = HandleDrainRetry(ctx, c, node, 120, 0, nil)
 	if result.SetDrainStuck {
 		t.Error("expected SetDrainStuck=false at 110s with 120s timeout")
 	}
@@ -506,7 +514,7 @@ func TestHandleDrainRetry_ShortTimeout_300Seconds(t *testing.T) {
 	ctx := context.Background()
 
 	// With 300s (5min) timeout, should be stuck after 310s
-	result := HandleDrainRetry(ctx, c, node, 300, 0)
+	result := HandleDrainRetry(ctx, c, node, 300, 0, nil)
 	if !result.SetDrainStuck {
 		t.Error("expected SetDrainStuck=true after 310s with 300s timeout")
 	}
@@ -516,7 +524,7 @@ func TestHandleDrainRetry_ShortTimeout_300Seconds(t *testing.T) {
 	node.Annotations[annotations.DrainStartedAt] = drainStart.Format(time.RFC3339)
 	_ = c.Update(ctx, node)
 
-	result = HandleDrainRetry(ctx, c, node, 300, 0)
+	result = HandleDrainRetry(ctx, c, node, 300, 0, nil)
 	if result.SetDrainStuck {
 		t.Error("expected SetDrainStuck=false at 290s with 300s timeout")
 	}
@@ -553,7 +561,7 @@ func TestHandleDrainRetry_TimeoutBoundary(t *testing.T) {
 			}
 
 			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(node).Build()
-			result := HandleDrainRetry(context.Background(), c, node, tt.timeoutSeconds, 0)
+			result := HandleDrainRetry(context.Background(), c, node, tt.timeoutSeconds, 0, nil)
 
 			if result.SetDrainStuck != tt.wantStuck {
 				t.Errorf("SetDrainStuck = %v, want %v", result.SetDrainStuck, tt.wantStuck)
@@ -615,7 +623,7 @@ func TestHandleDrainRetry_ConfigurableRequeueInterval(t *testing.T) {
 			}
 
 			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(node).Build()
-			result := HandleDrainRetry(context.Background(), c, node, tt.timeoutSeconds, tt.retrySeconds)
+			result := HandleDrainRetry(context.Background(), c, node, tt.timeoutSeconds, tt.retrySeconds, nil)
 
 			if result.SetDrainStuck != tt.wantStuck {
 				t.Errorf("SetDrainStuck = %v, want %v", result.SetDrainStuck, tt.wantStuck)
@@ -651,7 +659,7 @@ func TestHandleDrainRetry_CustomRetryInterval(t *testing.T) {
 	ctx := context.Background()
 
 	// With custom 20s retry interval
-	result := HandleDrainRetry(ctx, c, node, 300, 20)
+	result := HandleDrainRetry(ctx, c, node, 300, 20, nil)
 
 	// Should use the specified 20s interval
 	if result.RequeueAfter != 20*time.Second {
@@ -981,3 +989,509 @@ func TestFilterEvictablePods_ExcludesMCOMixed(t *testing.T) {
 		t.Error("MCO pods should not be in evictable list")
 	}
 }
+
+func TestDrainNode_DisableEvictionDeletesPods(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+	}
+
+	workload := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Spec:       corev1.PodSpec{NodeName: "test-node"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	mcoPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: MCONamespace},
+		Spec:       corev1.PodSpec{NodeName: "test-node"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(node, workload, mcoPod).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+			p := obj.(*corev1.Pod)
+			return []string{p.Spec.NodeName}
+		}).
+		Build()
+	ctx := context.Background()
+
+	config := DrainConfig{GracePeriod: -1, IgnoreDS: true, DeleteOrphans: true, DisableEviction: true}
+	if _, err := DrainNode(ctx, c, node, config); err != nil {
+		t.Fatalf("DrainNode() error = %v", err)
+	}
+
+	if err := c.Get(ctx, client.ObjectKeyFromObject(workload), &corev1.Pod{}); err == nil {
+		t.Error("expected workload pod to be deleted")
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(mcoPod), &corev1.Pod{}); err != nil {
+		t.Errorf("expected MCO pod to be kept, got %v", err)
+	}
+}
+
+func TestDeletePod_NotFound(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "gone", Namespace: "default"}}
+
+	if err := DeletePod(context.Background(), c, pod, 30); err != nil {
+		t.Errorf("DeletePod() error = %v, want nil for missing pod", err)
+	}
+}
+
+func TestDrainNode_EvictionTimeoutBoundsEachPod(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+	}
+	workload := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Spec:       corev1.PodSpec{NodeName: "test-node"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(node, workload).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+			p := obj.(*corev1.Pod)
+			return []string{p.Spec.NodeName}
+		}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(ctx context.Context, _ client.WithWatch, _ client.Object, _ ...client.DeleteOption) error {
+				// Simulate an API server that never answers.
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		}).
+		Build()
+
+	config := DrainConfig{GracePeriod: -1, DeleteOrphans: true, DisableEviction: true, EvictionTimeout: 50 * time.Millisecond}
+
+	start := time.Now()
+	_, err := DrainNode(context.Background(), c, node, config)
+	if err == nil {
+		t.Fatal("DrainNode() error = nil, want timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("DrainNode() took %v, eviction timeout not honored", elapsed)
+	}
+}
+
+func TestDrainDuration(t *testing.T) {
+	start := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		started   string
+		completed string
+		want      time.Duration
+		wantOK    bool
+	}{
+		{"both set", start.Format(time.RFC3339), start.Add(3 * time.Minute).Format(time.RFC3339), 3 * time.Minute, true},
+		{"not completed", start.Format(time.RFC3339), "", 0, false},
+		{"not started", "", start.Format(time.RFC3339), 0, false},
+		{"completed before start", start.Format(time.RFC3339), start.Add(-time.Minute).Format(time.RFC3339), 0, false},
+		{"invalid", "garbage", start.Format(time.RFC3339), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ann := map[string]string{
+				annotations.DrainStartedAt:   tt.started,
+				annotations.DrainCompletedAt: tt.completed,
+			}
+			got, _, ok := DrainDuration(ann)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("DrainDuration() = %v, %v; want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestDrainNode_MaxEvictions(t *testing.T) {
+	scheme := newTestScheme()
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+	objs := []client.Object{node}
+	for _, name := range []string{"a", "b", "c"} {
+		objs = append(objs, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: "test-node"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		})
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		}).
+		Build()
+	ctx := context.Background()
+
+	config := DrainConfig{GracePeriod: -1, DeleteOrphans: true, DisableEviction: true, MaxEvictions: 2}
+	removed, err := DrainNode(ctx, c, node, config)
+	if err != nil {
+		t.Fatalf("DrainNode() error = %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("removed = %d, want 2", removed)
+	}
+
+	remaining, err := RemainingEvictablePods(ctx, c, node, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 1 {
+		t.Errorf("remaining pods = %d, want 1", len(remaining))
+	}
+}
+
+func TestDrainNode_EvictionConcurrency(t *testing.T) {
+	scheme := newTestScheme()
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+	objs := []client.Object{node}
+	for _, name := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		objs = append(objs, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: "test-node"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		})
+	}
+
+	var mu sync.Mutex
+	inFlight, peak := 0, 0
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				mu.Lock()
+				inFlight++
+				peak = max(peak, inFlight)
+				mu.Unlock()
+				time.Sleep(20 * time.Millisecond)
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+				return c.Delete(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	config := DrainConfig{GracePeriod: -1, DeleteOrphans: true, DisableEviction: true, EvictionConcurrency: 3, MaxEvictions: 5}
+	removed, err := DrainNode(context.Background(), c, node, config)
+	if err != nil {
+		t.Fatalf("DrainNode() error = %v", err)
+	}
+	if removed != 5 {
+		t.Errorf("removed = %d, want 5", removed)
+	}
+	if peak < 2 || peak > 3 {
+		t.Errorf("peak concurrent removals = %d, want 2..3", peak)
+	}
+}
+
+func TestRemainingEvictionBudget(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{}
+	if got := RemainingEvictionBudget(pool, "rev-1"); got != -1 {
+		t.Errorf("no cap: budget = %d, want -1", got)
+	}
+
+	pool.Spec.Rollout.MaxPodEvictions = 10
+	if got := RemainingEvictionBudget(pool, "rev-1"); got != 10 {
+		t.Errorf("fresh rollout: budget = %d, want 10", got)
+	}
+
+	RecordPodEvictions(pool, "rev-1", 4)
+	RecordPodEvictions(pool, "rev-1", 7)
+	if pool.Status.PodEvictions.Count != 11 {
+		t.Errorf("count = %d, want 11", pool.Status.PodEvictions.Count)
+	}
+	if got := RemainingEvictionBudget(pool, "rev-1"); got != 0 {
+		t.Errorf("exhausted: budget = %d, want 0", got)
+	}
+
+	// A new revision starts a new count.
+	if got := RemainingEvictionBudget(pool, "rev-2"); got != 10 {
+		t.Errorf("new revision: budget = %d, want 10", got)
+	}
+	RecordPodEvictions(pool, "rev-2", 1)
+	if ev := pool.Status.PodEvictions; ev.Revision != "rev-2" || ev.Count != 1 {
+		t.Errorf("PodEvictions = %+v, want rev-2/1", ev)
+	}
+}
+
+func TestFilterEvictablePods_CriticalSystemPods(t *testing.T) {
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"},
+			Spec:       corev1.PodSpec{PriorityClassName: "system-cluster-critical"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "metrics", Namespace: "kube-system"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec:       corev1.PodSpec{PriorityClassName: "system-node-critical"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	}
+
+	result := FilterEvictablePods(pods, DrainConfig{DeleteOrphans: true})
+	if len(result) != 2 || result[0].Name != "metrics" || result[1].Name != "app" {
+		t.Errorf("expected metrics and app to be evictable, got %v", result)
+	}
+
+	result = FilterEvictablePods(pods, DrainConfig{DeleteOrphans: true, EvictCriticalPods: true})
+	if len(result) != 3 {
+		t.Errorf("expected all 3 pods evictable with EvictCriticalPods, got %d", len(result))
+	}
+}
+
+func TestFilterEvictablePods_SafeToEvict(t *testing.T) {
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "pinned",
+				Namespace:   "default",
+				Annotations: map[string]string{SafeToEvictAnnotation: "false"},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "movable",
+				Namespace:   "default",
+				Annotations: map[string]string{SafeToEvictAnnotation: "true"},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	}
+
+	result := FilterEvictablePods(pods, DrainConfig{DeleteOrphans: true})
+	if len(result) != 2 {
+		t.Errorf("expected both pods evictable by default, got %d", len(result))
+	}
+
+	result = FilterEvictablePods(pods, DrainConfig{DeleteOrphans: true, RespectSafeToEvict: true})
+	if len(result) != 1 || result[0].Name != "movable" {
+		t.Errorf("expected only movable to be evictable, got %v", result)
+	}
+}
+
+func TestSplitEmptyDirPods(t *testing.T) {
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "scratch", Namespace: "default"},
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{{
+					Name:         "cache",
+					VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+				}},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "plain", Namespace: "default"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	}
+
+	// emptyDir pods are still pods drain has to move off the node.
+	if result := FilterEvictablePods(pods, DrainConfig{DeleteOrphans: true}); len(result) != 2 {
+		t.Errorf("expected both pods left to drain, got %d", len(result))
+	}
+
+	rest, blocked := splitEmptyDirPods(pods, DrainConfig{DeleteOrphans: true})
+	if len(rest) != 1 || rest[0].Name != "plain" {
+		t.Errorf("rest = %v, want [plain]", rest)
+	}
+	if !reflect.DeepEqual(blocked, []string{"default/scratch"}) {
+		t.Errorf("blocked = %v, want [default/scratch]", blocked)
+	}
+
+	rest, blocked = splitEmptyDirPods(pods, DrainConfig{DeleteOrphans: true, DeleteEmptyDirData: true})
+	if len(rest) != 2 || blocked != nil {
+		t.Errorf("with DeleteEmptyDirData: rest = %d pods, blocked = %v, want 2 and none", len(rest), blocked)
+	}
+}
+
+func TestCanDrainNode(t *testing.T) {
+	scheme := newTestScheme()
+	_ = policyv1.AddToScheme(scheme)
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+	pod := func(name, app string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": app}},
+			Spec:       corev1.PodSpec{NodeName: "test-node"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	pdb := func(app string, allowed int32) *policyv1.PodDisruptionBudget {
+		return &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: app, Namespace: "default"},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}}},
+			Status:     policyv1.PodDisruptionBudgetStatus{DisruptionsAllowed: allowed},
+		}
+	}
+	config := DrainConfig{DeleteOrphans: true}
+
+	tests := []struct {
+		name   string
+		objs   []client.Object
+		config DrainConfig
+		want   bool
+	}{
+		{"no pods", nil, config, true},
+		{"no PDB", []client.Object{pod("web-1", "web")}, config, true},
+		{"all pods blocked", []client.Object{pod("web-1", "web"), pod("db-1", "db"), pdb("web", 0), pdb("db", 0)}, config, false},
+		{"one pod evictable", []client.Object{pod("web-1", "web"), pod("db-1", "db"), pdb("web", 0), pdb("db", 1)}, config, true},
+		{"PDB in other namespace", []client.Object{pod("web-1", "web"), &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "other"},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+		}}, config, true},
+		{"eviction disabled", []client.Object{pod("web-1", "web"), pdb("web", 0)}, DrainConfig{DeleteOrphans: true, DisableEviction: true}, true},
+		{"empty selector matches every pod", []client.Object{pod("web-1", "web"), pod("db-1", "db"), &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "all", Namespace: "default"},
+			Spec:       policyv1.PodDisruptionBudgetSpec{Selector: &metav1.LabelSelector{}},
+		}}, config, false},
+		{"nil selector matches no pod", []client.Object{pod("web-1", "web"), &policyv1.PodDisruptionBudget{
+			ObjectMeta: metav1.ObjectMeta{Name: "none", Namespace: "default"},
+		}}, config, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(append([]client.Object{node}, tt.objs...)...).
+				WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+					return []string{obj.(*corev1.Pod).Spec.NodeName}
+				}).
+				Build()
+			got, err := CanDrainNode(context.Background(), c, node, tt.config)
+			if err != nil {
+				t.Fatalf("CanDrainNode() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("CanDrainNode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDrainNode_PDBBlockedListsAllPods(t *testing.T) {
+	scheme := newTestScheme()
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}}
+	objs := []client.Object{node}
+	for _, name := range []string{"a", "b"} {
+		objs = append(objs, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: "test-node"},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		})
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+				return apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10)
+			},
+		}).
+		Build()
+
+	_, err := DrainNode(context.Background(), c, node, DrainConfig{GracePeriod: -1, DeleteOrphans: true})
+	var blocked *PDBBlockedError
+	if !errors.As(err, &blocked) {
+		t.Fatalf("DrainNode() error = %v, want PDBBlockedError", err)
+	}
+	if !reflect.DeepEqual(blocked.Pods, []string{"default/a", "default/b"}) {
+		t.Errorf("blocked pods = %v, want both", blocked.Pods)
+	}
+
+	result := HandleDrainRetry(context.Background(), c, node, DefaultDrainTimeoutSeconds, 0, err)
+	if result.SetDrainStuck {
+		t.Error("expected drain not stuck yet")
+	}
+	got := &corev1.Node{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(node), got); err != nil {
+		t.Fatal(err)
+	}
+	if r := got.Annotations[annotations.DrainBlockedReason]; r != annotations.DrainBlockedPDB {
+		t.Errorf("drain-blocked-reason = %q, want %q", r, annotations.DrainBlockedPDB)
+	}
+
+	HandleDrainRetry(context.Background(), c, got, DefaultDrainTimeoutSeconds, 0, errors.New("connection refused"))
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(node), got); err != nil {
+		t.Fatal(err)
+	}
+	if r := got.Annotations[annotations.DrainBlockedReason]; r != annotations.DrainBlockedEvictionFailed {
+		t.Errorf("drain-blocked-reason = %q, want %q", r, annotations.DrainBlockedEvictionFailed)
+	}
+}
+
+func TestSelectNodesForUpdate_SkipsPDBBlockedNodes(t *testing.T) {
+	maxUnavailable := intstr.FromInt(1)
+	pool := &mcov1alpha1.MachineConfigPool{
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			Rollout: mcov1alpha1.RolloutConfig{MaxUnavailable: &maxUnavailable},
+		},
+	}
+	now := time.Now()
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-1", CreationTimestamp: metav1.Time{Time: now.Add(-time.Hour)}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-2", CreationTimestamp: metav1.Time{Time: now}}},
+	}
+
+	result := selectNodesForUpdate(pool, nodes, singleTarget("rev-1"), map[string]bool{"node-1": true})
+	if len(result) != 1 || result[0].Name != "node-2" {
+		t.Errorf("selected %v, want node-2 in place of the PDB-blocked node-1", result)
+	}
+
+	reasons := nodeSkipReasons(pool, nodes, nil, singleTarget("rev-1"), result, "", map[string]bool{"node-1": true})
+	if reasons["node-1"] != SkipReasonPDBBlocked {
+		t.Errorf("skip reason = %q, want %q", reasons["node-1"], SkipReasonPDBBlocked)
+	}
+}
+
+func TestFilterEvictablePods_SkipsExcludedPods(t *testing.T) {
+	controller := true
+	owner := []metav1.OwnerReference{{Kind: "ReplicaSet", Controller: &controller}}
+	pods := []corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "db", OwnerReferences: owner},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "postgres", Namespace: "db", OwnerReferences: owner,
+				Labels: map[string]string{"app": "postgres"},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+	}
+	config := DrainConfig{Exclusions: []DrainExclusionRule{{
+		Namespaces:     []string{"db"},
+		LabelSelectors: []metav1.LabelSelector{{MatchLabels: map[string]string{"app": "postgres"}}},
+	}}}
+
+	result := FilterEvictablePods(pods, config)
+
+	if len(result) != 1 || result[0].Name != "web" {
+		t.Fatalf("expected only web pod, got %v", result)
+	}
+}
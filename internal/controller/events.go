@@ -17,6 +17,7 @@ limitations under the License.
 package controller
 
 import (
+	"fmt"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -43,6 +44,9 @@ const (
 	// ReasonDrainStuck indicates drain has exceeded timeout.
 	ReasonDrainStuck = "DrainStuck"
 
+	// ReasonCordonStuck indicates a node stayed cordoned past the cordon timeout.
+	ReasonCordonStuck = "CordonStuck"
+
 	// ReasonApplyTimeout indicates node apply has exceeded timeout.
 	ReasonApplyTimeout = "ApplyTimeout"
 
@@ -60,8 +64,73 @@ const (
 
 	// ReasonDrainFailed indicates a drain attempt failed (will retry).
 	ReasonDrainFailed = "DrainFailed"
+
+	// ReasonNodeUpdateFailed indicates the controller failed to process a node (will retry).
+	ReasonNodeUpdateFailed = "NodeUpdateFailed"
+
+	// ReasonDrainConfigInvalid indicates the drain ConfigMap could not be used.
+	ReasonDrainConfigInvalid = "DrainConfigInvalid"
+
+	// ReasonRetryFailed indicates failed nodes were reset for another attempt.
+	ReasonRetryFailed = "RetryFailed"
+
+	// ReasonDrainBlocked indicates pods on a node are blocking its drain.
+	ReasonDrainBlocked = "DrainBlocked"
+
+	// ReasonDrainForced indicates pods blocking a stuck drain were
+	// force-deleted.
+	ReasonDrainForced = "DrainForced"
+
+	// ReasonNodePoolChanged indicates a node was relabeled into the pool
+	// from another pool.
+	ReasonNodePoolChanged = "NodePoolChanged"
+
+	// ReasonRolledBack indicates AutoRollback gave up a failing target
+	// revision for the last successful one.
+	ReasonRolledBack = "RolledBack"
 )
 
+// Event verbosity levels, see MachineConfigPoolSpec.EventVerbosity.
+const (
+	EventVerbosityAll     = "All"
+	EventVerbosityReduced = "Reduced"
+	EventVerbosityErrors  = "Errors"
+)
+
+// eventLevel ranks event reasons: per-node lifecycle events are dropped
+// first, rollout milestones next, and problem events are always emitted.
+var eventLevel = map[string]int{
+	ReasonNodeCordon:          0,
+	ReasonNodeDrain:           0,
+	ReasonDrainComplete:       0,
+	ReasonNodeUncordon:        0,
+	ReasonRolloutBatch:        1,
+	ReasonRolloutComplete:     1,
+	ReasonPoolOverlapResolved: 1,
+	ReasonRetryFailed:         1,
+	ReasonNodePoolChanged:     1,
+}
+
+// eventEnabled reports whether pool's EventVerbosity allows reason.
+// Reasons not listed in eventLevel report problems and are always enabled.
+func eventEnabled(pool *mcov1alpha1.MachineConfigPool, reason string) bool {
+	level, ok := eventLevel[reason]
+	if !ok {
+		return true
+	}
+	switch pool.Spec.EventVerbosity {
+	case EventVerbosityReduced:
+		return level >= 1
+	case EventVerbosityErrors:
+		return false
+	default:
+		return true
+	}
+}
+
+// maxBlockingPodsInEvent caps how many pod names a DrainBlocked event lists.
+const maxBlockingPodsInEvent = 10
+
 // EventRecorder provides methods to emit Kubernetes events for rolling update lifecycle.
 type EventRecorder struct {
 	recorder record.EventRecorder
@@ -83,7 +152,7 @@ func (e *EventRecorder) PoolOverlapDetected(pool *mcov1alpha1.MachineConfigPool,
 
 // PoolOverlapResolved emits a normal event when pool overlap is resolved.
 func (e *EventRecorder) PoolOverlapResolved(pool *mcov1alpha1.MachineConfigPool) {
-	if e.recorder == nil {
+	if e.recorder == nil || !eventEnabled(pool, ReasonPoolOverlapResolved) {
 		return
 	}
 	e.recorder.Event(pool, corev1.EventTypeNormal, ReasonPoolOverlapResolved,
@@ -93,7 +162,7 @@ func (e *EventRecorder) PoolOverlapResolved(pool *mcov1alpha1.MachineConfigPool)
 // NodeCordonStarted emits a WARNING event when a node is cordoned for update.
 // Warning because cordon is a destructive action - node becomes unschedulable.
 func (e *EventRecorder) NodeCordonStarted(pool *mcov1alpha1.MachineConfigPool, nodeName string) {
-	if e.recorder == nil {
+	if e.recorder == nil || !eventEnabled(pool, ReasonNodeCordon) {
 		return
 	}
 	e.recorder.Eventf(pool, corev1.EventTypeWarning, ReasonNodeCordon,
@@ -103,7 +172,7 @@ func (e *EventRecorder) NodeCordonStarted(pool *mcov1alpha1.MachineConfigPool, n
 // NodeDrainStarted emits a WARNING event when drain is started on a node.
 // Warning because drain is a destructive action - pods are being evicted.
 func (e *EventRecorder) NodeDrainStarted(pool *mcov1alpha1.MachineConfigPool, nodeName string) {
-	if e.recorder == nil {
+	if e.recorder == nil || !eventEnabled(pool, ReasonNodeDrain) {
 		return
 	}
 	e.recorder.Eventf(pool, corev1.EventTypeWarning, ReasonNodeDrain,
@@ -119,6 +188,52 @@ func (e *EventRecorder) DrainStuck(pool *mcov1alpha1.MachineConfigPool, nodeName
 		"Drain stuck on node %s, timeout exceeded", nodeName)
 }
 
+// CordonStuck emits a warning event when a node stays cordoned past the
+// cordon timeout without its drain starting.
+func (e *EventRecorder) CordonStuck(pool *mcov1alpha1.MachineConfigPool, nodeName string, uncordoned bool) {
+	if e.recorder == nil {
+		return
+	}
+	action := "still cordoned"
+	if uncordoned {
+		action = "uncordoned"
+	}
+	e.recorder.Eventf(pool, corev1.EventTypeWarning, ReasonCordonStuck,
+		"Node %s stuck in cordon phase past timeout, %s", nodeName, action)
+}
+
+// DrainBlocked emits a warning event on the node naming the pods that keep
+// its drain from completing, so they show up in `kubectl describe node`.
+func (e *EventRecorder) DrainBlocked(node *corev1.Node, pods []string) {
+	if e.recorder == nil || len(pods) == 0 {
+		return
+	}
+	listed := pods
+	suffix := ""
+	if len(listed) > maxBlockingPodsInEvent {
+		suffix = fmt.Sprintf(" and %d more", len(listed)-maxBlockingPodsInEvent)
+		listed = listed[:maxBlockingPodsInEvent]
+	}
+	e.recorder.Eventf(node, corev1.EventTypeWarning, ReasonDrainBlocked,
+		"Drain blocked by %d pods: %s%s", len(pods), strings.Join(listed, ", "), suffix)
+}
+
+// DrainForced emits a warning event listing the pods force-deleted from a
+// drain-stuck node.
+func (e *EventRecorder) DrainForced(pool *mcov1alpha1.MachineConfigPool, nodeName string, pods []string) {
+	if e.recorder == nil || len(pods) == 0 {
+		return
+	}
+	listed := pods
+	suffix := ""
+	if len(listed) > maxBlockingPodsInEvent {
+		suffix = fmt.Sprintf(" and %d more", len(listed)-maxBlockingPodsInEvent)
+		listed = listed[:maxBlockingPodsInEvent]
+	}
+	e.recorder.Eventf(pool, corev1.EventTypeWarning, ReasonDrainForced,
+		"Drain stuck on node %s, force-deleted %d pods: %s%s", nodeName, len(pods), strings.Join(listed, ", "), suffix)
+}
+
 // ApplyTimeout emits a warning event when node apply exceeds timeout.
 func (e *EventRecorder) ApplyTimeout(pool *mcov1alpha1.MachineConfigPool, nodeName string, timeoutSeconds int) {
 	if e.recorder == nil {
@@ -130,7 +245,7 @@ func (e *EventRecorder) ApplyTimeout(pool *mcov1alpha1.MachineConfigPool, nodeNa
 
 // DrainComplete emits a normal event when drain completes successfully.
 func (e *EventRecorder) DrainComplete(pool *mcov1alpha1.MachineConfigPool, nodeName string) {
-	if e.recorder == nil {
+	if e.recorder == nil || !eventEnabled(pool, ReasonDrainComplete) {
 		return
 	}
 	e.recorder.Eventf(pool, corev1.EventTypeNormal, ReasonDrainComplete,
@@ -139,7 +254,7 @@ func (e *EventRecorder) DrainComplete(pool *mcov1alpha1.MachineConfigPool, nodeN
 
 // NodeUncordoned emits a normal event when a node is uncordoned after update.
 func (e *EventRecorder) NodeUncordoned(pool *mcov1alpha1.MachineConfigPool, nodeName string) {
-	if e.recorder == nil {
+	if e.recorder == nil || !eventEnabled(pool, ReasonNodeUncordon) {
 		return
 	}
 	e.recorder.Eventf(pool, corev1.EventTypeNormal, ReasonNodeUncordon,
@@ -148,7 +263,7 @@ func (e *EventRecorder) NodeUncordoned(pool *mcov1alpha1.MachineConfigPool, node
 
 // RolloutBatchStarted emits a normal event when a new batch of nodes starts updating.
 func (e *EventRecorder) RolloutBatchStarted(pool *mcov1alpha1.MachineConfigPool, nodeCount int, nodeNames []string) {
-	if e.recorder == nil {
+	if e.recorder == nil || !eventEnabled(pool, ReasonRolloutBatch) {
 		return
 	}
 	if len(nodeNames) <= 3 {
@@ -163,7 +278,7 @@ func (e *EventRecorder) RolloutBatchStarted(pool *mcov1alpha1.MachineConfigPool,
 
 // RolloutComplete emits a normal event when all nodes have been updated.
 func (e *EventRecorder) RolloutComplete(pool *mcov1alpha1.MachineConfigPool) {
-	if e.recorder == nil {
+	if e.recorder == nil || !eventEnabled(pool, ReasonRolloutComplete) {
 		return
 	}
 	e.recorder.Event(pool, corev1.EventTypeNormal, ReasonRolloutComplete,
@@ -179,6 +294,58 @@ func (e *EventRecorder) DrainFailed(pool *mcov1alpha1.MachineConfigPool, nodeNam
 		"Drain failed on node %s: %s (will retry)", nodeName, reason)
 }
 
+// NodeUpdateFailed emits a warning event when processing a node fails (will be retried).
+func (e *EventRecorder) NodeUpdateFailed(pool *mcov1alpha1.MachineConfigPool, nodeName string, err error) {
+	if e.recorder == nil {
+		return
+	}
+	e.recorder.Eventf(pool, corev1.EventTypeWarning, ReasonNodeUpdateFailed,
+		"Failed to update node %s: %v (will retry)", nodeName, err)
+}
+
+// DrainConfigInvalid emits a warning event when the drain ConfigMap cannot be
+// used. Drains wait and no new nodes are started until it is fixed.
+func (e *EventRecorder) DrainConfigInvalid(pool *mcov1alpha1.MachineConfigPool, err error) {
+	if e == nil || e.recorder == nil {
+		return
+	}
+	e.recorder.Eventf(pool, corev1.EventTypeWarning, ReasonDrainConfigInvalid,
+		"Drain config %s/%s is invalid, drains are blocked until it is fixed: %v", MCONamespace, DrainConfigMapName, err)
+}
+
+// RetryFailedNodes emits an event when failed nodes were reset on request.
+func (e *EventRecorder) RetryFailedNodes(pool *mcov1alpha1.MachineConfigPool, nodeNames []string) {
+	if e.recorder == nil || !eventEnabled(pool, ReasonRetryFailed) {
+		return
+	}
+	e.recorder.Eventf(pool, corev1.EventTypeNormal, ReasonRetryFailed,
+		"Retrying %d failed nodes: %s", len(nodeNames), strings.Join(nodeNames, ", "))
+}
+
+// NodePoolChanged emits a normal event when the pool takes over a node
+// relabeled out of previous.
+func (e *EventRecorder) NodePoolChanged(pool *mcov1alpha1.MachineConfigPool, nodeName, previous string) {
+	if e.recorder == nil || !eventEnabled(pool, ReasonNodePoolChanged) {
+		return
+	}
+	policy := pool.Spec.Rollout.PoolTransferPolicy
+	if policy == "" {
+		policy = PoolTransferRelease
+	}
+	e.recorder.Eventf(pool, corev1.EventTypeNormal, ReasonNodePoolChanged,
+		"Node %s moved from pool %s (%s)", nodeName, previous, policy)
+}
+
+// RolledBack emits a warning event when AutoRollback replaces the target
+// revision from with the last successful revision to.
+func (e *EventRecorder) RolledBack(pool *mcov1alpha1.MachineConfigPool, from, to string) {
+	if e.recorder == nil {
+		return
+	}
+	e.recorder.Eventf(pool, corev1.EventTypeWarning, ReasonRolledBack,
+		"Pool degraded while rolling out %s, rolling back to %s", from, to)
+}
+
 // CreateEventRecorder creates an EventRecorder from a manager's scheme.
 // This is a helper for setting up the recorder during manager initialization.
 func CreateEventRecorder(mgr interface {
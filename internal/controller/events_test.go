@@ -17,9 +17,11 @@ limitations under the License.
 package controller
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 
@@ -116,6 +118,57 @@ func TestEventRecorder_DrainStuck(t *testing.T) {
 	}
 }
 
+func TestEventRecorder_DrainBlocked(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	er := NewEventRecorder(recorder)
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}
+
+	er.DrainBlocked(node, []string{"default/web-0", "db/postgres-1"})
+
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, ReasonDrainBlocked) {
+			t.Errorf("expected reason %s, got %s", ReasonDrainBlocked, event)
+		}
+		if !strings.Contains(event, "default/web-0") || !strings.Contains(event, "db/postgres-1") {
+			t.Errorf("expected pod names in event, got %s", event)
+		}
+		if !strings.Contains(event, "Warning") {
+			t.Errorf("expected Warning type, got %s", event)
+		}
+	default:
+		t.Error("expected event to be recorded")
+	}
+
+	// No pods, no event.
+	er.DrainBlocked(node, nil)
+	select {
+	case event := <-recorder.Events:
+		t.Errorf("unexpected event %s", event)
+	default:
+	}
+}
+
+func TestEventRecorder_DrainBlocked_CapsPodList(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	er := NewEventRecorder(recorder)
+
+	var pods []string
+	for i := 0; i < maxBlockingPodsInEvent+3; i++ {
+		pods = append(pods, fmt.Sprintf("default/pod-%d", i))
+	}
+	er.DrainBlocked(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}}, pods)
+
+	event := <-recorder.Events
+	if !strings.Contains(event, "and 3 more") {
+		t.Errorf("expected truncated pod list, got %s", event)
+	}
+	if strings.Contains(event, fmt.Sprintf("pod-%d", maxBlockingPodsInEvent)) {
+		t.Errorf("expected pods beyond the cap to be omitted, got %s", event)
+	}
+}
+
 func TestEventRecorder_RolloutBatchStarted(t *testing.T) {
 	recorder := record.NewFakeRecorder(10)
 	er := NewEventRecorder(recorder)
@@ -172,6 +225,7 @@ func TestEventRecorder_NilRecorder(t *testing.T) {
 	er.PoolOverlapDetected(pool, []string{"node-1"})
 	er.NodeCordonStarted(pool, "node-1")
 	er.DrainStuck(pool, "node-1")
+	er.DrainBlocked(&corev1.Node{}, []string{"default/pod"})
 	er.DrainFailed(pool, "node-1", "test reason")
 	er.RolloutBatchStarted(pool, 1, []string{"node-1"})
 	er.RolloutComplete(pool)
@@ -269,3 +323,39 @@ func TestEventRecorder_DrainFailed(t *testing.T) {
 		t.Error("expected event to be recorded")
 	}
 }
+
+func TestEventRecorder_Verbosity(t *testing.T) {
+	tests := []struct {
+		verbosity string
+		want      []string
+	}{
+		{"", []string{ReasonNodeCordon, ReasonRolloutBatch, ReasonDrainStuck}},
+		{EventVerbosityAll, []string{ReasonNodeCordon, ReasonRolloutBatch, ReasonDrainStuck}},
+		{EventVerbosityReduced, []string{ReasonRolloutBatch, ReasonDrainStuck}},
+		{EventVerbosityErrors, []string{ReasonDrainStuck}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.verbosity, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			er := NewEventRecorder(recorder)
+			pool := &mcov1alpha1.MachineConfigPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+				Spec:       mcov1alpha1.MachineConfigPoolSpec{EventVerbosity: tt.verbosity},
+			}
+
+			er.NodeCordonStarted(pool, "node-1")
+			er.RolloutBatchStarted(pool, 1, []string{"node-1"})
+			er.DrainStuck(pool, "node-1")
+
+			if len(recorder.Events) != len(tt.want) {
+				t.Fatalf("got %d events, want %d", len(recorder.Events), len(tt.want))
+			}
+			for _, reason := range tt.want {
+				if event := <-recorder.Events; !strings.Contains(event, reason) {
+					t.Errorf("expected reason %s, got %s", reason, event)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ReconcileFairness selects the order in which pending pools are reconciled.
+type ReconcileFairness string
+
+const (
+	// FairnessNone keeps controller-runtime's default FIFO queue.
+	FairnessNone ReconcileFairness = ""
+
+	// FairnessRoundRobin serves pending pools in name order, continuing after
+	// the pool served last, so every pending pool is reconciled once per cycle
+	// no matter how often others are re-enqueued.
+	FairnessRoundRobin ReconcileFairness = "round-robin"
+
+	// FairnessOldestPendingFirst serves the pool that has been waiting longest,
+	// with ties broken by name.
+	FairnessOldestPendingFirst ReconcileFairness = "oldest-pending-first"
+)
+
+// ParseReconcileFairness validates a fairness mode name.
+func ParseReconcileFairness(s string) (ReconcileFairness, error) {
+	switch m := ReconcileFairness(s); m {
+	case FairnessNone, FairnessRoundRobin, FairnessOldestPendingFirst:
+		return m, nil
+	default:
+		return "", fmt.Errorf("unknown reconcile fairness %q (want %q or %q)",
+			s, FairnessRoundRobin, FairnessOldestPendingFirst)
+	}
+}
+
+// newFairQueue returns the controller work queue for mode. The workqueue
+// deduplicates requests and serializes calls, so the ordering queue only
+// decides which pending pool is popped next.
+func newFairQueue(
+	mode ReconcileFairness,
+	name string,
+	rateLimiter workqueue.TypedRateLimiter[reconcile.Request],
+) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+	queue := workqueue.NewTypedWithConfig(workqueue.TypedQueueConfig[reconcile.Request]{
+		Name:  name,
+		Queue: newFairOrder(mode, time.Now),
+	})
+	delaying := workqueue.NewTypedDelayingQueueWithConfig(workqueue.TypedDelayingQueueConfig[reconcile.Request]{
+		Name:  name,
+		Queue: queue,
+	})
+	return workqueue.NewTypedRateLimitingQueueWithConfig(rateLimiter,
+		workqueue.TypedRateLimitingQueueConfig[reconcile.Request]{
+			Name:          name,
+			DelayingQueue: delaying,
+		})
+}
+
+// fairOrder implements workqueue.Queue with a fairness-aware Pop.
+// It is only called with the workqueue's lock held.
+type fairOrder struct {
+	mode         ReconcileFairness
+	now          func() time.Time
+	items        []reconcile.Request
+	pendingSince map[reconcile.Request]time.Time
+	last         string
+}
+
+func newFairOrder(mode ReconcileFairness, now func() time.Time) *fairOrder {
+	return &fairOrder{
+		mode:         mode,
+		now:          now,
+		pendingSince: make(map[reconcile.Request]time.Time),
+	}
+}
+
+// Touch keeps the original pending time when a request is re-added.
+func (q *fairOrder) Touch(reconcile.Request) {}
+
+func (q *fairOrder) Push(item reconcile.Request) {
+	q.items = append(q.items, item)
+	q.pendingSince[item] = q.now()
+}
+
+func (q *fairOrder) Len() int {
+	return len(q.items)
+}
+
+func (q *fairOrder) Pop() reconcile.Request {
+	i := q.next()
+	item := q.items[i]
+	q.items = append(q.items[:i], q.items[i+1:]...)
+	delete(q.pendingSince, item)
+	q.last = item.String()
+	return item
+}
+
+// next returns the index of the item to pop.
+func (q *fairOrder) next() int {
+	best := 0
+	for i := 1; i < len(q.items); i++ {
+		if q.before(q.items[i], q.items[best]) {
+			best = i
+		}
+	}
+	return best
+}
+
+// before reports whether a should be served before b.
+func (q *fairOrder) before(a, b reconcile.Request) bool {
+	an, bn := a.String(), b.String()
+	switch q.mode {
+	case FairnessRoundRobin:
+		// Names after the last served pool come first, then wrap around.
+		aAfter, bAfter := an > q.last, bn > q.last
+		if aAfter != bAfter {
+			return aAfter
+		}
+		return an < bn
+	case FairnessOldestPendingFirst:
+		at, bt := q.pendingSince[a], q.pendingSince[b]
+		if !at.Equal(bt) {
+			return at.Before(bt)
+		}
+		return an < bn
+	default:
+		// FIFO: keep insertion order.
+		return false
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func poolRequest(name string) reconcile.Request {
+	return reconcile.Request{NamespacedName: types.NamespacedName{Name: name}}
+}
+
+func TestFairOrder_RoundRobin(t *testing.T) {
+	q := newFairOrder(FairnessRoundRobin, time.Now)
+
+	// "a" is re-enqueued after every reconcile; "b" and "c" must still be served.
+	q.Push(poolRequest("a"))
+	q.Push(poolRequest("c"))
+	q.Push(poolRequest("b"))
+
+	var got []string
+	for i := 0; i < 5; i++ {
+		item := q.Pop()
+		got = append(got, item.Name)
+		if item.Name == "a" {
+			q.Push(poolRequest("a"))
+		}
+		if q.Len() == 0 {
+			break
+		}
+	}
+
+	want := []string{"a", "b", "c", "a", "a"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("order = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestFairOrder_OldestPendingFirst(t *testing.T) {
+	now := time.Unix(1000, 0)
+	q := newFairOrder(FairnessOldestPendingFirst, func() time.Time { return now })
+
+	q.Push(poolRequest("b"))
+	q.Push(poolRequest("a")) // same time as b: name breaks the tie
+	now = now.Add(time.Second)
+	q.Push(poolRequest("0-newer"))
+
+	var got []string
+	for q.Len() > 0 {
+		got = append(got, q.Pop().Name)
+	}
+	want := []string{"a", "b", "0-newer"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("order = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestNewFairQueue_Dedup(t *testing.T) {
+	q := newFairQueue(FairnessRoundRobin, "", workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer q.ShutDown()
+
+	q.Add(poolRequest("b"))
+	q.Add(poolRequest("a"))
+	q.Add(poolRequest("b"))
+	if q.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", q.Len())
+	}
+
+	item, _ := q.Get()
+	if item.Name != "a" {
+		t.Errorf("first item = %s, want a", item.Name)
+	}
+	q.Done(item)
+}
+
+func TestParseReconcileFairness(t *testing.T) {
+	for _, s := range []string{"", "round-robin", "oldest-pending-first"} {
+		if _, err := ParseReconcileFairness(s); err != nil {
+			t.Errorf("ParseReconcileFairness(%q) error = %v", s, err)
+		}
+	}
+	if _, err := ParseReconcileFairness("random"); err == nil {
+		t.Error("ParseReconcileFairness(random) should fail")
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+// summarizePool builds the fleet summary entry for a pool.
+func summarizePool(pool *mcov1alpha1.MachineConfigPool) mcov1alpha1.PoolSummary {
+	return mcov1alpha1.PoolSummary{
+		Name:                   pool.Name,
+		TargetRevision:         pool.Status.TargetRevision,
+		LastSuccessfulRevision: pool.Status.LastSuccessfulRevision,
+		MachineCount:           pool.Status.MachineCount,
+		UpdatedMachineCount:    pool.Status.UpdatedMachineCount,
+		DegradedMachineCount:   pool.Status.DegradedMachineCount,
+		Ready:                  meta.IsStatusConditionTrue(pool.Status.Conditions, mcov1alpha1.ConditionReady),
+		Updating:               meta.IsStatusConditionTrue(pool.Status.Conditions, mcov1alpha1.ConditionUpdating),
+		Degraded:               meta.IsStatusConditionTrue(pool.Status.Conditions, mcov1alpha1.ConditionDegraded),
+	}
+}
+
+// BuildFleetStatus aggregates the status of the given pools. Summaries are
+// sorted by pool name so the result is stable across reconciles.
+func BuildFleetStatus(pools []mcov1alpha1.MachineConfigPool) mcov1alpha1.MachineConfigFleetStatus {
+	status := mcov1alpha1.MachineConfigFleetStatus{PoolCount: len(pools)}
+	for i := range pools {
+		s := summarizePool(&pools[i])
+		status.Pools = append(status.Pools, s)
+		status.MachineCount += s.MachineCount
+		status.UpdatedMachineCount += s.UpdatedMachineCount
+		status.DegradedMachineCount += s.DegradedMachineCount
+		if s.Ready {
+			status.ReadyPoolCount++
+		}
+		if s.Updating {
+			status.UpdatingPoolCount++
+		}
+		if s.Degraded {
+			status.DegradedPoolCount++
+		}
+	}
+	sort.Slice(status.Pools, func(i, j int) bool {
+		return status.Pools[i].Name < status.Pools[j].Name
+	})
+	return status
+}
+
+// SyncFleetPool refreshes the MachineConfigFleet after the pool named
+// poolName was reconciled. The fleet is only rebuilt, listing every pool,
+// when that pool's summary differs from the one the fleet holds or the
+// pool was deleted; a reconcile that left the pool's status alone costs
+// two cached reads.
+func SyncFleetPool(ctx context.Context, c client.Client, poolName string) error {
+	fleet := &mcov1alpha1.MachineConfigFleet{}
+	err := c.Get(ctx, client.ObjectKey{Name: mcov1alpha1.MachineConfigFleetName}, fleet)
+	if apierrors.IsNotFound(err) {
+		return SyncFleetStatus(ctx, c)
+	}
+	if err != nil {
+		return err
+	}
+
+	var current *mcov1alpha1.PoolSummary
+	for i := range fleet.Status.Pools {
+		if fleet.Status.Pools[i].Name == poolName {
+			current = &fleet.Status.Pools[i]
+			break
+		}
+	}
+
+	pool := &mcov1alpha1.MachineConfigPool{}
+	err = c.Get(ctx, client.ObjectKey{Name: poolName}, pool)
+	switch {
+	case apierrors.IsNotFound(err):
+		if current == nil {
+			return nil
+		}
+	case err != nil:
+		return err
+	case current != nil && equality.Semantic.DeepEqual(*current, summarizePool(pool)):
+		return nil
+	}
+	return SyncFleetStatus(ctx, c)
+}
+
+// SyncFleetStatus refreshes the MachineConfigFleet named
+// MachineConfigFleetName from the current pools, creating it if needed.
+// Deleted pools drop out of the summary on the next sync.
+func SyncFleetStatus(ctx context.Context, c client.Client) error {
+	pools := &mcov1alpha1.MachineConfigPoolList{}
+	if err := c.List(ctx, pools); err != nil {
+		return fmt.Errorf("list pools: %w", err)
+	}
+	status := BuildFleetStatus(pools.Items)
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		fleet := &mcov1alpha1.MachineConfigFleet{}
+		err := c.Get(ctx, client.ObjectKey{Name: mcov1alpha1.MachineConfigFleetName}, fleet)
+		if apierrors.IsNotFound(err) {
+			fleet = &mcov1alpha1.MachineConfigFleet{
+				ObjectMeta: metav1.ObjectMeta{Name: mcov1alpha1.MachineConfigFleetName},
+			}
+			if err := c.Create(ctx, fleet); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+
+		if equality.Semantic.DeepEqual(fleet.Status, status) {
+			return nil
+		}
+		fleet.Status = status
+		return c.Status().Update(ctx, fleet)
+	})
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+func TestBuildFleetStatus(t *testing.T) {
+	pools := []mcov1alpha1.MachineConfigPool{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+			Status: mcov1alpha1.MachineConfigPoolStatus{
+				TargetRevision:       "worker-b",
+				MachineCount:         5,
+				UpdatedMachineCount:  3,
+				DegradedMachineCount: 1,
+				Conditions: []metav1.Condition{
+					{Type: mcov1alpha1.ConditionUpdating, Status: metav1.ConditionTrue},
+					{Type: mcov1alpha1.ConditionDegraded, Status: metav1.ConditionTrue},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "master"},
+			Status: mcov1alpha1.MachineConfigPoolStatus{
+				TargetRevision:         "master-a",
+				LastSuccessfulRevision: "master-a",
+				MachineCount:           3,
+				UpdatedMachineCount:    3,
+				Conditions: []metav1.Condition{
+					{Type: mcov1alpha1.ConditionReady, Status: metav1.ConditionTrue},
+				},
+			},
+		},
+	}
+
+	got := BuildFleetStatus(pools)
+	if got.PoolCount != 2 || got.ReadyPoolCount != 1 || got.UpdatingPoolCount != 1 || got.DegradedPoolCount != 1 {
+		t.Errorf("pool counts = %+v", got)
+	}
+	if got.MachineCount != 8 || got.UpdatedMachineCount != 6 || got.DegradedMachineCount != 1 {
+		t.Errorf("machine counts = %d/%d/%d, want 8/6/1", got.MachineCount, got.UpdatedMachineCount, got.DegradedMachineCount)
+	}
+	if len(got.Pools) != 2 || got.Pools[0].Name != "master" || got.Pools[1].Name != "worker" {
+		t.Fatalf("Pools = %+v, want master then worker", got.Pools)
+	}
+	if !got.Pools[0].Ready || got.Pools[0].LastSuccessfulRevision != "master-a" {
+		t.Errorf("master summary = %+v", got.Pools[0])
+	}
+	if got.Pools[1].Ready || !got.Pools[1].Updating || !got.Pools[1].Degraded {
+		t.Errorf("worker summary = %+v", got.Pools[1])
+	}
+}
+
+func TestSyncFleetStatus(t *testing.T) {
+	worker := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+	master := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "master"}}
+	r := newReconciler(worker, master)
+	ctx := context.Background()
+
+	if err := SyncFleetStatus(ctx, r.Client); err != nil {
+		t.Fatalf("SyncFleetStatus() error = %v", err)
+	}
+	fleet := &mcov1alpha1.MachineConfigFleet{}
+	if err := r.Get(ctx, client.ObjectKey{Name: mcov1alpha1.MachineConfigFleetName}, fleet); err != nil {
+		t.Fatalf("fleet not created: %v", err)
+	}
+	if fleet.Status.PoolCount != 2 {
+		t.Errorf("PoolCount = %d, want 2", fleet.Status.PoolCount)
+	}
+
+	if err := r.Delete(ctx, worker); err != nil {
+		t.Fatal(err)
+	}
+	if err := SyncFleetStatus(ctx, r.Client); err != nil {
+		t.Fatalf("SyncFleetStatus() error = %v", err)
+	}
+	if err := r.Get(ctx, client.ObjectKey{Name: mcov1alpha1.MachineConfigFleetName}, fleet); err != nil {
+		t.Fatal(err)
+	}
+	if fleet.Status.PoolCount != 1 || len(fleet.Status.Pools) != 1 || fleet.Status.Pools[0].Name != "master" {
+		t.Errorf("after delete: %+v, want only master", fleet.Status)
+	}
+}
+
+func TestSyncFleetPool_RebuildsOnlyOnChange(t *testing.T) {
+	worker := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+	master := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "master"}}
+
+	scheme := runtime.NewScheme()
+	_ = mcov1alpha1.AddToScheme(scheme)
+	var lists int
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(worker, master).
+		WithStatusSubresource(&mcov1alpha1.MachineConfigPool{}, &mcov1alpha1.MachineConfigFleet{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if _, ok := list.(*mcov1alpha1.MachineConfigPoolList); ok {
+					lists++
+				}
+				return c.List(ctx, list, opts...)
+			},
+		}).
+		Build()
+	ctx := context.Background()
+
+	sync := func(pool string, wantLists int) *mcov1alpha1.MachineConfigFleet {
+		t.Helper()
+		if err := SyncFleetPool(ctx, c, pool); err != nil {
+			t.Fatalf("SyncFleetPool(%s) error = %v", pool, err)
+		}
+		if lists != wantLists {
+			t.Errorf("after SyncFleetPool(%s): pool lists = %d, want %d", pool, lists, wantLists)
+		}
+		fleet := &mcov1alpha1.MachineConfigFleet{}
+		if err := c.Get(ctx, client.ObjectKey{Name: mcov1alpha1.MachineConfigFleetName}, fleet); err != nil {
+			t.Fatalf("get fleet: %v", err)
+		}
+		return fleet
+	}
+
+	// A missing fleet is built in full.
+	if fleet := sync("worker", 1); fleet.Status.PoolCount != 2 {
+		t.Errorf("PoolCount = %d, want 2", fleet.Status.PoolCount)
+	}
+	// Nothing changed: no list, no write.
+	sync("worker", 1)
+	sync("master", 1)
+
+	if err := c.Get(ctx, client.ObjectKey{Name: "worker"}, worker); err != nil {
+		t.Fatal(err)
+	}
+	worker.Status.MachineCount = 3
+	if err := c.Status().Update(ctx, worker); err != nil {
+		t.Fatal(err)
+	}
+	if fleet := sync("worker", 2); fleet.Status.MachineCount != 3 {
+		t.Errorf("MachineCount = %d, want 3", fleet.Status.MachineCount)
+	}
+
+	if err := c.Delete(ctx, master); err != nil {
+		t.Fatal(err)
+	}
+	if fleet := sync("master", 3); fleet.Status.PoolCount != 1 || fleet.Status.Pools[0].Name != "worker" {
+		t.Errorf("after delete: %+v, want only worker", fleet.Status)
+	}
+	// A deleted pool the fleet no longer lists needs no rebuild.
+	sync("master", 3)
+}
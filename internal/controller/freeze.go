@@ -0,0 +1,95 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+// ActiveConfigFreeze returns the active ConfigFreeze with the lowest name,
+// or nil if no freeze is in effect.
+func ActiveConfigFreeze(ctx context.Context, c client.Client) (*mcov1alpha1.ConfigFreeze, error) {
+	list := &mcov1alpha1.ConfigFreezeList{}
+	if err := c.List(ctx, list); err != nil {
+		return nil, err
+	}
+	sort.Slice(list.Items, func(i, j int) bool {
+		return list.Items[i].Name < list.Items[j].Name
+	})
+	for i := range list.Items {
+		if list.Items[i].Spec.Active {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// frozenTargetRMC returns the RMC the pool was rolling out when the freeze
+// started, or nil if the pool has no target yet.
+func frozenTargetRMC(
+	ctx context.Context,
+	c client.Client,
+	pool *mcov1alpha1.MachineConfigPool,
+) (*mcov1alpha1.RenderedMachineConfig, error) {
+	return existingRMC(ctx, c, pool.Status.TargetRevision)
+}
+
+// SetFrozenCondition sets Frozen=True naming the active freeze.
+func SetFrozenCondition(pool *mcov1alpha1.MachineConfigPool, freeze *mcov1alpha1.ConfigFreeze) {
+	message := fmt.Sprintf("ConfigFreeze %s is active", freeze.Name)
+	if freeze.Spec.Reason != "" {
+		message += ": " + freeze.Spec.Reason
+	}
+	setCondition(pool, metav1.Condition{
+		Type:               mcov1alpha1.ConditionFrozen,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ConfigFreezeActive",
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// ClearFrozenCondition sets Frozen=False if it was previously set.
+func ClearFrozenCondition(pool *mcov1alpha1.MachineConfigPool) {
+	for _, c := range pool.Status.Conditions {
+		if c.Type == mcov1alpha1.ConditionFrozen {
+			setCondition(pool, metav1.Condition{
+				Type:               mcov1alpha1.ConditionFrozen,
+				Status:             metav1.ConditionFalse,
+				Reason:             "ConfigFreezeLifted",
+				LastTransitionTime: metav1.Now(),
+			})
+			return
+		}
+	}
+}
+
+// applyFrozenCondition sets or clears Frozen depending on freeze.
+func applyFrozenCondition(pool *mcov1alpha1.MachineConfigPool, freeze *mcov1alpha1.ConfigFreeze) {
+	if freeze != nil {
+		SetFrozenCondition(pool, freeze)
+	} else {
+		ClearFrozenCondition(pool)
+	}
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/internal/renderer"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+func TestActiveConfigFreeze(t *testing.T) {
+	inactive := &mcov1alpha1.ConfigFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "a-inactive"},
+		Spec:       mcov1alpha1.ConfigFreezeSpec{Active: false},
+	}
+	active := &mcov1alpha1.ConfigFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "b-release"},
+		Spec:       mcov1alpha1.ConfigFreezeSpec{Active: true, Reason: "release"},
+	}
+
+	r := newReconciler(inactive)
+	freeze, err := ActiveConfigFreeze(context.Background(), r.Client)
+	if err != nil {
+		t.Fatalf("ActiveConfigFreeze() error = %v", err)
+	}
+	if freeze != nil {
+		t.Errorf("freeze = %s, want none", freeze.Name)
+	}
+
+	r = newReconciler(inactive, active)
+	freeze, err = ActiveConfigFreeze(context.Background(), r.Client)
+	if err != nil {
+		t.Fatalf("ActiveConfigFreeze() error = %v", err)
+	}
+	if freeze == nil || freeze.Name != "b-release" {
+		t.Errorf("freeze = %v, want b-release", freeze)
+	}
+}
+
+func TestReconcile_ConfigFreezeHoldsRollout(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}},
+			MachineConfigSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"pool": "worker"},
+			},
+		},
+	}
+	held := renderer.BuildRMC("worker", renderer.Merge([]*mcov1alpha1.MachineConfig{{
+		Spec: mcov1alpha1.MachineConfigSpec{Files: []mcov1alpha1.FileSpec{{Path: "/etc/app.conf", Content: "v1"}}},
+	}}), pool)
+	pool.Status.TargetRevision = held.Name
+
+	// The config changed after the freeze started.
+	mc := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"pool": "worker"}},
+		Spec:       mcov1alpha1.MachineConfigSpec{Files: []mcov1alpha1.FileSpec{{Path: "/etc/app.conf", Content: "v2"}}},
+	}
+	inProgress := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "in-progress",
+		Labels: map[string]string{"role": "worker"},
+		Annotations: map[string]string{
+			annotations.CurrentRevision: "worker-old",
+			annotations.DesiredRevision: held.Name,
+			annotations.AgentState:      annotations.StateApplying,
+		},
+	}}
+	waiting := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:        "waiting",
+		Labels:      map[string]string{"role": "worker"},
+		Annotations: map[string]string{annotations.CurrentRevision: "worker-old"},
+	}}
+	freeze := &mcov1alpha1.ConfigFreeze{
+		ObjectMeta: metav1.ObjectMeta{Name: "release"},
+		Spec:       mcov1alpha1.ConfigFreezeSpec{Active: true, Reason: "release week"},
+	}
+
+	r := newReconciler(pool, held, mc, inProgress, waiting, freeze)
+	ctx := context.Background()
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Name: "worker"}}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	rmcs := &mcov1alpha1.RenderedMachineConfigList{}
+	if err := r.List(ctx, rmcs); err != nil {
+		t.Fatal(err)
+	}
+	if len(rmcs.Items) != 1 {
+		t.Errorf("RMC count = %d, want 1 (nothing rendered while frozen)", len(rmcs.Items))
+	}
+
+	got := &corev1.Node{}
+	if err := r.Get(ctx, client.ObjectKey{Name: "in-progress"}, got); err != nil {
+		t.Fatal(err)
+	}
+	if d := got.Annotations[annotations.DesiredRevision]; d != held.Name {
+		t.Errorf("in-progress desired = %q, want held target %q", d, held.Name)
+	}
+	if err := r.Get(ctx, client.ObjectKey{Name: "waiting"}, got); err != nil {
+		t.Fatal(err)
+	}
+	if d := got.Annotations[annotations.DesiredRevision]; d != "" {
+		t.Errorf("waiting node was started while frozen: desired = %q", d)
+	}
+
+	updated := &mcov1alpha1.MachineConfigPool{}
+	if err := r.Get(ctx, client.ObjectKey{Name: "worker"}, updated); err != nil {
+		t.Fatal(err)
+	}
+	cond := meta.FindStatusCondition(updated.Status.Conditions, mcov1alpha1.ConditionFrozen)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Fatalf("Frozen condition = %v, want True", cond)
+	}
+	if updated.Status.TargetRevision != held.Name {
+		t.Errorf("TargetRevision = %q, want %q", updated.Status.TargetRevision, held.Name)
+	}
+	var frozenSkips int
+	for _, s := range updated.Status.SkippedMachines {
+		if s.Reason == SkipReasonFrozen {
+			frozenSkips = s.Count
+		}
+	}
+	if frozenSkips != 1 {
+		t.Errorf("SkippedMachines = %+v, want 1 frozen", updated.Status.SkippedMachines)
+	}
+}
@@ -26,6 +26,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -43,7 +44,7 @@ func newIntegrationReconciler(objs ...client.Object) *MachineConfigPoolReconcile
 	c := fake.NewClientBuilder().
 		WithScheme(scheme).
 		WithObjects(objs...).
-		WithStatusSubresource(&mcov1alpha1.MachineConfigPool{}).
+		WithStatusSubresource(&mcov1alpha1.MachineConfigPool{}, &mcov1alpha1.RenderedMachineConfig{}).
 		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
 			pod := obj.(*corev1.Pod)
 			return []string{pod.Spec.NodeName}
@@ -123,7 +124,7 @@ func TestRollingUpdate_MaxUnavailable1(t *testing.T) {
 
 	mc := &mcov1alpha1.MachineConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
-		Spec:       mcov1alpha1.MachineConfigSpec{Priority: 50},
+		Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(50)},
 	}
 
 	allObjs := append([]client.Object{pool, mc}, nodes...)
@@ -171,7 +172,7 @@ func TestRollingUpdate_MaxUnavailable2(t *testing.T) {
 
 	mc := &mcov1alpha1.MachineConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
-		Spec:       mcov1alpha1.MachineConfigSpec{Priority: 50},
+		Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(50)},
 	}
 
 	allObjs := append([]client.Object{pool, mc}, nodes...)
@@ -217,7 +218,7 @@ func TestRollingUpdate_Percentage(t *testing.T) {
 
 	mc := &mcov1alpha1.MachineConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
-		Spec:       mcov1alpha1.MachineConfigSpec{Priority: 50},
+		Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(50)},
 	}
 
 	allObjs := append([]client.Object{pool, mc}, nodes...)
@@ -257,7 +258,7 @@ func TestCordonDrain_Flow(t *testing.T) {
 
 	mc := &mcov1alpha1.MachineConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
-		Spec:       mcov1alpha1.MachineConfigSpec{Priority: 50},
+		Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(50)},
 	}
 
 	r := newIntegrationReconciler(pool, node, mc)
@@ -366,7 +367,7 @@ func TestDrainStuck_Condition(t *testing.T) {
 	ctx := context.Background()
 	drainTimeoutSeconds := 60 // 1 minute
 
-	result := HandleDrainRetry(ctx, c, node, drainTimeoutSeconds, 0)
+	result := HandleDrainRetry(ctx, c, node, drainTimeoutSeconds, 0, nil)
 
 	if !result.SetDrainStuck {
 		t.Error("HandleDrainRetry should return SetDrainStuck=true when timeout exceeded")
@@ -472,7 +473,7 @@ func TestPoolOverlap_BlocksUpdate(t *testing.T) {
 
 	mc := &mcov1alpha1.MachineConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
-		Spec:       mcov1alpha1.MachineConfigSpec{Priority: 50},
+		Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(50)},
 	}
 
 	r := newIntegrationReconciler(pool1, pool2, overlappingNode, workerNode, mc)
@@ -533,7 +534,7 @@ func TestStatus_UpdatedCorrectly(t *testing.T) {
 
 	mc := &mcov1alpha1.MachineConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
-		Spec:       mcov1alpha1.MachineConfigSpec{Priority: 50},
+		Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(50)},
 	}
 
 	allObjs := append([]client.Object{pool, mc}, nodes...)
@@ -585,7 +586,7 @@ func TestRequeueAfter_Aggregation(t *testing.T) {
 
 	mc := &mcov1alpha1.MachineConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
-		Spec:       mcov1alpha1.MachineConfigSpec{Priority: 50},
+		Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(50)},
 	}
 
 	r := newIntegrationReconciler(pool, node, mc)
@@ -646,7 +647,7 @@ func TestDrainBeforeApply_NoDesiredRevisionWhileDraining(t *testing.T) {
 			Name:   "mc-drain-test",
 			Labels: map[string]string{"mco.in-cloud.io/pool": "worker"},
 		},
-		Spec: mcov1alpha1.MachineConfigSpec{Priority: 50},
+		Spec: mcov1alpha1.MachineConfigSpec{Priority: ptr.To(50)},
 	}
 
 	// Create a pod on the node that will prevent drain from completing
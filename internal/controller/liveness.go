@@ -0,0 +1,218 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultReconcileStuckThreshold is how long a pool may go without a
+// successful reconcile, while one is pending, before it is reported stuck.
+const DefaultReconcileStuckThreshold = 15 * time.Minute
+
+var (
+	reconcileStuckDesc = prometheus.NewDesc(
+		"mco_pool_reconcile_stuck",
+		"1 when a pool has gone longer than the stuck threshold without a successful reconcile",
+		[]string{"pool"}, nil,
+	)
+	lastSuccessfulReconcileDesc = prometheus.NewDesc(
+		"mco_pool_last_successful_reconcile_timestamp_seconds",
+		"Unix time of the last reconcile of a pool that returned without error",
+		[]string{"pool"}, nil,
+	)
+)
+
+// ReconcileLiveness tracks, per pool, whether reconciles are completing.
+// A pool is pending from the first reconcile attempt until one succeeds;
+// idle pools are never reported stuck, so a quiet cluster stays healthy.
+// Per-pool state is exported as metrics. The readiness Check only looks at
+// the controller as a whole, so a pool stuck on a MachineConfig error does
+// not take the webhooks out of service while other reconciles complete.
+// All methods are safe on a nil receiver.
+type ReconcileLiveness struct {
+	threshold time.Duration
+	now       func() time.Time
+
+	mu            sync.Mutex
+	pendingFrom   map[string]time.Time
+	lastSuccess   map[string]time.Time
+	lastCompleted time.Time
+}
+
+// NewReconcileLiveness returns a tracker that reports a pool stuck once it
+// has been pending longer than threshold.
+func NewReconcileLiveness(threshold time.Duration) *ReconcileLiveness {
+	return &ReconcileLiveness{
+		threshold:   threshold,
+		now:         time.Now,
+		pendingFrom: make(map[string]time.Time),
+		lastSuccess: make(map[string]time.Time),
+	}
+}
+
+// Begin records a reconcile attempt for pool. The pending time is kept
+// across failed attempts so a retry loop still counts as stuck.
+func (l *ReconcileLiveness) Begin(pool string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.pendingFrom[pool]; !ok {
+		l.pendingFrom[pool] = l.now()
+	}
+}
+
+// Succeeded records a reconcile of pool that returned without error. It
+// is a no-op when no attempt is pending, such as after Forget.
+func (l *ReconcileLiveness) Succeeded(pool string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.pendingFrom[pool]; !ok {
+		return
+	}
+	delete(l.pendingFrom, pool)
+	l.lastSuccess[pool] = l.now()
+}
+
+// Completed records that a reconcile returned, with or without error.
+func (l *ReconcileLiveness) Completed() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lastCompleted = l.now()
+}
+
+// Forget drops pool, so a deleted pool stops being reported.
+func (l *ReconcileLiveness) Forget(pool string) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.pendingFrom, pool)
+	delete(l.lastSuccess, pool)
+}
+
+// LastSuccess returns when pool was last reconciled successfully.
+func (l *ReconcileLiveness) LastSuccess(pool string) (time.Time, bool) {
+	if l == nil {
+		return time.Time{}, false
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	t, ok := l.lastSuccess[pool]
+	return t, ok
+}
+
+// StuckPools returns, sorted, the pools pending longer than the threshold.
+func (l *ReconcileLiveness) StuckPools() []string {
+	if l == nil || l.threshold <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var stuck []string
+	for pool, since := range l.pendingFrom {
+		if now.Sub(since) > l.threshold {
+			stuck = append(stuck, pool)
+		}
+	}
+	sort.Strings(stuck)
+	return stuck
+}
+
+// Check is a healthz.Checker that fails when reconciles have been pending
+// longer than the threshold and none, for any pool, has returned within it:
+// the controller is wedged rather than one pool failing.
+func (l *ReconcileLiveness) Check(_ *http.Request) error {
+	if l == nil || l.threshold <= 0 {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastCompleted) <= l.threshold {
+		return nil
+	}
+	var pending []string
+	for pool, since := range l.pendingFrom {
+		if now.Sub(since) > l.threshold {
+			pending = append(pending, pool)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	sort.Strings(pending)
+	return fmt.Errorf("no pool reconcile completed in %s; pending: %s",
+		l.threshold, strings.Join(pending, ", "))
+}
+
+// Describe implements prometheus.Collector.
+func (l *ReconcileLiveness) Describe(ch chan<- *prometheus.Desc) {
+	ch <- reconcileStuckDesc
+	ch <- lastSuccessfulReconcileDesc
+}
+
+// Collect implements prometheus.Collector. Every tracked pool reports
+// mco_pool_reconcile_stuck, 0 or 1; pools that ever succeeded also report
+// the time of their last success.
+func (l *ReconcileLiveness) Collect(ch chan<- prometheus.Metric) {
+	if l == nil {
+		return
+	}
+	stuck := make(map[string]bool)
+	for _, pool := range l.StuckPools() {
+		stuck[pool] = true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	pools := make(map[string]bool, len(l.pendingFrom)+len(l.lastSuccess))
+	for pool := range l.pendingFrom {
+		pools[pool] = true
+	}
+	for pool, t := range l.lastSuccess {
+		pools[pool] = true
+		ch <- prometheus.MustNewConstMetric(lastSuccessfulReconcileDesc, prometheus.GaugeValue,
+			float64(t.Unix()), pool)
+	}
+	for pool := range pools {
+		value := 0.0
+		if stuck[pool] {
+			value = 1
+		}
+		ch <- prometheus.MustNewConstMetric(reconcileStuckDesc, prometheus.GaugeValue, value, pool)
+	}
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+func TestReconcileLiveness_StuckPools(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := NewReconcileLiveness(10 * time.Minute)
+	l.now = func() time.Time { return now }
+
+	if stuck := l.StuckPools(); len(stuck) != 0 {
+		t.Fatalf("idle tracker reports stuck pools: %v", stuck)
+	}
+
+	l.Begin("worker")
+	l.Begin("infra")
+	now = now.Add(5 * time.Minute)
+	l.Begin("worker") // a retry keeps the original pending time
+	l.Succeeded("infra")
+	if stuck := l.StuckPools(); len(stuck) != 0 {
+		t.Fatalf("pending below threshold reported stuck: %v", stuck)
+	}
+
+	now = now.Add(6 * time.Minute)
+	if stuck := l.StuckPools(); !reflect.DeepEqual(stuck, []string{"worker"}) {
+		t.Errorf("StuckPools() = %v, want [worker]", stuck)
+	}
+
+	l.Succeeded("worker")
+	if stuck := l.StuckPools(); len(stuck) != 0 {
+		t.Errorf("expected no stuck pools after success: %v", stuck)
+	}
+	if got, ok := l.LastSuccess("worker"); !ok || !got.Equal(now) {
+		t.Errorf("LastSuccess = %v, %v", got, ok)
+	}
+}
+
+func TestReconcileLiveness_Check(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := NewReconcileLiveness(10 * time.Minute)
+	l.now = func() time.Time { return now }
+
+	if err := l.Check(nil); err != nil {
+		t.Fatalf("idle tracker should be ready: %v", err)
+	}
+
+	// worker keeps failing, but infra reconciles still complete.
+	l.Begin("worker")
+	l.Completed()
+	now = now.Add(8 * time.Minute)
+	l.Begin("infra")
+	l.Completed()
+	l.Succeeded("infra")
+	now = now.Add(8 * time.Minute)
+	if err := l.Check(nil); err != nil {
+		t.Fatalf("one failing pool should not fail readiness: %v", err)
+	}
+
+	// Nothing returns any more: the controller is wedged.
+	l.Begin("infra")
+	now = now.Add(11 * time.Minute)
+	err := l.Check(nil)
+	if err == nil {
+		t.Fatal("expected readiness failure once no reconcile completes within the threshold")
+	}
+	if !strings.Contains(err.Error(), "infra, worker") {
+		t.Errorf("error = %v, want it to name the pending pools", err)
+	}
+
+	l.Completed()
+	if err := l.Check(nil); err != nil {
+		t.Errorf("expected ready after a reconcile completes: %v", err)
+	}
+}
+
+func TestReconcileLiveness_Collect(t *testing.T) {
+	now := time.Unix(1767268800, 0)
+	l := NewReconcileLiveness(10 * time.Minute)
+	l.now = func() time.Time { return now }
+
+	l.Begin("infra")
+	l.Succeeded("infra")
+	l.Begin("worker")
+	now = now.Add(11 * time.Minute)
+
+	want := `
+# HELP mco_pool_last_successful_reconcile_timestamp_seconds Unix time of the last reconcile of a pool that returned without error
+# TYPE mco_pool_last_successful_reconcile_timestamp_seconds gauge
+mco_pool_last_successful_reconcile_timestamp_seconds{pool="infra"} 1.7672688e+09
+# HELP mco_pool_reconcile_stuck 1 when a pool has gone longer than the stuck threshold without a successful reconcile
+# TYPE mco_pool_reconcile_stuck gauge
+mco_pool_reconcile_stuck{pool="infra"} 0
+mco_pool_reconcile_stuck{pool="worker"} 1
+`
+	if err := testutil.CollectAndCompare(l, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+
+	l.Forget("worker")
+	l.Succeeded("worker") // the reconcile that saw the pool deleted
+	if count := testutil.CollectAndCount(l, "mco_pool_reconcile_stuck"); count != 1 {
+		t.Errorf("stuck series = %d, want only infra after Forget", count)
+	}
+}
+
+func TestReconcileLiveness_NilAndDisabled(t *testing.T) {
+	var l *ReconcileLiveness
+	l.Begin("worker")
+	l.Succeeded("worker")
+	l.Completed()
+	if stuck := l.StuckPools(); len(stuck) != 0 {
+		t.Errorf("nil tracker: %v", stuck)
+	}
+	if err := l.Check(nil); err != nil {
+		t.Errorf("nil tracker Check() = %v", err)
+	}
+
+	disabled := NewReconcileLiveness(0)
+	disabled.now = func() time.Time { return time.Unix(0, 0) }
+	disabled.Begin("worker")
+	disabled.now = time.Now
+	if stuck := disabled.StuckPools(); len(stuck) != 0 {
+		t.Errorf("disabled tracker: %v", stuck)
+	}
+	if err := disabled.Check(nil); err != nil {
+		t.Errorf("disabled tracker Check() = %v", err)
+	}
+}
+
+func TestReconcile_RecordsLiveness(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec:       mcov1alpha1.MachineConfigPoolSpec{Paused: true},
+	}
+	r := newReconciler(pool)
+	r.Liveness = NewReconcileLiveness(time.Minute)
+
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "worker"}}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if _, ok := r.Liveness.LastSuccess("worker"); !ok {
+		t.Error("expected successful reconcile to be recorded")
+	}
+}
@@ -21,44 +21,80 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
 	"in-cloud.io/machine-config/internal/renderer"
+	"in-cloud.io/machine-config/pkg/annotations"
 )
 
+// DefaultMaxReconcileDuration bounds the per-node processing loop of a single reconcile.
+const DefaultMaxReconcileDuration = 2 * time.Minute
+
 // MachineConfigPoolReconciler reconciles a MachineConfigPool object.
 type MachineConfigPoolReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 
+	// MaxReconcileDuration bounds the time spent processing nodes in one
+	// reconcile. When exceeded, no further node is started, the remaining
+	// ones are skipped and the pool is requeued; the node in flight is not
+	// interrupted. Defaults to DefaultMaxReconcileDuration.
+	MaxReconcileDuration time.Duration
+
+	// Fairness orders pending pool reconciles so busy pools cannot starve
+	// others. Empty keeps the default FIFO queue.
+	Fairness ReconcileFairness
+
+	// Liveness, when set, records reconcile progress for the stuck-pool
+	// metrics and the reconcile readiness check.
+	Liveness *ReconcileLiveness
+
+	// IgnoreSelfNodeUpdates drops node events caused only by the
+	// controller's own annotation writes, so they do not re-enqueue the pool.
+	IgnoreSelfNodeUpdates bool
+
+	// StatusConfigMap, when set, names a ConfigMap in MCONamespace that
+	// mirrors each pool's status for tools without access to MCO resources.
+	StatusConfigMap string
+
 	// Components
 	debounce  *DebounceState
 	annotator *NodeAnnotator
 	cleaner   *RMCCleaner
 	events    *EventRecorder
+
+	// fleetSynced is set once the fleet was rebuilt in full after start.
+	fleetSynced atomic.Bool
 }
 
 // NewMachineConfigPoolReconciler creates a new reconciler with all components.
 func NewMachineConfigPoolReconciler(c client.Client, scheme *runtime.Scheme) *MachineConfigPoolReconciler {
 	return &MachineConfigPoolReconciler{
-		Client:    c,
-		Scheme:    scheme,
-		debounce:  NewDebounceState(),
-		annotator: NewNodeAnnotator(c),
-		cleaner:   NewRMCCleaner(c),
-		events:    &EventRecorder{}, // nil-safe: methods check for nil recorder
+		Client:                c,
+		Scheme:                scheme,
+		MaxReconcileDuration:  DefaultMaxReconcileDuration,
+		IgnoreSelfNodeUpdates: true,
+		debounce:              NewDebounceState(),
+		annotator:             NewNodeAnnotator(c),
+		cleaner:               NewRMCCleaner(c),
+		events:                &EventRecorder{}, // nil-safe: methods check for nil recorder
 	}
 }
 
@@ -66,14 +102,53 @@ func NewMachineConfigPoolReconciler(c client.Client, scheme *runtime.Scheme) *Ma
 // +kubebuilder:rbac:groups=mco.in-cloud.io,resources=machineconfigpools/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=mco.in-cloud.io,resources=machineconfigpools/finalizers,verbs=update
 // +kubebuilder:rbac:groups=mco.in-cloud.io,resources=machineconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=mco.in-cloud.io,resources=configfreezes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=mco.in-cloud.io,resources=machineconfigfleets,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=mco.in-cloud.io,resources=machineconfigfleets/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=mco.in-cloud.io,resources=renderedmachineconfigs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=mco.in-cloud.io,resources=renderedmachineconfigs/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch;patch;update
-// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
 // +kubebuilder:rbac:groups="",resources=pods/eviction,verbs=create
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch;update
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",namespace=machine-config-system,resources=configmaps,verbs=create;update
 
 // Reconcile handles MachineConfigPool reconciliation.
 func (r *MachineConfigPoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	r.Liveness.Begin(req.Name)
+	result, err := r.reconcile(ctx, req)
+	r.Liveness.Completed()
+	if err == nil {
+		r.Liveness.Succeeded(req.Name)
+	}
+	if r.StatusConfigMap != "" {
+		if exportErr := ExportPoolStatus(ctx, r.Client, r.StatusConfigMap, req.Name); exportErr != nil {
+			log.FromContext(ctx).Error(exportErr, "failed to export pool status", "configMap", r.StatusConfigMap)
+		}
+	}
+	if fleetErr := r.syncFleet(ctx, req.Name); fleetErr != nil {
+		log.FromContext(ctx).Error(fleetErr, "failed to update fleet status")
+	}
+	return result, err
+}
+
+// syncFleet refreshes the fleet summary for the pool just reconciled. The
+// first sync after start rebuilds it in full, dropping pools deleted while
+// the controller was down; later ones only rebuild when the pool changed.
+func (r *MachineConfigPoolReconciler) syncFleet(ctx context.Context, poolName string) error {
+	if r.fleetSynced.Load() {
+		return SyncFleetPool(ctx, r.Client, poolName)
+	}
+	if err := SyncFleetStatus(ctx, r.Client); err != nil {
+		return err
+	}
+	r.fleetSynced.Store(true)
+	return nil
+}
+
+func (r *MachineConfigPoolReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 
 	// 1. Get the MachineConfigPool
@@ -82,6 +157,7 @@ func (r *MachineConfigPoolReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		if apierrors.IsNotFound(err) {
 			r.debounce.Reset(req.Name)
 			ResetPoolMetrics(req.Name)
+			r.Liveness.Forget(req.Name)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{}, err
@@ -100,6 +176,13 @@ func (r *MachineConfigPoolReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, nil
 	}
 
+	// A cluster-wide ConfigFreeze holds the pool on its current target:
+	// nothing new is rendered or started, in-progress nodes still finish.
+	freeze, err := ActiveConfigFreeze(ctx, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to check config freeze: %w", err)
+	}
+
 	// Detect pool overlap before processing nodes
 	overlap, err := DetectPoolOverlap(ctx, r.Client)
 	if err != nil {
@@ -140,6 +223,27 @@ func (r *MachineConfigPoolReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, fmt.Errorf("failed to select nodes: %w", err)
 	}
 
+	// Take over nodes relabeled into this pool from another one before
+	// anything else looks at their rollout state.
+	transferred := 0
+	for _, node := range FilterMaintenanceNodes(FilterNonConflictingNodes(nodes, overlap)) {
+		previous := PreviousPool(pool, &node)
+		if previous == "" {
+			continue
+		}
+		if err := TakeOverNode(ctx, r.Client, pool, &node); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to take over node %s from pool %s: %w", node.Name, previous, err)
+		}
+		log.Info("node moved into pool", "node", node.Name, "from", previous, "pool", pool.Name)
+		r.events.NodePoolChanged(pool, node.Name, previous)
+		transferred++
+	}
+	if transferred > 0 {
+		if nodes, err = SelectNodes(ctx, r.Client, pool); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to select nodes: %w", err)
+		}
+	}
+
 	// 3. Filter out conflicting nodes - they should not receive desired-revision
 	nonConflictingNodes := FilterNonConflictingNodes(nodes, overlap)
 	conflictingNodeCount := len(nodes) - len(nonConflictingNodes)
@@ -149,7 +253,36 @@ func (r *MachineConfigPoolReconciler) Reconcile(ctx context.Context, req ctrl.Re
 			"total", len(nodes))
 	}
 
+	// Nodes under maintenance are left alone entirely; they only show up in status.
+	nonConflictingNodes = FilterMaintenanceNodes(nonConflictingNodes)
+
+	// Operator asked to re-drive failed nodes after fixing the root cause.
+	if annotations.GetBoolAnnotation(pool.Annotations, annotations.RetryFailed) {
+		retried, err := RetryFailedNodes(ctx, r.Client, pool, nonConflictingNodes)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to retry failed nodes: %w", err)
+		}
+		if len(retried) > 0 {
+			log.Info("retrying failed nodes", "pool", pool.Name, "nodes", retried)
+			r.events.RetryFailedNodes(pool, retried)
+		}
+		if err := ClearRetryFailedAnnotation(ctx, r.Client, pool); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to clear retry-failed annotation: %w", err)
+		}
+	}
+
 	configs, err := SelectMachineConfigs(ctx, r.Client, pool)
+	if errors.Is(err, ErrBaseConfigNotFound) {
+		// Retrying cannot help: creating the base MachineConfig enqueues
+		// the pool again.
+		log.Info("base MachineConfig missing, waiting for it", "pool", pool.Name, "error", err.Error())
+		original := pool.Status.DeepCopy()
+		SetRenderDegradedCondition(pool, err.Error())
+		if updateErr := r.updateStatusIfChanged(ctx, pool, original); updateErr != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update pool status with RenderDegraded: %w", updateErr)
+		}
+		return ctrl.Result{}, nil
+	}
 	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to select MachineConfigs: %w", err)
 	}
@@ -159,11 +292,26 @@ func (r *MachineConfigPoolReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		configPtrs[i] = &configs[i]
 	}
 
-	merged := renderer.Merge(configPtrs)
+	merged := renderer.MergeForPool(pool, configPtrs)
+
+	// With revertWhenEmpty, deleting every MachineConfig rolls out a revert
+	// of the last target instead of leaving it applied.
+	var revertFrom string
+	if len(configs) == 0 && pool.Spec.RevertWhenEmpty && pool.Status.TargetRevision != "" {
+		last, err := existingRMC(ctx, r.Client, pool.Status.TargetRevision)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to get revision to revert: %w", err)
+		}
+		if last != nil {
+			merged = renderer.RevertConfig(last.Spec.Config)
+			revertFrom = last.Name
+			log.Info("no MachineConfigs for pool, reverting last revision", "pool", pool.Name, "revision", last.Name)
+		}
+	}
 
 	// Skip rollout if no MachineConfigs exist
 	// This prevents unnecessary cordon/drain when pool is created without configs
-	if len(configs) == 0 {
+	if len(configs) == 0 && revertFrom == "" {
 		log.Info("no MachineConfigs for pool, skipping rollout",
 			"pool", pool.Name,
 			"nodeCount", len(nodes))
@@ -174,59 +322,210 @@ func (r *MachineConfigPoolReconciler) Reconcile(ctx context.Context, req ctrl.Re
 			if err := r.Get(ctx, req.NamespacedName, pool); err != nil {
 				return err
 			}
+			original := pool.Status.DeepCopy()
 			pool.Status.MachineCount = len(nodes)
 			pool.Status.ReadyMachineCount = len(nodes)
 			pool.Status.UpdatedMachineCount = len(nodes)
 			pool.Status.TargetRevision = ""
+			pool.Status.ManagedFileCount = 0
+			pool.Status.ManagedUnitCount = 0
+			pool.Status.RenderValidationFailures = nil
 			// Apply overlap condition even for empty pools
 			ApplyOverlapCondition(pool, overlap)
-			return r.Status().Update(ctx, pool)
+			applyFrozenCondition(pool, freeze)
+			return r.updateStatusIfChanged(ctx, pool, original)
 		}); err != nil {
 			log.Error(err, "failed to update pool status for empty config")
 		}
 		return ctrl.Result{}, nil
 	}
 
-	hash := renderer.ComputeHash(merged)
-	debounceSeconds := pool.Spec.Rollout.DebounceSeconds
-	poolSpecHash := ComputePoolSpecHash(pool)
-	shouldProceed, requeueAfter := r.debounce.CheckAndUpdate(pool.Name, hash.Full, poolSpecHash, debounceSeconds)
-	if !shouldProceed {
-		// Even during debounce, we still want to keep PoolOverlap status fresh.
-		// Otherwise overlap conditions can get stuck until debounce completes.
-		if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-			if err := r.Get(ctx, req.NamespacedName, pool); err != nil {
-				return err
+	var rmc *mcov1alpha1.RenderedMachineConfig
+	if freeze != nil {
+		rmc, err = frozenTargetRMC(ctx, r.Client, pool)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to get frozen target revision: %w", err)
+		}
+		if rmc == nil {
+			log.Info("config freeze active and pool has no target revision, skipping rollout",
+				"freeze", freeze.Name)
+			if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+				if err := r.Get(ctx, req.NamespacedName, pool); err != nil {
+					return err
+				}
+				original := pool.Status.DeepCopy()
+				ApplyOverlapCondition(pool, overlap)
+				SetFrozenCondition(pool, freeze)
+				return r.updateStatusIfChanged(ctx, pool, original)
+			}); err != nil {
+				log.Error(err, "failed to update pool status during config freeze")
 			}
-			ApplyOverlapCondition(pool, overlap)
-			return r.Status().Update(ctx, pool)
-		}); err != nil {
-			log.Error(err, "failed to update overlap condition during debounce")
+			return ctrl.Result{}, nil
 		}
+		log.Info("config freeze active, holding target revision",
+			"freeze", freeze.Name, "target", rmc.Name)
+	} else {
+		hash := renderer.ComputeHash(merged)
+		debounceSeconds := pool.Spec.Rollout.DebounceSeconds
+		poolSpecHash := ComputePoolSpecHash(pool)
+		shouldProceed, requeueAfter := r.debounce.CheckAndUpdate(pool.Name, hash.Full, poolSpecHash, debounceSeconds)
+		if !shouldProceed {
+			// Even during debounce, we still want to keep PoolOverlap status fresh.
+			// Otherwise overlap conditions can get stuck until debounce completes.
+			if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+				if err := r.Get(ctx, req.NamespacedName, pool); err != nil {
+					return err
+				}
+				original := pool.Status.DeepCopy()
+				ApplyOverlapCondition(pool, overlap)
+				ClearFrozenCondition(pool)
+				applyDebounceCondition(pool, renderer.RMCName(pool.Name, hash), requeueAfter)
+				return r.updateStatusIfChanged(ctx, pool, original)
+			}); err != nil {
+				log.Error(err, "failed to update overlap condition during debounce")
+			}
 
-		log.Info("debounce active, requeuing", "after", requeueAfter)
-		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+			log.Info("debounce active, requeuing", "after", requeueAfter)
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+
+		rmc, err = r.ensureRMC(ctx, pool, merged)
+		if err == nil {
+			err = r.recordValidation(ctx, rmc)
+		}
+		if err != nil {
+			SetRenderDegradedCondition(pool, err.Error())
+			pool.Status.RenderValidationFailures = validationFailures(err)
+			if updateErr := r.Status().Update(ctx, pool); updateErr != nil {
+				log.Error(updateErr, "failed to update pool status with RenderDegraded")
+			}
+			return ctrl.Result{}, fmt.Errorf("failed to ensure RMC: %w", err)
+		}
+
+		// Clear RenderDegraded on success
+		ClearRenderDegradedCondition(pool)
 	}
 
-	rmc, err := r.ensureRMC(ctx, pool, merged)
-	if err != nil {
-		SetRenderDegradedCondition(pool, err.Error())
-		if updateErr := r.Status().Update(ctx, pool); updateErr != nil {
-			log.Error(updateErr, "failed to update pool status with RenderDegraded")
+	// AutoRollback: a target that kept the pool degraded gives way to the
+	// last successful revision until the MachineConfigs render another one.
+	var rolledBackFrom string
+	if freeze == nil && (pool.Status.RolledBackRevision == rmc.Name ||
+		ShouldAutoRollback(pool, rmc.Name, nonConflictingNodes, time.Now())) {
+		last, err := existingRMC(ctx, r.Client, pool.Status.LastSuccessfulRevision)
+		if err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to get revision to roll back to: %w", err)
+		}
+		if last == nil {
+			log.Info("last successful revision not found, not rolling back",
+				"pool", pool.Name, "revision", pool.Status.LastSuccessfulRevision)
+		} else {
+			rolledBackFrom = rmc.Name
+			rmc = last
+			if pool.Status.RolledBackRevision != rolledBackFrom {
+				log.Info("pool degraded too long, rolling back", "pool", pool.Name, "from", rolledBackFrom, "to", rmc.Name)
+				r.events.RolledBack(pool, rolledBackFrom, rmc.Name)
+			}
+			rolled, err := RollBackNodes(ctx, r.Client, pool, nonConflictingNodes, rolledBackFrom, rmc.Name)
+			if err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to roll back nodes: %w", err)
+			}
+			if len(rolled) > 0 {
+				log.Info("re-pointed nodes to the last successful revision", "pool", pool.Name, "nodes", rolled)
+			}
 		}
-		return ctrl.Result{}, fmt.Errorf("failed to ensure RMC: %w", err)
 	}
 
-	// Clear RenderDegraded on success
-	ClearRenderDegradedCondition(pool)
+	// Blue/green: nodes selected by the candidate converge to a second
+	// revision rendered with extra MachineConfigs. A freeze holds it too.
+	var candidateRevision string
+	if pool.Spec.Candidate != nil {
+		var candidate *mcov1alpha1.RenderedMachineConfig
+		if freeze != nil {
+			candidate, err = existingRMC(ctx, r.Client, pool.Status.CandidateRevision)
+		} else {
+			candidate, err = r.ensureCandidateRMC(ctx, pool, configs)
+		}
+		if err != nil {
+			SetRenderDegradedCondition(pool, err.Error())
+			pool.Status.RenderValidationFailures = validationFailures(err)
+			if updateErr := r.Status().Update(ctx, pool); updateErr != nil {
+				log.Error(updateErr, "failed to update pool status with RenderDegraded")
+			}
+			return ctrl.Result{}, fmt.Errorf("failed to ensure candidate RMC: %w", err)
+		}
+		if candidate != nil {
+			candidateRevision = candidate.Name
+		}
+	}
+	targetFor, err := candidateTargets(pool, rmc.Name, candidateRevision)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
 
 	// 4. Select nodes for update respecting maxUnavailable
 	// This returns nodes that can START a new update
-	newNodesToUpdate := SelectNodesForUpdate(pool, nonConflictingNodes, rmc.Name)
+	// No new nodes are started while frozen or once the rollout used up its
+	// pod eviction cap, nor once a rollout past its maximum duration is
+	// halted, nor while the soak period after the last completed node runs,
+	// nor while the drain config is invalid, nor outside the pool's
+	// maintenance windows. selectNodesForUpdate also
+	// keeps rollout.minHealthyNodes healthy.
+	// Nodes marked update-now are added outside the budget.
+	evictionBudget := RemainingEvictionBudget(pool, rmc.Name)
+	halted := pool.Spec.Rollout.HaltOnRolloutTimeout && RolloutTimedOut(pool, rmc.Name, time.Now())
+	if halted {
+		log.Info("rollout exceeded maximum duration, not starting new nodes", "pool", pool.Name)
+	}
+	soakRemaining, soaking := SoakRemaining(pool, time.Now())
+	if soaking {
+		log.V(1).Info("soak period running, not starting new nodes", "pool", pool.Name, "remaining", soakRemaining)
+	}
+	windows := pool.Spec.Rollout.MaintenanceWindows
+	if err := ValidateMaintenanceWindows(windows); err != nil {
+		log.Error(err, "ignoring invalid maintenance window", "pool", pool.Name)
+	}
+	outsideWindow := len(windows) > 0 && !InMaintenanceWindow(time.Now(), windows)
+	if outsideWindow {
+		log.V(1).Info("outside maintenance windows, not starting new nodes", "pool", pool.Name)
+	}
+	limit, ok := MinHealthyLimit(pool, nonConflictingNodes)
+	belowMinHealthy := ok && limit == 0
+	if belowMinHealthy {
+		log.Info("no healthy nodes to spare above minHealthyNodes, not starting new nodes", "pool", pool.Name)
+	}
+	// A node started now would sit cordoned: its drain waits for a valid
+	// drain config.
+	drainSettings, drainConfigErr := LoadDrainSettings(ctx, r.Client)
+	if drainConfigErr != nil {
+		log.Error(drainConfigErr, "invalid drain config, not starting new nodes", "pool", pool.Name)
+		r.events.DrainConfigInvalid(pool, drainConfigErr)
+	}
+	var newNodesToUpdate []corev1.Node
+	var pdbBlocked map[string]bool
+	if freeze == nil && evictionBudget != 0 && !halted && !soaking && !outsideWindow && drainConfigErr == nil {
+		pdbBlocked, err = PDBBlockedNodes(ctx, r.Client, pool, nonConflictingNodes, targetFor, drainSettings)
+		if err != nil {
+			// Fall back to selecting without the PDB pre-flight check.
+			log.Error(err, "failed to check PodDisruptionBudgets before selecting nodes")
+		} else if len(pdbBlocked) > 0 {
+			log.Info("skipping nodes whose drain is blocked by PodDisruptionBudgets", "pool", pool.Name, "nodes", len(pdbBlocked))
+		}
+		newNodesToUpdate = selectNodesForUpdate(pool, nonConflictingNodes, targetFor, pdbBlocked)
+		selected := make(map[string]bool, len(newNodesToUpdate))
+		for i := range newNodesToUpdate {
+			selected[newNodesToUpdate[i].Name] = true
+		}
+		for _, node := range selectScopedUpdates(nonConflictingNodes, targetFor) {
+			if !selected[node.Name] {
+				log.Info("starting scoped node update", "node", node.Name)
+				newNodesToUpdate = append(newNodesToUpdate, node)
+			}
+		}
+	}
 
 	// Also include nodes that are already in-progress (cordoned/draining)
 	// These need to continue their update lifecycle
-	nodesToProcess := collectNodesInProgress(nonConflictingNodes, rmc.Name)
+	nodesToProcess := collectNodesInProgressFor(nonConflictingNodes, targetFor)
 
 	// Merge: add new nodes to process list (avoiding duplicates)
 	inProgressNames := make(map[string]bool)
@@ -239,12 +538,39 @@ func (r *MachineConfigPoolReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		}
 	}
 
+	// Record why the remaining out-of-date nodes are not being updated.
+	held := ""
+	switch {
+	case freeze != nil:
+		held = SkipReasonFrozen
+	case halted:
+		held = SkipReasonHalted
+	case evictionBudget == 0:
+		held = SkipReasonEvictionLimit
+	case soaking:
+		held = SkipReasonSoaking
+	case drainConfigErr != nil:
+		held = SkipReasonDrainConfigInvalid
+	case outsideWindow:
+		held = SkipReasonOutsideWindow
+	case belowMinHealthy:
+		held = SkipReasonMinHealthy
+	}
+	skipReasons := nodeSkipReasons(pool, nodes, overlap, targetFor, nodesToProcess, held, pdbBlocked)
+	if len(skipReasons) > 0 {
+		log.V(1).Info("nodes skipped for update", "pool", pool.Name, "reasons", skipReasons)
+	}
+	if _, err := SyncSkipReasonAnnotations(ctx, r.Client, pool, nodes, skipReasons); err != nil {
+		log.Error(err, "failed to sync skip-reason annotations")
+	}
+
 	log.Info("processing node updates",
 		"pool", pool.Name,
 		"totalNodes", len(nonConflictingNodes),
 		"newNodes", len(newNodesToUpdate),
 		"inProgress", len(nodesToProcess)-len(newNodesToUpdate),
-		"maxUnavailable", pool.Spec.Rollout.MaxUnavailable)
+		"maxUnavailable", pool.Spec.Rollout.MaxUnavailable,
+		"maxSurge", pool.Spec.Rollout.MaxSurge)
 
 	// Emit event for new batch of nodes starting update
 	if len(newNodesToUpdate) > 0 {
@@ -258,7 +584,12 @@ func (r *MachineConfigPoolReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	// 5. Process each node through cordon/drain/update/uncordon lifecycle
 	var minRequeueAfter time.Duration
 	var drainStuckNodes []string
+	var drainNoProgressNodes []string
+	var cordonStuckNodes []string
+	var failedNodes []string
+	nodeErrors := make(map[string]string)
 	var uncordonedCount int
+	var evictedPods int
 
 	// Get drain timeout from spec (defaults to 3600)
 	drainTimeoutSeconds := pool.Spec.Rollout.DrainTimeoutSeconds
@@ -269,10 +600,53 @@ func (r *MachineConfigPoolReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	// Get drain retry interval from spec (0 means auto-calculate)
 	drainRetrySeconds := pool.Spec.Rollout.DrainRetrySeconds
 
+	// Bound the node loop so a slow API or a huge pool cannot block the
+	// workqueue. The budget only stops new nodes from being started: the
+	// node in flight finishes its API calls on ctx, so a cordon or a drain
+	// annotation is never cut off halfway.
+	maxDuration := r.MaxReconcileDuration
+	if maxDuration <= 0 {
+		maxDuration = DefaultMaxReconcileDuration
+	}
+	deadline := time.Now().Add(maxDuration)
+
 	for i := range nodesToProcess {
 		node := &nodesToProcess[i]
 
-		result := ProcessNodeUpdate(ctx, r.Client, pool, node, rmc.Name, drainTimeoutSeconds, drainRetrySeconds, r.events)
+		if i > 0 && time.Now().After(deadline) {
+			log.Info("max reconcile duration exceeded, requeuing remaining nodes",
+				"pool", pool.Name,
+				"maxDuration", maxDuration,
+				"remaining", len(nodesToProcess)-i)
+			minRequeueAfter = time.Second
+			break
+		}
+
+		// Recover nodes that sat cordoned without their drain ever starting.
+		cordonTimeout := time.Duration(pool.Spec.Rollout.CordonTimeoutSeconds) * time.Second
+		if CordonStuck(node, targetFor(node), cordonTimeout, time.Now()) {
+			cordonStuckNodes = append(cordonStuckNodes, node.Name)
+			if pool.Spec.Rollout.UncordonOnCordonTimeout && !HoldCordon(pool, node) {
+				if err := UncordonNode(ctx, r.Client, node); err != nil {
+					err = fmt.Errorf("uncordon after cordon timeout: %w", err)
+					log.Error(err, "failed to update node", "pool", pool.Name, "node", node.Name)
+					failedNodes = append(failedNodes, node.Name)
+					r.events.NodeUpdateFailed(pool, node.Name, err)
+					r.events.CordonStuck(pool, node.Name, false)
+				} else {
+					r.events.CordonStuck(pool, node.Name, true)
+				}
+				continue
+			}
+			r.events.CordonStuck(pool, node.Name, false)
+		}
+
+		budget := evictionBudget
+		if budget > 0 {
+			budget = max(budget-evictedPods, 0)
+		}
+		result := ProcessNodeUpdate(ctx, r.Client, pool, node, targetFor(node), drainTimeoutSeconds, drainRetrySeconds, budget, r.events)
+		evictedPods += result.EvictedPods
 
 		// Emit lifecycle events based on result flags
 		if result.Cordoned {
@@ -292,9 +666,29 @@ func (r *MachineConfigPoolReconciler) Reconcile(ctx context.Context, req ctrl.Re
 			uncordonedCount++
 		}
 
+		// A failure on one node must not stall the rest of the batch:
+		// record it and keep going, it is surfaced in status below.
+		// Status only gets a stable summary so a retry failing with a
+		// slightly different error text does not rewrite it; the full
+		// error goes to the event and the log.
+		if result.Err != nil && ctx.Err() == nil {
+			log.Error(result.Err, "failed to update node", "pool", pool.Name, "node", node.Name)
+			failedNodes = append(failedNodes, node.Name)
+			nodeErrors[node.Name] = NodeFailureSummary(result.Err)
+			r.events.NodeUpdateFailed(pool, node.Name, result.Err)
+		}
+
+		if len(result.ForcedPods) > 0 {
+			r.events.DrainForced(pool, node.Name, result.ForcedPods)
+		}
+
 		// Track drain stuck nodes
 		if result.DrainStuck {
 			drainStuckNodes = append(drainStuckNodes, node.Name)
+			r.events.DrainBlocked(node, result.BlockingPods)
+		}
+		if result.DrainNoProgress {
+			drainNoProgressNodes = append(drainNoProgressNodes, node.Name)
 		}
 
 		// Track minimum requeue time
@@ -314,6 +708,13 @@ func (r *MachineConfigPoolReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, fmt.Errorf("failed to re-fetch nodes for status: %w", err)
 	}
 
+	if _, err := SyncNodeLabels(ctx, r.annotator, pool, FilterMaintenanceNodes(FilterNonConflictingNodes(nodes, overlap))); err != nil {
+		log.Error(err, "failed to sync node labels")
+	}
+	if _, err := SyncOnUpdateLabels(ctx, r.annotator, pool, FilterMaintenanceNodes(FilterNonConflictingNodes(nodes, overlap)), targetFor); err != nil {
+		log.Error(err, "failed to sync on-update labels")
+	}
+
 	// Track if rollout just completed for event emission
 	wasNotComplete := pool.Status.UpdatedMachineCount != pool.Status.MachineCount ||
 		pool.Status.ReadyMachineCount != pool.Status.MachineCount
@@ -323,43 +724,114 @@ func (r *MachineConfigPoolReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	if applyTimeout <= 0 {
 		applyTimeout = DefaultApplyTimeoutSeconds
 	}
-	aggregatedStatus := AggregateStatus(rmc.Name, nodes, applyTimeout)
+	aggregatedStatus := aggregateStatus(rmc.Name, targetFor, nodes, applyTimeout, pool.Spec.Rollout.NotReadyToleranceSeconds)
 
 	// Track whether rollout just completed for event emission after retry loop
 	var rolloutJustCompleted bool
+	var rolloutReport RolloutReport
 
 	if err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 		if err := r.Get(ctx, req.NamespacedName, pool); err != nil {
 			return err
 		}
+		original := pool.Status.DeepCopy()
 		// Recompute status with potentially updated pool spec
-		status := AggregateStatus(rmc.Name, nodes, pool.Spec.Rollout.ApplyTimeoutSeconds)
+		status := aggregateStatus(rmc.Name, targetFor, nodes, pool.Spec.Rollout.ApplyTimeoutSeconds, pool.Spec.Rollout.NotReadyToleranceSeconds)
+		SetManagedCounts(status, rmc)
 		ApplyStatusToPool(pool, status)
+		SetCandidateStatus(pool, nodes, targetFor, candidateRevision)
+		SetCanaryCondition(pool, FilterNonConflictingNodes(nodes, overlap), targetFor)
+		pool.Status.SkippedMachines = SkippedMachineCounts(skipReasons)
 		// Apply overlap condition (adds PoolOverlap and potentially Degraded)
 		ApplyOverlapCondition(pool, overlap)
+		applyFrozenCondition(pool, freeze)
+		ClearDebounceActiveCondition(pool)
+		if freeze == nil {
+			// This reconcile rendered the target, so it passed validation.
+			pool.Status.RenderValidationFailures = nil
+			pool.Status.RolledBackRevision = rolledBackFrom
+			if rolledBackFrom != "" {
+				SetRolledBackCondition(pool, rolledBackFrom, rmc.Name)
+			} else {
+				ClearRolledBackCondition(pool)
+			}
+		}
+		if outsideWindow {
+			next, _ := NextMaintenanceWindow(time.Now(), windows)
+			SetRolloutPausedWindowCondition(pool, next)
+		} else {
+			ClearRolloutPausedWindowCondition(pool)
+		}
+
+		TrackRolloutStart(pool, original.TargetRevision, time.Now())
+		if uncordonedCount > 0 {
+			RecordNodeCompletion(pool, time.Now())
+		}
+		RecordRolloutFailures(pool, rmc.Name, nodes, status.TimedOutNodes, nodeErrors, time.Now())
+		if RolloutTimedOut(pool, rmc.Name, time.Now()) {
+			SetRolloutTimeoutCondition(pool)
+		} else {
+			ClearRolloutTimeoutCondition(pool)
+		}
+
+		RecordPodEvictions(pool, rmc.Name, evictedPods)
+		if RemainingEvictionBudget(pool, rmc.Name) == 0 {
+			SetEvictionLimitCondition(pool, pool.Status.PodEvictions.Count)
+		} else {
+			ClearEvictionLimitCondition(pool)
+		}
 
 		// Apply drain stuck condition
 		if len(drainStuckNodes) > 0 {
-			msg := fmt.Sprintf("Drain stuck on nodes: %s", strings.Join(drainStuckNodes, ", "))
+			msg := fmt.Sprintf("Drain stuck on nodes: %s", joinNodeNames(drainStuckNodes))
 			SetDrainStuckCondition(pool, msg)
 		} else {
 			ClearDrainStuckCondition(pool)
 		}
 
+		if len(drainNoProgressNodes) > 0 {
+			SetDrainNoProgressCondition(pool, drainNoProgressNodes)
+		} else {
+			ClearDrainNoProgressCondition(pool)
+		}
+
+		if len(cordonStuckNodes) > 0 {
+			SetCordonStuckCondition(pool, cordonStuckNodes)
+		} else {
+			ClearCordonStuckCondition(pool)
+		}
+
+		// Apply per-node failure condition
+		if len(failedNodes) > 0 {
+			SetNodeUpdateFailedCondition(pool, failedNodes)
+		} else {
+			ClearNodeUpdateFailedCondition(pool)
+		}
+
 		// Update metrics
 		UpdateCordonedNodesGauge(pool.Name, status.CordonedMachineCount)
 		UpdateDrainingNodesGauge(pool.Name, status.DrainingMachineCount)
+		UpdateOldestUpdatingAgeGauge(pool.Name, status.OldestUpdatingAge)
 
 		// Track rollout completion for event emission outside retry loop
 		rolloutJustCompleted = wasNotComplete && status.MachineCount > 0 &&
 			status.UpdatedMachineCount == status.MachineCount &&
 			status.ReadyMachineCount == status.MachineCount
+		if rolloutJustCompleted {
+			rolloutReport = BuildRolloutReport(pool, rmc.Name, original.LastSuccessfulRevision,
+				original.RolloutStartedAt, nodes, time.Now())
+		}
+		if status.UpdatedMachineCount == status.MachineCount {
+			pool.Status.RolloutFailures = nil
+		}
 
-		return r.Status().Update(ctx, pool)
+		return r.updateStatusIfChanged(ctx, pool, original)
 	}); err != nil {
 		return ctrl.Result{}, fmt.Errorf("failed to update pool status: %w", err)
 	}
 
+	RecordEvictedPods(pool.Name, evictedPods)
+
 	// Emit DrainStuck events
 	if len(drainStuckNodes) > 0 {
 		for _, nodeName := range drainStuckNodes {
@@ -374,6 +846,9 @@ func (r *MachineConfigPoolReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	if rolloutJustCompleted {
 		r.events.RolloutComplete(pool)
 		log.Info("rollout complete", "pool", pool.Name)
+		if err := StoreRolloutReport(ctx, r.Client, rolloutReport); err != nil {
+			log.Error(err, "failed to store rollout report", "configMap", RolloutReportConfigMapName)
+		}
 	}
 
 	// Emit ApplyTimeout events
@@ -396,6 +871,42 @@ func (r *MachineConfigPoolReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		log.Info("cleaned up old RMCs", "count", deleted)
 	}
 
+	// Re-evaluate NotReady nodes once their tolerance runs out; no node event
+	// fires when a node simply stays NotReady.
+	if recheck := aggregatedStatus.NotReadyRecheckAfter; recheck > 0 && (minRequeueAfter == 0 || recheck < minRequeueAfter) {
+		minRequeueAfter = recheck
+	}
+
+	// Likewise pick up new nodes when their grace period ends.
+	if recheck := NewNodeGraceRecheckAfter(pool, nonConflictingNodes); recheck > 0 && (minRequeueAfter == 0 || recheck < minRequeueAfter) {
+		minRequeueAfter = recheck
+	}
+
+	// Flag the rollout once it runs past its maximum duration.
+	if remaining, ok := RolloutRemaining(pool, rmc.Name, time.Now()); ok && remaining > 0 && (minRequeueAfter == 0 || remaining < minRequeueAfter) {
+		minRequeueAfter = remaining
+	}
+
+	// Start the next node once the soak period after the last one ends.
+	if remaining, ok := SoakRemaining(pool, time.Now()); ok && pool.Status.UpdatedMachineCount < pool.Status.MachineCount &&
+		(minRequeueAfter == 0 || remaining < minRequeueAfter) {
+		minRequeueAfter = remaining
+	}
+
+	// Wake up when the next maintenance window opens.
+	if outsideWindow && pool.Status.UpdatedMachineCount < pool.Status.MachineCount {
+		if next, ok := NextMaintenanceWindow(time.Now(), windows); ok {
+			if wait := max(time.Until(next), time.Second); minRequeueAfter == 0 || wait < minRequeueAfter {
+				minRequeueAfter = wait
+			}
+		}
+	}
+
+	// Read an invalid drain config again; no watch reports its fix.
+	if drainConfigErr != nil && (minRequeueAfter == 0 || DrainConfigRecheckInterval < minRequeueAfter) {
+		minRequeueAfter = DrainConfigRecheckInterval
+	}
+
 	// Return with requeue if node updates are in progress
 	if minRequeueAfter > 0 {
 		return ctrl.Result{RequeueAfter: minRequeueAfter}, nil
@@ -411,7 +922,15 @@ func (r *MachineConfigPoolReconciler) ensureRMC(
 ) (*mcov1alpha1.RenderedMachineConfig, error) {
 	log := log.FromContext(ctx)
 
+	if err := merged.Conflicts(); err != nil {
+		return nil, err
+	}
+
 	rmc := renderer.BuildRMC(pool.Name, merged, pool)
+	// An invalid render is never created, so it cannot be rolled out.
+	if err := validationError(rmc); err != nil {
+		return nil, err
+	}
 	if rmc.Labels == nil {
 		rmc.Labels = make(map[string]string)
 	}
@@ -427,17 +946,28 @@ func (r *MachineConfigPoolReconciler) ensureRMC(
 
 	if existing != nil {
 		if existing.Spec.ConfigHash == rmc.Spec.ConfigHash {
-			needsUpdate := existing.Spec.Reboot.Strategy != rmc.Spec.Reboot.Strategy ||
-				existing.Spec.Reboot.MinIntervalSeconds != rmc.Spec.Reboot.MinIntervalSeconds
+			policyChanged := existing.Spec.Reboot.Strategy != rmc.Spec.Reboot.Strategy ||
+				existing.Spec.Reboot.MinIntervalSeconds != rmc.Spec.Reboot.MinIntervalSeconds ||
+				!equality.Semantic.DeepEqual(existing.Spec.UnitRestart, rmc.Spec.UnitRestart)
 
-			if needsUpdate {
-				log.Info("updating RMC reboot spec",
+			updated := existing.DeepCopy()
+			annotationsChanged := renderer.SyncPropagatedAnnotations(updated, rmc, pool.Spec.PropagateAnnotations)
+
+			if policyChanged || annotationsChanged {
+				log.Info("updating RMC pool policy and propagated annotations",
 					"name", existing.Name,
 					"oldStrategy", existing.Spec.Reboot.Strategy,
-					"newStrategy", rmc.Spec.Reboot.Strategy)
-				existing.Spec.Reboot = rmc.Spec.Reboot
+					"newStrategy", rmc.Spec.Reboot.Strategy,
+					"annotationsChanged", annotationsChanged)
+				updated.Spec.Reboot.Strategy = rmc.Spec.Reboot.Strategy
+				updated.Spec.Reboot.MinIntervalSeconds = rmc.Spec.Reboot.MinIntervalSeconds
+				updated.Spec.UnitRestart = rmc.Spec.UnitRestart
+				if err := renderer.ValidateRMCUpdate(existing, updated); err != nil {
+					return nil, err
+				}
+				existing = updated
 				if err := r.Update(ctx, existing); err != nil {
-					return nil, fmt.Errorf("failed to update RMC reboot spec: %w", err)
+					return nil, fmt.Errorf("failed to update RMC: %w", err)
 				}
 			}
 			return existing, nil
@@ -495,20 +1025,127 @@ func (r *MachineConfigPoolReconciler) ensureRMC(
 	return rmc, nil
 }
 
+// ensureCandidateRMC renders the pool's configs plus the candidate
+// MachineConfigs into the candidate revision.
+func (r *MachineConfigPoolReconciler) ensureCandidateRMC(
+	ctx context.Context,
+	pool *mcov1alpha1.MachineConfigPool,
+	configs []mcov1alpha1.MachineConfig,
+) (*mcov1alpha1.RenderedMachineConfig, error) {
+	candidateConfigs, err := SelectCandidateMachineConfigs(ctx, r.Client, pool, configs)
+	if err != nil {
+		return nil, err
+	}
+	configPtrs := make([]*mcov1alpha1.MachineConfig, len(candidateConfigs))
+	for i := range candidateConfigs {
+		configPtrs[i] = &candidateConfigs[i]
+	}
+
+	rmc, err := r.ensureRMC(ctx, pool, renderer.MergeForPool(pool, configPtrs))
+	if err != nil {
+		return nil, err
+	}
+	if err := r.recordValidation(ctx, rmc); err != nil {
+		return nil, err
+	}
+	return rmc, nil
+}
+
+// updateStatusIfChanged writes pool status only when it differs from
+// original, so no-op reconciles cause no API writes or conflicts.
+func (r *MachineConfigPoolReconciler) updateStatusIfChanged(
+	ctx context.Context,
+	pool *mcov1alpha1.MachineConfigPool,
+	original *mcov1alpha1.MachineConfigPoolStatus,
+) error {
+	if equality.Semantic.DeepEqual(original, &pool.Status) {
+		return nil
+	}
+	return r.Status().Update(ctx, pool)
+}
+
+// recordValidation stores the per-entry validation results of rmc in its
+// status. Renders that fail validation are rejected by ensureRMC before
+// they are created; their failures are kept in the pool's
+// renderValidationFailures instead.
+func (r *MachineConfigPoolReconciler) recordValidation(ctx context.Context, rmc *mcov1alpha1.RenderedMachineConfig) error {
+	results := renderer.ValidateRenderedConfig(rmc.Spec.Config)
+	if equality.Semantic.DeepEqual(rmc.Status.ValidationResults, results) {
+		return nil
+	}
+	rmc.Status.ValidationResults = results
+	if err := r.Status().Update(ctx, rmc); err != nil {
+		return fmt.Errorf("failed to record RMC validation results: %w", err)
+	}
+	return nil
+}
+
+// renderValidationError reports the entries of a render that fail
+// validation.
+type renderValidationError struct {
+	name   string
+	failed []mcov1alpha1.ValidationResult
+}
+
+func (e *renderValidationError) Error() string {
+	msgs := make([]string, len(e.failed))
+	for i, f := range e.failed {
+		msgs[i] = fmt.Sprintf("%s %s: %s", f.Kind, f.Name, f.Message)
+	}
+	return fmt.Sprintf("render %s failed validation: %s", e.name, strings.Join(msgs, "; "))
+}
+
+// validationError returns an error naming every entry of rmc that fails
+// validation, or nil when all pass.
+func validationError(rmc *mcov1alpha1.RenderedMachineConfig) error {
+	failed := renderer.FailedValidations(renderer.ValidateRenderedConfig(rmc.Spec.Config))
+	if len(failed) == 0 {
+		return nil
+	}
+	return &renderValidationError{name: rmc.Name, failed: failed}
+}
+
+// validationFailures returns the failing validation results carried by
+// err, or nil when err is not a validation failure.
+func validationFailures(err error) []mcov1alpha1.ValidationResult {
+	var verr *renderValidationError
+	if errors.As(err, &verr) {
+		return verr.failed
+	}
+	return nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *MachineConfigPoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Initialize EventRecorder
 	r.events = NewEventRecorder(mgr.GetEventRecorderFor("machineconfigpool-controller"))
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr)
+	if r.Fairness != FairnessNone {
+		mode := r.Fairness
+		b = b.WithOptions(controller.Options{
+			NewQueue: func(name string, rl workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+				return newFairQueue(mode, name, rl)
+			},
+		})
+	}
+
+	var nodeOpts []builder.WatchesOption
+	if r.IgnoreSelfNodeUpdates {
+		nodeOpts = append(nodeOpts, builder.WithPredicates(ignoreSelfNodeUpdates()))
+	}
+
+	return b.
 		For(&mcov1alpha1.MachineConfigPool{}).
 		Owns(&mcov1alpha1.RenderedMachineConfig{}).
 		Watches(&mcov1alpha1.MachineConfig{}, handler.EnqueueRequestsFromMapFunc(r.mapMachineConfigToPool)).
-		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.mapNodeToPool)).
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.mapNodeToPool), nodeOpts...).
+		Watches(&mcov1alpha1.ConfigFreeze{}, handler.EnqueueRequestsFromMapFunc(r.mapConfigFreezeToPools)).
 		Complete(r)
 }
 
-// mapMachineConfigToPool maps a MachineConfig to the pools that select it.
+// mapMachineConfigToPool maps a MachineConfig to the pools that select it
+// or use it as their base.
 func (r *MachineConfigPoolReconciler) mapMachineConfigToPool(ctx context.Context, obj client.Object) []reconcile.Request {
 	mc, ok := obj.(*mcov1alpha1.MachineConfig)
 	if !ok {
@@ -523,7 +1160,8 @@ func (r *MachineConfigPoolReconciler) mapMachineConfigToPool(ctx context.Context
 	var requests []reconcile.Request
 	for _, pool := range pools.Items {
 		matches, err := MachineConfigMatchesPool(mc, &pool)
-		if err != nil || !matches {
+		isBase := pool.Spec.BaseConfigRef != nil && pool.Spec.BaseConfigRef.Name == mc.Name
+		if err != nil || (!matches && !isBase && !machineConfigMatchesCandidate(mc, &pool)) {
 			continue
 		}
 		requests = append(requests, reconcile.Request{
@@ -534,6 +1172,22 @@ func (r *MachineConfigPoolReconciler) mapMachineConfigToPool(ctx context.Context
 	return requests
 }
 
+// mapConfigFreezeToPools enqueues every pool: a freeze applies cluster-wide.
+func (r *MachineConfigPoolReconciler) mapConfigFreezeToPools(ctx context.Context, _ client.Object) []reconcile.Request {
+	pools := &mcov1alpha1.MachineConfigPoolList{}
+	if err := r.List(ctx, pools); err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(pools.Items))
+	for i := range pools.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&pools.Items[i]),
+		})
+	}
+	return requests
+}
+
 // mapNodeToPool maps a Node to the pools that select it.
 func (r *MachineConfigPoolReconciler) mapNodeToPool(ctx context.Context, obj client.Object) []reconcile.Request {
 	node, ok := obj.(*corev1.Node)
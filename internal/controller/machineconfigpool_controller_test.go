@@ -18,16 +18,23 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
 	"in-cloud.io/machine-config/internal/renderer"
@@ -37,12 +44,13 @@ import (
 func newReconciler(objs ...client.Object) *MachineConfigPoolReconciler {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
+	_ = policyv1.AddToScheme(scheme)
 	_ = mcov1alpha1.AddToScheme(scheme)
 
 	c := fake.NewClientBuilder().
 		WithScheme(scheme).
 		WithObjects(objs...).
-		WithStatusSubresource(&mcov1alpha1.MachineConfigPool{}).
+		WithStatusSubresource(&mcov1alpha1.MachineConfigPool{}, &mcov1alpha1.RenderedMachineConfig{}, &mcov1alpha1.MachineConfigFleet{}).
 		// Add pod index for drain operations
 		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
 			pod := obj.(*corev1.Pod)
@@ -123,7 +131,7 @@ func TestReconcile_Debounce(t *testing.T) {
 	mc := &mcov1alpha1.MachineConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
 		Spec: mcov1alpha1.MachineConfigSpec{
-			Priority: 50,
+			Priority: ptr.To(50),
 		},
 	}
 
@@ -159,7 +167,7 @@ func TestReconcile_CreatesRMC(t *testing.T) {
 	mc := &mcov1alpha1.MachineConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
 		Spec: mcov1alpha1.MachineConfigSpec{
-			Priority: 50,
+			Priority: ptr.To(50),
 			Files: []mcov1alpha1.FileSpec{
 				{Path: "/etc/test.conf", Content: "test"},
 			},
@@ -194,6 +202,209 @@ func TestReconcile_CreatesRMC(t *testing.T) {
 	}
 }
 
+func TestReconcile_InvalidUnitSuffixDegradesRender(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+	mc := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
+		Spec: mcov1alpha1.MachineConfigSpec{
+			Priority: ptr.To(50),
+			Systemd: mcov1alpha1.SystemdSpec{
+				Units: []mcov1alpha1.UnitSpec{{Name: "foo.servic"}},
+			},
+		},
+	}
+
+	r := newReconciler(pool, mc)
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "worker"}}
+
+	// First reconcile arms debounce, second renders
+	_, _ = r.Reconcile(ctx, req)
+	if _, err := r.Reconcile(ctx, req); err == nil {
+		t.Fatal("Reconcile() error = nil, want validation failure")
+	}
+
+	updatedPool := &mcov1alpha1.MachineConfigPool{}
+	if err := r.Get(ctx, req.NamespacedName, updatedPool); err != nil {
+		t.Fatalf("Failed to get pool: %v", err)
+	}
+	degraded := meta.FindStatusCondition(updatedPool.Status.Conditions, mcov1alpha1.ConditionDegraded)
+	if degraded == nil || degraded.Status != metav1.ConditionTrue || degraded.Reason != ReasonRenderFailed {
+		t.Fatalf("Degraded = %+v, want True with reason %s", degraded, ReasonRenderFailed)
+	}
+	if !strings.Contains(degraded.Message, "foo.servic") {
+		t.Errorf("Degraded message = %q, want the bad unit name", degraded.Message)
+	}
+}
+
+func TestReconcile_ExclusiveConfigsDegradeRender(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+	plugin := func(name string) *mcov1alpha1.MachineConfig {
+		return &mcov1alpha1.MachineConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: mcov1alpha1.MachineConfigSpec{
+				Priority:       ptr.To(50),
+				ExclusiveGroup: "network-plugin",
+				Files:          []mcov1alpha1.FileSpec{{Path: "/etc/cni/" + name, Content: name}},
+			},
+		}
+	}
+
+	r := newReconciler(pool, plugin("calico"), plugin("cilium"))
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "worker"}}
+
+	_, _ = r.Reconcile(ctx, req)
+	if _, err := r.Reconcile(ctx, req); err == nil {
+		t.Fatal("Reconcile() error = nil, want exclusive group failure")
+	}
+
+	updatedPool := &mcov1alpha1.MachineConfigPool{}
+	if err := r.Get(ctx, req.NamespacedName, updatedPool); err != nil {
+		t.Fatalf("Failed to get pool: %v", err)
+	}
+	degraded := meta.FindStatusCondition(updatedPool.Status.Conditions, mcov1alpha1.ConditionDegraded)
+	if degraded == nil || degraded.Reason != ReasonRenderFailed {
+		t.Fatalf("Degraded = %+v, want reason %s", degraded, ReasonRenderFailed)
+	}
+	want := `MachineConfigs calico, cilium are mutually exclusive (exclusiveGroup "network-plugin")`
+	if !strings.Contains(degraded.Message, want) {
+		t.Errorf("Degraded message = %q, want %q", degraded.Message, want)
+	}
+}
+
+func TestReconcile_DuplicateEntriesDegradeRender(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+	mc := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
+		Spec: mcov1alpha1.MachineConfigSpec{
+			Priority: ptr.To(50),
+			Files: []mcov1alpha1.FileSpec{
+				{Path: "/etc/foo.conf", Content: "a"},
+				{Path: "/etc/foo.conf", Content: "b"},
+			},
+		},
+	}
+
+	r := newReconciler(pool, mc)
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "worker"}}
+
+	// First reconcile arms debounce, second renders
+	_, _ = r.Reconcile(ctx, req)
+	if _, err := r.Reconcile(ctx, req); err == nil {
+		t.Fatal("Reconcile() error = nil, want duplicate entries failure")
+	}
+
+	updatedPool := &mcov1alpha1.MachineConfigPool{}
+	if err := r.Get(ctx, req.NamespacedName, updatedPool); err != nil {
+		t.Fatalf("Failed to get pool: %v", err)
+	}
+	degraded := meta.FindStatusCondition(updatedPool.Status.Conditions, mcov1alpha1.ConditionDegraded)
+	if degraded == nil || degraded.Status != metav1.ConditionTrue || degraded.Reason != ReasonRenderFailed {
+		t.Fatalf("Degraded = %+v, want True with reason %s", degraded, ReasonRenderFailed)
+	}
+	if !strings.Contains(degraded.Message, "/etc/foo.conf") {
+		t.Errorf("Degraded message = %q, want the duplicated path", degraded.Message)
+	}
+
+	rmcList := &mcov1alpha1.RenderedMachineConfigList{}
+	if err := r.List(ctx, rmcList); err != nil {
+		t.Fatalf("Failed to list RMCs: %v", err)
+	}
+	if len(rmcList.Items) != 0 {
+		t.Errorf("RMCs = %d, want none rendered", len(rmcList.Items))
+	}
+}
+
+// TestReconcile_RecordsRMCValidationResults verifies that a render failing
+// validation is never created, and so never rolled out, but its failures
+// are kept in the pool status, while the results of a valid render are
+// recorded in its status.
+func TestReconcile_RecordsRMCValidationResults(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+	mc := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
+		Spec: mcov1alpha1.MachineConfigSpec{
+			Priority: ptr.To(50),
+			Files: []mcov1alpha1.FileSpec{
+				{Path: "/etc/test.conf", Content: "test"},
+				{Path: "/etc/kubernetes/override.conf", Content: "test"},
+			},
+		},
+	}
+
+	r := newReconciler(pool, mc)
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "worker"}}
+
+	// First reconcile arms debounce, second renders
+	_, _ = r.Reconcile(ctx, req)
+	if _, err := r.Reconcile(ctx, req); err == nil {
+		t.Fatal("Reconcile() error = nil, want validation failure")
+	}
+
+	rmcList := &mcov1alpha1.RenderedMachineConfigList{}
+	if err := r.List(ctx, rmcList); err != nil {
+		t.Fatalf("Failed to list RMCs: %v", err)
+	}
+	if len(rmcList.Items) != 0 {
+		t.Fatalf("RMC count = %d, want 0: an invalid render must not be created", len(rmcList.Items))
+	}
+
+	updatedPool := &mcov1alpha1.MachineConfigPool{}
+	if err := r.Get(ctx, req.NamespacedName, updatedPool); err != nil {
+		t.Fatalf("Failed to get pool: %v", err)
+	}
+	var degraded *metav1.Condition
+	for i := range updatedPool.Status.Conditions {
+		if updatedPool.Status.Conditions[i].Type == mcov1alpha1.ConditionDegraded {
+			degraded = &updatedPool.Status.Conditions[i]
+		}
+	}
+	if degraded == nil || degraded.Status != metav1.ConditionTrue || degraded.Reason != ReasonRenderFailed {
+		t.Fatalf("Degraded = %+v, want True with reason %s", degraded, ReasonRenderFailed)
+	}
+	if !strings.Contains(degraded.Message, "/etc/kubernetes/override.conf") {
+		t.Errorf("Degraded message = %q, want failing path", degraded.Message)
+	}
+	failures := updatedPool.Status.RenderValidationFailures
+	if len(failures) != 1 || failures[0].Name != "/etc/kubernetes/override.conf" ||
+		failures[0].Kind != mcov1alpha1.ValidationKindFile || failures[0].Valid || failures[0].Message == "" {
+		t.Errorf("RenderValidationFailures = %+v, want the failing file", failures)
+	}
+
+	// Fixing the MachineConfig renders a revision with its results recorded.
+	if err := r.Get(ctx, client.ObjectKeyFromObject(mc), mc); err != nil {
+		t.Fatalf("Failed to get MachineConfig: %v", err)
+	}
+	mc.Spec.Files[1].Path = "/etc/other.conf"
+	if err := r.Update(ctx, mc); err != nil {
+		t.Fatalf("Failed to update MachineConfig: %v", err)
+	}
+	r.debounce = NewDebounceState()
+	_, _ = r.Reconcile(ctx, req)
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := r.List(ctx, rmcList); err != nil {
+		t.Fatalf("Failed to list RMCs: %v", err)
+	}
+	if len(rmcList.Items) != 1 {
+		t.Fatalf("RMC count = %d, want 1", len(rmcList.Items))
+	}
+	results := rmcList.Items[0].Status.ValidationResults
+	if len(results) != 2 || !results[0].Valid || !results[1].Valid {
+		t.Errorf("ValidationResults = %+v, want both files valid", results)
+	}
+	if err := r.Get(ctx, req.NamespacedName, updatedPool); err != nil {
+		t.Fatalf("Failed to get pool: %v", err)
+	}
+	if len(updatedPool.Status.RenderValidationFailures) != 0 {
+		t.Errorf("RenderValidationFailures = %+v, want cleared", updatedPool.Status.RenderValidationFailures)
+	}
+}
+
 func TestReconcile_SetsNodeAnnotations(t *testing.T) {
 	pool := &mcov1alpha1.MachineConfigPool{
 		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
@@ -217,7 +428,7 @@ func TestReconcile_SetsNodeAnnotations(t *testing.T) {
 	mc := &mcov1alpha1.MachineConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
 		Spec: mcov1alpha1.MachineConfigSpec{
-			Priority: 50,
+			Priority: ptr.To(50),
 		},
 	}
 
@@ -305,7 +516,7 @@ func TestReconcile_UpdatesPoolStatus(t *testing.T) {
 
 	mc := &mcov1alpha1.MachineConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
-		Spec:       mcov1alpha1.MachineConfigSpec{Priority: 50},
+		Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(50)},
 	}
 
 	allObjs := append([]client.Object{pool, mc}, nodes...)
@@ -349,7 +560,7 @@ func TestReconcile_ReusesExistingRMC(t *testing.T) {
 
 	mc := &mcov1alpha1.MachineConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
-		Spec:       mcov1alpha1.MachineConfigSpec{Priority: 50},
+		Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(50)},
 	}
 
 	r := newReconciler(pool, mc)
@@ -754,6 +965,72 @@ func TestReconcile_EmptyMachineConfigList_NoRollout(t *testing.T) {
 	}
 }
 
+// TestReconcile_RevertWhenEmpty verifies that deleting every MachineConfig
+// of a pool with revertWhenEmpty rolls out a revert of the last revision.
+func TestReconcile_RevertWhenEmpty(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			NodeSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}},
+			RevertWhenEmpty: true,
+		},
+	}
+	last := renderer.BuildRMC("worker", renderer.Merge([]*mcov1alpha1.MachineConfig{{
+		Spec: mcov1alpha1.MachineConfigSpec{Files: []mcov1alpha1.FileSpec{{Path: "/etc/app.conf", Content: "v1", State: "present"}}},
+	}}), pool)
+	pool.Status.TargetRevision = last.Name
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "worker-1",
+		Labels: map[string]string{"role": "worker"},
+		Annotations: map[string]string{
+			annotations.CurrentRevision: last.Name,
+			annotations.DesiredRevision: last.Name,
+			annotations.AgentState:      annotations.StateDone,
+		},
+	}}
+
+	r := newReconciler(pool, last, node)
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "worker"}}
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(ctx, req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+	}
+
+	updated := &mcov1alpha1.MachineConfigPool{}
+	if err := r.Get(ctx, req.NamespacedName, updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status.TargetRevision == "" || updated.Status.TargetRevision == last.Name {
+		t.Fatalf("TargetRevision = %q, want a new revert revision", updated.Status.TargetRevision)
+	}
+	revert := &mcov1alpha1.RenderedMachineConfig{}
+	if err := r.Get(ctx, client.ObjectKey{Name: updated.Status.TargetRevision}, revert); err != nil {
+		t.Fatal(err)
+	}
+	// The revert is empty: the agent removes only the files it created.
+	if files := revert.Spec.Config.Files; len(files) != 0 {
+		t.Errorf("revert files = %+v, want none", files)
+	}
+
+	// Without the flag the last revision stays applied.
+	pool.Spec.RevertWhenEmpty = false
+	r = newReconciler(pool, last, node)
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(ctx, req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+	}
+	rmcs := &mcov1alpha1.RenderedMachineConfigList{}
+	if err := r.List(ctx, rmcs); err != nil {
+		t.Fatal(err)
+	}
+	if len(rmcs.Items) != 1 {
+		t.Errorf("RMC count = %d, want 1 without revertWhenEmpty", len(rmcs.Items))
+	}
+}
+
 // TestReconcile_EmptyToNonEmpty_TriggersRollout verifies that adding MachineConfig
 // to previously empty pool triggers rollout correctly.
 func TestReconcile_EmptyToNonEmpty_TriggersRollout(t *testing.T) {
@@ -800,7 +1077,7 @@ func TestReconcile_EmptyToNonEmpty_TriggersRollout(t *testing.T) {
 	mc := &mcov1alpha1.MachineConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
 		Spec: mcov1alpha1.MachineConfigSpec{
-			Priority: 50,
+			Priority: ptr.To(50),
 			Files: []mcov1alpha1.FileSpec{
 				{Path: "/etc/test.conf", Content: "test"},
 			},
@@ -899,3 +1176,569 @@ func TestEnsureRMC_NoCollision_ReusesExisting(t *testing.T) {
 		t.Errorf("RMC name = %q, want %q (should reuse existing with matching hash)", rmc.Name, existingRMC.Name)
 	}
 }
+
+func TestReconcile_NodeFailureIsolated(t *testing.T) {
+	maxUnavailable := intstr.FromInt(2)
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			NodeSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"role": "worker"},
+			},
+			Rollout: mcov1alpha1.RolloutConfig{
+				MaxUnavailable: &maxUnavailable,
+			},
+		},
+	}
+	good := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "good", Labels: map[string]string{"role": "worker"}}}
+	bad := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "bad", Labels: map[string]string{"role": "worker"}}}
+	mc := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
+		Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(50)},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = mcov1alpha1.AddToScheme(scheme)
+
+	var attempts int
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pool, good, bad, mc).
+		WithStatusSubresource(&mcov1alpha1.MachineConfigPool{}, &mcov1alpha1.RenderedMachineConfig{}).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+			pod := obj.(*corev1.Pod)
+			return []string{pod.Spec.NodeName}
+		}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if _, ok := obj.(*corev1.Node); ok && obj.GetName() == "bad" {
+					attempts++
+					return fmt.Errorf("api unavailable (attempt %d)", attempts)
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := NewMachineConfigPoolReconciler(c, scheme)
+
+	// First reconcile arms debounce, the next ones process the batch and
+	// fail on "bad" with a different error text each time.
+	for i := 0; i < 3; i++ {
+		if _, err := r.Reconcile(context.Background(), ctrl.Request{
+			NamespacedName: client.ObjectKey{Name: "worker"},
+		}); err != nil {
+			t.Fatalf("Reconcile() error = %v, want per-node failure to be isolated", err)
+		}
+	}
+
+	updatedGood := &corev1.Node{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: "good"}, updatedGood); err != nil {
+		t.Fatalf("Failed to get node: %v", err)
+	}
+	if !updatedGood.Spec.Unschedulable {
+		t.Error("healthy node should be cordoned despite failure on another node")
+	}
+
+	updatedPool := &mcov1alpha1.MachineConfigPool{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: "worker"}, updatedPool); err != nil {
+		t.Fatalf("Failed to get pool: %v", err)
+	}
+	var found bool
+	for _, cond := range updatedPool.Status.Conditions {
+		if cond.Type == mcov1alpha1.ConditionNodeUpdateFailed {
+			found = true
+			if cond.Status != metav1.ConditionTrue {
+				t.Errorf("NodeUpdateFailed status = %s, want True", cond.Status)
+			}
+			if !strings.Contains(cond.Message, "bad") || strings.Contains(cond.Message, "good") {
+				t.Errorf("NodeUpdateFailed message = %q, want only failed node", cond.Message)
+			}
+			if strings.Contains(cond.Message, "api unavailable") {
+				t.Errorf("NodeUpdateFailed message = %q, want no raw error text", cond.Message)
+			}
+		}
+	}
+	if !found {
+		t.Error("NodeUpdateFailed condition not found")
+	}
+	for _, f := range updatedPool.Status.RolloutFailures {
+		if strings.Contains(f.Message, "api unavailable") {
+			t.Errorf("rollout failure message = %q, want no raw error text", f.Message)
+		}
+	}
+}
+
+func TestReconcile_MaxReconcileDurationRequeues(t *testing.T) {
+	maxUnavailable := intstr.FromInt(2)
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			NodeSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"role": "worker"},
+			},
+			Rollout: mcov1alpha1.RolloutConfig{
+				MaxUnavailable: &maxUnavailable,
+			},
+		},
+	}
+	node1 := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"role": "worker"}}}
+	node2 := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2", Labels: map[string]string{"role": "worker"}}}
+	mc := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
+		Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(50)},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = mcov1alpha1.AddToScheme(scheme)
+
+	// Every node write is slow enough to exhaust the budget after the first
+	// node, and fails like a real client if its context ends meanwhile.
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pool, node1, node2, mc).
+		WithStatusSubresource(&mcov1alpha1.MachineConfigPool{}, &mcov1alpha1.RenderedMachineConfig{}).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+			pod := obj.(*corev1.Pod)
+			return []string{pod.Spec.NodeName}
+		}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if _, ok := obj.(*corev1.Node); ok {
+					time.Sleep(50 * time.Millisecond)
+					if err := ctx.Err(); err != nil {
+						return err
+					}
+				}
+				return c.Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := NewMachineConfigPoolReconciler(c, scheme)
+	r.MaxReconcileDuration = 10 * time.Millisecond
+
+	var result ctrl.Result
+	for i := 0; i < 2; i++ {
+		var err error
+		result, err = r.Reconcile(context.Background(), ctrl.Request{
+			NamespacedName: client.ObjectKey{Name: "worker"},
+		})
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+	}
+
+	if result.RequeueAfter == 0 {
+		t.Error("expected requeue after aborting node loop")
+	}
+
+	cordoned := 0
+	for _, name := range []string{"node-1", "node-2"} {
+		n := &corev1.Node{}
+		if err := c.Get(context.Background(), client.ObjectKey{Name: name}, n); err != nil {
+			t.Fatalf("Failed to get node: %v", err)
+		}
+		if n.Spec.Unschedulable {
+			cordoned++
+		}
+	}
+	if cordoned != 1 {
+		t.Errorf("cordoned nodes = %d, want 1 (the node in flight finishes, the next is not started)", cordoned)
+	}
+
+	updatedPool := &mcov1alpha1.MachineConfigPool{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: "worker"}, updatedPool); err != nil {
+		t.Fatalf("Failed to get pool: %v", err)
+	}
+	for _, cond := range updatedPool.Status.Conditions {
+		if cond.Type == mcov1alpha1.ConditionNodeUpdateFailed && cond.Status == metav1.ConditionTrue {
+			t.Errorf("NodeUpdateFailed = %q, want no node cut off by the budget", cond.Message)
+		}
+	}
+}
+
+func TestReconcile_SkipsUnchangedStatusWrite(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			NodeSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"role": "worker"},
+			},
+		},
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"role": "worker"}}}
+	mc := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
+		Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(50)},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = mcov1alpha1.AddToScheme(scheme)
+
+	statusWrites := 0
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pool, node, mc).
+		WithStatusSubresource(&mcov1alpha1.MachineConfigPool{}, &mcov1alpha1.RenderedMachineConfig{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				if _, ok := obj.(*mcov1alpha1.MachineConfigPool); ok {
+					statusWrites++
+				}
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	r := NewMachineConfigPoolReconciler(c, scheme)
+
+	// Converge: render the target, then report the node as updated to it.
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "worker"}}
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+	}
+	updated := &mcov1alpha1.MachineConfigPool{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: "worker"}, updated); err != nil {
+		t.Fatalf("Failed to get pool: %v", err)
+	}
+	target := updated.Status.TargetRevision
+	if target == "" {
+		t.Fatal("TargetRevision not set")
+	}
+	n := &corev1.Node{}
+	if err := c.Get(context.Background(), client.ObjectKey{Name: "node-1"}, n); err != nil {
+		t.Fatalf("Failed to get node: %v", err)
+	}
+	n.Annotations = map[string]string{
+		annotations.Pool:            "worker",
+		annotations.DesiredRevision: target,
+		annotations.CurrentRevision: target,
+		annotations.AgentState:      annotations.StateDone,
+	}
+	if err := c.Update(context.Background(), n); err != nil {
+		t.Fatalf("Failed to update node: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+	}
+
+	statusWrites = 0
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if statusWrites != 0 {
+		t.Errorf("status written %d times on a no-op reconcile, want 0", statusWrites)
+	}
+}
+
+func TestReconcile_UpdateNowBypassesBudget(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			NodeSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"role": "worker"},
+			},
+		},
+	}
+	labels := map[string]string{"role": "worker"}
+	nodeA := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "a", Labels: labels}}
+	nodeB := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "b", Labels: labels}}
+	nodeC := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:        "c",
+		Labels:      labels,
+		Annotations: map[string]string{annotations.UpdateNow: "true"},
+	}}
+	mc := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
+		Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(50)},
+	}
+	r := newReconciler(pool, nodeA, nodeB, nodeC, mc)
+
+	// First reconcile arms debounce, second processes the batch
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(context.Background(), ctrl.Request{
+			NamespacedName: client.ObjectKey{Name: "worker"},
+		}); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+	}
+
+	cordoned := map[string]bool{}
+	for _, name := range []string{"a", "b", "c"} {
+		node := &corev1.Node{}
+		if err := r.Get(context.Background(), client.ObjectKey{Name: name}, node); err != nil {
+			t.Fatalf("get node %s: %v", name, err)
+		}
+		cordoned[name] = node.Spec.Unschedulable
+	}
+	// maxUnavailable defaults to 1: "a" is picked by the budget, "c" on request.
+	if !cordoned["a"] || cordoned["b"] || !cordoned["c"] {
+		t.Errorf("cordoned = %v, want a and c", cordoned)
+	}
+}
+
+func TestReconcile_UncordonsNodeStuckInCordon(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			NodeSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"role": "worker"},
+			},
+			Rollout: mcov1alpha1.RolloutConfig{
+				CordonTimeoutSeconds:    600,
+				UncordonOnCordonTimeout: true,
+			},
+		},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "stuck",
+			Labels: map[string]string{"role": "worker"},
+			Annotations: map[string]string{
+				annotations.Cordoned:   "true",
+				annotations.CordonedAt: time.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+			},
+		},
+		Spec: corev1.NodeSpec{Unschedulable: true},
+	}
+	mc := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
+		Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(50)},
+	}
+	r := newReconciler(pool, node, mc)
+
+	// First reconcile arms debounce, second processes the batch
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(context.Background(), ctrl.Request{
+			NamespacedName: client.ObjectKey{Name: "worker"},
+		}); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+	}
+
+	updated := &corev1.Node{}
+	if err := r.Get(context.Background(), client.ObjectKey{Name: "stuck"}, updated); err != nil {
+		t.Fatalf("get node: %v", err)
+	}
+	if updated.Spec.Unschedulable {
+		t.Error("node stuck in cordon phase should be uncordoned")
+	}
+
+	updatedPool := &mcov1alpha1.MachineConfigPool{}
+	if err := r.Get(context.Background(), client.ObjectKey{Name: "worker"}, updatedPool); err != nil {
+		t.Fatalf("get pool: %v", err)
+	}
+	cond := meta.FindStatusCondition(updatedPool.Status.Conditions, mcov1alpha1.ConditionCordonStuck)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Errorf("CordonStuck = %v, want True", cond)
+	}
+}
+
+// TestReconcile_HoldCordonOnErrorKeepsStuckNodeCordoned verifies that the
+// cordon timeout recovery leaves a failed node cordoned when the pool holds
+// cordons on error.
+func TestReconcile_HoldCordonOnErrorKeepsStuckNodeCordoned(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			NodeSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"role": "worker"},
+			},
+			Rollout: mcov1alpha1.RolloutConfig{
+				CordonTimeoutSeconds:    600,
+				UncordonOnCordonTimeout: true,
+				HoldCordonOnError:       true,
+			},
+		},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "failed",
+			Labels: map[string]string{"role": "worker"},
+			Annotations: map[string]string{
+				annotations.Cordoned:        "true",
+				annotations.CordonedAt:      time.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+				annotations.CurrentRevision: "worker-old",
+				annotations.DesiredRevision: "worker-old",
+				annotations.AgentState:      annotations.StateError,
+			},
+		},
+		Spec: corev1.NodeSpec{Unschedulable: true},
+	}
+	mc := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
+		Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(50)},
+	}
+	r := newReconciler(pool, node, mc)
+
+	// First reconcile arms debounce, second processes the batch
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(context.Background(), ctrl.Request{
+			NamespacedName: client.ObjectKey{Name: "worker"},
+		}); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+	}
+
+	updated := &corev1.Node{}
+	if err := r.Get(context.Background(), client.ObjectKey{Name: "failed"}, updated); err != nil {
+		t.Fatalf("get node: %v", err)
+	}
+	if !updated.Spec.Unschedulable || !IsNodeCordoned(updated) {
+		t.Error("failed node should stay cordoned with holdCordonOnError")
+	}
+}
+
+// TestReconcile_MissingBaseConfigDegradesRender verifies a missing base
+// MachineConfig degrades the pool without failing the reconcile, and the
+// pool renders once the base appears.
+func TestReconcile_MissingBaseConfigDegradesRender(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			BaseConfigRef: &mcov1alpha1.MachineConfigReference{Name: "00-base"},
+		},
+	}
+	mc := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "mc-1"},
+		Spec: mcov1alpha1.MachineConfigSpec{
+			Priority: ptr.To(50),
+			Files:    []mcov1alpha1.FileSpec{{Path: "/etc/test.conf", Content: "test"}},
+		},
+	}
+	r := newReconciler(pool, mc)
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "worker"}}
+
+	result, err := r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v, want nil while the base is missing", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("RequeueAfter = %v, want no requeue", result.RequeueAfter)
+	}
+
+	updatedPool := &mcov1alpha1.MachineConfigPool{}
+	if err := r.Get(ctx, req.NamespacedName, updatedPool); err != nil {
+		t.Fatalf("Failed to get pool: %v", err)
+	}
+	degraded := meta.FindStatusCondition(updatedPool.Status.Conditions, mcov1alpha1.ConditionDegraded)
+	if degraded == nil || degraded.Status != metav1.ConditionTrue || degraded.Reason != ReasonRenderFailed {
+		t.Fatalf("Degraded = %+v, want True with reason %s", degraded, ReasonRenderFailed)
+	}
+	if !strings.Contains(degraded.Message, "00-base") {
+		t.Errorf("Degraded message = %q, want the missing base name", degraded.Message)
+	}
+
+	base := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "00-base"},
+		Spec: mcov1alpha1.MachineConfigSpec{
+			Files: []mcov1alpha1.FileSpec{{Path: "/etc/base.conf", Content: "base"}},
+		},
+	}
+	if err := r.Create(ctx, base); err != nil {
+		t.Fatalf("Failed to create base: %v", err)
+	}
+	// First reconcile arms debounce, second renders
+	_, _ = r.Reconcile(ctx, req)
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	rmcList := &mcov1alpha1.RenderedMachineConfigList{}
+	if err := r.List(ctx, rmcList); err != nil {
+		t.Fatalf("Failed to list RMCs: %v", err)
+	}
+	if len(rmcList.Items) != 1 || len(rmcList.Items[0].Spec.Config.Files) != 2 {
+		t.Fatalf("RMCs = %+v, want one render with the base file", rmcList.Items)
+	}
+	if err := r.Get(ctx, req.NamespacedName, updatedPool); err != nil {
+		t.Fatalf("Failed to get pool: %v", err)
+	}
+	degraded = meta.FindStatusCondition(updatedPool.Status.Conditions, mcov1alpha1.ConditionDegraded)
+	if degraded != nil && degraded.Reason == ReasonRenderFailed {
+		t.Errorf("Degraded = %+v, want RenderFailed cleared", degraded)
+	}
+}
+
+func TestReconcile_InvalidDrainConfigRequeuesAndWarnsOnce(t *testing.T) {
+	tests := []struct {
+		name       string
+		inProgress bool
+	}{
+		{"no node in progress", false},
+		{"node in progress", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &mcov1alpha1.MachineConfigPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+				Spec: mcov1alpha1.MachineConfigPoolSpec{
+					NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}},
+				},
+			}
+			mc := &mcov1alpha1.MachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Spec:       mcov1alpha1.MachineConfigSpec{Files: []mcov1alpha1.FileSpec{{Path: "/etc/app.conf", Content: "v1"}}},
+			}
+			cm := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: DrainConfigMapName, Namespace: MCONamespace},
+				Data:       map[string]string{DrainConfigMapKey: "exclusions:\n  - {}\n"},
+			}
+			waiting := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name:        "waiting",
+				Labels:      map[string]string{"role": "worker"},
+				Annotations: map[string]string{annotations.CurrentRevision: "worker-old"},
+			}}
+			objs := []client.Object{pool, mc, cm, waiting}
+			if tt.inProgress {
+				objs = append(objs, &corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:   "draining",
+						Labels: map[string]string{"role": "worker"},
+						Annotations: map[string]string{
+							annotations.CurrentRevision: "worker-old",
+							annotations.DesiredRevision: "worker-old",
+							annotations.Cordoned:        "true",
+						},
+					},
+					Spec: corev1.NodeSpec{Unschedulable: true},
+				})
+			}
+
+			r := newReconciler(objs...)
+			ctx := context.Background()
+			req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "worker"}}
+			// First reconcile arms debounce, second proceeds.
+			if _, err := r.Reconcile(ctx, req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			recorder := record.NewFakeRecorder(20)
+			r.events = NewEventRecorder(recorder)
+			result, err := r.Reconcile(ctx, req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if result.RequeueAfter <= 0 || result.RequeueAfter > DrainConfigRecheckInterval {
+				t.Errorf("RequeueAfter = %v, want at most %v to pick up a fixed config", result.RequeueAfter, DrainConfigRecheckInterval)
+			}
+
+			close(recorder.Events)
+			invalid := 0
+			for event := range recorder.Events {
+				if strings.Contains(event, ReasonDrainConfigInvalid) {
+					invalid++
+				}
+			}
+			if invalid != 1 {
+				t.Errorf("DrainConfigInvalid events = %d, want 1", invalid)
+			}
+		})
+	}
+}
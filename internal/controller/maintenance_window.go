@@ -0,0 +1,165 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+// windowOpenings returns the times w opens on the calendar days from
+// firstDay to lastDay relative to now's date in the window's time zone.
+func windowOpenings(w mcov1alpha1.MaintenanceWindow, now time.Time, firstDay, lastDay int) ([]time.Time, error) {
+	loc := time.UTC
+	if w.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(w.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid maintenance window time zone %q: %w", w.TimeZone, err)
+		}
+	}
+	hour, minute, err := parseWindowStart(w.Start)
+	if err != nil {
+		return nil, err
+	}
+
+	local := now.In(loc)
+	var openings []time.Time
+	for day := firstDay; day <= lastDay; day++ {
+		open := time.Date(local.Year(), local.Month(), local.Day()+day, hour, minute, 0, 0, loc)
+		if windowOpensOn(w, open.Weekday()) {
+			openings = append(openings, open)
+		}
+	}
+	return openings, nil
+}
+
+func parseWindowStart(start string) (int, int, error) {
+	h, m, ok := strings.Cut(start, ":")
+	hour, herr := strconv.Atoi(h)
+	minute, merr := strconv.Atoi(m)
+	if !ok || herr != nil || merr != nil || hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0, 0, fmt.Errorf("invalid maintenance window start %q, expected HH:MM", start)
+	}
+	return hour, minute, nil
+}
+
+func windowOpensOn(w mcov1alpha1.MaintenanceWindow, day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+	for _, d := range w.Days {
+		if d == day.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// windowDays is how many days back an opening can still cover now.
+func windowDays(w mcov1alpha1.MaintenanceWindow) int {
+	return (w.DurationMinutes + 24*60 - 1) / (24 * 60)
+}
+
+// InMaintenanceWindow reports whether now falls inside any of windows.
+// Windows that run past midnight count for the following day too.
+// Windows with an invalid start or time zone never match.
+func InMaintenanceWindow(now time.Time, windows []mcov1alpha1.MaintenanceWindow) bool {
+	for _, w := range windows {
+		openings, err := windowOpenings(w, now, -windowDays(w), 0)
+		if err != nil {
+			continue
+		}
+		duration := time.Duration(w.DurationMinutes) * time.Minute
+		for _, open := range openings {
+			if !now.Before(open) && now.Before(open.Add(duration)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NextMaintenanceWindow returns when the next of windows opens after now.
+// ok is false when none of the windows can open.
+func NextMaintenanceWindow(now time.Time, windows []mcov1alpha1.MaintenanceWindow) (time.Time, bool) {
+	var next time.Time
+	for _, w := range windows {
+		if w.DurationMinutes <= 0 {
+			continue
+		}
+		// A week ahead plus one day covers zone offset changes.
+		openings, err := windowOpenings(w, now, 0, 8)
+		if err != nil {
+			continue
+		}
+		for _, open := range openings {
+			if open.After(now) && (next.IsZero() || open.Before(next)) {
+				next = open
+			}
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// ValidateMaintenanceWindows returns the first invalid start or time zone
+// among windows.
+func ValidateMaintenanceWindows(windows []mcov1alpha1.MaintenanceWindow) error {
+	for _, w := range windows {
+		if _, err := windowOpenings(w, time.Now(), 0, -1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetRolloutPausedWindowCondition sets RolloutPausedWindow=True. next is
+// when the next window opens, zero if unknown.
+func SetRolloutPausedWindowCondition(pool *mcov1alpha1.MachineConfigPool, next time.Time) {
+	message := "Outside all maintenance windows; no new nodes are started"
+	if !next.IsZero() {
+		message += fmt.Sprintf(" until %s", next.UTC().Format(time.RFC3339))
+	}
+	setCondition(pool, metav1.Condition{
+		Type:               mcov1alpha1.ConditionRolloutPausedWindow,
+		Status:             metav1.ConditionTrue,
+		Reason:             "OutsideMaintenanceWindow",
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// ClearRolloutPausedWindowCondition sets RolloutPausedWindow=False if it
+// was previously set.
+func ClearRolloutPausedWindowCondition(pool *mcov1alpha1.MachineConfigPool) {
+	for _, c := range pool.Status.Conditions {
+		if c.Type == mcov1alpha1.ConditionRolloutPausedWindow {
+			setCondition(pool, metav1.Condition{
+				Type:               mcov1alpha1.ConditionRolloutPausedWindow,
+				Status:             metav1.ConditionFalse,
+				Reason:             "InsideMaintenanceWindow",
+				LastTransitionTime: metav1.Now(),
+			})
+			return
+		}
+	}
+}
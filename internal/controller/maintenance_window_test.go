@@ -0,0 +1,157 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/internal/renderer"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+func TestInMaintenanceWindow(t *testing.T) {
+	// Friday 22:00 for four hours, i.e. until Saturday 02:00.
+	overnight := []mcov1alpha1.MaintenanceWindow{{Days: []string{"Friday"}, Start: "22:00", DurationMinutes: 240}}
+	moscow := []mcov1alpha1.MaintenanceWindow{{Start: "03:00", DurationMinutes: 60, TimeZone: "Europe/Moscow"}}
+	at := func(day, hour, minute int) time.Time {
+		// 2026-10-16 is a Friday.
+		return time.Date(2026, 10, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		windows []mcov1alpha1.MaintenanceWindow
+		now     time.Time
+		want    bool
+	}{
+		{"before opening", overnight, at(16, 21, 59), false},
+		{"at opening", overnight, at(16, 22, 0), true},
+		{"after midnight", overnight, at(17, 1, 30), true},
+		{"at closing", overnight, at(17, 2, 0), false},
+		{"wrong day", overnight, at(15, 23, 0), false},
+		{"previous day spills into allowed day only", overnight, at(16, 1, 0), false},
+		{"time zone inside", moscow, at(16, 0, 30), true},
+		{"time zone outside", moscow, at(16, 3, 30), false},
+		{"invalid time zone", []mcov1alpha1.MaintenanceWindow{{Start: "00:00", DurationMinutes: 1440, TimeZone: "Nowhere/City"}}, at(16, 12, 0), false},
+		{"second window matches", append([]mcov1alpha1.MaintenanceWindow{moscow[0]}, overnight...), at(16, 23, 0), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InMaintenanceWindow(tt.now, tt.windows); got != tt.want {
+				t.Errorf("InMaintenanceWindow(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNextMaintenanceWindow(t *testing.T) {
+	windows := []mcov1alpha1.MaintenanceWindow{
+		{Days: []string{"Friday"}, Start: "22:00", DurationMinutes: 240},
+		{Days: []string{"Tuesday"}, Start: "09:30", DurationMinutes: 60, TimeZone: "Europe/Moscow"},
+	}
+
+	next, ok := NextMaintenanceWindow(time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC), windows)
+	want := time.Date(2026, 10, 20, 6, 30, 0, 0, time.UTC)
+	if !ok || !next.Equal(want) {
+		t.Errorf("NextMaintenanceWindow() = %v, %v, want %v", next, ok, want)
+	}
+
+	next, ok = NextMaintenanceWindow(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC), windows)
+	want = time.Date(2026, 10, 16, 22, 0, 0, 0, time.UTC)
+	if !ok || !next.Equal(want) {
+		t.Errorf("NextMaintenanceWindow() = %v, %v, want %v", next, ok, want)
+	}
+
+	if _, ok := NextMaintenanceWindow(time.Now(), nil); ok {
+		t.Error("NextMaintenanceWindow() without windows reported an opening")
+	}
+}
+
+func TestReconcile_OutsideMaintenanceWindow(t *testing.T) {
+	// A window that opens tomorrow, so now is outside of it.
+	tomorrow := time.Now().UTC().Add(24 * time.Hour)
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}},
+			MachineConfigSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"pool": "worker"},
+			},
+			Rollout: mcov1alpha1.RolloutConfig{MaintenanceWindows: []mcov1alpha1.MaintenanceWindow{{
+				Days:            []string{tomorrow.Weekday().String()},
+				Start:           tomorrow.Format("15:04"),
+				DurationMinutes: 60,
+			}}},
+		},
+	}
+	mc := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"pool": "worker"}},
+		Spec:       mcov1alpha1.MachineConfigSpec{Files: []mcov1alpha1.FileSpec{{Path: "/etc/app.conf", Content: "v1"}}},
+	}
+	rmc := renderer.BuildRMC("worker", renderer.Merge([]*mcov1alpha1.MachineConfig{mc}), pool)
+	pool.Status.TargetRevision = rmc.Name
+
+	waiting := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:        "waiting",
+		Labels:      map[string]string{"role": "worker"},
+		Annotations: map[string]string{annotations.CurrentRevision: "worker-old"},
+	}}
+
+	r := newReconciler(pool, mc, rmc, waiting)
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "worker"}}
+	// First reconcile arms debounce, second proceeds.
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	result, err := r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	got := &corev1.Node{}
+	if err := r.Get(ctx, client.ObjectKey{Name: "waiting"}, got); err != nil {
+		t.Fatal(err)
+	}
+	if d := got.Annotations[annotations.DesiredRevision]; d != "" {
+		t.Errorf("node started outside maintenance window: desired = %q", d)
+	}
+	if result.RequeueAfter <= 23*time.Hour || result.RequeueAfter > 24*time.Hour {
+		t.Errorf("RequeueAfter = %v, want about 24h until the window opens", result.RequeueAfter)
+	}
+
+	updated := &mcov1alpha1.MachineConfigPool{}
+	if err := r.Get(ctx, client.ObjectKey{Name: "worker"}, updated); err != nil {
+		t.Fatal(err)
+	}
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, mcov1alpha1.ConditionRolloutPausedWindow) {
+		t.Errorf("RolloutPausedWindow not set: %+v", updated.Status.Conditions)
+	}
+	want := []mcov1alpha1.SkippedMachineCount{{Reason: SkipReasonOutsideWindow, Count: 1}}
+	if got := updated.Status.SkippedMachines; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("SkippedMachines = %+v, want %+v", got, want)
+	}
+}
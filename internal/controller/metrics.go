@@ -17,6 +17,8 @@ limitations under the License.
 package controller
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
@@ -71,6 +73,14 @@ var (
 		[]string{"pool"},
 	)
 
+	evictedPodsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mco_evicted_pods_total",
+			Help: "Total number of pods evicted or deleted by drains per pool",
+		},
+		[]string{"pool"},
+	)
+
 	cordonedNodes = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "mco_cordoned_nodes",
@@ -86,6 +96,24 @@ var (
 		},
 		[]string{"pool"},
 	)
+
+	// configApplyDuration is deliberately unlabeled: it is the fleet-wide
+	// distribution of config propagation latency across all pools.
+	configApplyDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "mco_config_apply_duration_seconds",
+			Help:    "Time from setting a node's desired revision until its update completed, across all nodes",
+			Buckets: prometheus.ExponentialBuckets(5, 2, 12), // 5s to ~2.8h
+		},
+	)
+
+	oldestUpdatingAge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "mco_oldest_updating_node_age_seconds",
+			Help: "Time since the longest-running in-progress node update started per pool",
+		},
+		[]string{"pool"},
+	)
 )
 
 func init() {
@@ -96,8 +124,11 @@ func init() {
 		poolReconcileDuration,
 		drainDuration,
 		drainStuckTotal,
+		evictedPodsTotal,
 		cordonedNodes,
 		drainingNodes,
+		oldestUpdatingAge,
+		configApplyDuration,
 	)
 }
 
@@ -140,6 +171,7 @@ func ResetPoolMetrics(pool string) {
 	poolOverlapNodesTotal.DeleteLabelValues(pool)
 	cordonedNodes.DeleteLabelValues(pool)
 	drainingNodes.DeleteLabelValues(pool)
+	oldestUpdatingAge.DeleteLabelValues(pool)
 }
 
 func RecordDrainDuration(pool, node string, durationSeconds float64) {
@@ -150,6 +182,16 @@ func RecordDrainStuck(pool string) {
 	drainStuckTotal.WithLabelValues(pool).Inc()
 }
 
+func RecordEvictedPods(pool string, count int) {
+	if count > 0 {
+		evictedPodsTotal.WithLabelValues(pool).Add(float64(count))
+	}
+}
+
+func RecordConfigApplyDuration(durationSeconds float64) {
+	configApplyDuration.Observe(durationSeconds)
+}
+
 func UpdateCordonedNodesGauge(pool string, count int) {
 	cordonedNodes.WithLabelValues(pool).Set(float64(count))
 }
@@ -157,3 +199,7 @@ func UpdateCordonedNodesGauge(pool string, count int) {
 func UpdateDrainingNodesGauge(pool string, count int) {
 	drainingNodes.WithLabelValues(pool).Set(float64(count))
 }
+
+func UpdateOldestUpdatingAgeGauge(pool string, age time.Duration) {
+	oldestUpdatingAge.WithLabelValues(pool).Set(age.Seconds())
+}
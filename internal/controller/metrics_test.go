@@ -18,8 +18,11 @@ package controller
 
 import (
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"in-cloud.io/machine-config/pkg/annotations"
 )
 
 func TestRecordDrainDuration(t *testing.T) {
@@ -34,6 +37,26 @@ func TestRecordDrainDuration(t *testing.T) {
 	}
 }
 
+func TestRecordConfigApplyDuration(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	ann := map[string]string{
+		annotations.DesiredRevisionSetAt: now.Add(-90 * time.Second).Format(time.RFC3339),
+	}
+
+	duration, ok := ApplyDuration(ann, now)
+	if !ok || duration != 90*time.Second {
+		t.Fatalf("ApplyDuration() = %v, %v, want 90s", duration, ok)
+	}
+	if _, ok := ApplyDuration(nil, now); ok {
+		t.Error("ApplyDuration() without annotation should not be ok")
+	}
+
+	RecordConfigApplyDuration(duration.Seconds())
+	if count := testutil.CollectAndCount(configApplyDuration); count != 1 {
+		t.Errorf("expected a single unlabeled series, got %d", count)
+	}
+}
+
 func TestRecordDrainStuck(t *testing.T) {
 	drainStuckTotal.Reset()
 
@@ -86,6 +109,17 @@ func TestUpdateDrainingNodesGauge(t *testing.T) {
 	}
 }
 
+func TestUpdateOldestUpdatingAgeGauge(t *testing.T) {
+	oldestUpdatingAge.Reset()
+
+	UpdateOldestUpdatingAgeGauge("workers", 90*time.Second)
+
+	val := testutil.ToFloat64(oldestUpdatingAge.WithLabelValues("workers"))
+	if val != 90 {
+		t.Errorf("oldest updating age gauge = %f, want 90", val)
+	}
+}
+
 func TestResetPoolMetrics_ClearsNewMetrics(t *testing.T) {
 	cordonedNodes.Reset()
 	drainingNodes.Reset()
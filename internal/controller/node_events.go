@@ -0,0 +1,121 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+// controllerNodeAnnotations are the node annotations written by this
+// controller. A node update touching only these is the echo of our own write.
+var controllerNodeAnnotations = []string{
+	annotations.DesiredRevision,
+	annotations.DesiredRevisionSetAt,
+	annotations.Pool,
+	annotations.RebootStrategy,
+	annotations.SkipReason,
+	annotations.Cordoned,
+	annotations.CordonedAt,
+	annotations.DrainStartedAt,
+	annotations.DrainCompletedAt,
+	annotations.DrainRetryCount,
+	annotations.DrainBlockedReason,
+	annotations.DrainLowestRemaining,
+	annotations.DrainNoProgressCount,
+	annotations.DrainProgressCheckedAt,
+	annotations.OnUpdateLabels,
+}
+
+// controllerRemovedNodeAnnotations are set by users and removed by this
+// controller once consumed. Only their removal is the echo of our own
+// write; setting one must still reconcile the pool.
+var controllerRemovedNodeAnnotations = []string{
+	annotations.UpdateRequested,
+	annotations.UpdateNow,
+}
+
+// ignoreSelfNodeUpdates drops node update events whose only change is to
+// controller-written annotations. The reconcile that made the write already
+// re-reads nodes for status and requeues when it needs to come back.
+func ignoreSelfNodeUpdates() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, ok := e.ObjectOld.(*corev1.Node)
+			if !ok {
+				return true
+			}
+			newNode, ok := e.ObjectNew.(*corev1.Node)
+			if !ok {
+				return true
+			}
+			return !onlyControllerAnnotationsChanged(oldNode, newNode)
+		},
+	}
+}
+
+// onlyControllerAnnotationsChanged reports whether newNode differs from
+// oldNode in controller-written annotations and nothing else that matters.
+func onlyControllerAnnotationsChanged(oldNode, newNode *corev1.Node) bool {
+	changed := false
+	for _, key := range controllerNodeAnnotations {
+		oldValue, oldOK := oldNode.Annotations[key]
+		newValue, newOK := newNode.Annotations[key]
+		if oldOK != newOK || oldValue != newValue {
+			changed = true
+			break
+		}
+	}
+	for _, key := range controllerRemovedNodeAnnotations {
+		oldValue, oldOK := oldNode.Annotations[key]
+		newValue, newOK := newNode.Annotations[key]
+		if newOK && (!oldOK || oldValue != newValue) {
+			return false
+		}
+		if oldOK && !newOK {
+			changed = true
+		}
+	}
+	if !changed {
+		return false
+	}
+
+	return equality.Semantic.DeepEqual(oldNode.Labels, newNode.Labels) &&
+		equality.Semantic.DeepEqual(oldNode.Spec, newNode.Spec) &&
+		equality.Semantic.DeepEqual(oldNode.Status, newNode.Status) &&
+		equality.Semantic.DeepEqual(withoutControllerAnnotations(oldNode.Annotations), withoutControllerAnnotations(newNode.Annotations))
+}
+
+// withoutControllerAnnotations returns a copy of in without the
+// controller-written keys.
+func withoutControllerAnnotations(in map[string]string) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	for _, key := range controllerNodeAnnotations {
+		delete(out, key)
+	}
+	for _, key := range controllerRemovedNodeAnnotations {
+		delete(out, key)
+	}
+	return out
+}
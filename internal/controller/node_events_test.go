@@ -0,0 +1,159 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+func TestIgnoreSelfNodeUpdates(t *testing.T) {
+	base := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "worker-1",
+			ResourceVersion: "1",
+			Labels:          map[string]string{"role": "worker"},
+			Annotations: map[string]string{
+				annotations.CurrentRevision: "worker-abc",
+				annotations.AgentState:      annotations.StateDone,
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(n *corev1.Node)
+		want   bool
+	}{
+		{
+			name: "controller annotation only",
+			mutate: func(n *corev1.Node) {
+				n.Annotations[annotations.DesiredRevision] = "worker-def"
+				n.Annotations[annotations.DesiredRevisionSetAt] = "2026-01-01T00:00:00Z"
+			},
+			want: false,
+		},
+		{
+			name: "skip reason",
+			mutate: func(n *corev1.Node) {
+				n.Annotations[annotations.SkipReason] = SkipReasonBudget
+			},
+			want: false,
+		},
+		{
+			name: "drain blocked reason",
+			mutate: func(n *corev1.Node) {
+				n.Annotations[annotations.DrainBlockedReason] = annotations.DrainBlockedPDB
+			},
+			want: false,
+		},
+		{
+			name: "on-update labels bookkeeping",
+			mutate: func(n *corev1.Node) {
+				n.Annotations[annotations.OnUpdateLabels] = "example.com/updated"
+			},
+			want: false,
+		},
+		{
+			name: "update request set",
+			mutate: func(n *corev1.Node) {
+				n.Annotations[annotations.UpdateNow] = annotations.ValueTrue
+			},
+			want: true,
+		},
+		{
+			name: "agent annotation",
+			mutate: func(n *corev1.Node) {
+				n.Annotations[annotations.AgentState] = annotations.StateApplying
+			},
+			want: true,
+		},
+		{
+			name: "controller and agent annotations",
+			mutate: func(n *corev1.Node) {
+				n.Annotations[annotations.DesiredRevision] = "worker-def"
+				n.Annotations[annotations.AgentState] = annotations.StateApplying
+			},
+			want: true,
+		},
+		{
+			name: "cordon changes spec",
+			mutate: func(n *corev1.Node) {
+				n.Annotations[annotations.Cordoned] = annotations.ValueTrue
+				n.Spec.Unschedulable = true
+			},
+			want: true,
+		},
+		{
+			name: "label change",
+			mutate: func(n *corev1.Node) {
+				n.Labels["role"] = "infra"
+			},
+			want: true,
+		},
+		{
+			name:   "resync",
+			mutate: func(n *corev1.Node) {},
+			want:   true,
+		},
+	}
+
+	p := ignoreSelfNodeUpdates()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := base.DeepCopy()
+			updated.ResourceVersion = "2"
+			tt.mutate(updated)
+			got := p.Update(event.UpdateEvent{ObjectOld: base, ObjectNew: updated})
+			if got != tt.want {
+				t.Errorf("Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIgnoreSelfNodeUpdates_ConsumedUpdateRequest(t *testing.T) {
+	requested := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "worker-1",
+			ResourceVersion: "1",
+			Annotations: map[string]string{
+				annotations.CurrentRevision: "worker-abc",
+				annotations.UpdateNow:       annotations.ValueTrue,
+				annotations.UpdateRequested: annotations.ValueTrue,
+			},
+		},
+	}
+	consumed := requested.DeepCopy()
+	consumed.ResourceVersion = "2"
+	delete(consumed.Annotations, annotations.UpdateNow)
+	delete(consumed.Annotations, annotations.UpdateRequested)
+	consumed.Annotations[annotations.DesiredRevision] = "worker-def"
+
+	p := ignoreSelfNodeUpdates()
+	if p.Update(event.UpdateEvent{ObjectOld: requested, ObjectNew: consumed}) {
+		t.Error("removing consumed update requests should not re-enqueue the pool")
+	}
+	if !p.Update(event.UpdateEvent{ObjectOld: consumed, ObjectNew: requested}) {
+		t.Error("setting update requests should re-enqueue the pool")
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+// DesiredNodeLabels returns the mirrored labels a node should carry.
+// Values are nil when the label should be removed: when mirroring is
+// disabled, or when the node has no current revision yet.
+func DesiredNodeLabels(pool *mcov1alpha1.MachineConfigPool, node *corev1.Node) map[string]*string {
+	labels := map[string]*string{
+		annotations.LabelPool:     nil,
+		annotations.LabelRevision: nil,
+	}
+	if !pool.Spec.MirrorNodeLabels {
+		return labels
+	}
+
+	poolValue := labelValue(pool.Name)
+	labels[annotations.LabelPool] = &poolValue
+
+	current := annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision)
+	if current != "" {
+		revision := labelValue(strings.TrimPrefix(current, pool.Name+"-"))
+		labels[annotations.LabelRevision] = &revision
+	}
+
+	return labels
+}
+
+// SyncNodeLabels reconciles mirrored pool/revision labels on the pool's nodes.
+// When mirroring is disabled, only labels previously written for this pool
+// are removed so that nodes of other pools are left untouched.
+// Returns the number of nodes patched.
+func SyncNodeLabels(ctx context.Context, annotator *NodeAnnotator, pool *mcov1alpha1.MachineConfigPool, nodes []corev1.Node) (int, error) {
+	patched := 0
+	for i := range nodes {
+		node := &nodes[i]
+
+		if !pool.Spec.MirrorNodeLabels && node.Labels[annotations.LabelPool] != labelValue(pool.Name) {
+			continue
+		}
+
+		desired := DesiredNodeLabels(pool, node)
+		if labelsInSync(node.Labels, desired) {
+			continue
+		}
+
+		if err := annotator.SetLabels(ctx, node.Name, desired); err != nil {
+			return patched, fmt.Errorf("failed to sync labels on node %s: %w", node.Name, err)
+		}
+		patched++
+	}
+	return patched, nil
+}
+
+// DesiredOnUpdateLabels returns the pool's onUpdateLabels as they should
+// be on node, and the keys the controller owns afterwards. The labels are
+// set while the node runs target and its agent is done. Keys the controller
+// set earlier are removed (nil) once the node regresses or the key is no
+// longer configured; labels it never set are left alone.
+func DesiredOnUpdateLabels(pool *mcov1alpha1.MachineConfigPool, node *corev1.Node, target string) (map[string]*string, []string) {
+	labels := make(map[string]*string)
+	for _, key := range strings.Split(annotations.GetAnnotation(node.Annotations, annotations.OnUpdateLabels), ",") {
+		if key != "" {
+			labels[key] = nil
+		}
+	}
+
+	converged := target != "" &&
+		annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision) == target &&
+		annotations.GetAnnotation(node.Annotations, annotations.AgentState) == annotations.StateDone
+	if !converged {
+		return labels, nil
+	}
+
+	owned := make([]string, 0, len(pool.Spec.Rollout.OnUpdateLabels))
+	for key, value := range pool.Spec.Rollout.OnUpdateLabels {
+		labels[key] = &value
+		owned = append(owned, key)
+	}
+	sort.Strings(owned)
+	return labels, owned
+}
+
+// SyncOnUpdateLabels reconciles the pool's onUpdateLabels on its nodes and
+// records the keys it set in the on-update-labels annotation.
+// Returns the number of nodes patched.
+func SyncOnUpdateLabels(
+	ctx context.Context,
+	annotator *NodeAnnotator,
+	pool *mcov1alpha1.MachineConfigPool,
+	nodes []corev1.Node,
+	targetFor targetFunc,
+) (int, error) {
+	patched := 0
+	for i := range nodes {
+		node := &nodes[i]
+
+		desired, owned := DesiredOnUpdateLabels(pool, node, targetFor(node))
+		ownedValue := strings.Join(owned, ",")
+		if labelsInSync(node.Labels, desired) &&
+			annotations.GetAnnotation(node.Annotations, annotations.OnUpdateLabels) == ownedValue {
+			continue
+		}
+
+		var ownedAnnotation *string
+		if ownedValue != "" {
+			ownedAnnotation = &ownedValue
+		}
+		if err := annotator.SetMetadata(ctx, node.Name, desired,
+			map[string]*string{annotations.OnUpdateLabels: ownedAnnotation}); err != nil {
+			return patched, fmt.Errorf("failed to sync on-update labels on node %s: %w", node.Name, err)
+		}
+		patched++
+	}
+	return patched, nil
+}
+
+func labelsInSync(current map[string]string, desired map[string]*string) bool {
+	for key, value := range desired {
+		got, ok := current[key]
+		if value == nil {
+			if ok {
+				return false
+			}
+			continue
+		}
+		if !ok || got != *value {
+			return false
+		}
+	}
+	return true
+}
+
+// labelValue fits s into the 63 character label value limit. Longer values
+// are truncated and suffixed with a hash of the full value to stay unique.
+func labelValue(s string) string {
+	if len(s) <= validation.LabelValueMaxLength {
+		return s
+	}
+	sum := sha256.Sum256([]byte(s))
+	suffix := hex.EncodeToString(sum[:4])
+	prefix := strings.TrimRight(s[:validation.LabelValueMaxLength-len(suffix)-1], "-_.")
+	return prefix + "-" + suffix
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+func TestLabelValue(t *testing.T) {
+	if got := labelValue("worker"); got != "worker" {
+		t.Errorf("labelValue(short) = %q, want unchanged", got)
+	}
+
+	long := strings.Repeat("a", 100)
+	got := labelValue(long)
+	if len(got) > validation.LabelValueMaxLength {
+		t.Errorf("labelValue(long) length = %d, want <= %d", len(got), validation.LabelValueMaxLength)
+	}
+	if errs := validation.IsValidLabelValue(got); len(errs) > 0 {
+		t.Errorf("labelValue(long) = %q is not a valid label value: %v", got, errs)
+	}
+	if got == labelValue(strings.Repeat("a", 99)+"b") {
+		t.Error("truncated values of different inputs should differ")
+	}
+}
+
+func TestDesiredNodeLabels(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec:       mcov1alpha1.MachineConfigPoolSpec{MirrorNodeLabels: true},
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:        "n1",
+		Annotations: map[string]string{annotations.CurrentRevision: "worker-abc1234567"},
+	}}
+
+	labels := DesiredNodeLabels(pool, node)
+	if v := labels[annotations.LabelPool]; v == nil || *v != "worker" {
+		t.Errorf("pool label = %v, want worker", v)
+	}
+	if v := labels[annotations.LabelRevision]; v == nil || *v != "abc1234567" {
+		t.Errorf("revision label = %v, want abc1234567", v)
+	}
+
+	pool.Spec.MirrorNodeLabels = false
+	labels = DesiredNodeLabels(pool, node)
+	if labels[annotations.LabelPool] != nil || labels[annotations.LabelRevision] != nil {
+		t.Error("expected labels to be removed when mirroring is disabled")
+	}
+}
+
+func TestSyncNodeLabels(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec:       mcov1alpha1.MachineConfigPoolSpec{MirrorNodeLabels: true},
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:        "n1",
+		Labels:      map[string]string{"role": "worker"},
+		Annotations: map[string]string{annotations.CurrentRevision: "worker-abc"},
+	}}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(node).Build()
+	annotator := NewNodeAnnotator(c)
+	ctx := context.Background()
+
+	patched, err := SyncNodeLabels(ctx, annotator, pool, []corev1.Node{*node})
+	if err != nil || patched != 1 {
+		t.Fatalf("SyncNodeLabels() = %d, %v; want 1, nil", patched, err)
+	}
+
+	updated := &corev1.Node{}
+	if err := c.Get(ctx, client.ObjectKey{Name: "n1"}, updated); err != nil {
+		t.Fatalf("Failed to get node: %v", err)
+	}
+	if updated.Labels[annotations.LabelPool] != "worker" || updated.Labels[annotations.LabelRevision] != "abc" {
+		t.Errorf("labels = %v, want pool and revision mirrored", updated.Labels)
+	}
+	if updated.Labels["role"] != "worker" {
+		t.Error("existing labels must be preserved")
+	}
+
+	// Already in sync: no patch
+	patched, err = SyncNodeLabels(ctx, annotator, pool, []corev1.Node{*updated})
+	if err != nil || patched != 0 {
+		t.Errorf("SyncNodeLabels() in sync = %d, %v; want 0, nil", patched, err)
+	}
+
+	// Disabling mirroring removes the labels
+	pool.Spec.MirrorNodeLabels = false
+	if _, err := SyncNodeLabels(ctx, annotator, pool, []corev1.Node{*updated}); err != nil {
+		t.Fatalf("SyncNodeLabels() error = %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKey{Name: "n1"}, updated); err != nil {
+		t.Fatalf("Failed to get node: %v", err)
+	}
+	if _, ok := updated.Labels[annotations.LabelPool]; ok {
+		t.Error("pool label should be removed when mirroring is disabled")
+	}
+}
+
+func TestSyncOnUpdateLabels(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			Rollout: mcov1alpha1.RolloutConfig{
+				OnUpdateLabels: map[string]string{"example.com/ready": "true"},
+			},
+		},
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "n1",
+		Labels: map[string]string{"example.com/other": "keep"},
+		Annotations: map[string]string{
+			annotations.CurrentRevision: "worker-old",
+			annotations.AgentState:      annotations.StateApplying,
+		},
+	}}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(node).Build()
+	annotator := NewNodeAnnotator(c)
+	ctx := context.Background()
+	target := singleTarget("worker-new")
+
+	get := func() *corev1.Node {
+		t.Helper()
+		n := &corev1.Node{}
+		if err := c.Get(ctx, client.ObjectKey{Name: "n1"}, n); err != nil {
+			t.Fatalf("Failed to get node: %v", err)
+		}
+		return n
+	}
+	setState := func(current, state string) {
+		t.Helper()
+		n := get()
+		n.Annotations[annotations.CurrentRevision] = current
+		n.Annotations[annotations.AgentState] = state
+		if err := c.Update(ctx, n); err != nil {
+			t.Fatalf("Failed to update node: %v", err)
+		}
+	}
+
+	// Not converged yet: nothing to do
+	if patched, err := SyncOnUpdateLabels(ctx, annotator, pool, []corev1.Node{*get()}, target); err != nil || patched != 0 {
+		t.Fatalf("SyncOnUpdateLabels() before convergence = %d, %v; want 0, nil", patched, err)
+	}
+
+	setState("worker-new", annotations.StateDone)
+	if _, err := SyncOnUpdateLabels(ctx, annotator, pool, []corev1.Node{*get()}, target); err != nil {
+		t.Fatalf("SyncOnUpdateLabels() error = %v", err)
+	}
+	updated := get()
+	if updated.Labels["example.com/ready"] != "true" {
+		t.Errorf("labels = %v, want on-update label set", updated.Labels)
+	}
+	if updated.Annotations[annotations.OnUpdateLabels] != "example.com/ready" {
+		t.Errorf("owned keys = %q, want example.com/ready", updated.Annotations[annotations.OnUpdateLabels])
+	}
+
+	// Already in sync: no patch
+	if patched, err := SyncOnUpdateLabels(ctx, annotator, pool, []corev1.Node{*updated}, target); err != nil || patched != 0 {
+		t.Errorf("SyncOnUpdateLabels() in sync = %d, %v; want 0, nil", patched, err)
+	}
+
+	// A new target makes the node regress: the label is removed
+	if _, err := SyncOnUpdateLabels(ctx, annotator, pool, []corev1.Node{*updated}, singleTarget("worker-next")); err != nil {
+		t.Fatalf("SyncOnUpdateLabels() error = %v", err)
+	}
+	updated = get()
+	if _, ok := updated.Labels["example.com/ready"]; ok {
+		t.Error("on-update label should be removed once the node regresses")
+	}
+	if _, ok := updated.Annotations[annotations.OnUpdateLabels]; ok {
+		t.Error("owned keys annotation should be removed with the labels")
+	}
+	if updated.Labels["example.com/other"] != "keep" {
+		t.Error("labels not set by the controller must be preserved")
+	}
+}
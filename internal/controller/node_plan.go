@@ -0,0 +1,137 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/internal/configdiff"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+// NodeActionType names a step of a node update.
+type NodeActionType string
+
+const (
+	// NodeActionCordon marks the node unschedulable.
+	NodeActionCordon NodeActionType = "cordon"
+	// NodeActionDrain evicts the node's pods.
+	NodeActionDrain NodeActionType = "drain"
+	// NodeActionSetRevision hands the node its desired revision.
+	NodeActionSetRevision NodeActionType = "set-revision"
+	// NodeActionReboot is the agent rebooting the node after apply.
+	NodeActionReboot NodeActionType = "reboot"
+	// NodeActionUncordon makes the node schedulable again.
+	NodeActionUncordon NodeActionType = "uncordon"
+)
+
+// NodeAction is one step of a planned node update.
+type NodeAction struct {
+	Type NodeActionType `json:"type"`
+	// Detail says what the step acts on, e.g. the pods left to drain.
+	Detail string `json:"detail,omitempty"`
+}
+
+// String returns the action as "type (detail)".
+func (a NodeAction) String() string {
+	if a.Detail == "" {
+		return string(a.Type)
+	}
+	return fmt.Sprintf("%s (%s)", a.Type, a.Detail)
+}
+
+// PlanNodeUpdate is the plan mode of ProcessNodeUpdate: it returns, in
+// order, the actions ProcessNodeUpdate and the agent would take to move
+// node to target, without performing any of them. Steps already done are
+// left out, so a node cordoned and drained earlier plans only what is
+// left. target need not exist in the cluster yet.
+func PlanNodeUpdate(
+	ctx context.Context,
+	c client.Client,
+	pool *mcov1alpha1.MachineConfigPool,
+	node *corev1.Node,
+	target *mcov1alpha1.RenderedMachineConfig,
+) ([]NodeAction, error) {
+	var plan []NodeAction
+
+	setRevision := annotations.GetAnnotation(node.Annotations, annotations.DesiredRevision) != target.Name
+	if isNewNodeForPool(node, pool) {
+		plan = append(plan, NodeAction{Type: NodeActionSetRevision, Detail: target.Name})
+		return append(plan, planReboot(ctx, c, pool, node, target)...), nil
+	}
+
+	if !IsNodeCordoned(node) {
+		plan = append(plan, NodeAction{Type: NodeActionCordon})
+	}
+
+	drainWasStarted := annotations.GetAnnotation(node.Annotations, annotations.DrainStartedAt) != ""
+	skipDrain := pool.Spec.Rollout.SkipDrainWithoutReboot && !drainWasStarted &&
+		!drainNeeded(ctx, c, node, target)
+	if !skipDrain {
+		settings, err := LoadDrainSettings(ctx, c)
+		if err != nil {
+			// ProcessNodeUpdate does not drain until the config is fixed.
+			detail := fmt.Sprintf("blocked: drain config invalid: %v", err)
+			return append(plan, NodeAction{Type: NodeActionDrain, Detail: detail}), nil
+		}
+		pods, err := RemainingEvictablePods(ctx, c, node, settings.Apply(poolDrainConfig(pool)))
+		if err != nil {
+			return nil, fmt.Errorf("list pods to drain: %w", err)
+		}
+		if len(pods) > 0 {
+			plan = append(plan, NodeAction{Type: NodeActionDrain, Detail: fmt.Sprintf("%d pods to evict", len(pods))})
+		}
+	}
+
+	if setRevision {
+		plan = append(plan, NodeAction{Type: NodeActionSetRevision, Detail: target.Name})
+	}
+	plan = append(plan, planReboot(ctx, c, pool, node, target)...)
+	return append(plan, NodeAction{Type: NodeActionUncordon}), nil
+}
+
+// planReboot returns the reboot step when the agent would reboot node
+// after applying target under the pool's reboot strategy.
+func planReboot(
+	ctx context.Context,
+	c client.Client,
+	pool *mcov1alpha1.MachineConfigPool,
+	node *corev1.Node,
+	target *mcov1alpha1.RenderedMachineConfig,
+) []NodeAction {
+	current := annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision)
+	if current == target.Name || !rebootsWhenRequired(pool) {
+		return nil
+	}
+	decision := configdiff.NewRebootDeterminer(rmcClientFetcher{c: c}).DetermineReboot(ctx, current, target)
+	if !decision.Required {
+		return nil
+	}
+	return []NodeAction{{Type: NodeActionReboot, Detail: strings.Join(decision.Reasons, "; ")}}
+}
+
+// rebootsWhenRequired reports whether the pool's reboot strategy lets the
+// agent reboot a node whose update requires it.
+func rebootsWhenRequired(pool *mcov1alpha1.MachineConfigPool) bool {
+	return pool.Spec.Reboot.Strategy == "IfRequired" || pool.Spec.Reboot.Strategy == "Kexec"
+}
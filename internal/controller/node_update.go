@@ -3,10 +3,14 @@ package controller
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -20,6 +24,11 @@ type NodeUpdateResult struct {
 	Result        ctrl.Result
 	DrainStuck    bool
 	DrainStuckMsg string
+	// BlockingPods lists the pods (namespace/name) still on a stuck node.
+	BlockingPods []string
+	// ForcedPods lists the pods (namespace/name) force-deleted from a
+	// stuck node because the pool sets ForceDrainAfterStuck.
+	ForcedPods []string
 
 	// Event flags for centralized event emission
 	Cordoned       bool   // Node was just cordoned in this reconcile
@@ -28,6 +37,24 @@ type NodeUpdateResult struct {
 	Uncordoned     bool   // Node was just uncordoned in this reconcile
 	DrainFailed    bool   // Drain attempt failed (will retry)
 	DrainFailedMsg string // Reason for drain failure
+
+	// DrainConfigInvalid is true when the drain did not run because the
+	// drain ConfigMap could not be used.
+	DrainConfigInvalid bool
+
+	// EvictedPods is the number of pods drain removed in this reconcile.
+	EvictedPods int
+	// EvictionLimited is true when drain was held back by the pool's
+	// maxPodEvictions cap.
+	EvictionLimited bool
+	// DrainNoProgress is true when the pods left to evict have not
+	// decreased over DrainNoProgressChecks drain checks.
+	DrainNoProgress bool
+
+	// Err is set when an API call for this node failed. The failure is
+	// isolated to this node: the reconciler keeps processing the rest of
+	// the batch and reports failed nodes in pool status.
+	Err error
 }
 
 // ProcessNodeUpdate handles the node update lifecycle: cordon -> drain -> set revision -> uncordon.
@@ -35,6 +62,7 @@ type NodeUpdateResult struct {
 // drainRetrySeconds specifies the interval between drain retry attempts.
 // If drainTimeoutSeconds is 0, DefaultDrainTimeoutSeconds (3600) is used.
 // If drainRetrySeconds is 0, it is calculated as max(30, drainTimeoutSeconds/12).
+// evictionBudget is how many pods drain may still remove, -1 for no limit.
 func ProcessNodeUpdate(
 	ctx context.Context,
 	c client.Client,
@@ -43,6 +71,7 @@ func ProcessNodeUpdate(
 	targetRevision string,
 	drainTimeoutSeconds int,
 	drainRetrySeconds int,
+	evictionBudget int,
 	events *EventRecorder,
 ) NodeUpdateResult {
 	logger := log.FromContext(ctx)
@@ -55,26 +84,37 @@ func ProcessNodeUpdate(
 	// 4. Node is not cordoned (neither by MCO nor manually)
 	// For these truly new nodes, skip cordon/drain entirely and set desired-revision directly.
 	// This prevents new nodes from blocking other nodes during rollout.
-	currentRevision := annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision)
-	poolAnnotation := annotations.GetAnnotation(node.Annotations, annotations.Pool)
-	isCordoned := annotations.GetBoolAnnotation(node.Annotations, annotations.Cordoned)
-	isNewNode := currentRevision == "" && poolAnnotation == "" && !isCordoned && !node.Spec.Unschedulable
-	poolHasExistingConfig := pool.Status.LastSuccessfulRevision != ""
-	if isNewNode && poolHasExistingConfig {
+	if isNewNodeForPool(node, pool) {
 		logger.Info("new node joined existing pool, skipping cordon/drain",
 			"node", node.Name,
 			"targetRevision", targetRevision,
 			"poolLastSuccessfulRevision", pool.Status.LastSuccessfulRevision)
 
+		if err := checkTargetRMCExists(ctx, c, targetRevision); err != nil {
+			logger.Error(err, "not setting desired revision on new node", "node", node.Name)
+			return NodeUpdateResult{
+				Result: ctrl.Result{RequeueAfter: 5 * time.Second},
+				Err:    err,
+			}
+		}
+
+		if err := syncRebootStrategy(ctx, c, pool, node); err != nil {
+			logger.Error(err, "failed to sync reboot strategy on new node", "node", node.Name)
+		}
+
 		// Set desired-revision directly, agent will apply config
 		if err := SetNodeAnnotation(ctx, c, node, annotations.DesiredRevision, targetRevision); err != nil {
 			logger.Error(err, "failed to set desired revision on new node", "node", node.Name)
-			return NodeUpdateResult{Result: ctrl.Result{RequeueAfter: 5 * time.Second}}
+			return NodeUpdateResult{
+				Result: ctrl.Result{RequeueAfter: 5 * time.Second},
+				Err:    fmt.Errorf("set desired revision on new node: %w", err),
+			}
 		}
 		// Record when we set the desired revision for apply timeout detection
 		if err := SetNodeAnnotation(ctx, c, node, annotations.DesiredRevisionSetAt, time.Now().UTC().Format(time.RFC3339)); err != nil {
 			logger.Error(err, "failed to set desired-revision-set-at on new node", "node", node.Name)
 		}
+		clearUpdateRequests(ctx, c, node)
 		if err := SetNodeAnnotation(ctx, c, node, annotations.Pool, pool.Name); err != nil {
 			logger.Error(err, "failed to set pool annotation on new node", "node", node.Name)
 		}
@@ -88,7 +128,10 @@ func ProcessNodeUpdate(
 	if !IsNodeCordoned(node) {
 		if err := CordonNode(ctx, c, node); err != nil {
 			logger.Error(err, "failed to cordon node", "node", node.Name)
-			return NodeUpdateResult{Result: ctrl.Result{RequeueAfter: 5 * time.Second}}
+			return NodeUpdateResult{
+				Result: ctrl.Result{RequeueAfter: 5 * time.Second},
+				Err:    fmt.Errorf("cordon node: %w", err),
+			}
 		}
 		// Node was just cordoned
 		return NodeUpdateResult{
@@ -97,60 +140,152 @@ func ProcessNodeUpdate(
 		}
 	}
 
-	drainConfig := DrainConfig{
-		GracePeriod:   -1,
-		IgnoreDS:      true,
-		DeleteOrphans: true,
+	// Changes that neither need a reboot nor disrupt units are applied in
+	// place: the node stays cordoned only briefly and is not drained.
+	skipDrain := pool.Spec.Rollout.SkipDrainWithoutReboot && !drainWasStarted &&
+		!drainNeededForUpdate(ctx, c, node, targetRevision)
+	if skipDrain {
+		logger.V(1).Info("no reboot or disruptive unit change, skipping drain", "node", node.Name)
 	}
 
-	complete, err := IsDrainComplete(ctx, c, node, drainConfig)
-	if err != nil {
-		logger.Error(err, "failed to check drain status", "node", node.Name)
-		return NodeUpdateResult{Result: ctrl.Result{RequeueAfter: 5 * time.Second}}
-	}
+	complete := true
+	if !skipDrain {
+		// Draining with built-in defaults would evict the pods the config
+		// protects, so the drain waits until the config is fixed.
+		settings, err := LoadDrainSettings(ctx, c)
+		if err != nil {
+			// The pool reconcile already emitted the DrainConfigInvalid event.
+			logger.Error(err, "invalid drain config, not draining", "node", node.Name)
+			return NodeUpdateResult{
+				Result:             ctrl.Result{RequeueAfter: DrainConfigRecheckInterval},
+				DrainConfigInvalid: true,
+			}
+		}
+		drainConfig := settings.Apply(poolDrainConfig(pool))
+
+		remainingPods, err := RemainingEvictablePods(ctx, c, node, drainConfig)
+		if err != nil {
+			logger.Error(err, "failed to check drain status", "node", node.Name)
+			return NodeUpdateResult{
+				Result: ctrl.Result{RequeueAfter: 5 * time.Second},
+				Err:    fmt.Errorf("check drain status: %w", err),
+			}
+		}
 
-	if !complete {
-		if err := DrainNode(ctx, c, node, drainConfig); err != nil {
-			logger.Info("drain incomplete, scheduling retry", "node", node.Name, "error", err)
-			retry := HandleDrainRetry(ctx, c, node, drainTimeoutSeconds, drainRetrySeconds)
+		complete = len(remainingPods) == 0
 
-			result := NodeUpdateResult{
-				Result:         ctrl.Result{RequeueAfter: retry.RequeueAfter},
-				DrainStuck:     retry.SetDrainStuck,
-				DrainFailed:    true,
-				DrainFailedMsg: err.Error(),
+		if !complete {
+			if evictionBudget == 0 {
+				logger.Info("pool eviction limit reached, drain paused", "node", node.Name)
+				return NodeUpdateResult{
+					Result:          ctrl.Result{RequeueAfter: time.Minute},
+					EvictionLimited: true,
+				}
 			}
-			if retry.SetDrainStuck {
-				result.DrainStuckMsg = fmt.Sprintf("Node %s drain timeout: %v", node.Name, err)
-				RecordDrainStuck(pool.Name)
+			noProgress := false
+			retryInterval := calculateRetryInterval(drainTimeoutSeconds, drainRetrySeconds)
+			if checks, err := TrackDrainProgress(ctx, c, node, len(remainingPods), retryInterval); err != nil {
+				logger.Error(err, "failed to record drain progress", "node", node.Name)
+			} else if checks >= DrainNoProgressChecks {
+				noProgress = true
+				logger.Info("pods left to evict are not decreasing", "node", node.Name,
+					"remaining", len(remainingPods), "checks", checks)
 			}
-			// DrainStarted event: first time drain started (annotation was just set)
-			if !drainWasStarted {
-				result.DrainStarted = true
+			if evictionBudget > 0 {
+				drainConfig.MaxEvictions = evictionBudget
+			}
+			evicted, err := DrainNode(ctx, c, node, drainConfig)
+			if err != nil {
+				logger.Info("drain incomplete, scheduling retry", "node", node.Name, "error", err)
+				retry := HandleDrainRetry(ctx, c, node, drainTimeoutSeconds, drainRetrySeconds, err)
+
+				result := NodeUpdateResult{
+					Result:          ctrl.Result{RequeueAfter: retry.RequeueAfter},
+					DrainStuck:      retry.SetDrainStuck,
+					DrainFailed:     true,
+					DrainFailedMsg:  err.Error(),
+					EvictedPods:     evicted,
+					DrainNoProgress: noProgress,
+				}
+				if retry.SetDrainStuck && pool.Spec.Rollout.ForceDrainAfterStuck {
+					forced, forceErr := ForceDeletePods(ctx, c, node, drainConfig)
+					result.ForcedPods = forced
+					if forceErr == nil {
+						// Nothing blocks the drain any more: the next reconcile
+						// finds it complete.
+						logger.Info("drain stuck, force-deleted remaining pods", "node", node.Name, "pods", forced)
+						result.DrainStuck = false
+						result.Result = ctrl.Result{RequeueAfter: 5 * time.Second}
+					} else {
+						logger.Error(forceErr, "failed to force-delete pods blocking drain", "node", node.Name)
+					}
+				}
+				if result.DrainStuck {
+					result.DrainStuckMsg = fmt.Sprintf("Node %s drain timeout: %v", node.Name, err)
+					RecordDrainStuck(pool.Name)
+					if pods, listErr := RemainingEvictablePods(ctx, c, node, drainConfig); listErr != nil {
+						logger.Error(listErr, "failed to list pods blocking drain", "node", node.Name)
+					} else {
+						for _, pod := range pods {
+							result.BlockingPods = append(result.BlockingPods, pod.Namespace+"/"+pod.Name)
+						}
+					}
+				}
+				// DrainStarted event: first time drain started (annotation was just set)
+				if !drainWasStarted {
+					result.DrainStarted = true
+				}
+				return result
+			}
+			// Drain making progress but not complete - always return and requeue
+			// This prevents falling through to set desired-revision while pods are still draining
+			return NodeUpdateResult{
+				Result:          ctrl.Result{RequeueAfter: 5 * time.Second},
+				DrainStarted:    !drainWasStarted, // true only on first call
+				EvictedPods:     evicted,
+				DrainNoProgress: noProgress,
 			}
-			return result
-		}
-		// Drain making progress but not complete - always return and requeue
-		// This prevents falling through to set desired-revision while pods are still draining
-		return NodeUpdateResult{
-			Result:       ctrl.Result{RequeueAfter: 5 * time.Second},
-			DrainStarted: !drainWasStarted, // true only on first call
 		}
 	}
 
 	// Drain is complete - set flag if we just transitioned
 	drainJustCompleted := drainWasStarted && complete
+	if drainWasStarted && annotations.GetAnnotation(node.Annotations, annotations.DrainCompletedAt) == "" {
+		completedAt := time.Now().UTC().Format(time.RFC3339)
+		if err := SetNodeAnnotation(ctx, c, node, annotations.DrainCompletedAt, completedAt); err != nil {
+			logger.Error(err, "failed to set drain-completed-at", "node", node.Name)
+		} else if duration, _, ok := DrainDuration(map[string]string{
+			annotations.DrainStartedAt:   annotations.GetAnnotation(node.Annotations, annotations.DrainStartedAt),
+			annotations.DrainCompletedAt: completedAt,
+		}); ok {
+			RecordDrainDuration(pool.Name, node.Name, duration.Seconds())
+		}
+	}
 
 	currentDesired := annotations.GetAnnotation(node.Annotations, annotations.DesiredRevision)
 	if currentDesired != targetRevision {
+		if err := checkTargetRMCExists(ctx, c, targetRevision); err != nil {
+			logger.Error(err, "not setting desired revision", "node", node.Name)
+			return NodeUpdateResult{
+				Result: ctrl.Result{RequeueAfter: 5 * time.Second},
+				Err:    err,
+			}
+		}
+		if err := syncRebootStrategy(ctx, c, pool, node); err != nil {
+			logger.Error(err, "failed to sync reboot strategy", "node", node.Name)
+		}
 		if err := SetNodeAnnotation(ctx, c, node, annotations.DesiredRevision, targetRevision); err != nil {
 			logger.Error(err, "failed to set desired revision", "node", node.Name)
-			return NodeUpdateResult{Result: ctrl.Result{RequeueAfter: 5 * time.Second}}
+			return NodeUpdateResult{
+				Result: ctrl.Result{RequeueAfter: 5 * time.Second},
+				Err:    fmt.Errorf("set desired revision: %w", err),
+			}
 		}
 		// Record when we set the desired revision for apply timeout detection
 		if err := SetNodeAnnotation(ctx, c, node, annotations.DesiredRevisionSetAt, time.Now().UTC().Format(time.RFC3339)); err != nil {
 			logger.Error(err, "failed to set desired-revision-set-at", "node", node.Name)
 		}
+		clearUpdateRequests(ctx, c, node)
 		if err := SetNodeAnnotation(ctx, c, node, annotations.Pool, pool.Name); err != nil {
 			logger.Error(err, "failed to set pool annotation", "node", node.Name)
 		}
@@ -161,18 +296,17 @@ func ProcessNodeUpdate(
 	}
 
 	if ShouldUncordon(node, targetRevision) {
-		drainStarted := annotations.GetAnnotation(node.Annotations, annotations.DrainStartedAt)
-		if drainStarted != "" {
-			if startTime, err := time.Parse(time.RFC3339, drainStarted); err == nil {
-				duration := time.Since(startTime).Seconds()
-				RecordDrainDuration(pool.Name, node.Name, duration)
-			}
-		}
 		if err := UncordonNode(ctx, c, node); err != nil {
 			logger.Error(err, "failed to uncordon node", "node", node.Name)
-			return NodeUpdateResult{Result: ctrl.Result{RequeueAfter: 5 * time.Second}}
+			return NodeUpdateResult{
+				Result: ctrl.Result{RequeueAfter: 5 * time.Second},
+				Err:    fmt.Errorf("uncordon node: %w", err),
+			}
 		}
 		logger.Info("node update complete", "node", node.Name, "revision", targetRevision)
+		if duration, ok := ApplyDuration(node.Annotations, time.Now()); ok {
+			RecordConfigApplyDuration(duration.Seconds())
+		}
 		// Node was just uncordoned - update complete
 		return NodeUpdateResult{
 			Result:     ctrl.Result{},
@@ -183,6 +317,88 @@ func ProcessNodeUpdate(
 	return NodeUpdateResult{Result: ctrl.Result{RequeueAfter: 10 * time.Second}}
 }
 
+// ApplyDuration returns the time since the node's desired revision was set.
+func ApplyDuration(ann map[string]string, now time.Time) (time.Duration, bool) {
+	setAt, err := time.Parse(time.RFC3339, annotations.GetAnnotation(ann, annotations.DesiredRevisionSetAt))
+	if err != nil {
+		return 0, false
+	}
+	return now.Sub(setAt), true
+}
+
+// checkTargetRMCExists re-reads the target RMC right before it is written
+// to a node, so a concurrently deleted RMC never becomes a desired revision
+// the agent cannot fetch.
+func checkTargetRMCExists(ctx context.Context, c client.Client, name string) error {
+	rmc := &mcov1alpha1.RenderedMachineConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, rmc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("target revision %s no longer exists", name)
+		}
+		return fmt.Errorf("get target revision %s: %w", name, err)
+	}
+	if !rmc.DeletionTimestamp.IsZero() {
+		return fmt.Errorf("target revision %s is being deleted", name)
+	}
+	return nil
+}
+
+// NodeRebootStrategy returns the reboot strategy override for node, or ""
+// when the pool's strategy can be used as is. A Kexec pool falls back to
+// IfRequired on nodes whose agent reported reboot methods without kexec.
+func NodeRebootStrategy(pool *mcov1alpha1.MachineConfigPool, node *corev1.Node) string {
+	if pool.Spec.Reboot.Strategy != "Kexec" {
+		return ""
+	}
+	methods := annotations.GetAnnotation(node.Annotations, annotations.RebootMethods)
+	if methods == "" {
+		// Agent did not report capabilities; it falls back on its own.
+		return ""
+	}
+	for _, m := range strings.Split(methods, ",") {
+		if strings.TrimSpace(m) == "kexec" {
+			return ""
+		}
+	}
+	return "IfRequired"
+}
+
+// clearUpdateRequests removes consumed update-requested and update-now
+// annotations once the node has been handed its desired revision.
+func clearUpdateRequests(ctx context.Context, c client.Client, node *corev1.Node) {
+	for _, key := range []string{annotations.UpdateRequested, annotations.UpdateNow} {
+		if annotations.GetAnnotation(node.Annotations, key) == "" {
+			continue
+		}
+		if err := RemoveNodeAnnotation(ctx, c, node, key); err != nil {
+			log.FromContext(ctx).Error(err, "failed to clear update request", "node", node.Name, "annotation", key)
+		}
+	}
+}
+
+// syncRebootStrategy writes or removes the node's reboot-strategy override.
+func syncRebootStrategy(ctx context.Context, c client.Client, pool *mcov1alpha1.MachineConfigPool, node *corev1.Node) error {
+	desired := NodeRebootStrategy(pool, node)
+	if annotations.GetAnnotation(node.Annotations, annotations.RebootStrategy) == desired {
+		return nil
+	}
+	if desired == "" {
+		return RemoveNodeAnnotation(ctx, c, node, annotations.RebootStrategy)
+	}
+	return SetNodeAnnotation(ctx, c, node, annotations.RebootStrategy, desired)
+}
+
+// isNewNodeForPool reports whether node is a brand new node joining a pool
+// that already has a config: MCO has never touched it and it isn't cordoned.
+// Such nodes get their desired revision without cordon/drain.
+func isNewNodeForPool(node *corev1.Node, pool *mcov1alpha1.MachineConfigPool) bool {
+	return annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision) == "" &&
+		annotations.GetAnnotation(node.Annotations, annotations.Pool) == "" &&
+		!annotations.GetBoolAnnotation(node.Annotations, annotations.Cordoned) &&
+		!node.Spec.Unschedulable &&
+		pool.Status.LastSuccessfulRevision != ""
+}
+
 // SetDrainStuckCondition sets DrainStuck=True and also Degraded=True.
 func SetDrainStuckCondition(pool *mcov1alpha1.MachineConfigPool, message string) {
 	condition := metav1.Condition{
@@ -261,6 +477,101 @@ func ClearDrainStuckCondition(pool *mcov1alpha1.MachineConfigPool) {
 	pool.Status.Conditions = append(pool.Status.Conditions, condition)
 }
 
+// NodeFailureSummary is the stable text status records for a controller
+// error on a node: the API status reason when there is one, never the raw
+// error text, which changes from one retry to the next.
+func NodeFailureSummary(err error) string {
+	if reason := apierrors.ReasonForError(err); reason != metav1.StatusReasonUnknown {
+		return fmt.Sprintf("controller failed to update node: %s", reason)
+	}
+	return "controller failed to update node"
+}
+
+// SetNodeUpdateFailedCondition sets NodeUpdateFailed=True naming the nodes
+// that failed. The errors themselves are in the NodeUpdateFailed events.
+func SetNodeUpdateFailedCondition(pool *mcov1alpha1.MachineConfigPool, nodes []string) {
+	nodes = slices.Sorted(slices.Values(nodes))
+	setCondition(pool, metav1.Condition{
+		Type:               mcov1alpha1.ConditionNodeUpdateFailed,
+		Status:             metav1.ConditionTrue,
+		Reason:             "APIError",
+		Message:            fmt.Sprintf("Failed to update nodes: %s (see NodeUpdateFailed events)", joinNodeNames(nodes)),
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// ClearNodeUpdateFailedCondition sets NodeUpdateFailed=False if it was previously set.
+func ClearNodeUpdateFailedCondition(pool *mcov1alpha1.MachineConfigPool) {
+	for _, c := range pool.Status.Conditions {
+		if c.Type == mcov1alpha1.ConditionNodeUpdateFailed {
+			setCondition(pool, metav1.Condition{
+				Type:               mcov1alpha1.ConditionNodeUpdateFailed,
+				Status:             metav1.ConditionFalse,
+				Reason:             "AllNodesProcessed",
+				LastTransitionTime: metav1.Now(),
+			})
+			return
+		}
+	}
+}
+
+// SetEvictionLimitCondition sets EvictionLimitReached=True.
+func SetEvictionLimitCondition(pool *mcov1alpha1.MachineConfigPool, evicted int) {
+	setCondition(pool, metav1.Condition{
+		Type:               mcov1alpha1.ConditionEvictionLimitReached,
+		Status:             metav1.ConditionTrue,
+		Reason:             "MaxPodEvictionsReached",
+		Message:            fmt.Sprintf("Drains paused after evicting %d pods during this rollout", evicted),
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// ClearEvictionLimitCondition sets EvictionLimitReached=False if it was previously set.
+func ClearEvictionLimitCondition(pool *mcov1alpha1.MachineConfigPool) {
+	for _, c := range pool.Status.Conditions {
+		if c.Type == mcov1alpha1.ConditionEvictionLimitReached {
+			setCondition(pool, metav1.Condition{
+				Type:               mcov1alpha1.ConditionEvictionLimitReached,
+				Status:             metav1.ConditionFalse,
+				Reason:             "WithinLimit",
+				LastTransitionTime: metav1.Now(),
+			})
+			return
+		}
+	}
+}
+
+// SetCordonStuckCondition sets CordonStuck=True naming the stuck nodes.
+func SetCordonStuckCondition(pool *mcov1alpha1.MachineConfigPool, nodes []string) {
+	message := fmt.Sprintf("Nodes cordoned for more than %ds without drain starting: %s",
+		pool.Spec.Rollout.CordonTimeoutSeconds, joinNodeNames(nodes))
+	if pool.Spec.Rollout.UncordonOnCordonTimeout {
+		message += " (uncordoned)"
+	}
+	setCondition(pool, metav1.Condition{
+		Type:               mcov1alpha1.ConditionCordonStuck,
+		Status:             metav1.ConditionTrue,
+		Reason:             "CordonTimeoutExceeded",
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// ClearCordonStuckCondition sets CordonStuck=False if it was previously set.
+func ClearCordonStuckCondition(pool *mcov1alpha1.MachineConfigPool) {
+	for _, c := range pool.Status.Conditions {
+		if c.Type == mcov1alpha1.ConditionCordonStuck {
+			setCondition(pool, metav1.Condition{
+				Type:               mcov1alpha1.ConditionCordonStuck,
+				Status:             metav1.ConditionFalse,
+				Reason:             "NoCordonStuck",
+				LastTransitionTime: metav1.Now(),
+			})
+			return
+		}
+	}
+}
+
 // SetDrainingCondition sets Draining=True with the given reason and message.
 // This condition shows drain status before DrainStuck timeout is reached.
 func SetDrainingCondition(pool *mcov1alpha1.MachineConfigPool, reason, message string) {
@@ -305,3 +616,17 @@ func ClearDrainingCondition(pool *mcov1alpha1.MachineConfigPool) {
 	}
 	pool.Status.Conditions = append(pool.Status.Conditions, condition)
 }
+
+// poolDrainConfig is the drain configuration set by the pool's rollout spec,
+// before cluster-wide drain settings are applied.
+func poolDrainConfig(pool *mcov1alpha1.MachineConfigPool) DrainConfig {
+	rollout := pool.Spec.Rollout
+	return DrainConfig{
+		GracePeriod:         -1,
+		IgnoreDS:            rollout.DrainIgnoreDaemonSets == nil || *rollout.DrainIgnoreDaemonSets,
+		DeleteOrphans:       rollout.DrainDeleteOrphans == nil || *rollout.DrainDeleteOrphans,
+		DisableEviction:     rollout.DisableEviction,
+		DeleteEmptyDirData:  rollout.DrainDeleteEmptyDirData != nil && *rollout.DrainDeleteEmptyDirData,
+		EvictionConcurrency: rollout.DrainEvictionConcurrency,
+	}
+}
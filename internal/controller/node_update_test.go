@@ -1,12 +1,24 @@
 package controller
 
 import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
 )
 
 func TestSetDrainStuckCondition(t *testing.T) {
@@ -429,3 +441,689 @@ func TestIsNewNode_FalseWhenPoolHasNoConfig(t *testing.T) {
 		t.Error("should NOT skip cordon/drain for new node in new pool")
 	}
 }
+
+func TestNodeUpdateFailedCondition(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{}
+
+	// Clearing a condition that was never set is a no-op
+	ClearNodeUpdateFailedCondition(pool)
+	if len(pool.Status.Conditions) != 0 {
+		t.Fatalf("expected no conditions, got %d", len(pool.Status.Conditions))
+	}
+
+	SetNodeUpdateFailedCondition(pool, []string{"n2", "n1"})
+	if len(pool.Status.Conditions) != 1 {
+		t.Fatalf("expected 1 condition, got %d", len(pool.Status.Conditions))
+	}
+	cond := pool.Status.Conditions[0]
+	if cond.Type != mcov1alpha1.ConditionNodeUpdateFailed || cond.Status != metav1.ConditionTrue {
+		t.Errorf("condition = %s/%s, want NodeUpdateFailed/True", cond.Type, cond.Status)
+	}
+	if want := "Failed to update nodes: n1, n2 (see NodeUpdateFailed events)"; cond.Message != want {
+		t.Errorf("message = %q, want %q", cond.Message, want)
+	}
+
+	ClearNodeUpdateFailedCondition(pool)
+	if pool.Status.Conditions[0].Status != metav1.ConditionFalse {
+		t.Errorf("status = %s, want False after clear", pool.Status.Conditions[0].Status)
+	}
+}
+
+func TestNodeFailureSummary(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "api status error",
+			err:  apierrors.NewConflict(schema.GroupResource{Resource: "nodes"}, "n1", errors.New("object was modified")),
+			want: "controller failed to update node: Conflict",
+		},
+		{
+			name: "plain error",
+			err:  errors.New("dial tcp 10.0.0.1:6443: connect: connection refused"),
+			want: "controller failed to update node",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NodeFailureSummary(tt.err); got != tt.want {
+				t.Errorf("NodeFailureSummary() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProcessNodeUpdate_DrainStuckReportsBlockingPods(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			Rollout: mcov1alpha1.RolloutConfig{DisableEviction: true},
+		},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+			Annotations: map[string]string{
+				annotations.Cordoned:       "true",
+				annotations.DrainStartedAt: time.Now().Add(-2 * time.Hour).Format(time.RFC3339),
+			},
+		},
+		Spec: corev1.NodeSpec{Unschedulable: true},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "stubborn", Namespace: "default"},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme()).
+		WithObjects(node, pod).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(_ context.Context, _ client.WithWatch, _ client.Object, _ ...client.DeleteOption) error {
+				return errors.New("pod refuses to leave")
+			},
+		}).
+		Build()
+
+	result := ProcessNodeUpdate(context.Background(), c, pool, node, "worker-abc", 60, 0, -1, &EventRecorder{})
+
+	if !result.DrainStuck {
+		t.Fatal("expected DrainStuck")
+	}
+	if len(result.BlockingPods) != 1 || result.BlockingPods[0] != "default/stubborn" {
+		t.Errorf("BlockingPods = %v, want [default/stubborn]", result.BlockingPods)
+	}
+}
+
+func TestProcessNodeUpdate_ForceDrainAfterStuck(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			Rollout: mcov1alpha1.RolloutConfig{ForceDrainAfterStuck: true},
+		},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+			Annotations: map[string]string{
+				annotations.Cordoned:       "true",
+				annotations.DrainStartedAt: time.Now().Add(-2 * time.Hour).Format(time.RFC3339),
+			},
+		},
+		Spec: corev1.NodeSpec{Unschedulable: true},
+	}
+	controller := true
+	pod := func(name, namespace string, labels map[string]string, ownerKind string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name, Namespace: namespace, Labels: labels,
+				OwnerReferences: []metav1.OwnerReference{{Kind: ownerKind, Name: "owner", Controller: &controller}},
+			},
+			Spec:   corev1.PodSpec{NodeName: "node-1"},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+	stubborn := pod("stubborn", "default", nil, "ReplicaSet")
+	protected := pod("postgres-0", "db", map[string]string{"app": "postgres"}, "StatefulSet")
+	daemon := pod("node-exporter", "monitoring", nil, "DaemonSet")
+	mco := pod("agent", MCONamespace, nil, "DaemonSet")
+	drainSettings := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: DrainConfigMapName, Namespace: MCONamespace},
+		Data: map[string]string{
+			DrainConfigMapKey: "exclusions:\n  - namespaces: [db]\n    labelSelectors:\n      - matchLabels: {app: postgres}\n",
+		},
+	}
+
+	var grace []int64
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme()).
+		WithObjects(node, stubborn, protected, daemon, mco, drainSettings).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceCreate: func(context.Context, client.Client, string, client.Object, client.Object, ...client.SubResourceCreateOption) error {
+				return apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10)
+			},
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				deleteOpts := &client.DeleteOptions{}
+				deleteOpts.ApplyOptions(opts)
+				if deleteOpts.GracePeriodSeconds != nil {
+					grace = append(grace, *deleteOpts.GracePeriodSeconds)
+				}
+				return c.Delete(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	result := ProcessNodeUpdate(context.Background(), c, pool, node, "worker-abc", 60, 0, -1, &EventRecorder{})
+
+	if result.DrainStuck {
+		t.Error("expected DrainStuck to be resolved by force deletion")
+	}
+	if !reflect.DeepEqual(result.ForcedPods, []string{"default/stubborn"}) {
+		t.Errorf("ForcedPods = %v, want [default/stubborn]", result.ForcedPods)
+	}
+	if !reflect.DeepEqual(grace, []int64{0}) {
+		t.Errorf("delete grace periods = %v, want [0]", grace)
+	}
+	for _, kept := range []*corev1.Pod{protected, daemon, mco} {
+		if err := c.Get(context.Background(), client.ObjectKeyFromObject(kept), &corev1.Pod{}); err != nil {
+			t.Errorf("pod %s/%s was removed: %v", kept.Namespace, kept.Name, err)
+		}
+	}
+}
+
+func TestProcessNodeUpdate_EvictionBudgetExhausted(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+			Annotations: map[string]string{
+				annotations.Cordoned:       "true",
+				annotations.DrainStartedAt: time.Now().Format(time.RFC3339),
+			},
+		},
+		Spec: corev1.NodeSpec{Unschedulable: true},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: "default"},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme()).
+		WithObjects(node, pod).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		}).
+		Build()
+
+	result := ProcessNodeUpdate(context.Background(), c, pool, node, "worker-abc", 60, 0, 0, &EventRecorder{})
+
+	if !result.EvictionLimited {
+		t.Error("expected EvictionLimited")
+	}
+	if result.EvictedPods != 0 {
+		t.Errorf("EvictedPods = %d, want 0", result.EvictedPods)
+	}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(pod), &corev1.Pod{}); err != nil {
+		t.Errorf("pod was removed despite exhausted budget: %v", err)
+	}
+}
+
+func TestProcessNodeUpdate_TargetRMCDeleted(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+			Annotations: map[string]string{
+				annotations.CurrentRevision: "worker-old",
+				annotations.DesiredRevision: "worker-old",
+				annotations.Cordoned:        "true",
+				annotations.DrainStartedAt:  time.Now().Format(time.RFC3339),
+			},
+		},
+		Spec: corev1.NodeSpec{Unschedulable: true},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme()).
+		WithObjects(node).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		}).
+		Build()
+
+	result := ProcessNodeUpdate(context.Background(), c, pool, node, "worker-abc", 60, 0, -1, &EventRecorder{})
+
+	if result.Err == nil {
+		t.Fatal("expected error for missing target RMC")
+	}
+	if result.Result.RequeueAfter == 0 {
+		t.Error("expected requeue")
+	}
+	updated := &corev1.Node{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(node), updated); err != nil {
+		t.Fatalf("get node: %v", err)
+	}
+	if got := updated.Annotations[annotations.DesiredRevision]; got != "worker-old" {
+		t.Errorf("desired-revision = %q, want worker-old", got)
+	}
+}
+
+func TestNodeRebootStrategy(t *testing.T) {
+	kexecPool := &mcov1alpha1.MachineConfigPool{
+		Spec: mcov1alpha1.MachineConfigPoolSpec{Reboot: mcov1alpha1.RebootPolicy{Strategy: "Kexec"}},
+	}
+	tests := []struct {
+		name    string
+		pool    *mcov1alpha1.MachineConfigPool
+		methods string
+		want    string
+	}{
+		{"not kexec", &mcov1alpha1.MachineConfigPool{}, "reboot", ""},
+		{"not reported", kexecPool, "", ""},
+		{"kexec supported", kexecPool, "reboot,kexec", ""},
+		{"kexec unsupported", kexecPool, "reboot", "IfRequired"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &corev1.Node{}
+			if tt.methods != "" {
+				node.Annotations = map[string]string{annotations.RebootMethods: tt.methods}
+			}
+			if got := NodeRebootStrategy(tt.pool, node); got != tt.want {
+				t.Errorf("NodeRebootStrategy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncRebootStrategy(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		Spec: mcov1alpha1.MachineConfigPoolSpec{Reboot: mcov1alpha1.RebootPolicy{Strategy: "Kexec"}},
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:        "node-1",
+		Annotations: map[string]string{annotations.RebootMethods: "reboot"},
+	}}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(node).Build()
+
+	if err := syncRebootStrategy(context.Background(), c, pool, node); err != nil {
+		t.Fatalf("syncRebootStrategy() error = %v", err)
+	}
+	updated := &corev1.Node{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(node), updated); err != nil {
+		t.Fatalf("get node: %v", err)
+	}
+	if got := updated.Annotations[annotations.RebootStrategy]; got != "IfRequired" {
+		t.Fatalf("reboot-strategy = %q, want IfRequired", got)
+	}
+
+	updated.Annotations[annotations.RebootMethods] = "reboot,kexec"
+	if err := syncRebootStrategy(context.Background(), c, pool, updated); err != nil {
+		t.Fatalf("syncRebootStrategy() error = %v", err)
+	}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(node), updated); err != nil {
+		t.Fatalf("get node: %v", err)
+	}
+	if _, ok := updated.Annotations[annotations.RebootStrategy]; ok {
+		t.Error("reboot-strategy should be removed once kexec is supported")
+	}
+}
+
+func TestProcessNodeUpdate_ConsumesUpdateRequest(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+	pool.Spec.Rollout.UpdateStrategy = UpdateStrategyOnDelete
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+			Annotations: map[string]string{
+				annotations.CurrentRevision: "worker-old",
+				annotations.DesiredRevision: "worker-old",
+				annotations.UpdateRequested: "true",
+				annotations.Cordoned:        "true",
+				annotations.DrainStartedAt:  time.Now().Format(time.RFC3339),
+			},
+		},
+		Spec: corev1.NodeSpec{Unschedulable: true},
+	}
+	rmc := &mcov1alpha1.RenderedMachineConfig{ObjectMeta: metav1.ObjectMeta{Name: "worker-abc"}}
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme()).
+		WithObjects(node, rmc).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		}).
+		Build()
+
+	result := ProcessNodeUpdate(context.Background(), c, pool, node, "worker-abc", 60, 0, -1, &EventRecorder{})
+	if result.Err != nil {
+		t.Fatalf("ProcessNodeUpdate() error = %v", result.Err)
+	}
+
+	updated := &corev1.Node{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(node), updated); err != nil {
+		t.Fatalf("get node: %v", err)
+	}
+	if got := updated.Annotations[annotations.DesiredRevision]; got != "worker-abc" {
+		t.Errorf("desired-revision = %q, want worker-abc", got)
+	}
+	if _, ok := updated.Annotations[annotations.UpdateRequested]; ok {
+		t.Error("update-requested should be removed once the desired revision is set")
+	}
+}
+
+func TestProcessNodeUpdate_SkipDrainWithoutReboot(t *testing.T) {
+	rmc := func(name string, reboot bool, units ...mcov1alpha1.UnitSpec) *mcov1alpha1.RenderedMachineConfig {
+		return &mcov1alpha1.RenderedMachineConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: mcov1alpha1.RenderedMachineConfigSpec{
+				Config: mcov1alpha1.RenderedConfig{
+					Files:   []mcov1alpha1.FileSpec{{Path: "/etc/app.conf", Content: name}},
+					Systemd: mcov1alpha1.SystemdSpec{Units: units},
+				},
+				Reboot: mcov1alpha1.RenderedRebootSpec{Required: reboot},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		target    *mcov1alpha1.RenderedMachineConfig
+		wantDrain bool
+	}{
+		{"file change only", rmc("worker-new", false), false},
+		{"reboot required", rmc("worker-new", true), true},
+		{"unit restarted", rmc("worker-new", false, mcov1alpha1.UnitSpec{Name: "app.service", State: "restarted"}), true},
+		{"unit reloaded", rmc("worker-new", false, mcov1alpha1.UnitSpec{Name: "app.service", State: "reloaded"}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+			pool.Spec.Rollout.SkipDrainWithoutReboot = true
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node-1",
+					Annotations: map[string]string{
+						annotations.CurrentRevision: "worker-old",
+						annotations.DesiredRevision: "worker-old",
+						annotations.Cordoned:        "true",
+					},
+				},
+				Spec: corev1.NodeSpec{Unschedulable: true},
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+				Spec:       corev1.PodSpec{NodeName: "node-1"},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			}
+			c := fake.NewClientBuilder().
+				WithScheme(newTestScheme()).
+				WithObjects(node, pod, rmc("worker-old", false), tt.target).
+				WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+					return []string{obj.(*corev1.Pod).Spec.NodeName}
+				}).
+				Build()
+
+			result := ProcessNodeUpdate(context.Background(), c, pool, node, "worker-new", 60, 0, -1, &EventRecorder{})
+			if result.Err != nil {
+				t.Fatalf("ProcessNodeUpdate() error = %v", result.Err)
+			}
+
+			updated := &corev1.Node{}
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(node), updated); err != nil {
+				t.Fatalf("get node: %v", err)
+			}
+			drained := updated.Annotations[annotations.DrainStartedAt] != ""
+			if drained != tt.wantDrain {
+				t.Errorf("drain started = %v, want %v", drained, tt.wantDrain)
+			}
+			desiredSet := updated.Annotations[annotations.DesiredRevision] == "worker-new"
+			if desiredSet == tt.wantDrain {
+				t.Errorf("desired revision set = %v, want %v", desiredSet, !tt.wantDrain)
+			}
+		})
+	}
+}
+
+func TestProcessNodeUpdate_EmptyDirPodsBlockDrain(t *testing.T) {
+	keep := false
+	tests := []struct {
+		name    string
+		setting *bool
+	}{
+		{"unset", nil},
+		{"false", &keep},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+			pool.Spec.Rollout.DrainDeleteEmptyDirData = tt.setting
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "node-1",
+					Annotations: map[string]string{
+						annotations.CurrentRevision: "worker-old",
+						annotations.DesiredRevision: "worker-old",
+						annotations.Cordoned:        "true",
+					},
+				},
+				Spec: corev1.NodeSpec{Unschedulable: true},
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "scratch", Namespace: "default"},
+				Spec: corev1.PodSpec{
+					NodeName: "node-1",
+					Volumes: []corev1.Volume{{
+						Name:         "cache",
+						VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+					}},
+				},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning},
+			}
+			c := fake.NewClientBuilder().
+				WithScheme(newTestScheme()).
+				WithObjects(node, pod).
+				WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+					return []string{obj.(*corev1.Pod).Spec.NodeName}
+				}).
+				Build()
+
+			for i := range 3 {
+				current := &corev1.Node{}
+				if err := c.Get(context.Background(), client.ObjectKeyFromObject(node), current); err != nil {
+					t.Fatalf("get node: %v", err)
+				}
+				result := ProcessNodeUpdate(context.Background(), c, pool, current, "worker-new", 3600, 0, -1, &EventRecorder{})
+				if !result.DrainFailed {
+					t.Fatalf("reconcile %d: DrainFailed = false, want the emptyDir pod to block drain", i)
+				}
+				if !strings.Contains(result.DrainFailedMsg, "default/scratch") {
+					t.Errorf("reconcile %d: DrainFailedMsg = %q, want it to name the emptyDir pods", i, result.DrainFailedMsg)
+				}
+			}
+
+			updated := &corev1.Node{}
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(node), updated); err != nil {
+				t.Fatalf("get node: %v", err)
+			}
+			if got := updated.Annotations[annotations.DesiredRevision]; got != "worker-old" {
+				t.Errorf("desired revision = %q, want worker-old: the node must not move on to apply and reboot", got)
+			}
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(pod), &corev1.Pod{}); err != nil {
+				t.Errorf("emptyDir pod was removed: %v", err)
+			}
+		})
+	}
+}
+
+func TestProcessNodeUpdate_InvalidDrainConfigBlocksDrain(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+			Annotations: map[string]string{
+				annotations.CurrentRevision: "worker-old",
+				annotations.DesiredRevision: "worker-old",
+				annotations.Cordoned:        "true",
+			},
+		},
+		Spec: corev1.NodeSpec{Unschedulable: true},
+	}
+	// The first rule protects the database; the second is invalid.
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: DrainConfigMapName, Namespace: MCONamespace},
+		Data: map[string]string{DrainConfigMapKey: `
+exclusions:
+  - namespaces: [databases]
+  - podNamePatterns: ["[invalid"]
+`},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "postgres-0", Namespace: "databases"},
+		Spec:       corev1.PodSpec{NodeName: "node-1"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(newTestScheme()).
+		WithObjects(node, cm, pod).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		}).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+
+	result := ProcessNodeUpdate(context.Background(), c, pool, node, "worker-new", 3600, 0, -1, NewEventRecorder(recorder))
+	if !result.DrainConfigInvalid {
+		t.Error("DrainConfigInvalid = false, want the drain held back")
+	}
+	if result.Result.RequeueAfter == 0 {
+		t.Error("expected a requeue to pick up a fixed config")
+	}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(pod), &corev1.Pod{}); err != nil {
+		t.Errorf("protected pod was evicted with built-in defaults: %v", err)
+	}
+	updated := &corev1.Node{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(node), updated); err != nil {
+		t.Fatalf("get node: %v", err)
+	}
+	if got := updated.Annotations[annotations.DesiredRevision]; got != "worker-old" {
+		t.Errorf("desired revision = %q, want worker-old", got)
+	}
+	select {
+	case event := <-recorder.Events:
+		t.Errorf("unexpected event %q: the pool reconcile reports the invalid config", event)
+	default:
+	}
+}
+
+func TestPoolDrainConfig(t *testing.T) {
+	off := false
+
+	pool := &mcov1alpha1.MachineConfigPool{}
+	cfg := poolDrainConfig(pool)
+	if !cfg.IgnoreDS || !cfg.DeleteOrphans {
+		t.Errorf("defaults: IgnoreDS=%v DeleteOrphans=%v, want both true", cfg.IgnoreDS, cfg.DeleteOrphans)
+	}
+	if cfg.GracePeriod != -1 {
+		t.Errorf("GracePeriod = %d, want -1", cfg.GracePeriod)
+	}
+
+	pool.Spec.Rollout.DrainIgnoreDaemonSets = &off
+	pool.Spec.Rollout.DrainDeleteOrphans = &off
+	pool.Spec.Rollout.DisableEviction = true
+	cfg = poolDrainConfig(pool)
+	if cfg.IgnoreDS || cfg.DeleteOrphans {
+		t.Errorf("overrides: IgnoreDS=%v DeleteOrphans=%v, want both false", cfg.IgnoreDS, cfg.DeleteOrphans)
+	}
+	if !cfg.DisableEviction {
+		t.Error("DisableEviction not carried over")
+	}
+}
+
+func TestPlanNodeUpdate(t *testing.T) {
+	rmc := func(name string, reboot bool) *mcov1alpha1.RenderedMachineConfig {
+		return &mcov1alpha1.RenderedMachineConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: mcov1alpha1.RenderedMachineConfigSpec{
+				Config: mcov1alpha1.RenderedConfig{
+					Files: []mcov1alpha1.FileSpec{{Path: "/etc/app.conf", Content: name}},
+				},
+				Reboot: mcov1alpha1.RenderedRebootSpec{Required: reboot},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		skipDrain   bool
+		target      *mcov1alpha1.RenderedMachineConfig
+		want        []NodeActionType
+	}{
+		{
+			name:        "full update with reboot",
+			annotations: map[string]string{annotations.CurrentRevision: "worker-old", annotations.Pool: "worker"},
+			target:      rmc("worker-new", true),
+			want: []NodeActionType{
+				NodeActionCordon, NodeActionDrain, NodeActionSetRevision, NodeActionReboot, NodeActionUncordon,
+			},
+		},
+		{
+			name:        "drain skipped without reboot",
+			annotations: map[string]string{annotations.CurrentRevision: "worker-old", annotations.Pool: "worker"},
+			skipDrain:   true,
+			target:      rmc("worker-new", false),
+			want:        []NodeActionType{NodeActionCordon, NodeActionSetRevision, NodeActionUncordon},
+		},
+		{
+			name: "revision already handed out",
+			annotations: map[string]string{
+				annotations.CurrentRevision: "worker-old",
+				annotations.DesiredRevision: "worker-new",
+				annotations.Pool:            "worker",
+				annotations.Cordoned:        "true",
+			},
+			target: rmc("worker-new", false),
+			want:   []NodeActionType{NodeActionDrain, NodeActionUncordon},
+		},
+		{
+			name:   "new node",
+			target: rmc("worker-new", true),
+			want:   []NodeActionType{NodeActionSetRevision, NodeActionReboot},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+			pool.Spec.Reboot.Strategy = "IfRequired"
+			pool.Spec.Rollout.SkipDrainWithoutReboot = tt.skipDrain
+			pool.Status.LastSuccessfulRevision = "worker-old"
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Annotations: tt.annotations}}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+				Spec:       corev1.PodSpec{NodeName: "node-1"},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			}
+			c := fake.NewClientBuilder().
+				WithScheme(newTestScheme()).
+				WithObjects(node, pod, rmc("worker-old", false)).
+				WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+					return []string{obj.(*corev1.Pod).Spec.NodeName}
+				}).
+				Build()
+
+			plan, err := PlanNodeUpdate(context.Background(), c, pool, node, tt.target)
+			if err != nil {
+				t.Fatalf("PlanNodeUpdate() error = %v", err)
+			}
+			var got []NodeActionType
+			for _, a := range plan {
+				got = append(got, a.Type)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("plan = %v, want %v", plan, tt.want)
+			}
+
+			// Planning must not touch the node.
+			updated := &corev1.Node{}
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(node), updated); err != nil {
+				t.Fatalf("get node: %v", err)
+			}
+			if !reflect.DeepEqual(updated.Annotations, node.Annotations) || updated.Spec.Unschedulable {
+				t.Errorf("node changed by planning: annotations %v, unschedulable %v",
+					updated.Annotations, updated.Spec.Unschedulable)
+			}
+		})
+	}
+}
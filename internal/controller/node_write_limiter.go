@@ -0,0 +1,105 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// LimitNodeWrites wraps c so that node updates and patches, pod deletions
+// and pod evictions wait for a token from a shared token bucket of qps
+// writes per second and the given burst. Other requests pass through
+// unchanged. Rollouts of large pools then stay within the API server's
+// priority-and-fairness limits. qps <= 0 returns c unchanged.
+func LimitNodeWrites(c client.Client, qps float32, burst int) client.Client {
+	if qps <= 0 {
+		return c
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &nodeWriteLimitedClient{
+		Client:  c,
+		limiter: flowcontrol.NewTokenBucketRateLimiter(qps, burst),
+	}
+}
+
+type nodeWriteLimitedClient struct {
+	client.Client
+	limiter flowcontrol.RateLimiter
+}
+
+// isLimitedWrite reports whether writes to obj go through the limiter.
+func isLimitedWrite(obj client.Object) bool {
+	switch obj.(type) {
+	case *corev1.Node, *corev1.Pod:
+		return true
+	}
+	return false
+}
+
+func (c *nodeWriteLimitedClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if isLimitedWrite(obj) {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *nodeWriteLimitedClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if isLimitedWrite(obj) {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *nodeWriteLimitedClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if isLimitedWrite(obj) {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *nodeWriteLimitedClient) SubResource(subResource string) client.SubResourceClient {
+	inner := c.Client.SubResource(subResource)
+	if subResource != "eviction" {
+		return inner
+	}
+	return &limitedSubResourceClient{SubResourceClient: inner, limiter: c.limiter}
+}
+
+// limitedSubResourceClient rate limits evictions.
+type limitedSubResourceClient struct {
+	client.SubResourceClient
+	limiter flowcontrol.RateLimiter
+}
+
+func (c *limitedSubResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.SubResourceClient.Create(ctx, obj, subResource, opts...)
+}
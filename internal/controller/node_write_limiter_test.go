@@ -0,0 +1,67 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+func TestLimitNodeWrites(t *testing.T) {
+	base := fake.NewClientBuilder().WithScheme(newTestScheme()).Build()
+	if LimitNodeWrites(base, 0, 10) != base {
+		t.Error("qps 0 should return the client unchanged")
+	}
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n1"}}
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: MCONamespace, Name: "cm"}}
+	c := LimitNodeWrites(fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(node, cm).Build(), 20, 1)
+	ctx := context.Background()
+
+	// Other objects are not limited.
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		if err := c.Update(ctx, cm); err != nil {
+			t.Fatalf("Update(ConfigMap) error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 150*time.Millisecond {
+		t.Errorf("ConfigMap updates took %v, should not be rate limited", elapsed)
+	}
+
+	// Burst 1 at 20/s: five node writes need at least four 50ms tokens.
+	start = time.Now()
+	for i := 0; i < 5; i++ {
+		if err := SetNodeAnnotation(ctx, c, node, annotations.Cordoned, "true"); err != nil {
+			t.Fatalf("SetNodeAnnotation() error = %v", err)
+		}
+		if err := RemoveNodeAnnotation(ctx, c, node, annotations.Cordoned); err != nil {
+			t.Fatalf("RemoveNodeAnnotation() error = %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("10 node writes took %v, want them rate limited to 20/s", elapsed)
+	}
+
+}
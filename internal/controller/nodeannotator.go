@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
@@ -62,12 +63,12 @@ func (a *NodeAnnotator) RemoveDesiredRevision(ctx context.Context, nodeName stri
 }
 
 // SetDesiredRevisionForNodes sets the desired-revision on multiple nodes.
-// Skips paused nodes. Returns the count of nodes updated and any error.
+// Skips paused and maintenance nodes. Returns the count of nodes updated and any error.
 func (a *NodeAnnotator) SetDesiredRevisionForNodes(ctx context.Context, nodes []corev1.Node, revision, pool string) (int, error) {
 	updated := 0
 	for _, node := range nodes {
-		// Skip paused nodes
-		if annotations.IsNodePaused(node.Annotations) {
+		// Skip paused and maintenance nodes
+		if annotations.IsNodePaused(node.Annotations) || annotations.IsNodeInMaintenance(node.Annotations) {
 			continue
 		}
 
@@ -83,6 +84,28 @@ func (a *NodeAnnotator) SetDesiredRevisionForNodes(ctx context.Context, nodes []
 	return updated, nil
 }
 
+// SetLabels sets or removes labels on a node. A nil value removes the label.
+func (a *NodeAnnotator) SetLabels(ctx context.Context, nodeName string, labels map[string]*string) error {
+	return a.SetMetadata(ctx, nodeName, labels, nil)
+}
+
+// SetMetadata sets or removes labels and annotations on a node in a single
+// patch. A nil value removes the key.
+func (a *NodeAnnotator) SetMetadata(ctx context.Context, nodeName string, labels, nodeAnnotations map[string]*string) error {
+	metadata := map[string]any{}
+	if len(labels) > 0 {
+		metadata["labels"] = labels
+	}
+	if len(nodeAnnotations) > 0 {
+		metadata["annotations"] = nodeAnnotations
+	}
+	patch, err := json.Marshal(map[string]any{"metadata": metadata})
+	if err != nil {
+		return fmt.Errorf("failed to build metadata patch: %w", err)
+	}
+	return a.patch(ctx, nodeName, string(patch))
+}
+
 func (a *NodeAnnotator) patch(ctx context.Context, nodeName, patch string) error {
 	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 		node := &corev1.Node{}
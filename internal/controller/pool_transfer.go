@@ -0,0 +1,97 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+// Pool transfer policies, see RolloutConfig.PoolTransferPolicy.
+const (
+	PoolTransferRelease = "Release"
+	PoolTransferAdopt   = "Adopt"
+)
+
+// PreviousPool returns the pool node was last handled by when that is not
+// pool, or "" when the node is new or already belongs to pool.
+func PreviousPool(pool *mcov1alpha1.MachineConfigPool, node *corev1.Node) string {
+	previous := annotations.GetAnnotation(node.Annotations, annotations.Pool)
+	if previous == pool.Name {
+		return ""
+	}
+	return previous
+}
+
+// TakeOverNode moves node from the pool it was relabeled out of into pool.
+// With the Release policy, the cordon MCO set, the drain annotations and a
+// desired revision the agent has not started applying are cleared first, so the
+// node does not stay cordoned or pick up the old pool's config. Cordons
+// not set by MCO are left alone.
+func TakeOverNode(ctx context.Context, c client.Client, pool *mcov1alpha1.MachineConfigPool, node *corev1.Node) error {
+	release := pool.Spec.Rollout.PoolTransferPolicy != PoolTransferAdopt
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		current := &corev1.Node{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(node), current); err != nil {
+			return err
+		}
+		if current.Annotations == nil {
+			current.Annotations = map[string]string{}
+		}
+
+		if release {
+			if annotations.GetBoolAnnotation(current.Annotations, annotations.Cordoned) {
+				current.Spec.Unschedulable = false
+			}
+			for _, key := range []string{
+				annotations.Cordoned,
+				annotations.CordonedAt,
+				annotations.DrainStartedAt,
+				annotations.DrainCompletedAt,
+				annotations.DrainRetryCount,
+				annotations.DrainBlockedReason,
+				annotations.DrainLowestRemaining,
+				annotations.DrainNoProgressCount,
+				annotations.DrainProgressCheckedAt,
+				annotations.DesiredRevisionSetAt,
+				annotations.SkipReason,
+			} {
+				delete(current.Annotations, key)
+			}
+			// Roll a desired revision the agent has not started on back to
+			// what the node runs; an apply in progress is left to finish.
+			applied := annotations.GetAnnotation(current.Annotations, annotations.CurrentRevision)
+			switch {
+			case annotations.GetAnnotation(current.Annotations, annotations.AgentState) == annotations.StateApplying:
+			case applied == "":
+				delete(current.Annotations, annotations.DesiredRevision)
+			default:
+				current.Annotations[annotations.DesiredRevision] = applied
+			}
+		}
+		current.Annotations[annotations.Pool] = pool.Name
+
+		return c.Update(ctx, current)
+	})
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/internal/renderer"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+func TestPreviousPool(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        string
+	}{
+		{"new node", nil, ""},
+		{"same pool", map[string]string{annotations.Pool: "worker"}, ""},
+		{"other pool", map[string]string{annotations.Pool: "infra"}, "infra"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n", Annotations: tt.annotations}}
+			if got := PreviousPool(pool, node); got != tt.want {
+				t.Errorf("PreviousPool() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTakeOverNode(t *testing.T) {
+	midDrain := func() *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-1",
+				Annotations: map[string]string{
+					annotations.Pool:                 "infra",
+					annotations.CurrentRevision:      "infra-old",
+					annotations.DesiredRevision:      "infra-new",
+					annotations.AgentState:           annotations.StateDone,
+					annotations.Cordoned:             "true",
+					annotations.DrainStartedAt:       "2026-01-01T00:00:00Z",
+					annotations.DrainRetryCount:      "2",
+					annotations.DesiredRevisionSetAt: "2026-01-01T00:00:00Z",
+				},
+			},
+			Spec: corev1.NodeSpec{Unschedulable: true},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		policy           string
+		node             func() *corev1.Node
+		wantCordoned     bool
+		wantDesired      string
+		wantDrainCleared bool
+	}{
+		{
+			name:             "release clears previous pool state",
+			node:             midDrain,
+			wantCordoned:     false,
+			wantDesired:      "infra-old",
+			wantDrainCleared: true,
+		},
+		{
+			name:   "release keeps a cordon not set by MCO",
+			policy: PoolTransferRelease,
+			node: func() *corev1.Node {
+				node := midDrain()
+				delete(node.Annotations, annotations.Cordoned)
+				return node
+			},
+			wantCordoned:     true,
+			wantDesired:      "infra-old",
+			wantDrainCleared: true,
+		},
+		{
+			name: "release lets an apply in progress finish",
+			node: func() *corev1.Node {
+				node := midDrain()
+				node.Annotations[annotations.AgentState] = annotations.StateApplying
+				return node
+			},
+			wantCordoned:     false,
+			wantDesired:      "infra-new",
+			wantDrainCleared: true,
+		},
+		{
+			name: "release drops desired of a never configured node",
+			node: func() *corev1.Node {
+				node := midDrain()
+				delete(node.Annotations, annotations.CurrentRevision)
+				return node
+			},
+			wantCordoned:     false,
+			wantDesired:      "",
+			wantDrainCleared: true,
+		},
+		{
+			name:         "adopt keeps the update running",
+			policy:       PoolTransferAdopt,
+			node:         midDrain,
+			wantCordoned: true,
+			wantDesired:  "infra-new",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &mcov1alpha1.MachineConfigPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+				Spec: mcov1alpha1.MachineConfigPoolSpec{
+					Rollout: mcov1alpha1.RolloutConfig{PoolTransferPolicy: tt.policy},
+				},
+			}
+			node := tt.node()
+			c := fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(node).Build()
+			ctx := context.Background()
+
+			if err := TakeOverNode(ctx, c, pool, node); err != nil {
+				t.Fatalf("TakeOverNode() error = %v", err)
+			}
+
+			got := &corev1.Node{}
+			if err := c.Get(ctx, client.ObjectKeyFromObject(node), got); err != nil {
+				t.Fatal(err)
+			}
+			if p := got.Annotations[annotations.Pool]; p != "worker" {
+				t.Errorf("pool annotation = %q, want worker", p)
+			}
+			if got.Spec.Unschedulable != tt.wantCordoned {
+				t.Errorf("unschedulable = %v, want %v", got.Spec.Unschedulable, tt.wantCordoned)
+			}
+			if d := got.Annotations[annotations.DesiredRevision]; d != tt.wantDesired {
+				t.Errorf("desired revision = %q, want %q", d, tt.wantDesired)
+			}
+			_, hasDrain := got.Annotations[annotations.DrainStartedAt]
+			_, hasRetries := got.Annotations[annotations.DrainRetryCount]
+			if cleared := !hasDrain && !hasRetries; cleared != tt.wantDrainCleared {
+				t.Errorf("drain annotations cleared = %v, want %v", cleared, tt.wantDrainCleared)
+			}
+		})
+	}
+}
+
+func TestReconcile_NodeMovedFromOtherPool(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}},
+			MachineConfigSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"pool": "worker"},
+			},
+		},
+	}
+	mc := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"pool": "worker"}},
+		Spec:       mcov1alpha1.MachineConfigSpec{Files: []mcov1alpha1.FileSpec{{Path: "/etc/app.conf", Content: "v1"}}},
+	}
+	rmc := renderer.BuildRMC("worker", renderer.Merge([]*mcov1alpha1.MachineConfig{mc}), pool)
+	pool.Status.TargetRevision = rmc.Name
+
+	// Relabeled out of infra while infra had it cordoned; paused so this
+	// pool does not start its own update right away.
+	moved := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "moved",
+			Labels: map[string]string{"role": "worker"},
+			Annotations: map[string]string{
+				annotations.Pool:            "infra",
+				annotations.CurrentRevision: "infra-old",
+				annotations.DesiredRevision: "infra-new",
+				annotations.Cordoned:        "true",
+				annotations.DrainStartedAt:  "2026-01-01T00:00:00Z",
+				annotations.Paused:          "true",
+			},
+		},
+		Spec: corev1.NodeSpec{Unschedulable: true},
+	}
+
+	r := newReconciler(pool, mc, rmc, moved)
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "worker"}}
+	// First reconcile arms debounce, second proceeds.
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	got := &corev1.Node{}
+	if err := r.Get(ctx, client.ObjectKey{Name: "moved"}, got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Spec.Unschedulable {
+		t.Error("node left cordoned by the previous pool")
+	}
+	if p := got.Annotations[annotations.Pool]; p != "worker" {
+		t.Errorf("pool annotation = %q, want worker", p)
+	}
+	if d := got.Annotations[annotations.DesiredRevision]; d != "infra-old" {
+		t.Errorf("desired revision = %q, want infra-old", d)
+	}
+}
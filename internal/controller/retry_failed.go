@@ -0,0 +1,92 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+// IsFailedNode reports whether a node is degraded from the pool's point of
+// view: the agent reported an error, or the apply exceeded its timeout.
+func IsFailedNode(node *corev1.Node, applyTimeout time.Duration) bool {
+	switch annotations.GetAnnotation(node.Annotations, annotations.AgentState) {
+	case annotations.StateError:
+		return true
+	case annotations.StateApplying:
+		return isApplyTimedOut(node.Annotations, applyTimeout)
+	}
+	return false
+}
+
+// RetryFailedNodes clears the error state of failed nodes so the agent
+// re-applies their desired revision. Healthy nodes are not touched.
+// Returns the names of the nodes that were reset.
+func RetryFailedNodes(ctx context.Context, c client.Client, pool *mcov1alpha1.MachineConfigPool, nodes []corev1.Node) ([]string, error) {
+	timeout := pool.Spec.Rollout.ApplyTimeoutSeconds
+	if timeout <= 0 {
+		timeout = DefaultApplyTimeoutSeconds
+	}
+
+	var retried []string
+	for i := range nodes {
+		node := &nodes[i]
+		if !IsFailedNode(node, time.Duration(timeout)*time.Second) {
+			continue
+		}
+		if err := resetFailedNode(ctx, c, node); err != nil {
+			return retried, fmt.Errorf("reset node %s: %w", node.Name, err)
+		}
+		retried = append(retried, node.Name)
+	}
+
+	return retried, nil
+}
+
+// resetFailedNode sets the agent state back to idle, drops the last error and
+// restarts the apply timeout. The annotation change wakes the agent's node
+// watch, which re-applies the desired revision.
+func resetFailedNode(ctx context.Context, c client.Client, node *corev1.Node) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		current := &corev1.Node{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(node), current); err != nil {
+			return err
+		}
+
+		current.Annotations = annotations.SetAnnotation(current.Annotations, annotations.AgentState, annotations.StateIdle)
+		delete(current.Annotations, annotations.LastError)
+		if annotations.GetAnnotation(current.Annotations, annotations.DesiredRevision) != "" {
+			current.Annotations[annotations.DesiredRevisionSetAt] = time.Now().UTC().Format(time.RFC3339)
+		}
+		return c.Update(ctx, current)
+	})
+}
+
+// ClearRetryFailedAnnotation removes the retry-failed request from the pool.
+func ClearRetryFailedAnnotation(ctx context.Context, c client.Client, pool *mcov1alpha1.MachineConfigPool) error {
+	patch := client.MergeFrom(pool.DeepCopy())
+	delete(pool.Annotations, annotations.RetryFailed)
+	return c.Patch(ctx, pool, patch)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+func TestIsFailedNode(t *testing.T) {
+	stale := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	fresh := time.Now().UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name string
+		ann  map[string]string
+		want bool
+	}{
+		{"error", map[string]string{annotations.AgentState: annotations.StateError}, true},
+		{"applying timed out", map[string]string{annotations.AgentState: annotations.StateApplying, annotations.DesiredRevisionSetAt: stale}, true},
+		{"applying in time", map[string]string{annotations.AgentState: annotations.StateApplying, annotations.DesiredRevisionSetAt: fresh}, false},
+		{"done", map[string]string{annotations.AgentState: annotations.StateDone}, false},
+		{"no state", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "n", Annotations: tt.ann}}
+			if got := IsFailedNode(node, 10*time.Minute); got != tt.want {
+				t.Errorf("IsFailedNode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcile_RetryFailedResetsOnlyFailedNodes(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "worker",
+			Annotations: map[string]string{annotations.RetryFailed: "true"},
+		},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			NodeSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"role": "worker"},
+			},
+		},
+	}
+	failed := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "failed",
+		Labels: map[string]string{"role": "worker"},
+		Annotations: map[string]string{
+			annotations.DesiredRevision: "worker-abc",
+			annotations.AgentState:      annotations.StateError,
+			annotations.LastError:       "write file: permission denied",
+		},
+	}}
+	healthy := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "healthy",
+		Labels: map[string]string{"role": "worker"},
+		Annotations: map[string]string{
+			annotations.CurrentRevision: "worker-abc",
+			annotations.DesiredRevision: "worker-abc",
+			annotations.AgentState:      annotations.StateDone,
+		},
+	}}
+
+	r := newReconciler(pool, failed, healthy)
+	ctx := context.Background()
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKey{Name: "worker"}}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	got := &corev1.Node{}
+	if err := r.Get(ctx, client.ObjectKey{Name: "failed"}, got); err != nil {
+		t.Fatalf("Failed to get node: %v", err)
+	}
+	if state := got.Annotations[annotations.AgentState]; state != annotations.StateIdle {
+		t.Errorf("failed node agent-state = %q, want %q", state, annotations.StateIdle)
+	}
+	if _, ok := got.Annotations[annotations.LastError]; ok {
+		t.Error("failed node last-error should be cleared")
+	}
+	if got.Annotations[annotations.DesiredRevisionSetAt] == "" {
+		t.Error("failed node apply timeout should be restarted")
+	}
+
+	if err := r.Get(ctx, client.ObjectKey{Name: "healthy"}, got); err != nil {
+		t.Fatalf("Failed to get node: %v", err)
+	}
+	if state := got.Annotations[annotations.AgentState]; state != annotations.StateDone {
+		t.Errorf("healthy node agent-state = %q, want untouched %q", state, annotations.StateDone)
+	}
+
+	updatedPool := &mcov1alpha1.MachineConfigPool{}
+	if err := r.Get(ctx, client.ObjectKey{Name: "worker"}, updatedPool); err != nil {
+		t.Fatalf("Failed to get pool: %v", err)
+	}
+	if _, ok := updatedPool.Annotations[annotations.RetryFailed]; ok {
+		t.Error("retry-failed annotation should be removed after retry")
+	}
+}
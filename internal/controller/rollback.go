@@ -0,0 +1,145 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+// DefaultAutoRollbackAfterSeconds is how long a pool must be Degraded
+// before AutoRollback kicks in when rollout.autoRollbackAfterSeconds is 0.
+const DefaultAutoRollbackAfterSeconds = 600
+
+// ShouldAutoRollback reports whether the rollout of target should be given
+// up for the pool's last successful revision: AutoRollback is on, the pool
+// is rolling out target, has been Degraded for autoRollbackAfterSeconds and
+// at least one node failed applying target. It never rolls back to an
+// empty revision or to target itself.
+func ShouldAutoRollback(pool *mcov1alpha1.MachineConfigPool, target string, nodes []corev1.Node, now time.Time) bool {
+	last := pool.Status.LastSuccessfulRevision
+	if !pool.Spec.Rollout.AutoRollback || last == "" || last == target || pool.Status.TargetRevision != target {
+		return false
+	}
+
+	degraded := meta.FindStatusCondition(pool.Status.Conditions, mcov1alpha1.ConditionDegraded)
+	if degraded == nil || degraded.Status != metav1.ConditionTrue {
+		return false
+	}
+	after := pool.Spec.Rollout.AutoRollbackAfterSeconds
+	if after <= 0 {
+		after = DefaultAutoRollbackAfterSeconds
+	}
+	if now.Sub(degraded.LastTransitionTime.Time) < time.Duration(after)*time.Second {
+		return false
+	}
+
+	applyTimeout := pool.Spec.Rollout.ApplyTimeoutSeconds
+	if applyTimeout <= 0 {
+		applyTimeout = DefaultApplyTimeoutSeconds
+	}
+	for i := range nodes {
+		if annotations.GetAnnotation(nodes[i].Annotations, annotations.DesiredRevision) == target &&
+			IsFailedNode(&nodes[i], time.Duration(applyTimeout)*time.Second) {
+			return true
+		}
+	}
+	return false
+}
+
+// RollBackNodes re-points nodes that still run to but were handed from
+// back to to. The regular update lifecycle never visits them, since they
+// already run their target. Nodes whose apply of from failed get their
+// agent state and current revision reset as well, so the agent applies to
+// again and undoes whatever part of from it wrote. Nodes that reached from
+// are rolled back by the regular lifecycle. Returns the names of the nodes
+// that were changed.
+func RollBackNodes(ctx context.Context, c client.Client, pool *mcov1alpha1.MachineConfigPool, nodes []corev1.Node, from, to string) ([]string, error) {
+	applyTimeout := pool.Spec.Rollout.ApplyTimeoutSeconds
+	if applyTimeout <= 0 {
+		applyTimeout = DefaultApplyTimeoutSeconds
+	}
+
+	var rolledBack []string
+	for i := range nodes {
+		node := &nodes[i]
+		if annotations.GetAnnotation(node.Annotations, annotations.DesiredRevision) != from ||
+			annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision) != to {
+			continue
+		}
+		failed := IsFailedNode(node, time.Duration(applyTimeout)*time.Second)
+		if err := rollBackNode(ctx, c, node, to, failed); err != nil {
+			return rolledBack, fmt.Errorf("roll back node %s: %w", node.Name, err)
+		}
+		rolledBack = append(rolledBack, node.Name)
+	}
+	return rolledBack, nil
+}
+
+func rollBackNode(ctx context.Context, c client.Client, node *corev1.Node, to string, failed bool) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		current := &corev1.Node{}
+		if err := c.Get(ctx, client.ObjectKeyFromObject(node), current); err != nil {
+			return err
+		}
+
+		current.Annotations = annotations.SetAnnotation(current.Annotations, annotations.DesiredRevision, to)
+		current.Annotations[annotations.DesiredRevisionSetAt] = time.Now().UTC().Format(time.RFC3339)
+		if failed {
+			current.Annotations[annotations.AgentState] = annotations.StateIdle
+			delete(current.Annotations, annotations.CurrentRevision)
+			delete(current.Annotations, annotations.LastError)
+		}
+		return c.Update(ctx, current)
+	})
+}
+
+// SetRolledBackCondition sets RolledBack=True.
+func SetRolledBackCondition(pool *mcov1alpha1.MachineConfigPool, from, to string) {
+	setCondition(pool, metav1.Condition{
+		Type:               mcov1alpha1.ConditionRolledBack,
+		Status:             metav1.ConditionTrue,
+		Reason:             "DegradedTooLong",
+		Message:            fmt.Sprintf("Rolled back from %s to %s; %s is not rolled out again until the MachineConfigs change", from, to, from),
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// ClearRolledBackCondition sets RolledBack=False if it was previously set.
+func ClearRolledBackCondition(pool *mcov1alpha1.MachineConfigPool) {
+	for _, c := range pool.Status.Conditions {
+		if c.Type == mcov1alpha1.ConditionRolledBack {
+			setCondition(pool, metav1.Condition{
+				Type:               mcov1alpha1.ConditionRolledBack,
+				Status:             metav1.ConditionFalse,
+				Reason:             "TargetChanged",
+				LastTransitionTime: metav1.Now(),
+			})
+			return
+		}
+	}
+}
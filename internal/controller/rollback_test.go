@@ -0,0 +1,247 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/internal/renderer"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+func degradedSince(since time.Time) []metav1.Condition {
+	return []metav1.Condition{{
+		Type:               mcov1alpha1.ConditionDegraded,
+		Status:             metav1.ConditionTrue,
+		Reason:             "NodeError",
+		LastTransitionTime: metav1.NewTime(since),
+	}}
+}
+
+func TestShouldAutoRollback(t *testing.T) {
+	now := time.Now()
+	failed := makeNode("failed", "worker-old", annotations.StateError)
+	failed.Annotations[annotations.DesiredRevision] = "worker-new"
+	healthy := makeNode("healthy", "worker-new", annotations.StateDone)
+	healthy.Annotations[annotations.DesiredRevision] = "worker-new"
+
+	tests := []struct {
+		name   string
+		mutate func(pool *mcov1alpha1.MachineConfigPool)
+		nodes  []corev1.Node
+		want   bool
+	}{
+		{name: "degraded past threshold", nodes: []corev1.Node{failed, healthy}, want: true},
+		{
+			name:   "disabled",
+			mutate: func(pool *mcov1alpha1.MachineConfigPool) { pool.Spec.Rollout.AutoRollback = false },
+			nodes:  []corev1.Node{failed},
+		},
+		{
+			name: "degraded not long enough",
+			mutate: func(pool *mcov1alpha1.MachineConfigPool) {
+				pool.Status.Conditions = degradedSince(now.Add(-time.Minute))
+			},
+			nodes: []corev1.Node{failed},
+		},
+		{
+			name:   "custom threshold",
+			mutate: func(pool *mcov1alpha1.MachineConfigPool) { pool.Spec.Rollout.AutoRollbackAfterSeconds = 3600 },
+			nodes:  []corev1.Node{failed},
+		},
+		{
+			name:   "not degraded",
+			mutate: func(pool *mcov1alpha1.MachineConfigPool) { pool.Status.Conditions = nil },
+			nodes:  []corev1.Node{failed},
+		},
+		{name: "no node failed on target", nodes: []corev1.Node{healthy}},
+		{
+			name:   "no last successful revision",
+			mutate: func(pool *mcov1alpha1.MachineConfigPool) { pool.Status.LastSuccessfulRevision = "" },
+			nodes:  []corev1.Node{failed},
+		},
+		{
+			name:   "last successful revision is the target",
+			mutate: func(pool *mcov1alpha1.MachineConfigPool) { pool.Status.LastSuccessfulRevision = "worker-new" },
+			nodes:  []corev1.Node{failed},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &mcov1alpha1.MachineConfigPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+				Spec: mcov1alpha1.MachineConfigPoolSpec{
+					Rollout: mcov1alpha1.RolloutConfig{AutoRollback: true},
+				},
+				Status: mcov1alpha1.MachineConfigPoolStatus{
+					TargetRevision:         "worker-new",
+					LastSuccessfulRevision: "worker-old",
+					Conditions:             degradedSince(now.Add(-20 * time.Minute)),
+				},
+			}
+			if tt.mutate != nil {
+				tt.mutate(pool)
+			}
+			if got := ShouldAutoRollback(pool, "worker-new", tt.nodes, now); got != tt.want {
+				t.Errorf("ShouldAutoRollback() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRollBackNodes(t *testing.T) {
+	failed := makeNode("failed", "worker-old", annotations.StateError)
+	failed.Annotations[annotations.DesiredRevision] = "worker-new"
+	failed.Annotations[annotations.LastError] = "apply failed"
+	pending := makeNode("pending", "worker-old", annotations.StateDone)
+	pending.Annotations[annotations.DesiredRevision] = "worker-new"
+	reached := makeNode("reached", "worker-new", annotations.StateDone)
+	reached.Annotations[annotations.DesiredRevision] = "worker-new"
+
+	pool := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(&failed, &pending, &reached).Build()
+	ctx := context.Background()
+
+	rolled, err := RollBackNodes(ctx, c, pool, []corev1.Node{failed, pending, reached}, "worker-new", "worker-old")
+	if err != nil {
+		t.Fatalf("RollBackNodes() error = %v", err)
+	}
+	if len(rolled) != 2 || rolled[0] != "failed" || rolled[1] != "pending" {
+		t.Errorf("RollBackNodes() = %v, want [failed pending]", rolled)
+	}
+
+	get := func(name string) *corev1.Node {
+		node := &corev1.Node{}
+		if err := c.Get(ctx, client.ObjectKey{Name: name}, node); err != nil {
+			t.Fatal(err)
+		}
+		return node
+	}
+	got := get("failed")
+	if d := got.Annotations[annotations.DesiredRevision]; d != "worker-old" {
+		t.Errorf("failed node desired = %q, want worker-old", d)
+	}
+	if _, ok := got.Annotations[annotations.CurrentRevision]; ok {
+		t.Error("failed node keeps its current revision, agent would not re-apply")
+	}
+	if s := got.Annotations[annotations.AgentState]; s != annotations.StateIdle {
+		t.Errorf("failed node state = %q, want idle", s)
+	}
+	got = get("pending")
+	if d := got.Annotations[annotations.DesiredRevision]; d != "worker-old" {
+		t.Errorf("pending node desired = %q, want worker-old", d)
+	}
+	if cur := got.Annotations[annotations.CurrentRevision]; cur != "worker-old" {
+		t.Errorf("pending node current = %q, want worker-old", cur)
+	}
+	if d := get("reached").Annotations[annotations.DesiredRevision]; d != "worker-new" {
+		t.Errorf("node on the failed revision changed outside the update lifecycle: desired = %q", d)
+	}
+}
+
+func TestReconcile_AutoRollback(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}},
+			MachineConfigSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"pool": "worker"},
+			},
+			Rollout: mcov1alpha1.RolloutConfig{AutoRollback: true},
+		},
+	}
+	oldMC := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"pool": "worker"}},
+		Spec:       mcov1alpha1.MachineConfigSpec{Files: []mcov1alpha1.FileSpec{{Path: "/etc/app.conf", Content: "v1"}}},
+	}
+	mc := oldMC.DeepCopy()
+	mc.Spec.Files[0].Content = "v2"
+	oldRMC := renderer.BuildRMC("worker", renderer.Merge([]*mcov1alpha1.MachineConfig{oldMC}), pool)
+	newRMC := renderer.BuildRMC("worker", renderer.Merge([]*mcov1alpha1.MachineConfig{mc}), pool)
+	pool.Status.TargetRevision = newRMC.Name
+	pool.Status.LastSuccessfulRevision = oldRMC.Name
+	pool.Status.Conditions = degradedSince(time.Now().Add(-20 * time.Minute))
+
+	failed := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "failed",
+			Labels: map[string]string{"role": "worker"},
+			Annotations: map[string]string{
+				annotations.CurrentRevision: oldRMC.Name,
+				annotations.DesiredRevision: newRMC.Name,
+				annotations.AgentState:      annotations.StateError,
+				annotations.LastError:       "unit failed to start",
+				annotations.Cordoned:        "true",
+			},
+		},
+		Spec: corev1.NodeSpec{Unschedulable: true},
+	}
+
+	r := newReconciler(pool, mc, oldRMC, newRMC, failed)
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "worker"}}
+	// First reconcile arms debounce, second proceeds.
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	got := &corev1.Node{}
+	if err := r.Get(ctx, client.ObjectKey{Name: "failed"}, got); err != nil {
+		t.Fatal(err)
+	}
+	if d := got.Annotations[annotations.DesiredRevision]; d != oldRMC.Name {
+		t.Errorf("desired revision = %q, want %q", d, oldRMC.Name)
+	}
+
+	updated := &mcov1alpha1.MachineConfigPool{}
+	if err := r.Get(ctx, client.ObjectKey{Name: "worker"}, updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status.TargetRevision != oldRMC.Name {
+		t.Errorf("TargetRevision = %q, want %q", updated.Status.TargetRevision, oldRMC.Name)
+	}
+	if updated.Status.RolledBackRevision != newRMC.Name {
+		t.Errorf("RolledBackRevision = %q, want %q", updated.Status.RolledBackRevision, newRMC.Name)
+	}
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, mcov1alpha1.ConditionRolledBack) {
+		t.Errorf("RolledBack condition not set: %+v", updated.Status.Conditions)
+	}
+
+	// The failed revision stays abandoned on later reconciles.
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := r.Get(ctx, client.ObjectKey{Name: "worker"}, updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status.TargetRevision != oldRMC.Name {
+		t.Errorf("TargetRevision after another reconcile = %q, want %q", updated.Status.TargetRevision, oldRMC.Name)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -17,30 +18,68 @@ const (
 	zoneLabel = "topology.kubernetes.io/zone"
 )
 
+// Pool update strategies.
+const (
+	UpdateStrategyRollingUpdate = "RollingUpdate"
+	UpdateStrategyOnDelete      = "OnDelete"
+)
+
 func CalculateMaxUnavailable(maxUnavailable *intstr.IntOrString, nodeCount int) int {
 	if maxUnavailable == nil {
 		return 1
 	}
 
-	var effective int
+	effective, ok := scaledValue(maxUnavailable, nodeCount)
+	if !ok || effective < 1 {
+		effective = 1
+	}
+
+	return effective
+}
 
-	if maxUnavailable.Type == intstr.Int {
-		effective = maxUnavailable.IntValue()
-	} else {
-		pctStr := maxUnavailable.StrVal
-		pctStr = strings.TrimSuffix(pctStr, "%")
-		pct, err := strconv.Atoi(pctStr)
-		if err != nil {
-			return 1
-		}
-		effective = int(math.Ceil(float64(nodeCount) * float64(pct) / 100.0))
+// CalculateMaxSurge returns how many nodes may be updated on top of
+// maxUnavailable, for pools whose workloads tolerate replacement capacity
+// coming up first. Percentages round up like maxUnavailable; unset,
+// invalid or negative values mean no surge.
+func CalculateMaxSurge(maxSurge *intstr.IntOrString, nodeCount int) int {
+	if maxSurge == nil {
+		return 0
+	}
+	effective, ok := scaledValue(maxSurge, nodeCount)
+	if !ok || effective < 0 {
+		return 0
 	}
+	return effective
+}
 
-	if effective < 1 {
-		effective = 1
+// CalculateUpdateBudget returns how many nodes of the pool may be updating
+// at once: maxUnavailable, plus maxSurge when set. With a surge,
+// maxUnavailable may be 0; the budget is never below 1.
+func CalculateUpdateBudget(rollout mcov1alpha1.RolloutConfig, nodeCount int) int {
+	surge := CalculateMaxSurge(rollout.MaxSurge, nodeCount)
+	if surge == 0 {
+		return CalculateMaxUnavailable(rollout.MaxUnavailable, nodeCount)
+	}
+	unavailable := 1
+	if rollout.MaxUnavailable != nil {
+		if v, ok := scaledValue(rollout.MaxUnavailable, nodeCount); ok && v >= 0 {
+			unavailable = v
+		}
 	}
+	return max(unavailable+surge, 1)
+}
 
-	return effective
+// scaledValue resolves an integer or a percentage of nodeCount, rounding
+// percentages up.
+func scaledValue(v *intstr.IntOrString, nodeCount int) (int, bool) {
+	if v.Type == intstr.Int {
+		return v.IntValue(), true
+	}
+	pct, err := strconv.Atoi(strings.TrimSuffix(v.StrVal, "%"))
+	if err != nil {
+		return 0, false
+	}
+	return int(math.Ceil(float64(nodeCount) * float64(pct) / 100.0)), true
 }
 
 func SortNodesForUpdate(nodes []corev1.Node) {
@@ -79,6 +118,11 @@ func IsNodeUnavailable(node *corev1.Node) bool {
 		return false
 	}
 
+	// Nodes under maintenance are outside MCO's control and its update budget.
+	if ann != nil && annotations.IsNodeInMaintenance(ann) {
+		return false
+	}
+
 	// Check for manual cordon (kubectl cordon) - spec.unschedulable
 	// This must be checked BEFORE MCO annotations to respect manual admin action.
 	if node.Spec.Unschedulable {
@@ -116,6 +160,18 @@ func SelectNodesForUpdate(
 	allNodes []corev1.Node,
 	targetRevision string,
 ) []corev1.Node {
+	return selectNodesForUpdate(pool, allNodes, singleTarget(targetRevision), nil)
+}
+
+// selectNodesForUpdate is SelectNodesForUpdate with a per-node target.
+// Nodes in pdbBlocked are skipped without using up the update budget.
+func selectNodesForUpdate(
+	pool *mcov1alpha1.MachineConfigPool,
+	allNodes []corev1.Node,
+	targetFor targetFunc,
+	pdbBlocked map[string]bool,
+) []corev1.Node {
+	now := time.Now()
 	var needsUpdate []corev1.Node
 	for _, node := range allNodes {
 		ann := node.Annotations
@@ -123,15 +179,30 @@ func SelectNodesForUpdate(
 			ann = make(map[string]string)
 		}
 
-		// Skip paused nodes entirely - they should not be selected for update
-		if annotations.IsNodePaused(ann) {
+		// Skip paused and maintenance nodes entirely - they should not be selected for update
+		if annotations.IsNodePaused(ann) || annotations.IsNodeInMaintenance(ann) {
+			continue
+		}
+
+		// Freshly registered nodes get time to settle before their first update
+		if _, ok := newNodeGraceRemaining(&node, pool.Spec.Rollout.NewNodeGraceSeconds, now); ok {
+			continue
+		}
+
+		// OnDelete pools only pick nodes the operator asked for
+		if !UpdateRequestedByStrategy(pool, &node) {
+			continue
+		}
+
+		// Draining would only wait on PodDisruptionBudgets; try other nodes
+		if pdbBlocked[node.Name] {
 			continue
 		}
 
 		current := ann[annotations.CurrentRevision]
 		// Only include nodes that are NOT already in progress (cordoned/draining)
 		// Those are handled separately by collectNodesInProgress
-		if current != targetRevision && !IsNodeUnavailable(&node) {
+		if current != targetFor(&node) && !IsNodeUnavailable(&node) {
 			needsUpdate = append(needsUpdate, node)
 		}
 	}
@@ -149,8 +220,18 @@ func SelectNodesForUpdate(
 		}
 	}
 
-	maxUnavailable := CalculateMaxUnavailable(pool.Spec.Rollout.MaxUnavailable, len(allNodes))
-	canUpdateCount := maxUnavailable - unavailableCount
+	budget := CalculateUpdateBudget(pool.Spec.Rollout, len(allNodes))
+	canUpdateCount := budget - unavailableCount
+
+	// While the canary phase runs, only the canary nodes may start
+	if limit, ok := canaryLimit(pool, allNodes, targetFor); ok && limit < canUpdateCount {
+		canUpdateCount = limit
+	}
+
+	// Never start more nodes than the healthy margin above minHealthyNodes
+	if limit, ok := MinHealthyLimit(pool, allNodes); ok && limit < canUpdateCount {
+		canUpdateCount = limit
+	}
 
 	if canUpdateCount <= 0 {
 		return nil
@@ -163,9 +244,124 @@ func SelectNodesForUpdate(
 	return needsUpdate[:canUpdateCount]
 }
 
+// IsNodeHealthy reports whether a node counts towards minHealthyNodes: it
+// is Ready, not unavailable for an update and its agent reported no error.
+func IsNodeHealthy(node *corev1.Node) bool {
+	if _, notReady := notReadyDuration(node, time.Now()); notReady {
+		return false
+	}
+	return !IsNodeUnavailable(node) &&
+		annotations.GetAnnotation(node.Annotations, annotations.AgentState) != annotations.StateError
+}
+
+// MinHealthyLimit returns how many more nodes may start updating before
+// fewer than rollout.minHealthyNodes healthy nodes would remain, never
+// below 0. ok is false when no minimum is set. Percentages round up like
+// maxUnavailable.
+func MinHealthyLimit(pool *mcov1alpha1.MachineConfigPool, nodes []corev1.Node) (int, bool) {
+	if pool.Spec.Rollout.MinHealthyNodes == nil {
+		return 0, false
+	}
+	minHealthy, ok := scaledValue(pool.Spec.Rollout.MinHealthyNodes, len(nodes))
+	if !ok || minHealthy <= 0 {
+		return 0, false
+	}
+	healthy := 0
+	for i := range nodes {
+		if IsNodeHealthy(&nodes[i]) {
+			healthy++
+		}
+	}
+	return max(healthy-minHealthy, 0), true
+}
+
+// selectScopedUpdates returns the out-of-date nodes the operator asked to
+// update right away with the update-now annotation, regardless of the
+// update budget, strategy or new-node grace. Paused, maintenance and
+// in-progress nodes are left out.
+func selectScopedUpdates(allNodes []corev1.Node, targetFor targetFunc) []corev1.Node {
+	var scoped []corev1.Node
+	for i := range allNodes {
+		node := &allNodes[i]
+		if !annotations.GetBoolAnnotation(node.Annotations, annotations.UpdateNow) {
+			continue
+		}
+		if annotations.IsNodePaused(node.Annotations) || annotations.IsNodeInMaintenance(node.Annotations) {
+			continue
+		}
+		if annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision) == targetFor(node) || IsNodeUnavailable(node) {
+			continue
+		}
+		scoped = append(scoped, *node)
+	}
+	return scoped
+}
+
+// UpdateRequestedByStrategy reports whether the pool's update strategy
+// lets node be picked for update. RollingUpdate pools pick any node;
+// OnDelete pools pick nodes with the update-requested annotation and nodes
+// MCO has never configured. Manually cordoned nodes are driven as in
+// progress regardless of strategy.
+func UpdateRequestedByStrategy(pool *mcov1alpha1.MachineConfigPool, node *corev1.Node) bool {
+	if pool.Spec.Rollout.UpdateStrategy != UpdateStrategyOnDelete {
+		return true
+	}
+	return annotations.GetBoolAnnotation(node.Annotations, annotations.UpdateRequested) ||
+		annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision) == ""
+}
+
+// FilterMaintenanceNodes drops nodes annotated for maintenance, which MCO
+// must not cordon, drain or update.
+func FilterMaintenanceNodes(nodes []corev1.Node) []corev1.Node {
+	result := make([]corev1.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if !annotations.IsNodeInMaintenance(node.Annotations) {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+// newNodeGraceRemaining returns how much of the new-node grace period a node
+// has left. Only nodes MCO has never configured (no current revision) and
+// that are not cordoned are held back.
+func newNodeGraceRemaining(node *corev1.Node, graceSeconds int, now time.Time) (time.Duration, bool) {
+	if graceSeconds <= 0 || node.CreationTimestamp.IsZero() {
+		return 0, false
+	}
+	if annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision) != "" || IsNodeUnavailable(node) {
+		return 0, false
+	}
+	remaining := time.Duration(graceSeconds)*time.Second - now.Sub(node.CreationTimestamp.Time)
+	if remaining <= 0 {
+		return 0, false
+	}
+	return remaining, true
+}
+
+// NewNodeGraceRecheckAfter returns when the next node held back by the
+// pool's new-node grace period becomes eligible for update. Zero when no
+// node is waiting.
+func NewNodeGraceRecheckAfter(pool *mcov1alpha1.MachineConfigPool, nodes []corev1.Node) time.Duration {
+	now := time.Now()
+	var recheck time.Duration
+	for i := range nodes {
+		remaining, ok := newNodeGraceRemaining(&nodes[i], pool.Spec.Rollout.NewNodeGraceSeconds, now)
+		if ok && (recheck == 0 || remaining < recheck) {
+			recheck = remaining
+		}
+	}
+	return recheck
+}
+
 // collectNodesInProgress returns nodes that are already in the update process
 // (cordoned or draining) and haven't completed their update yet.
 func collectNodesInProgress(allNodes []corev1.Node, targetRevision string) []corev1.Node {
+	return collectNodesInProgressFor(allNodes, singleTarget(targetRevision))
+}
+
+// collectNodesInProgressFor is collectNodesInProgress with a per-node target.
+func collectNodesInProgressFor(allNodes []corev1.Node, targetFor targetFunc) []corev1.Node {
 	var inProgress []corev1.Node
 	for _, node := range allNodes {
 		ann := node.Annotations
@@ -173,13 +369,14 @@ func collectNodesInProgress(allNodes []corev1.Node, targetRevision string) []cor
 			ann = make(map[string]string)
 		}
 
-		// Skip paused nodes - they are intentionally paused
-		if annotations.IsNodePaused(ann) {
+		// Skip paused nodes - they are intentionally paused - and nodes under maintenance
+		if annotations.IsNodePaused(ann) || annotations.IsNodeInMaintenance(ann) {
 			continue
 		}
 
 		// Node is in-progress if it's cordoned/draining but not yet at target revision
 		current := ann[annotations.CurrentRevision]
+		targetRevision := targetFor(&node)
 
 		// Include nodes that are unavailable (cordoned/draining/applying).
 		// Also include nodes that already reached the target revision but still
@@ -0,0 +1,249 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+// RolloutReportConfigMapName is the ConfigMap in MCONamespace holding the
+// reports of completed rollouts, one JSON document per "<revision>.json" key.
+const RolloutReportConfigMapName = "mco-rollout-reports"
+
+// RolloutReportsPerPool is how many reports are kept for each pool; older
+// ones are dropped when a new rollout completes.
+const RolloutReportsPerPool = 5
+
+// RolloutReport summarizes a completed rollout for audits and postmortems.
+type RolloutReport struct {
+	Pool             string     `json:"pool"`
+	Revision         string     `json:"revision"`
+	PreviousRevision string     `json:"previousRevision,omitempty"`
+	StartedAt        *time.Time `json:"startedAt,omitempty"`
+	CompletedAt      time.Time  `json:"completedAt"`
+	DurationSeconds  int64      `json:"durationSeconds,omitempty"`
+	NodesUpdated     int        `json:"nodesUpdated"`
+	// Reboots counts the nodes whose update required a reboot.
+	Reboots      int                              `json:"reboots"`
+	PodEvictions int                              `json:"podEvictions"`
+	Nodes        []NodeRolloutReport              `json:"nodes"`
+	Failures     []mcov1alpha1.NodeRolloutFailure `json:"failures,omitempty"`
+}
+
+// NodeRolloutReport is one node's part of a RolloutReport.
+type NodeRolloutReport struct {
+	Name string `json:"name"`
+	// UpdateStartedAt is when the node was given the revision.
+	UpdateStartedAt *time.Time `json:"updateStartedAt,omitempty"`
+	// DrainSeconds is how long the node's drain took, when it was drained.
+	DrainSeconds int64 `json:"drainSeconds,omitempty"`
+	// RebootReason is why the revision required a reboot, if it did.
+	RebootReason string `json:"rebootReason,omitempty"`
+}
+
+// RecordRolloutFailures updates pool.Status.RolloutFailures with the nodes
+// failing to roll out target: nodes whose agent reports an error, nodes
+// past the apply timeout, and errors the controller hit on a node
+// (nodeErrors, by node name). Failures of an earlier target are dropped.
+// A node keeps its first-seen time while it fails again.
+func RecordRolloutFailures(
+	pool *mcov1alpha1.MachineConfigPool,
+	target string,
+	nodes []corev1.Node,
+	timedOut []string,
+	nodeErrors map[string]string,
+	now time.Time,
+) {
+	failures := make(map[string]string, len(nodeErrors))
+	for name, msg := range nodeErrors {
+		failures[name] = msg
+	}
+	for _, name := range timedOut {
+		if _, ok := failures[name]; !ok {
+			failures[name] = "apply timed out"
+		}
+	}
+	for i := range nodes {
+		node := &nodes[i]
+		if annotations.GetAnnotation(node.Annotations, annotations.AgentState) != annotations.StateError {
+			continue
+		}
+		if annotations.GetAnnotation(node.Annotations, annotations.DesiredRevision) != target {
+			continue
+		}
+		if _, ok := failures[node.Name]; !ok {
+			failures[node.Name] = annotations.GetAnnotation(node.Annotations, annotations.LastError)
+		}
+	}
+
+	var kept []mcov1alpha1.NodeRolloutFailure
+	for _, f := range pool.Status.RolloutFailures {
+		if f.Revision != target {
+			continue
+		}
+		if msg, ok := failures[f.Node]; ok {
+			f.Message = msg
+			delete(failures, f.Node)
+		}
+		kept = append(kept, f)
+	}
+	for name, msg := range failures {
+		kept = append(kept, mcov1alpha1.NodeRolloutFailure{
+			Node:        name,
+			Revision:    target,
+			Message:     msg,
+			FirstSeenAt: metav1.NewTime(now),
+		})
+	}
+	sort.Slice(kept, func(i, j int) bool { return kept[i].Node < kept[j].Node })
+	pool.Status.RolloutFailures = kept
+}
+
+// BuildRolloutReport builds the report of the rollout of target that just
+// completed. startedAt and previousRevision come from the pool status
+// before the rollout completed, since completion clears them.
+func BuildRolloutReport(
+	pool *mcov1alpha1.MachineConfigPool,
+	target, previousRevision string,
+	startedAt *metav1.Time,
+	nodes []corev1.Node,
+	now time.Time,
+) RolloutReport {
+	report := RolloutReport{
+		Pool:             pool.Name,
+		Revision:         target,
+		PreviousRevision: previousRevision,
+		CompletedAt:      now.UTC(),
+		Failures:         pool.Status.RolloutFailures,
+	}
+	if startedAt != nil {
+		started := startedAt.UTC()
+		report.StartedAt = &started
+		report.DurationSeconds = int64(now.Sub(started).Seconds())
+	}
+	if pool.Status.PodEvictions != nil && pool.Status.PodEvictions.Revision == target {
+		report.PodEvictions = pool.Status.PodEvictions.Count
+	}
+
+	drains := make(map[string]mcov1alpha1.NodeDrainDuration, len(pool.Status.DrainDurations))
+	for _, d := range pool.Status.DrainDurations {
+		drains[d.Node] = d
+	}
+
+	for i := range nodes {
+		node := &nodes[i]
+		if annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision) != target {
+			continue
+		}
+		report.NodesUpdated++
+
+		nodeReport := NodeRolloutReport{
+			Name:         node.Name,
+			RebootReason: annotations.GetAnnotation(node.Annotations, annotations.RebootReason),
+		}
+		if setAt, err := time.Parse(time.RFC3339, annotations.GetAnnotation(node.Annotations, annotations.DesiredRevisionSetAt)); err == nil {
+			nodeReport.UpdateStartedAt = &setAt
+		}
+		// Only drains of this rollout count; the status keeps the last
+		// drain of every node.
+		if d, ok := drains[node.Name]; ok && (startedAt == nil || !d.CompletedAt.Before(startedAt)) {
+			nodeReport.DrainSeconds = d.DurationSeconds
+		}
+		if nodeReport.RebootReason != "" {
+			report.Reboots++
+		}
+		report.Nodes = append(report.Nodes, nodeReport)
+	}
+	sort.Slice(report.Nodes, func(i, j int) bool { return report.Nodes[i].Name < report.Nodes[j].Name })
+
+	return report
+}
+
+// StoreRolloutReport writes report into the RolloutReportConfigMapName
+// ConfigMap under "<revision>.json", dropping the pool's oldest reports
+// beyond RolloutReportsPerPool.
+func StoreRolloutReport(ctx context.Context, c client.Client, report RolloutReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("encode rollout report: %w", err)
+	}
+	key := report.Revision + ".json"
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		cm := &corev1.ConfigMap{}
+		err := c.Get(ctx, client.ObjectKey{Namespace: MCONamespace, Name: RolloutReportConfigMapName}, cm)
+		if apierrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: MCONamespace, Name: RolloutReportConfigMapName},
+				Data:       map[string]string{key: string(data)},
+			}
+			return c.Create(ctx, cm)
+		}
+		if err != nil {
+			return err
+		}
+
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		cm.Data[key] = string(data)
+		pruneRolloutReports(cm.Data, report.Pool)
+		return c.Update(ctx, cm)
+	})
+}
+
+// pruneRolloutReports drops the oldest reports of pool from data so that
+// at most RolloutReportsPerPool remain. Entries that do not decode are
+// left alone.
+func pruneRolloutReports(data map[string]string, pool string) {
+	type entry struct {
+		key         string
+		completedAt time.Time
+	}
+	var reports []entry
+	for key, value := range data {
+		if !strings.HasSuffix(key, ".json") {
+			continue
+		}
+		var r RolloutReport
+		if err := json.Unmarshal([]byte(value), &r); err != nil || r.Pool != pool {
+			continue
+		}
+		reports = append(reports, entry{key: key, completedAt: r.CompletedAt})
+	}
+	if len(reports) <= RolloutReportsPerPool {
+		return
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].completedAt.After(reports[j].completedAt) })
+	for _, r := range reports[RolloutReportsPerPool:] {
+		delete(data, r.key)
+	}
+}
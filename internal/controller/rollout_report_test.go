@@ -0,0 +1,168 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+func TestRecordRolloutFailures(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+	pool.Status.RolloutFailures = []mcov1alpha1.NodeRolloutFailure{
+		{Node: "stale", Revision: "worker-old", Message: "old"},
+	}
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "n1", Annotations: map[string]string{
+			annotations.DesiredRevision: "worker-new",
+			annotations.AgentState:      annotations.StateError,
+			annotations.LastError:       "write file: permission denied",
+		}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "n2", Annotations: map[string]string{
+			annotations.DesiredRevision: "worker-new",
+			annotations.AgentState:      annotations.StateDone,
+		}}},
+	}
+	first := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	RecordRolloutFailures(pool, "worker-new", nodes, []string{"n3"}, map[string]string{"n2": "cordon node: conflict"}, first)
+	if got := len(pool.Status.RolloutFailures); got != 3 {
+		t.Fatalf("expected 3 failures, got %d: %+v", got, pool.Status.RolloutFailures)
+	}
+	byNode := map[string]mcov1alpha1.NodeRolloutFailure{}
+	for _, f := range pool.Status.RolloutFailures {
+		byNode[f.Node] = f
+	}
+	if byNode["n1"].Message != "write file: permission denied" {
+		t.Errorf("n1 message = %q", byNode["n1"].Message)
+	}
+	if byNode["n2"].Message != "cordon node: conflict" {
+		t.Errorf("n2 message = %q", byNode["n2"].Message)
+	}
+	if byNode["n3"].Message != "apply timed out" {
+		t.Errorf("n3 message = %q", byNode["n3"].Message)
+	}
+	if _, ok := byNode["stale"]; ok {
+		t.Error("failures of an earlier revision should be dropped")
+	}
+
+	// A node failing again keeps its first-seen time but takes the new message.
+	nodes[0].Annotations[annotations.LastError] = "write file: disk full"
+	RecordRolloutFailures(pool, "worker-new", nodes, nil, nil, first.Add(time.Hour))
+	for _, f := range pool.Status.RolloutFailures {
+		if f.Node != "n1" {
+			continue
+		}
+		if f.Message != "write file: disk full" || !f.FirstSeenAt.Time.Equal(first) {
+			t.Errorf("n1 = %+v, want updated message and original first-seen time", f)
+		}
+	}
+}
+
+func TestBuildRolloutReport(t *testing.T) {
+	started := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	now := started.Add(30 * time.Minute)
+	pool := &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+	pool.Status.PodEvictions = &mcov1alpha1.PodEvictionCount{Revision: "worker-new", Count: 7}
+	pool.Status.DrainDurations = []mcov1alpha1.NodeDrainDuration{
+		{Node: "n1", DurationSeconds: 42, CompletedAt: metav1.NewTime(started.Add(5 * time.Minute))},
+		{Node: "n2", DurationSeconds: 99, CompletedAt: metav1.NewTime(started.Add(-time.Hour))},
+	}
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "n2", Annotations: map[string]string{
+			annotations.CurrentRevision:      "worker-new",
+			annotations.DesiredRevisionSetAt: started.Add(10 * time.Minute).Format(time.RFC3339),
+		}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "n1", Annotations: map[string]string{
+			annotations.CurrentRevision: "worker-new",
+			annotations.RebootReason:    "file /etc/foo changed",
+		}}},
+	}
+
+	startedAt := metav1.NewTime(started)
+	report := BuildRolloutReport(pool, "worker-new", "worker-old", &startedAt, nodes, now)
+
+	if report.DurationSeconds != 1800 || report.NodesUpdated != 2 || report.Reboots != 1 || report.PodEvictions != 7 {
+		t.Errorf("report = %+v, want 1800s, 2 nodes, 1 reboot, 7 evictions", report)
+	}
+	if len(report.Nodes) != 2 || report.Nodes[0].Name != "n1" {
+		t.Fatalf("nodes = %+v, want n1 and n2 sorted", report.Nodes)
+	}
+	if report.Nodes[0].DrainSeconds != 42 {
+		t.Errorf("n1 drain = %d, want 42", report.Nodes[0].DrainSeconds)
+	}
+	if report.Nodes[1].DrainSeconds != 0 {
+		t.Errorf("n2 drain before the rollout should not count, got %d", report.Nodes[1].DrainSeconds)
+	}
+	if report.Nodes[1].UpdateStartedAt == nil {
+		t.Error("n2 update start should be reported")
+	}
+}
+
+func TestStoreRolloutReport_PrunesPerPool(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(newTestScheme()).Build()
+	ctx := context.Background()
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < RolloutReportsPerPool+2; i++ {
+		report := RolloutReport{
+			Pool:        "worker",
+			Revision:    fmt.Sprintf("worker-%d", i),
+			CompletedAt: base.Add(time.Duration(i) * time.Hour),
+		}
+		if err := StoreRolloutReport(ctx, c, report); err != nil {
+			t.Fatalf("StoreRolloutReport() error = %v", err)
+		}
+	}
+	if err := StoreRolloutReport(ctx, c, RolloutReport{Pool: "master", Revision: "master-0", CompletedAt: base}); err != nil {
+		t.Fatalf("StoreRolloutReport() error = %v", err)
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: MCONamespace, Name: RolloutReportConfigMapName}, cm); err != nil {
+		t.Fatalf("get ConfigMap: %v", err)
+	}
+	if len(cm.Data) != RolloutReportsPerPool+1 {
+		t.Errorf("ConfigMap has %d reports, want %d", len(cm.Data), RolloutReportsPerPool+1)
+	}
+	for _, key := range []string{"worker-0.json", "worker-1.json"} {
+		if _, ok := cm.Data[key]; ok {
+			t.Errorf("oldest report %s should be pruned", key)
+		}
+	}
+	var latest RolloutReport
+	if err := json.Unmarshal([]byte(cm.Data[fmt.Sprintf("worker-%d.json", RolloutReportsPerPool+1)]), &latest); err != nil {
+		t.Fatalf("decode latest report: %v", err)
+	}
+	if latest.Pool != "worker" {
+		t.Errorf("latest report pool = %q, want worker", latest.Pool)
+	}
+	if _, ok := cm.Data["master-0.json"]; !ok {
+		t.Error("reports of other pools must be kept")
+	}
+}
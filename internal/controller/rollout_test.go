@@ -82,6 +82,57 @@ func TestCalculateMaxUnavailable_InvalidPercentage(t *testing.T) {
 	}
 }
 
+func TestCalculateMaxSurge(t *testing.T) {
+	intVal := func(v int) *intstr.IntOrString { x := intstr.FromInt(v); return &x }
+	strVal := func(v string) *intstr.IntOrString { x := intstr.FromString(v); return &x }
+
+	tests := []struct {
+		name      string
+		value     *intstr.IntOrString
+		nodeCount int
+		expected  int
+	}{
+		{"nil means no surge", nil, 4, 0},
+		{"integer 2", intVal(2), 4, 2},
+		{"integer 0", intVal(0), 4, 0},
+		{"50% of 4 nodes", strVal("50%"), 4, 2},
+		{"10% of 4 nodes (ceil)", strVal("10%"), 4, 1},
+		{"invalid percentage", strVal("abc%"), 4, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculateMaxSurge(tt.value, tt.nodeCount); got != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCalculateUpdateBudget(t *testing.T) {
+	intVal := func(v int) *intstr.IntOrString { x := intstr.FromInt(v); return &x }
+	strVal := func(v string) *intstr.IntOrString { x := intstr.FromString(v); return &x }
+
+	tests := []struct {
+		name     string
+		rollout  mcov1alpha1.RolloutConfig
+		expected int
+	}{
+		{"defaults", mcov1alpha1.RolloutConfig{}, 1},
+		{"both zero falls back to 1", mcov1alpha1.RolloutConfig{MaxUnavailable: intVal(0), MaxSurge: intVal(0)}, 1},
+		{"50% surge on default maxUnavailable", mcov1alpha1.RolloutConfig{MaxSurge: strVal("50%")}, 3},
+		{"50% surge with maxUnavailable 0", mcov1alpha1.RolloutConfig{MaxUnavailable: intVal(0), MaxSurge: strVal("50%")}, 2},
+		{"integer maxUnavailable and percentage surge", mcov1alpha1.RolloutConfig{MaxUnavailable: intVal(1), MaxSurge: strVal("25%")}, 2},
+		{"percentage maxUnavailable and integer surge", mcov1alpha1.RolloutConfig{MaxUnavailable: strVal("50%"), MaxSurge: intVal(1)}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalculateUpdateBudget(tt.rollout, 4); got != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
 func TestSortNodesForUpdate_ByZone(t *testing.T) {
 	now := time.Now()
 	nodes := []corev1.Node{
@@ -271,6 +322,42 @@ func TestSelectNodesForUpdate_RespectsMaxUnavailable(t *testing.T) {
 	}
 }
 
+func TestSelectNodesForUpdate_MaxSurge(t *testing.T) {
+	maxUnavailable := intstr.FromInt(0)
+	maxSurge := intstr.FromString("50%")
+	pool := &mcov1alpha1.MachineConfigPool{
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			Rollout: mcov1alpha1.RolloutConfig{
+				MaxUnavailable: &maxUnavailable,
+				MaxSurge:       &maxSurge,
+			},
+		},
+	}
+	now := time.Now()
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-1", CreationTimestamp: metav1.Time{Time: now}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-2", CreationTimestamp: metav1.Time{Time: now}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-3", CreationTimestamp: metav1.Time{Time: now}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-4", CreationTimestamp: metav1.Time{Time: now}}},
+	}
+
+	if result := SelectNodesForUpdate(pool, nodes, "rev-1"); len(result) != 2 {
+		t.Errorf("expected 2 nodes (50%% surge of 4), got %d", len(result))
+	}
+
+	// A paused node is never picked and an in-progress node uses up budget.
+	nodes[0].Annotations = map[string]string{annotations.Paused: "true"}
+	nodes[1].Spec.Unschedulable = true
+	nodes[1].Annotations = map[string]string{annotations.Cordoned: "true"}
+	result := SelectNodesForUpdate(pool, nodes, "rev-1")
+	if len(result) != 1 {
+		t.Fatalf("expected 1 node, got %d", len(result))
+	}
+	if name := result[0].Name; name == "node-1" || name == "node-2" {
+		t.Errorf("selected %s, want a node that is neither paused nor in progress", name)
+	}
+}
+
 func TestSelectNodesForUpdate_AlreadyUnavailable(t *testing.T) {
 	maxUnavailable := intstr.FromInt(2)
 	pool := &mcov1alpha1.MachineConfigPool{
@@ -935,3 +1022,221 @@ func TestSelectNodesForUpdate_ManualCordonPartialCapacity(t *testing.T) {
 		t.Errorf("expected 1 node (maxUnavailable=2, 1 manual cordon), got %d", len(result))
 	}
 }
+
+func TestSelectNodesForUpdate_NewNodeGrace(t *testing.T) {
+	maxUnavailable := intstr.FromInt(3)
+	pool := &mcov1alpha1.MachineConfigPool{
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			Rollout: mcov1alpha1.RolloutConfig{
+				MaxUnavailable:      &maxUnavailable,
+				NewNodeGraceSeconds: 600,
+			},
+		},
+	}
+	now := time.Now()
+	nodes := []corev1.Node{
+		// Joined a minute ago, never configured: held back.
+		{ObjectMeta: metav1.ObjectMeta{Name: "fresh", CreationTimestamp: metav1.Time{Time: now.Add(-time.Minute)}}},
+		// Past the grace period.
+		{ObjectMeta: metav1.ObjectMeta{Name: "settled", CreationTimestamp: metav1.Time{Time: now.Add(-time.Hour)}}},
+		// Young but already configured by MCO: not a new node.
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:              "configured",
+			CreationTimestamp: metav1.Time{Time: now.Add(-time.Minute)},
+			Annotations:       map[string]string{annotations.CurrentRevision: "rev-0"},
+		}},
+	}
+
+	result := SelectNodesForUpdate(pool, nodes, "rev-1")
+	names := map[string]bool{}
+	for _, n := range result {
+		names[n.Name] = true
+	}
+	if names["fresh"] {
+		t.Error("fresh node selected during grace period")
+	}
+	if !names["settled"] || !names["configured"] {
+		t.Errorf("selected = %v, want settled and configured", names)
+	}
+
+	recheck := NewNodeGraceRecheckAfter(pool, nodes)
+	if recheck <= 8*time.Minute || recheck > 9*time.Minute {
+		t.Errorf("NewNodeGraceRecheckAfter() = %v, want ~9m", recheck)
+	}
+
+	pool.Spec.Rollout.NewNodeGraceSeconds = 0
+	if got := NewNodeGraceRecheckAfter(pool, nodes); got != 0 {
+		t.Errorf("NewNodeGraceRecheckAfter() with grace disabled = %v, want 0", got)
+	}
+	if len(SelectNodesForUpdate(pool, nodes, "rev-1")) != 3 {
+		t.Error("grace disabled should select all nodes")
+	}
+}
+
+func TestMaintenanceNodesExcludedFromRollout(t *testing.T) {
+	maintenance := corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name: "maint",
+		Annotations: map[string]string{
+			annotations.Maintenance: "true",
+			annotations.Cordoned:    "true",
+		},
+	}}
+	maintenance.Spec.Unschedulable = true
+	regular := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "regular"}}
+
+	if IsNodeUnavailable(&maintenance) {
+		t.Error("maintenance node should not count against maxUnavailable")
+	}
+
+	filtered := FilterMaintenanceNodes([]corev1.Node{maintenance, regular})
+	if len(filtered) != 1 || filtered[0].Name != "regular" {
+		t.Errorf("FilterMaintenanceNodes() = %v, want [regular]", filtered)
+	}
+
+	// With the maintenance node manually cordoned, the regular node still
+	// gets the single update slot.
+	result := SelectNodesForUpdate(&mcov1alpha1.MachineConfigPool{}, []corev1.Node{maintenance, regular}, "rev-1")
+	if len(result) != 1 || result[0].Name != "regular" {
+		t.Errorf("SelectNodesForUpdate() = %v, want [regular]", result)
+	}
+}
+
+func TestSelectNodesForUpdate_OnDeleteStrategy(t *testing.T) {
+	maxUnavailable := intstr.FromInt(5)
+	pool := &mcov1alpha1.MachineConfigPool{
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			Rollout: mcov1alpha1.RolloutConfig{
+				MaxUnavailable: &maxUnavailable,
+				UpdateStrategy: UpdateStrategyOnDelete,
+			},
+		},
+	}
+	now := time.Now()
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:              "requested",
+			CreationTimestamp: metav1.Time{Time: now},
+			Annotations: map[string]string{
+				annotations.CurrentRevision: "rev-0",
+				annotations.UpdateRequested: "true",
+			},
+		}},
+		{ObjectMeta: metav1.ObjectMeta{
+			Name:              "untouched",
+			CreationTimestamp: metav1.Time{Time: now},
+			Annotations:       map[string]string{annotations.CurrentRevision: "rev-0"},
+		}},
+		// Never configured by MCO: gets its first config like a new pod.
+		{ObjectMeta: metav1.ObjectMeta{Name: "unconfigured", CreationTimestamp: metav1.Time{Time: now}}},
+	}
+
+	names := map[string]bool{}
+	for _, n := range SelectNodesForUpdate(pool, nodes, "rev-1") {
+		names[n.Name] = true
+	}
+	if !names["requested"] || !names["unconfigured"] || names["untouched"] {
+		t.Errorf("selected = %v, want requested and unconfigured only", names)
+	}
+
+	reasons := NodeSkipReasons(pool, nodes, nil, "rev-1", nil, false)
+	if reasons["untouched"] != SkipReasonNotRequested {
+		t.Errorf("skip reason = %q, want %q", reasons["untouched"], SkipReasonNotRequested)
+	}
+
+	pool.Spec.Rollout.UpdateStrategy = UpdateStrategyRollingUpdate
+	if len(SelectNodesForUpdate(pool, nodes, "rev-1")) != 3 {
+		t.Error("RollingUpdate should select all nodes")
+	}
+}
+
+func TestSelectScopedUpdates(t *testing.T) {
+	now := map[string]string{annotations.UpdateNow: "true"}
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "requested", Annotations: now}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "plain"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "paused", Annotations: map[string]string{
+			annotations.UpdateNow: "true",
+			annotations.Paused:    "true",
+		}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "up-to-date", Annotations: map[string]string{
+			annotations.UpdateNow:       "true",
+			annotations.CurrentRevision: "rev-1",
+		}}},
+	}
+
+	result := selectScopedUpdates(nodes, singleTarget("rev-1"))
+	if len(result) != 1 || result[0].Name != "requested" {
+		t.Errorf("selectScopedUpdates() = %v, want only requested", result)
+	}
+}
+
+func TestMinHealthyLimit(t *testing.T) {
+	notReady := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "not-ready"}}
+	notReady.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}}
+	failed := corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:        "failed",
+		Annotations: map[string]string{annotations.AgentState: annotations.StateError},
+	}}
+	cordoned := corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cordoned"}, Spec: corev1.NodeSpec{Unschedulable: true}}
+	healthy := func(name string) corev1.Node {
+		return corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+	nodes := []corev1.Node{healthy("a"), healthy("b"), healthy("c"), notReady, failed, cordoned}
+	ref := func(v intstr.IntOrString) *intstr.IntOrString { return &v }
+
+	tests := []struct {
+		name       string
+		minHealthy *intstr.IntOrString
+		wantLimit  int
+		wantOK     bool
+	}{
+		{"unset", nil, 0, false},
+		{"zero", ref(intstr.FromInt(0)), 0, false},
+		{"margin of one", ref(intstr.FromInt(2)), 1, true},
+		{"at minimum", ref(intstr.FromInt(3)), 0, true},
+		{"below minimum", ref(intstr.FromInt(5)), 0, true},
+		{"percentage rounds up", ref(intstr.FromString("34%")), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &mcov1alpha1.MachineConfigPool{
+				Spec: mcov1alpha1.MachineConfigPoolSpec{
+					Rollout: mcov1alpha1.RolloutConfig{MinHealthyNodes: tt.minHealthy},
+				},
+			}
+			limit, ok := MinHealthyLimit(pool, nodes)
+			if limit != tt.wantLimit || ok != tt.wantOK {
+				t.Errorf("MinHealthyLimit() = %d, %v, want %d, %v", limit, ok, tt.wantLimit, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestSelectNodesForUpdate_MinHealthyNodes(t *testing.T) {
+	maxUnavailable := intstr.FromInt(3)
+	minHealthy := intstr.FromInt(3)
+	pool := &mcov1alpha1.MachineConfigPool{
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			Rollout: mcov1alpha1.RolloutConfig{
+				MaxUnavailable:  &maxUnavailable,
+				MinHealthyNodes: &minHealthy,
+			},
+		},
+	}
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-3"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-4"}},
+	}
+
+	if result := SelectNodesForUpdate(pool, nodes, "rev-1"); len(result) != 1 {
+		t.Fatalf("expected 1 node above the healthy minimum, got %d", len(result))
+	}
+
+	// A node unrelated to the rollout goes NotReady: the pool is at its minimum.
+	nodes[3].Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionFalse}}
+	if result := SelectNodesForUpdate(pool, nodes, "rev-1"); len(result) != 0 {
+		t.Errorf("expected no nodes at the healthy minimum, got %d", len(result))
+	}
+}
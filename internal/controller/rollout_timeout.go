@@ -0,0 +1,110 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+// TrackRolloutStart records when the rollout of the pool's target revision
+// started. previousTarget is the target before this reconcile; a change
+// restarts the clock. The start is cleared once every node is updated.
+func TrackRolloutStart(pool *mcov1alpha1.MachineConfigPool, previousTarget string, now time.Time) {
+	if pool.Status.UpdatedMachineCount >= pool.Status.MachineCount {
+		pool.Status.RolloutStartedAt = nil
+		return
+	}
+	if pool.Status.RolloutStartedAt == nil || previousTarget != pool.Status.TargetRevision {
+		started := metav1.NewTime(now)
+		pool.Status.RolloutStartedAt = &started
+	}
+}
+
+// RolloutRemaining returns how much of rollout.maxRolloutDurationSeconds
+// the rollout of targetRevision has left, negative once exceeded. ok is
+// false when no limit is set or no rollout of targetRevision is running.
+func RolloutRemaining(pool *mcov1alpha1.MachineConfigPool, targetRevision string, now time.Time) (time.Duration, bool) {
+	limit := pool.Spec.Rollout.MaxRolloutDurationSeconds
+	started := pool.Status.RolloutStartedAt
+	if limit <= 0 || started == nil || pool.Status.TargetRevision != targetRevision {
+		return 0, false
+	}
+	return time.Duration(limit)*time.Second - now.Sub(started.Time), true
+}
+
+// RolloutTimedOut reports whether the rollout of targetRevision ran past
+// rollout.maxRolloutDurationSeconds.
+func RolloutTimedOut(pool *mcov1alpha1.MachineConfigPool, targetRevision string, now time.Time) bool {
+	remaining, ok := RolloutRemaining(pool, targetRevision, now)
+	return ok && remaining <= 0
+}
+
+// SetRolloutTimeoutCondition sets RolloutTimeout=True.
+func SetRolloutTimeoutCondition(pool *mcov1alpha1.MachineConfigPool) {
+	message := fmt.Sprintf("Rollout of %s has not completed within %ds",
+		pool.Status.TargetRevision, pool.Spec.Rollout.MaxRolloutDurationSeconds)
+	if pool.Spec.Rollout.HaltOnRolloutTimeout {
+		message += "; no new nodes are started"
+	}
+	setCondition(pool, metav1.Condition{
+		Type:               mcov1alpha1.ConditionRolloutTimeout,
+		Status:             metav1.ConditionTrue,
+		Reason:             "MaxRolloutDurationExceeded",
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// ClearRolloutTimeoutCondition sets RolloutTimeout=False if it was previously set.
+func ClearRolloutTimeoutCondition(pool *mcov1alpha1.MachineConfigPool) {
+	for _, c := range pool.Status.Conditions {
+		if c.Type == mcov1alpha1.ConditionRolloutTimeout {
+			setCondition(pool, metav1.Condition{
+				Type:               mcov1alpha1.ConditionRolloutTimeout,
+				Status:             metav1.ConditionFalse,
+				Reason:             "WithinLimit",
+				LastTransitionTime: metav1.Now(),
+			})
+			return
+		}
+	}
+}
+
+// RecordNodeCompletion notes that a node of the pool finished updating, so
+// the soak period starts over.
+func RecordNodeCompletion(pool *mcov1alpha1.MachineConfigPool, now time.Time) {
+	completed := metav1.NewTime(now)
+	pool.Status.LastNodeCompletedAt = &completed
+}
+
+// SoakRemaining returns how much of rollout.soakSeconds is left since the
+// pool's last node completion. ok is false when no soak is configured or
+// the soak period already passed.
+func SoakRemaining(pool *mcov1alpha1.MachineConfigPool, now time.Time) (time.Duration, bool) {
+	soak := pool.Spec.Rollout.SoakSeconds
+	completed := pool.Status.LastNodeCompletedAt
+	if soak <= 0 || completed == nil {
+		return 0, false
+	}
+	remaining := time.Duration(soak)*time.Second - now.Sub(completed.Time)
+	return remaining, remaining > 0
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/internal/renderer"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+func TestTrackRolloutStart(t *testing.T) {
+	now := time.Now()
+	pool := &mcov1alpha1.MachineConfigPool{}
+	pool.Status.TargetRevision = "worker-b"
+	pool.Status.MachineCount = 3
+	pool.Status.UpdatedMachineCount = 1
+
+	TrackRolloutStart(pool, "worker-a", now)
+	if pool.Status.RolloutStartedAt == nil || !pool.Status.RolloutStartedAt.Time.Equal(now) {
+		t.Fatalf("RolloutStartedAt = %v, want %v", pool.Status.RolloutStartedAt, now)
+	}
+
+	// Same target later: the start is kept.
+	TrackRolloutStart(pool, "worker-b", now.Add(time.Hour))
+	if !pool.Status.RolloutStartedAt.Time.Equal(now) {
+		t.Errorf("RolloutStartedAt moved to %v, want %v", pool.Status.RolloutStartedAt, now)
+	}
+
+	// New target: the clock restarts.
+	pool.Status.TargetRevision = "worker-c"
+	TrackRolloutStart(pool, "worker-b", now.Add(2*time.Hour))
+	if !pool.Status.RolloutStartedAt.Time.Equal(now.Add(2 * time.Hour)) {
+		t.Errorf("RolloutStartedAt = %v, want restart on new target", pool.Status.RolloutStartedAt)
+	}
+
+	// All nodes updated: cleared.
+	pool.Status.UpdatedMachineCount = 3
+	TrackRolloutStart(pool, "worker-c", now.Add(3*time.Hour))
+	if pool.Status.RolloutStartedAt != nil {
+		t.Errorf("RolloutStartedAt = %v, want nil once complete", pool.Status.RolloutStartedAt)
+	}
+}
+
+func TestRolloutTimedOut(t *testing.T) {
+	now := time.Now()
+	started := metav1.NewTime(now.Add(-time.Hour))
+	pool := &mcov1alpha1.MachineConfigPool{}
+	pool.Status.TargetRevision = "worker-b"
+	pool.Status.RolloutStartedAt = &started
+
+	if RolloutTimedOut(pool, "worker-b", now) {
+		t.Error("no limit set: should not time out")
+	}
+
+	pool.Spec.Rollout.MaxRolloutDurationSeconds = 7200
+	if RolloutTimedOut(pool, "worker-b", now) {
+		t.Error("within limit: should not time out")
+	}
+	if remaining, ok := RolloutRemaining(pool, "worker-b", now); !ok || remaining != time.Hour {
+		t.Errorf("RolloutRemaining() = %v, %v, want 1h, true", remaining, ok)
+	}
+
+	pool.Spec.Rollout.MaxRolloutDurationSeconds = 1800
+	if !RolloutTimedOut(pool, "worker-b", now) {
+		t.Error("past limit: should time out")
+	}
+	if RolloutTimedOut(pool, "worker-c", now) {
+		t.Error("start belongs to another target: should not time out")
+	}
+
+	SetRolloutTimeoutCondition(pool)
+	cond := meta.FindStatusCondition(pool.Status.Conditions, mcov1alpha1.ConditionRolloutTimeout)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		t.Fatalf("RolloutTimeout = %v, want True", cond)
+	}
+	ClearRolloutTimeoutCondition(pool)
+	cond = meta.FindStatusCondition(pool.Status.Conditions, mcov1alpha1.ConditionRolloutTimeout)
+	if cond == nil || cond.Status != metav1.ConditionFalse {
+		t.Errorf("RolloutTimeout = %v, want False after clear", cond)
+	}
+}
+
+func TestSoakRemaining(t *testing.T) {
+	now := time.Now()
+	pool := &mcov1alpha1.MachineConfigPool{}
+
+	RecordNodeCompletion(pool, now.Add(-time.Minute))
+	if _, ok := SoakRemaining(pool, now); ok {
+		t.Error("no soak set: should not soak")
+	}
+
+	pool.Spec.Rollout.SoakSeconds = 300
+	if remaining, ok := SoakRemaining(pool, now); !ok || remaining != 4*time.Minute {
+		t.Errorf("SoakRemaining() = %v, %v, want 4m, true", remaining, ok)
+	}
+
+	if _, ok := SoakRemaining(pool, now.Add(5*time.Minute)); ok {
+		t.Error("soak period passed: should not soak")
+	}
+
+	pool.Status.LastNodeCompletedAt = nil
+	if _, ok := SoakRemaining(pool, now); ok {
+		t.Error("no node completed yet: should not soak")
+	}
+}
+
+func TestReconcile_SoakHoldsNextNode(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}},
+			MachineConfigSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"pool": "worker"},
+			},
+			Rollout: mcov1alpha1.RolloutConfig{SoakSeconds: 600},
+		},
+	}
+	mc := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"pool": "worker"}},
+		Spec:       mcov1alpha1.MachineConfigSpec{Files: []mcov1alpha1.FileSpec{{Path: "/etc/app.conf", Content: "v1"}}},
+	}
+	rmc := renderer.BuildRMC("worker", renderer.Merge([]*mcov1alpha1.MachineConfig{mc}), pool)
+	completed := metav1.NewTime(time.Now().Add(-time.Minute))
+	pool.Status.TargetRevision = rmc.Name
+	pool.Status.LastNodeCompletedAt = &completed
+
+	done := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "done",
+		Labels: map[string]string{"role": "worker"},
+		Annotations: map[string]string{
+			annotations.CurrentRevision: rmc.Name,
+			annotations.DesiredRevision: rmc.Name,
+			annotations.AgentState:      annotations.StateDone,
+		},
+	}}
+	waiting := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:        "waiting",
+		Labels:      map[string]string{"role": "worker"},
+		Annotations: map[string]string{annotations.CurrentRevision: "worker-old"},
+	}}
+
+	r := newReconciler(pool, mc, rmc, done, waiting)
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "worker"}}
+	// First reconcile arms debounce, second proceeds.
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	result, err := r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	got := &corev1.Node{}
+	if err := r.Get(ctx, client.ObjectKey{Name: "waiting"}, got); err != nil {
+		t.Fatal(err)
+	}
+	if d := got.Annotations[annotations.DesiredRevision]; d != "" {
+		t.Errorf("waiting node started during soak: desired = %q", d)
+	}
+	if got.Spec.Unschedulable {
+		t.Error("waiting node cordoned during soak")
+	}
+	if result.RequeueAfter <= 0 || result.RequeueAfter > 9*time.Minute {
+		t.Errorf("RequeueAfter = %v, want the remaining soak of about 9m", result.RequeueAfter)
+	}
+}
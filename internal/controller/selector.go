@@ -18,9 +18,11 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -48,8 +50,14 @@ func SelectNodes(ctx context.Context, c client.Client, pool *mcov1alpha1.Machine
 	return nodeList.Items, nil
 }
 
+// ErrBaseConfigNotFound is returned by SelectMachineConfigs when the pool's
+// baseConfigRef names a MachineConfig that does not exist.
+var ErrBaseConfigNotFound = errors.New("base MachineConfig not found")
+
 // SelectMachineConfigs returns MachineConfigs matching the pool's machineConfigSelector.
 // If machineConfigSelector is nil or empty, returns all MachineConfigs.
+// Configs without a priority get the pool's defaultMachineConfigPriority.
+// The pool's base MachineConfig is included even if the selector misses it.
 func SelectMachineConfigs(ctx context.Context, c client.Client, pool *mcov1alpha1.MachineConfigPool) ([]mcov1alpha1.MachineConfig, error) {
 	selector, err := selectorFromLabelSelector(pool.Spec.MachineConfigSelector)
 	if err != nil {
@@ -65,7 +73,39 @@ func SelectMachineConfigs(ctx context.Context, c client.Client, pool *mcov1alpha
 		return nil, fmt.Errorf("failed to list MachineConfigs: %w", err)
 	}
 
-	return mcList.Items, nil
+	ApplyDefaultPriority(pool, mcList.Items)
+
+	if pool.Spec.BaseConfigRef == nil {
+		return mcList.Items, nil
+	}
+	for i := range mcList.Items {
+		if mcList.Items[i].Name == pool.Spec.BaseConfigRef.Name {
+			return mcList.Items, nil
+		}
+	}
+	base := &mcov1alpha1.MachineConfig{}
+	if err := c.Get(ctx, client.ObjectKey{Name: pool.Spec.BaseConfigRef.Name}, base); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: %s", ErrBaseConfigNotFound, pool.Spec.BaseConfigRef.Name)
+		}
+		return nil, fmt.Errorf("failed to get base MachineConfig %s: %w", pool.Spec.BaseConfigRef.Name, err)
+	}
+	return append(mcList.Items, *base), nil
+}
+
+// ApplyDefaultPriority sets the pool's defaultMachineConfigPriority on configs
+// that leave priority unset. An explicit priority, 0 included, is kept.
+// The configs are modified in place.
+func ApplyDefaultPriority(pool *mcov1alpha1.MachineConfigPool, configs []mcov1alpha1.MachineConfig) {
+	if pool.Spec.DefaultMachineConfigPriority == nil {
+		return
+	}
+	for i := range configs {
+		if configs[i].Spec.Priority == nil {
+			priority := *pool.Spec.DefaultMachineConfigPriority
+			configs[i].Spec.Priority = &priority
+		}
+	}
 }
 
 func selectorFromLabelSelector(ls *metav1.LabelSelector) (labels.Selector, error) {
@@ -18,11 +18,13 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -231,6 +233,62 @@ func TestSelectMachineConfigs_MatchLabels(t *testing.T) {
 	}
 }
 
+// TestSelectMachineConfigs_DefaultPriority verifies unprioritized MCs take
+// the pool's default priority while explicit priorities, 0 included, are kept.
+func TestSelectMachineConfigs_DefaultPriority(t *testing.T) {
+	scheme := newTestScheme()
+
+	mcs := []client.Object{
+		&mcov1alpha1.MachineConfig{ObjectMeta: metav1.ObjectMeta{Name: "unset"}},
+		&mcov1alpha1.MachineConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "explicit"},
+			Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(10)},
+		},
+		&mcov1alpha1.MachineConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "explicit-zero"},
+			Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(0)},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(mcs...).Build()
+
+	tests := []struct {
+		name        string
+		poolDefault *int
+		wantUnset   int
+	}{
+		{"no pool default", nil, mcov1alpha1.DefaultPriority},
+		{"pool default", ptr.To(40), 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &mcov1alpha1.MachineConfigPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+				Spec:       mcov1alpha1.MachineConfigPoolSpec{DefaultMachineConfigPriority: tt.poolDefault},
+			}
+
+			result, err := SelectMachineConfigs(context.Background(), c, pool)
+			if err != nil {
+				t.Fatalf("SelectMachineConfigs() error = %v", err)
+			}
+
+			priorities := make(map[string]int)
+			for _, mc := range result {
+				priorities[mc.Name] = mc.Spec.GetPriority()
+			}
+			if priorities["unset"] != tt.wantUnset {
+				t.Errorf("unset priority = %d, want %d", priorities["unset"], tt.wantUnset)
+			}
+			if priorities["explicit"] != 10 {
+				t.Errorf("explicit priority = %d, want 10", priorities["explicit"])
+			}
+			if priorities["explicit-zero"] != 0 {
+				t.Errorf("explicit-zero priority = %d, want 0", priorities["explicit-zero"])
+			}
+		})
+	}
+}
+
 // TestNodeMatchesPool_Matches verifies node matching.
 func TestNodeMatchesPool_Matches(t *testing.T) {
 	node := &corev1.Node{
@@ -543,3 +601,43 @@ func TestMachineConfigMatchesPool_InvalidSelector(t *testing.T) {
 		t.Error("MachineConfigMatchesPool() should return error for invalid selector")
 	}
 }
+
+// TestSelectMachineConfigs_BaseConfigRef verifies the base MachineConfig is
+// included without matching the selector, and only once.
+func TestSelectMachineConfigs_BaseConfigRef(t *testing.T) {
+	mcs := []client.Object{
+		&mcov1alpha1.MachineConfig{ObjectMeta: metav1.ObjectMeta{Name: "mc-worker", Labels: map[string]string{"pool": "worker"}}},
+		&mcov1alpha1.MachineConfig{ObjectMeta: metav1.ObjectMeta{Name: "base"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(mcs...).Build()
+
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			MachineConfigSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "worker"}},
+			BaseConfigRef:         &mcov1alpha1.MachineConfigReference{Name: "base"},
+		},
+	}
+
+	result, err := SelectMachineConfigs(context.Background(), c, pool)
+	if err != nil {
+		t.Fatalf("SelectMachineConfigs() error = %v", err)
+	}
+	if len(result) != 2 {
+		t.Errorf("SelectMachineConfigs() returned %d MCs, want 2", len(result))
+	}
+
+	pool.Spec.MachineConfigSelector = nil
+	result, err = SelectMachineConfigs(context.Background(), c, pool)
+	if err != nil {
+		t.Fatalf("SelectMachineConfigs() error = %v", err)
+	}
+	if len(result) != 2 {
+		t.Errorf("selected base returned %d MCs, want 2", len(result))
+	}
+
+	pool.Spec.BaseConfigRef.Name = "missing"
+	if _, err := SelectMachineConfigs(context.Background(), c, pool); !errors.Is(err, ErrBaseConfigNotFound) {
+		t.Errorf("SelectMachineConfigs() with missing base: error = %v, want ErrBaseConfigNotFound", err)
+	}
+}
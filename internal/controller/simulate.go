@@ -0,0 +1,205 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/internal/configdiff"
+	"in-cloud.io/machine-config/internal/renderer"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+// RolloutSimulation describes what a rollout of a proposed set of
+// MachineConfigs would do to a pool. It is computed read-only.
+type RolloutSimulation struct {
+	// Pool is the simulated pool name.
+	Pool string `json:"pool"`
+
+	// Paused is true when the pool is paused and nothing would roll out yet.
+	Paused bool `json:"paused,omitempty"`
+
+	// RMC is the RenderedMachineConfig the proposed configs render to.
+	RMC *mcov1alpha1.RenderedMachineConfig `json:"rmc"`
+
+	// RMCExists is true when an identical RMC is already in the cluster.
+	RMCExists bool `json:"rmcExists"`
+
+	// ValidationFailures lists entries of the rendered config that fail validation.
+	ValidationFailures []mcov1alpha1.ValidationResult `json:"validationFailures,omitempty"`
+
+	// Nodes has one entry per pool node, in rollout order.
+	Nodes []NodeSimulation `json:"nodes"`
+}
+
+// NodeSimulation describes what the rollout would do to a single node.
+type NodeSimulation struct {
+	Name            string `json:"name"`
+	CurrentRevision string `json:"currentRevision,omitempty"`
+
+	// Update is true when the node is not already on the rendered revision.
+	Update bool `json:"update"`
+
+	// Drain is true when the node would be cordoned and drained first.
+	// New nodes joining a pool with an existing config skip cordon/drain.
+	Drain bool `json:"drain"`
+
+	// RebootRequired is the agent's diff-based reboot decision.
+	RebootRequired bool     `json:"rebootRequired"`
+	RebootReasons  []string `json:"rebootReasons,omitempty"`
+
+	// Reboot is true when the node would actually reboot under the pool's
+	// reboot strategy (RebootRequired and strategy IfRequired).
+	Reboot bool `json:"reboot"`
+
+	// Skipped explains why the node would not be updated, if it would not.
+	Skipped string `json:"skipped,omitempty"`
+
+	// Actions is the ordered plan for updating the node; see PlanNodeUpdate.
+	Actions []NodeAction `json:"actions,omitempty"`
+}
+
+// NodesToDrain returns the names of nodes that would be cordoned and drained.
+func (s *RolloutSimulation) NodesToDrain() []string {
+	var names []string
+	for _, n := range s.Nodes {
+		if n.Drain {
+			names = append(names, n.Name)
+		}
+	}
+	return names
+}
+
+// NodesToReboot returns the names of nodes that would reboot.
+func (s *RolloutSimulation) NodesToReboot() []string {
+	var names []string
+	for _, n := range s.Nodes {
+		if n.Reboot {
+			names = append(names, n.Name)
+		}
+	}
+	return names
+}
+
+// SimulateRollout renders configs for pool and reports the resulting RMC,
+// which nodes would be cordoned/drained and which would reboot, without
+// writing anything to the cluster. configs is the full hypothetical set of
+// MachineConfigs; those neither selected by the pool nor its base are ignored.
+func SimulateRollout(
+	ctx context.Context,
+	c client.Client,
+	pool *mcov1alpha1.MachineConfigPool,
+	configs []mcov1alpha1.MachineConfig,
+) (*RolloutSimulation, error) {
+	var selected []mcov1alpha1.MachineConfig
+	for i := range configs {
+		matches, err := MachineConfigMatchesPool(&configs[i], pool)
+		if err != nil {
+			return nil, fmt.Errorf("match MachineConfig %s: %w", configs[i].Name, err)
+		}
+		isBase := pool.Spec.BaseConfigRef != nil && pool.Spec.BaseConfigRef.Name == configs[i].Name
+		if matches || isBase {
+			selected = append(selected, *configs[i].DeepCopy())
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no MachineConfigs select pool %s", pool.Name)
+	}
+	ApplyDefaultPriority(pool, selected)
+
+	configPtrs := make([]*mcov1alpha1.MachineConfig, len(selected))
+	for i := range selected {
+		configPtrs[i] = &selected[i]
+	}
+
+	rendered, err := renderer.Render(ctx, c, pool.Name, configPtrs, pool)
+	if err != nil {
+		return nil, fmt.Errorf("render: %w", err)
+	}
+	rmc := rendered.RMC
+
+	sim := &RolloutSimulation{
+		Pool:               pool.Name,
+		Paused:             pool.Spec.Paused,
+		RMC:                rmc,
+		RMCExists:          rendered.Existing,
+		ValidationFailures: renderer.FailedValidations(renderer.ValidateRenderedConfig(rmc.Spec.Config)),
+	}
+
+	nodes, err := SelectNodes(ctx, c, pool)
+	if err != nil {
+		return nil, err
+	}
+	SortNodesForUpdate(nodes)
+
+	determiner := configdiff.NewRebootDeterminer(rmcClientFetcher{c: c})
+	canReboot := rebootsWhenRequired(pool)
+
+	for i := range nodes {
+		node := &nodes[i]
+		current := annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision)
+		ns := NodeSimulation{
+			Name:            node.Name,
+			CurrentRevision: current,
+			Update:          current != rmc.Name,
+		}
+
+		if ns.Update {
+			if annotations.IsNodePaused(node.Annotations) {
+				ns.Skipped = "node is paused"
+			} else if annotations.IsNodeInMaintenance(node.Annotations) {
+				ns.Skipped = "node is in maintenance"
+			} else {
+				ns.Drain = !isNewNodeForPool(node, pool)
+				decision := determiner.DetermineReboot(ctx, current, rmc)
+				ns.RebootRequired = decision.Required
+				ns.RebootReasons = decision.Reasons
+				ns.Reboot = decision.Required && canReboot
+				if ns.Drain && pool.Spec.Rollout.SkipDrainWithoutReboot && current != "" && !decision.Required {
+					if currentRMC, err := existingRMC(ctx, c, current); err == nil && currentRMC != nil {
+						ns.Drain = hasDisruptiveUnitChange(currentRMC, rmc)
+					}
+				}
+				if ns.Actions, err = PlanNodeUpdate(ctx, c, pool, node, rmc); err != nil {
+					return nil, fmt.Errorf("plan node %s: %w", node.Name, err)
+				}
+			}
+		}
+
+		sim.Nodes = append(sim.Nodes, ns)
+	}
+
+	return sim, nil
+}
+
+// rmcClientFetcher adapts a client to configdiff.RMCFetcher.
+type rmcClientFetcher struct {
+	c client.Client
+}
+
+func (f rmcClientFetcher) FetchRMC(ctx context.Context, name string) (*mcov1alpha1.RenderedMachineConfig, error) {
+	rmc := &mcov1alpha1.RenderedMachineConfig{}
+	if err := f.c.Get(ctx, types.NamespacedName{Name: name}, rmc); err != nil {
+		return nil, err
+	}
+	return rmc, nil
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/internal/renderer"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+func TestSimulateRollout(t *testing.T) {
+	base := mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "base"},
+		Spec: mcov1alpha1.MachineConfigSpec{
+			Priority: ptr.To(10),
+			Files:    []mcov1alpha1.FileSpec{{Path: "/etc/base.conf", Content: "base"}},
+		},
+	}
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			Reboot: mcov1alpha1.RebootPolicy{Strategy: "IfRequired"},
+		},
+	}
+	current := renderer.BuildRMC("worker", renderer.Merge([]*mcov1alpha1.MachineConfig{&base}), pool)
+	pool.Status.LastSuccessfulRevision = current.Name
+
+	nodes := []*corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Annotations: map[string]string{
+			annotations.CurrentRevision: current.Name,
+			annotations.Pool:            "worker",
+		}}},
+		// Never touched by MCO: joins without cordon/drain.
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node-c", Annotations: map[string]string{
+			annotations.CurrentRevision: current.Name,
+			annotations.Pool:            "worker",
+			annotations.Paused:          "true",
+		}}},
+	}
+
+	scheme := newTestScheme()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec:       corev1.PodSpec{NodeName: "node-a"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pool, current, pod).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		})
+	for _, n := range nodes {
+		builder = builder.WithObjects(n)
+	}
+	c := builder.Build()
+
+	proposed := []mcov1alpha1.MachineConfig{
+		base,
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "kernel"},
+			Spec: mcov1alpha1.MachineConfigSpec{
+				Priority: ptr.To(20),
+				Files:    []mcov1alpha1.FileSpec{{Path: "/etc/sysctl.d/90-kernel.conf", Content: "vm.swappiness=10"}},
+				Reboot:   mcov1alpha1.RebootRequirementSpec{Required: true},
+			},
+		},
+	}
+
+	sim, err := SimulateRollout(context.Background(), c, pool, proposed)
+	if err != nil {
+		t.Fatalf("SimulateRollout() error = %v", err)
+	}
+
+	if sim.RMC.Name == current.Name {
+		t.Fatal("proposed configs should render a new RMC")
+	}
+	if sim.RMCExists {
+		t.Error("RMCExists = true, want false")
+	}
+
+	got := map[string]NodeSimulation{}
+	for _, n := range sim.Nodes {
+		got[n.Name] = n
+	}
+	if a := got["node-a"]; !a.Update || !a.Drain || !a.RebootRequired || !a.Reboot {
+		t.Errorf("node-a = %+v, want update, drain and reboot", a)
+	}
+	if b := got["node-b"]; !b.Update || b.Drain {
+		t.Errorf("node-b = %+v, want update without drain", b)
+	}
+	if c := got["node-c"]; c.Skipped == "" || c.Drain || c.Reboot {
+		t.Errorf("node-c = %+v, want skipped", c)
+	}
+
+	wantActions := map[string][]NodeActionType{
+		"node-a": {NodeActionCordon, NodeActionDrain, NodeActionSetRevision, NodeActionReboot, NodeActionUncordon},
+		"node-b": {NodeActionSetRevision, NodeActionReboot},
+		"node-c": nil,
+	}
+	for name, want := range wantActions {
+		var types []NodeActionType
+		for _, a := range got[name].Actions {
+			types = append(types, a.Type)
+		}
+		if !reflect.DeepEqual(types, want) {
+			t.Errorf("%s actions = %v, want %v", name, got[name].Actions, want)
+		}
+	}
+	if a := got["node-a"].Actions; len(a) > 1 && a[1].Detail != "1 pods to evict" {
+		t.Errorf("node-a drain detail = %q, want %q", a[1].Detail, "1 pods to evict")
+	}
+
+	// Simulation must not change the cluster.
+	rmcs := &mcov1alpha1.RenderedMachineConfigList{}
+	if err := c.List(context.Background(), rmcs); err != nil {
+		t.Fatal(err)
+	}
+	if len(rmcs.Items) != 1 {
+		t.Errorf("RMC count = %d, want 1", len(rmcs.Items))
+	}
+	node := &corev1.Node{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "node-a"}, node); err != nil {
+		t.Fatal(err)
+	}
+	if node.Spec.Unschedulable {
+		t.Error("node-a was cordoned by simulation")
+	}
+}
+
+func TestSimulateRollout_NoRebootUnderNeverStrategy(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			Reboot: mcov1alpha1.RebootPolicy{Strategy: "Never"},
+		},
+	}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(pool, node).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(obj client.Object) []string {
+			return []string{obj.(*corev1.Pod).Spec.NodeName}
+		}).
+		Build()
+
+	proposed := []mcov1alpha1.MachineConfig{{
+		ObjectMeta: metav1.ObjectMeta{Name: "kernel"},
+		Spec: mcov1alpha1.MachineConfigSpec{
+			Files:  []mcov1alpha1.FileSpec{{Path: "/etc/sysctl.d/90-kernel.conf", Content: "vm.swappiness=10"}},
+			Reboot: mcov1alpha1.RebootRequirementSpec{Required: true},
+		},
+	}}
+
+	sim, err := SimulateRollout(context.Background(), c, pool, proposed)
+	if err != nil {
+		t.Fatalf("SimulateRollout() error = %v", err)
+	}
+	if len(sim.Nodes) != 1 {
+		t.Fatalf("nodes = %d, want 1", len(sim.Nodes))
+	}
+	n := sim.Nodes[0]
+	if !n.Drain || !n.RebootRequired || n.Reboot {
+		t.Errorf("node = %+v, want drain, reboot required but no reboot", n)
+	}
+	if len(sim.NodesToReboot()) != 0 {
+		t.Errorf("NodesToReboot() = %v, want none", sim.NodesToReboot())
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+// Reasons a node that needs the target revision is not being updated.
+const (
+	SkipReasonPaused             = "paused"
+	SkipReasonMaintenance        = "maintenance"
+	SkipReasonOverlap            = "overlap"
+	SkipReasonNewNodeGrace       = "new-node-grace"
+	SkipReasonUnavailable        = "unavailable"
+	SkipReasonBudget             = "budget"
+	SkipReasonFrozen             = "frozen"
+	SkipReasonNotRequested       = "not-requested"
+	SkipReasonPDBBlocked         = "pdb-blocked"
+	SkipReasonOutsideWindow      = "outside-window"
+	SkipReasonMinHealthy         = "min-healthy"
+	SkipReasonHalted             = "halted"
+	SkipReasonSoaking            = "soaking"
+	SkipReasonEvictionLimit      = "eviction-limit"
+	SkipReasonDrainConfigInvalid = "drain-config-invalid"
+)
+
+// NodeSkipReasons returns, for every pool node that is not on
+// targetRevision and not in processing, why it was left out of this
+// reconcile. processing is the set of nodes being driven this reconcile;
+// frozen is true while a ConfigFreeze blocks new updates.
+func NodeSkipReasons(
+	pool *mcov1alpha1.MachineConfigPool,
+	nodes []corev1.Node,
+	overlap *OverlapResult,
+	targetRevision string,
+	processing []corev1.Node,
+	frozen bool,
+) map[string]string {
+	held := ""
+	if frozen {
+		held = SkipReasonFrozen
+	}
+	return nodeSkipReasons(pool, nodes, overlap, singleTarget(targetRevision), processing, held, nil)
+}
+
+// nodeSkipReasons is NodeSkipReasons with a per-node target. held is the
+// reason no new node is started at all, empty if they may be. pdbBlocked
+// holds the nodes whose drain PodDisruptionBudgets would block.
+func nodeSkipReasons(
+	pool *mcov1alpha1.MachineConfigPool,
+	nodes []corev1.Node,
+	overlap *OverlapResult,
+	targetFor targetFunc,
+	processing []corev1.Node,
+	held string,
+	pdbBlocked map[string]bool,
+) map[string]string {
+	driven := make(map[string]bool, len(processing))
+	for i := range processing {
+		driven[processing[i].Name] = true
+	}
+
+	now := time.Now()
+	reasons := make(map[string]string)
+	for i := range nodes {
+		node := &nodes[i]
+		if driven[node.Name] ||
+			annotations.GetAnnotation(node.Annotations, annotations.CurrentRevision) == targetFor(node) {
+			continue
+		}
+
+		switch {
+		case annotations.IsNodeInMaintenance(node.Annotations):
+			reasons[node.Name] = SkipReasonMaintenance
+		case overlap != nil && overlap.IsNodeConflicting(node.Name):
+			reasons[node.Name] = SkipReasonOverlap
+		case annotations.IsNodePaused(node.Annotations):
+			reasons[node.Name] = SkipReasonPaused
+		case IsNodeUnavailable(node):
+			reasons[node.Name] = SkipReasonUnavailable
+		case held != "":
+			reasons[node.Name] = held
+		case !UpdateRequestedByStrategy(pool, node):
+			reasons[node.Name] = SkipReasonNotRequested
+		case pdbBlocked[node.Name]:
+			reasons[node.Name] = SkipReasonPDBBlocked
+		default:
+			if _, ok := newNodeGraceRemaining(node, pool.Spec.Rollout.NewNodeGraceSeconds, now); ok {
+				reasons[node.Name] = SkipReasonNewNodeGrace
+			} else {
+				reasons[node.Name] = SkipReasonBudget
+			}
+		}
+	}
+	return reasons
+}
+
+// SkippedMachineCounts aggregates per-node skip reasons for pool status,
+// sorted by reason.
+func SkippedMachineCounts(reasons map[string]string) []mcov1alpha1.SkippedMachineCount {
+	counts := make(map[string]int)
+	for _, reason := range reasons {
+		counts[reason]++
+	}
+	result := make([]mcov1alpha1.SkippedMachineCount, 0, len(counts))
+	for reason, count := range counts {
+		result = append(result, mcov1alpha1.SkippedMachineCount{Reason: reason, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Reason < result[j].Reason
+	})
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// SyncSkipReasonAnnotations writes the skip-reason annotation on nodes
+// when the pool enables annotateSkipReasons and removes it from nodes that
+// are no longer skipped. Nodes under maintenance are never touched.
+// Returns the number of nodes patched.
+func SyncSkipReasonAnnotations(
+	ctx context.Context,
+	c client.Client,
+	pool *mcov1alpha1.MachineConfigPool,
+	nodes []corev1.Node,
+	reasons map[string]string,
+) (int, error) {
+	patched := 0
+	for i := range nodes {
+		node := &nodes[i]
+		if annotations.IsNodeInMaintenance(node.Annotations) {
+			continue
+		}
+
+		desired := ""
+		if pool.Spec.Rollout.AnnotateSkipReasons {
+			desired = reasons[node.Name]
+		} else if reasons[node.Name] == SkipReasonOverlap {
+			// Another pool selecting this node may annotate it.
+			continue
+		}
+		current := annotations.GetAnnotation(node.Annotations, annotations.SkipReason)
+		if current == desired {
+			continue
+		}
+
+		var err error
+		if desired == "" {
+			err = RemoveNodeAnnotation(ctx, c, node, annotations.SkipReason)
+		} else {
+			err = SetNodeAnnotation(ctx, c, node, annotations.SkipReason, desired)
+		}
+		if err != nil {
+			return patched, err
+		}
+		patched++
+	}
+	return patched, nil
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/internal/renderer"
+	"in-cloud.io/machine-config/pkg/annotations"
+)
+
+func TestNodeSkipReasons(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			Rollout: mcov1alpha1.RolloutConfig{NewNodeGraceSeconds: 600},
+		},
+	}
+	old := metav1.Time{Time: time.Now().Add(-time.Hour)}
+	node := func(name string, ann map[string]string) corev1.Node {
+		return corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: ann, CreationTimestamp: old}}
+	}
+	nodes := []corev1.Node{
+		node("updated", map[string]string{annotations.CurrentRevision: "rev-1"}),
+		node("driven", map[string]string{annotations.CurrentRevision: "rev-0"}),
+		node("paused", map[string]string{annotations.CurrentRevision: "rev-0", annotations.Paused: "true"}),
+		node("maint", map[string]string{annotations.CurrentRevision: "rev-0", annotations.Maintenance: "true"}),
+		node("shared", map[string]string{annotations.CurrentRevision: "rev-0"}),
+		node("waiting", map[string]string{annotations.CurrentRevision: "rev-0"}),
+		{ObjectMeta: metav1.ObjectMeta{Name: "fresh", CreationTimestamp: metav1.Now()}},
+	}
+	overlap := &OverlapResult{ConflictingNodes: map[string][]string{"shared": {"worker", "infra"}}}
+
+	got := NodeSkipReasons(pool, nodes, overlap, "rev-1", nodes[1:2], false)
+
+	want := map[string]string{
+		"paused":  SkipReasonPaused,
+		"maint":   SkipReasonMaintenance,
+		"shared":  SkipReasonOverlap,
+		"waiting": SkipReasonBudget,
+		"fresh":   SkipReasonNewNodeGrace,
+	}
+	if len(got) != len(want) {
+		t.Errorf("reasons = %v, want %v", got, want)
+	}
+	for name, reason := range want {
+		if got[name] != reason {
+			t.Errorf("reason[%s] = %q, want %q", name, got[name], reason)
+		}
+	}
+
+	counts := SkippedMachineCounts(got)
+	if len(counts) != 5 || counts[0].Reason != SkipReasonBudget || counts[0].Count != 1 {
+		t.Errorf("SkippedMachineCounts() = %+v", counts)
+	}
+	if SkippedMachineCounts(nil) != nil {
+		t.Error("SkippedMachineCounts(nil) should be nil")
+	}
+}
+
+func TestSyncSkipReasonAnnotations(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			Rollout: mcov1alpha1.RolloutConfig{AnnotateSkipReasons: true},
+		},
+	}
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "skipped"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "resumed", Annotations: map[string]string{
+			annotations.SkipReason: SkipReasonBudget,
+		}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "maint", Annotations: map[string]string{
+			annotations.Maintenance: "true",
+		}}},
+	}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme()).
+		WithObjects(&nodes[0], &nodes[1], &nodes[2]).Build()
+
+	reasons := map[string]string{"skipped": SkipReasonBudget, "maint": SkipReasonMaintenance}
+	patched, err := SyncSkipReasonAnnotations(context.Background(), c, pool, nodes, reasons)
+	if err != nil {
+		t.Fatalf("SyncSkipReasonAnnotations() error = %v", err)
+	}
+	if patched != 2 {
+		t.Errorf("patched = %d, want 2", patched)
+	}
+
+	get := func(name string) map[string]string {
+		n := &corev1.Node{}
+		if err := c.Get(context.Background(), types.NamespacedName{Name: name}, n); err != nil {
+			t.Fatal(err)
+		}
+		return n.Annotations
+	}
+	if got := get("skipped")[annotations.SkipReason]; got != SkipReasonBudget {
+		t.Errorf("skipped annotation = %q, want %q", got, SkipReasonBudget)
+	}
+	if _, ok := get("resumed")[annotations.SkipReason]; ok {
+		t.Error("resumed node still carries skip-reason")
+	}
+	if _, ok := get("maint")[annotations.SkipReason]; ok {
+		t.Error("maintenance node was annotated")
+	}
+}
+
+func TestReconcile_HeldRolloutSkipReasons(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(pool *mcov1alpha1.MachineConfigPool)
+		objects []client.Object
+		want    string
+	}{
+		{
+			name: "halted after max rollout duration",
+			setup: func(pool *mcov1alpha1.MachineConfigPool) {
+				started := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+				pool.Spec.Rollout.MaxRolloutDurationSeconds = 3600
+				pool.Spec.Rollout.HaltOnRolloutTimeout = true
+				pool.Status.RolloutStartedAt = &started
+			},
+			want: SkipReasonHalted,
+		},
+		{
+			name: "soak period running",
+			setup: func(pool *mcov1alpha1.MachineConfigPool) {
+				completed := metav1.NewTime(time.Now().Add(-time.Minute))
+				pool.Spec.Rollout.SoakSeconds = 600
+				pool.Status.LastNodeCompletedAt = &completed
+			},
+			want: SkipReasonSoaking,
+		},
+		{
+			name: "eviction cap used up",
+			setup: func(pool *mcov1alpha1.MachineConfigPool) {
+				pool.Spec.Rollout.MaxPodEvictions = 5
+				pool.Status.PodEvictions = &mcov1alpha1.PodEvictionCount{Revision: pool.Status.TargetRevision, Count: 5}
+			},
+			want: SkipReasonEvictionLimit,
+		},
+		{
+			name:  "drain config invalid",
+			setup: func(pool *mcov1alpha1.MachineConfigPool) {},
+			objects: []client.Object{&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: DrainConfigMapName, Namespace: MCONamespace},
+				Data:       map[string]string{DrainConfigMapKey: "exclusions:\n  - {}\n"},
+			}},
+			want: SkipReasonDrainConfigInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool := &mcov1alpha1.MachineConfigPool{
+				ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+				Spec: mcov1alpha1.MachineConfigPoolSpec{
+					NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "worker"}},
+					MachineConfigSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"pool": "worker"},
+					},
+				},
+			}
+			mc := &mcov1alpha1.MachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Labels: map[string]string{"pool": "worker"}},
+				Spec:       mcov1alpha1.MachineConfigSpec{Files: []mcov1alpha1.FileSpec{{Path: "/etc/app.conf", Content: "v1"}}},
+			}
+			rmc := renderer.BuildRMC("worker", renderer.Merge([]*mcov1alpha1.MachineConfig{mc}), pool)
+			pool.Status.TargetRevision = rmc.Name
+			pool.Status.MachineCount = 2
+			pool.Status.UpdatedMachineCount = 1
+			tt.setup(pool)
+
+			done := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name:   "done",
+				Labels: map[string]string{"role": "worker"},
+				Annotations: map[string]string{
+					annotations.CurrentRevision: rmc.Name,
+					annotations.DesiredRevision: rmc.Name,
+					annotations.AgentState:      annotations.StateDone,
+				},
+			}}
+			waiting := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name:        "waiting",
+				Labels:      map[string]string{"role": "worker"},
+				Annotations: map[string]string{annotations.CurrentRevision: "worker-old"},
+			}}
+
+			r := newReconciler(append([]client.Object{pool, mc, rmc, done, waiting}, tt.objects...)...)
+			ctx := context.Background()
+			req := ctrl.Request{NamespacedName: client.ObjectKey{Name: "worker"}}
+			// First reconcile arms debounce, second proceeds.
+			for i := 0; i < 2; i++ {
+				if _, err := r.Reconcile(ctx, req); err != nil {
+					t.Fatalf("Reconcile() error = %v", err)
+				}
+			}
+
+			got := &corev1.Node{}
+			if err := r.Get(ctx, client.ObjectKey{Name: "waiting"}, got); err != nil {
+				t.Fatal(err)
+			}
+			if d := got.Annotations[annotations.DesiredRevision]; d != "" {
+				t.Errorf("waiting node started: desired = %q", d)
+			}
+
+			updated := &mcov1alpha1.MachineConfigPool{}
+			if err := r.Get(ctx, client.ObjectKey{Name: "worker"}, updated); err != nil {
+				t.Fatal(err)
+			}
+			want := []mcov1alpha1.SkippedMachineCount{{Reason: tt.want, Count: 1}}
+			if got := updated.Status.SkippedMachines; len(got) != 1 || got[0] != want[0] {
+				t.Errorf("SkippedMachines = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
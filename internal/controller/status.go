@@ -19,6 +19,7 @@ package controller
 import (
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -31,6 +32,25 @@ import (
 // DefaultApplyTimeoutSeconds is the default timeout for node apply operations.
 const DefaultApplyTimeoutSeconds = 600
 
+// DefaultAcknowledgeGraceSeconds is how long an agent may take to start
+// applying a newly set desired revision before the node is reported as
+// unacknowledged.
+const DefaultAcknowledgeGraceSeconds = 120
+
+// DefaultNotReadyToleranceSeconds is how long a node may be NotReady before it
+// counts as degraded.
+const DefaultNotReadyToleranceSeconds = 300
+
+// MaxNodeStatuses caps how many nodes pool status lists individually.
+const MaxNodeStatuses = 100
+
+// maxNodesInCondition caps how many node names a condition message lists.
+const maxNodesInCondition = 10
+
+// RebootPendingUnknown is the reboot pending reason reported for nodes whose
+// agent did not record one.
+const RebootPendingUnknown = "Unknown"
+
 // ReasonRenderFailed is the reason for Degraded condition when rendering fails.
 const ReasonRenderFailed = "RenderFailed"
 
@@ -47,17 +67,60 @@ type AggregatedStatus struct {
 	PendingRebootCount      int
 	CordonedMachineCount    int
 	DrainingMachineCount    int
-	TimedOutNodes           []string // Nodes that exceeded apply timeout
-	Conditions              []metav1.Condition
+	// PendingRebootReasons counts pending reboots by the reason they are
+	// held back.
+	PendingRebootReasons map[string]int
+	// MaintenanceMachineCount counts nodes excluded from MCO for maintenance.
+	MaintenanceMachineCount int
+	// UnacknowledgedMachineCount counts nodes whose agent never picked up the target.
+	UnacknowledgedMachineCount int
+	UnacknowledgedNodes        []string
+	// OldestUpdatingAge is the time since the oldest in-progress node was
+	// given its desired revision. Zero when no node is in progress.
+	OldestUpdatingAge time.Duration
+	ManagedFileCount  int
+	ManagedUnitCount  int
+	// ReferencedRevisions are the RMCs named by some node's current or
+	// desired revision.
+	ReferencedRevisions []string
+	TimedOutNodes       []string // Nodes that exceeded apply timeout
+	NotReadyNodes       []string // Nodes NotReady for longer than the tolerance
+	// NotReadyRecheckAfter is when the next NotReady node within tolerance
+	// would exceed it. Zero when no node is waiting out the tolerance.
+	NotReadyRecheckAfter time.Duration
+	// DrainDurations holds drains measured on nodes that are still between
+	// drain completion and uncordon.
+	DrainDurations []mcov1alpha1.NodeDrainDuration
+	// NodeStatuses is the per-node rollout state, capped at MaxNodeStatuses.
+	NodeStatuses          []mcov1alpha1.NodeStatus
+	NodeStatusesTruncated bool
+	Conditions            []metav1.Condition
+
+	// nodeNames is the set of pool members, used to prune stale entries.
+	nodeNames map[string]bool
 }
 
 // AggregateStatus computes pool status from node states.
 // applyTimeoutSeconds specifies the maximum time a node can be in applying state.
 // If 0, DefaultApplyTimeoutSeconds is used.
-func AggregateStatus(target string, nodes []corev1.Node, applyTimeoutSeconds int) *AggregatedStatus {
+// notReadyToleranceSeconds specifies how long a node may be NotReady before it
+// counts as degraded. If 0, DefaultNotReadyToleranceSeconds is used.
+func AggregateStatus(target string, nodes []corev1.Node, applyTimeoutSeconds, notReadyToleranceSeconds int) *AggregatedStatus {
+	return aggregateStatus(target, singleTarget(target), nodes, applyTimeoutSeconds, notReadyToleranceSeconds)
+}
+
+// aggregateStatus is AggregateStatus with a per-node target: a node counts
+// as updated when it runs targetFor(node). target is the pool's main target.
+func aggregateStatus(
+	target string,
+	targetFor targetFunc,
+	nodes []corev1.Node,
+	applyTimeoutSeconds, notReadyToleranceSeconds int,
+) *AggregatedStatus {
 	status := &AggregatedStatus{
 		TargetRevision: target,
 		MachineCount:   len(nodes),
+		nodeNames:      make(map[string]bool, len(nodes)),
 	}
 
 	// Use default if not specified
@@ -66,10 +129,18 @@ func AggregateStatus(target string, nodes []corev1.Node, applyTimeoutSeconds int
 		timeout = DefaultApplyTimeoutSeconds
 	}
 	timeoutDuration := time.Duration(timeout) * time.Second
+	notReadyTolerance := time.Duration(notReadyToleranceSeconds) * time.Second
+	if notReadyTolerance <= 0 {
+		notReadyTolerance = DefaultNotReadyToleranceSeconds * time.Second
+	}
+	now := time.Now()
 
 	revisionCounts := make(map[string]int)
+	nodeStatuses := make([]mcov1alpha1.NodeStatus, 0, len(nodes))
+	settled := make(map[string]bool, len(nodes))
 
 	for _, node := range nodes {
+		status.nodeNames[node.Name] = true
 		nodeAnnotations := node.Annotations
 		if nodeAnnotations == nil {
 			nodeAnnotations = make(map[string]string)
@@ -78,22 +149,74 @@ func AggregateStatus(target string, nodes []corev1.Node, applyTimeoutSeconds int
 		current := annotations.GetAnnotation(nodeAnnotations, annotations.CurrentRevision)
 		state := annotations.GetAnnotation(nodeAnnotations, annotations.AgentState)
 		rebootPending := annotations.GetBoolAnnotation(nodeAnnotations, annotations.RebootPending)
+		var rebootPendingReason string
+		if rebootPending {
+			rebootPendingReason = annotations.GetAnnotation(nodeAnnotations, annotations.RebootPendingReason)
+			if rebootPendingReason == "" {
+				rebootPendingReason = RebootPendingUnknown
+			}
+		}
 
 		if current != "" {
 			revisionCounts[current]++
 		}
 
-		isUpdated := current == target
+		nodeTarget := targetFor(&node)
+		isUpdated := current == nodeTarget
 		if isUpdated {
 			status.UpdatedMachineCount++
 		}
 
-		switch state {
-		case annotations.StateDone, annotations.StateIdle:
+		drainStarted := annotations.GetAnnotation(nodeAnnotations, annotations.DrainStartedAt)
+		drainCompleted := annotations.GetAnnotation(nodeAnnotations, annotations.DrainCompletedAt)
+		nodeStatuses = append(nodeStatuses, mcov1alpha1.NodeStatus{
+			Node:            node.Name,
+			CurrentRevision: current,
+			DesiredRevision: annotations.GetAnnotation(nodeAnnotations, annotations.DesiredRevision),
+			AgentState:      state,
+			Cordoned:        node.Spec.Unschedulable,
+			Draining:        drainStarted != "" && drainCompleted == "",
+			TimedOut:        state == annotations.StateApplying && isApplyTimedOut(nodeAnnotations, timeoutDuration),
+
+			RebootPendingReason: rebootPendingReason,
+		})
+		settled[node.Name] = isUpdated && (state == annotations.StateDone || state == annotations.StateIdle) &&
+			!node.Spec.Unschedulable
+
+		// Maintenance nodes are outside MCO's control: report them, but don't
+		// let their cordon, readiness or agent state drive pool conditions.
+		if annotations.IsNodeInMaintenance(nodeAnnotations) {
+			status.MaintenanceMachineCount++
+			if isUpdated && (state == annotations.StateDone || state == annotations.StateIdle) {
+				status.ReadyMachineCount++
+			}
+			continue
+		}
+
+		// A NotReady node only counts once it stays NotReady past the tolerance,
+		// so a kubelet restart or reboot does not flap the Degraded condition.
+		notReadyFor, isNotReady := notReadyDuration(&node, now)
+		notReady := isNotReady && notReadyFor > notReadyTolerance
+		if notReady {
+			status.NotReadyNodes = append(status.NotReadyNodes, node.Name)
+		} else if isNotReady {
+			remaining := notReadyTolerance - notReadyFor
+			if status.NotReadyRecheckAfter == 0 || remaining < status.NotReadyRecheckAfter {
+				status.NotReadyRecheckAfter = remaining
+			}
+		}
+
+		// A NotReady node is degraded and unavailable whatever its agent
+		// state says: the agent cannot make progress on it either way.
+		switch {
+		case notReady:
+			status.DegradedMachineCount++
+			status.UnavailableMachineCount++
+		case state == annotations.StateDone || state == annotations.StateIdle:
 			if isUpdated {
 				status.ReadyMachineCount++
 			}
-		case annotations.StateApplying:
+		case state == annotations.StateApplying:
 			if isApplyTimedOut(nodeAnnotations, timeoutDuration) {
 				// Timed out nodes count as degraded, not updating
 				status.DegradedMachineCount++
@@ -102,7 +225,7 @@ func AggregateStatus(target string, nodes []corev1.Node, applyTimeoutSeconds int
 				status.UpdatingMachineCount++
 			}
 			status.UnavailableMachineCount++
-		case annotations.StateError:
+		case state == annotations.StateError:
 			status.DegradedMachineCount++
 			status.UnavailableMachineCount++
 		default:
@@ -111,6 +234,17 @@ func AggregateStatus(target string, nodes []corev1.Node, applyTimeoutSeconds int
 
 		if rebootPending {
 			status.PendingRebootCount++
+			if status.PendingRebootReasons == nil {
+				status.PendingRebootReasons = make(map[string]int)
+			}
+			status.PendingRebootReasons[rebootPendingReason]++
+		} else if isUnacknowledged(nodeAnnotations, nodeTarget, DefaultAcknowledgeGraceSeconds*time.Second) {
+			status.UnacknowledgedMachineCount++
+			status.UnacknowledgedNodes = append(status.UnacknowledgedNodes, node.Name)
+		}
+
+		if age, ok := updatingAge(nodeAnnotations, nodeTarget, now); ok && age > status.OldestUpdatingAge {
+			status.OldestUpdatingAge = age
 		}
 
 		cordoned := annotations.GetBoolAnnotation(nodeAnnotations, annotations.Cordoned)
@@ -118,19 +252,45 @@ func AggregateStatus(target string, nodes []corev1.Node, applyTimeoutSeconds int
 			status.CordonedMachineCount++
 		}
 
-		drainStarted := annotations.GetAnnotation(nodeAnnotations, annotations.DrainStartedAt)
-		if drainStarted != "" {
+		if drainStarted != "" && drainCompleted == "" {
 			status.DrainingMachineCount++
 		}
+		if duration, completedAt, ok := DrainDuration(nodeAnnotations); ok {
+			status.DrainDurations = append(status.DrainDurations, mcov1alpha1.NodeDrainDuration{
+				Node:            node.Name,
+				DurationSeconds: int64(duration.Seconds()),
+				CompletedAt:     metav1.NewTime(completedAt),
+			})
+		}
 	}
 
 	status.CurrentRevision = computeCurrentRevision(revisionCounts, target)
+	status.ReferencedRevisions = ReferencedRevisions(nodes)
+	status.NodeStatuses, status.NodeStatusesTruncated = capNodeStatuses(nodeStatuses, settled, MaxNodeStatuses)
 
 	status.Conditions = computeConditions(status)
 
 	return status
 }
 
+// capNodeStatuses keeps at most limit entries, dropping nodes that are
+// settled on their target before the others, and sorts the result by node
+// name. truncated reports whether any entry was dropped.
+func capNodeStatuses(statuses []mcov1alpha1.NodeStatus, settled map[string]bool, limit int) ([]mcov1alpha1.NodeStatus, bool) {
+	truncated := len(statuses) > limit
+	if truncated {
+		sort.SliceStable(statuses, func(i, j int) bool {
+			if settled[statuses[i].Node] != settled[statuses[j].Node] {
+				return !settled[statuses[i].Node]
+			}
+			return statuses[i].Node < statuses[j].Node
+		})
+		statuses = statuses[:limit]
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Node < statuses[j].Node })
+	return statuses, truncated
+}
+
 // isApplyTimedOut checks if a node's apply operation has exceeded the timeout.
 func isApplyTimedOut(nodeAnnotations map[string]string, timeout time.Duration) bool {
 	setAtStr := annotations.GetAnnotation(nodeAnnotations, annotations.DesiredRevisionSetAt)
@@ -148,6 +308,58 @@ func isApplyTimedOut(nodeAnnotations map[string]string, timeout time.Duration) b
 	return time.Since(setAt) > timeout
 }
 
+// notReadyDuration returns how long the node's Ready condition has been False
+// or Unknown. Nodes without a Ready condition are not judged, since there is
+// nothing to measure the tolerance from.
+func notReadyDuration(node *corev1.Node, now time.Time) (time.Duration, bool) {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type != corev1.NodeReady {
+			continue
+		}
+		if cond.Status == corev1.ConditionTrue {
+			return 0, false
+		}
+		return now.Sub(cond.LastTransitionTime.Time), true
+	}
+	return 0, false
+}
+
+// updatingAge returns how long a node has been working towards target, based on
+// desired-revision-set-at. Only nodes that were given target and have not yet
+// reached it are in progress.
+func updatingAge(nodeAnnotations map[string]string, target string, now time.Time) (time.Duration, bool) {
+	if annotations.GetAnnotation(nodeAnnotations, annotations.DesiredRevision) != target ||
+		annotations.GetAnnotation(nodeAnnotations, annotations.CurrentRevision) == target {
+		return 0, false
+	}
+
+	setAt, err := time.Parse(time.RFC3339, annotations.GetAnnotation(nodeAnnotations, annotations.DesiredRevisionSetAt))
+	if err != nil {
+		return 0, false
+	}
+
+	return now.Sub(setAt), true
+}
+
+// isUnacknowledged reports whether a node was given the target revision but its
+// agent has not moved to applying it within the grace period. Nodes that are
+// applying or in error have acknowledged the revision and are handled by the
+// apply timeout and error paths instead.
+func isUnacknowledged(nodeAnnotations map[string]string, target string, grace time.Duration) bool {
+	if annotations.GetAnnotation(nodeAnnotations, annotations.DesiredRevision) != target ||
+		annotations.GetAnnotation(nodeAnnotations, annotations.CurrentRevision) == target {
+		return false
+	}
+
+	switch annotations.GetAnnotation(nodeAnnotations, annotations.AgentState) {
+	case annotations.StateApplying, annotations.StateError:
+		return false
+	}
+
+	// Reuse the apply timeout check: it only looks at desired-revision-set-at.
+	return isApplyTimedOut(nodeAnnotations, grace)
+}
+
 func computeCurrentRevision(counts map[string]int, target string) string {
 	if len(counts) == 0 {
 		return target
@@ -177,9 +389,47 @@ func computeCurrentRevision(counts map[string]int, target string) string {
 	return candidates[0]
 }
 
+// degradedMessage describes degraded nodes, naming those that are NotReady.
+func degradedMessage(status *AggregatedStatus) string {
+	msg := fmt.Sprintf("%d nodes in error state", status.DegradedMachineCount)
+	if len(status.NotReadyNodes) > 0 {
+		msg += fmt.Sprintf(" (NotReady: %s)", joinNodeNames(status.NotReadyNodes))
+	}
+	return msg
+}
+
+// joinNodeNames lists node names for a condition message, naming at most
+// maxNodesInCondition of them so large pools don't bloat the status.
+func joinNodeNames(nodes []string) string {
+	if len(nodes) <= maxNodesInCondition {
+		return strings.Join(nodes, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(nodes[:maxNodesInCondition], ", "), len(nodes)-maxNodesInCondition)
+}
+
+// pendingRebootMessage reports pending reboots broken down by the reason
+// they are held back, e.g. "3 nodes waiting to reboot (MinInterval: 1,
+// StrategyNever: 2)".
+func pendingRebootMessage(status *AggregatedStatus) string {
+	msg := fmt.Sprintf("%d nodes waiting to reboot", status.PendingRebootCount)
+	if len(status.PendingRebootReasons) == 0 {
+		return msg
+	}
+	reasons := make([]string, 0, len(status.PendingRebootReasons))
+	for reason := range status.PendingRebootReasons {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+	parts := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		parts = append(parts, fmt.Sprintf("%s: %d", reason, status.PendingRebootReasons[reason]))
+	}
+	return msg + " (" + strings.Join(parts, ", ") + ")"
+}
+
 func computeConditions(status *AggregatedStatus) []metav1.Condition {
 	now := metav1.Now()
-	conditions := make([]metav1.Condition, 0, 4) // Ready, Updating, Degraded, Draining
+	conditions := make([]metav1.Condition, 0, 6) // Ready, Updating, Degraded, NodesUnacknowledged, Draining, RolloutComplete
 
 	// Ready condition: True when all nodes updated and no errors
 	if status.MachineCount > 0 && status.UpdatedMachineCount == status.MachineCount && status.DegradedMachineCount == 0 {
@@ -241,7 +491,7 @@ func computeConditions(status *AggregatedStatus) []metav1.Condition {
 			Type:               mcov1alpha1.ConditionDegraded,
 			Status:             metav1.ConditionTrue,
 			Reason:             "NodeErrors",
-			Message:            fmt.Sprintf("%d nodes in error state", status.DegradedMachineCount),
+			Message:            degradedMessage(status),
 			LastTransitionTime: now,
 		})
 	} else {
@@ -254,6 +504,24 @@ func computeConditions(status *AggregatedStatus) []metav1.Condition {
 		})
 	}
 
+	if status.UnacknowledgedMachineCount > 0 {
+		conditions = append(conditions, metav1.Condition{
+			Type:               mcov1alpha1.ConditionNodesUnacknowledged,
+			Status:             metav1.ConditionTrue,
+			Reason:             "AgentNotResponding",
+			Message:            fmt.Sprintf("%d nodes have not picked up the target revision: %s", status.UnacknowledgedMachineCount, joinNodeNames(status.UnacknowledgedNodes)),
+			LastTransitionTime: now,
+		})
+	} else {
+		conditions = append(conditions, metav1.Condition{
+			Type:               mcov1alpha1.ConditionNodesUnacknowledged,
+			Status:             metav1.ConditionFalse,
+			Reason:             "AllAcknowledged",
+			Message:            "All nodes picked up their desired revision",
+			LastTransitionTime: now,
+		})
+	}
+
 	// Draining condition - True when nodes are being drained.
 	if status.DrainingMachineCount > 0 {
 		conditions = append(conditions, metav1.Condition{
@@ -273,9 +541,43 @@ func computeConditions(status *AggregatedStatus) []metav1.Condition {
 		})
 	}
 
+	conditions = append(conditions, rolloutCompleteCondition(status, now))
+
 	return conditions
 }
 
+// rolloutCompleteCondition is the single gate for CI: True only when every
+// node runs its target, is ready, and none is degraded or waiting to reboot.
+func rolloutCompleteCondition(status *AggregatedStatus, now metav1.Time) metav1.Condition {
+	cond := metav1.Condition{
+		Type:               mcov1alpha1.ConditionRolloutComplete,
+		Status:             metav1.ConditionFalse,
+		LastTransitionTime: now,
+	}
+	switch {
+	case status.MachineCount == 0:
+		cond.Reason = "NoNodes"
+		cond.Message = "Pool has no nodes"
+	case status.DegradedMachineCount > 0:
+		cond.Reason = "NodesDegraded"
+		cond.Message = degradedMessage(status)
+	case status.UpdatedMachineCount < status.MachineCount:
+		cond.Reason = "NodesNotUpdated"
+		cond.Message = fmt.Sprintf("%d of %d nodes updated", status.UpdatedMachineCount, status.MachineCount)
+	case status.PendingRebootCount > 0:
+		cond.Reason = "RebootPending"
+		cond.Message = pendingRebootMessage(status)
+	case status.ReadyMachineCount < status.MachineCount:
+		cond.Reason = "NodesNotReady"
+		cond.Message = fmt.Sprintf("%d of %d nodes ready", status.ReadyMachineCount, status.MachineCount)
+	default:
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "RolloutSucceeded"
+		cond.Message = fmt.Sprintf("All %d nodes updated and ready", status.MachineCount)
+	}
+	return cond
+}
+
 // ApplyStatusToPool updates the pool status with aggregated values.
 func ApplyStatusToPool(pool *mcov1alpha1.MachineConfigPool, status *AggregatedStatus) {
 	pool.Status.TargetRevision = status.TargetRevision
@@ -289,6 +591,15 @@ func ApplyStatusToPool(pool *mcov1alpha1.MachineConfigPool, status *AggregatedSt
 	pool.Status.PendingRebootCount = status.PendingRebootCount
 	pool.Status.CordonedMachineCount = status.CordonedMachineCount
 	pool.Status.DrainingMachineCount = status.DrainingMachineCount
+	pool.Status.MaintenanceMachineCount = status.MaintenanceMachineCount
+	pool.Status.UnacknowledgedMachineCount = status.UnacknowledgedMachineCount
+	pool.Status.OldestUpdatingAgeSeconds = int64(status.OldestUpdatingAge.Seconds())
+	pool.Status.DrainDurations = mergeDrainDurations(pool.Status.DrainDurations, status.DrainDurations, status.nodeNames)
+	pool.Status.ManagedFileCount = status.ManagedFileCount
+	pool.Status.ManagedUnitCount = status.ManagedUnitCount
+	pool.Status.ReferencedRevisions = status.ReferencedRevisions
+	pool.Status.NodeStatuses = status.NodeStatuses
+	pool.Status.NodeStatusesTruncated = status.NodeStatusesTruncated
 
 	// Update LastSuccessfulRevision when all nodes are successfully updated
 	// with no degraded or pending-reboot nodes
@@ -302,6 +613,39 @@ func ApplyStatusToPool(pool *mcov1alpha1.MachineConfigPool, status *AggregatedSt
 	pool.Status.Conditions = mergeConditions(pool.Status.Conditions, status.Conditions)
 }
 
+// SetManagedCounts records how many files and units the target RMC manages.
+func SetManagedCounts(status *AggregatedStatus, rmc *mcov1alpha1.RenderedMachineConfig) {
+	if rmc == nil {
+		return
+	}
+	status.ManagedFileCount = len(rmc.Spec.Config.Files)
+	status.ManagedUnitCount = len(rmc.Spec.Config.Systemd.Units)
+}
+
+// mergeDrainDurations keeps each node's last recorded drain, replaces it with
+// a newer measurement, and drops nodes that left the pool. The result is
+// sorted by node name.
+func mergeDrainDurations(existing, measured []mcov1alpha1.NodeDrainDuration, members map[string]bool) []mcov1alpha1.NodeDrainDuration {
+	byNode := make(map[string]mcov1alpha1.NodeDrainDuration, len(existing)+len(measured))
+	for _, d := range existing {
+		byNode[d.Node] = d
+	}
+	for _, d := range measured {
+		byNode[d.Node] = d
+	}
+
+	var result []mcov1alpha1.NodeDrainDuration
+	for name, d := range byNode {
+		if members[name] {
+			result = append(result, d)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Node < result[j].Node
+	})
+	return result
+}
+
 func mergeConditions(existing, new []metav1.Condition) []metav1.Condition {
 	existingMap := make(map[string]metav1.Condition)
 	for _, c := range existing {
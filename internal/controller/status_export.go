@@ -0,0 +1,90 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+// statusExportKey is the ConfigMap data key holding a pool's status.
+func statusExportKey(pool string) string {
+	return pool + ".json"
+}
+
+// ExportPoolStatus mirrors the status of the named pool into the ConfigMap
+// name in MCONamespace as JSON under "<pool>.json", for tools that can read
+// ConfigMaps but not MCO resources. The key of a deleted pool is removed.
+func ExportPoolStatus(ctx context.Context, c client.Client, name, pool string) error {
+	value := ""
+	mcp := &mcov1alpha1.MachineConfigPool{}
+	if err := c.Get(ctx, client.ObjectKey{Name: pool}, mcp); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("get pool: %w", err)
+		}
+	} else {
+		data, err := json.Marshal(mcp.Status)
+		if err != nil {
+			return fmt.Errorf("encode status: %w", err)
+		}
+		value = string(data)
+	}
+
+	key := statusExportKey(pool)
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		cm := &corev1.ConfigMap{}
+		err := c.Get(ctx, client.ObjectKey{Namespace: MCONamespace, Name: name}, cm)
+		if apierrors.IsNotFound(err) {
+			if value == "" {
+				return nil
+			}
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: MCONamespace, Name: name},
+				Data:       map[string]string{key: value},
+			}
+			return c.Create(ctx, cm)
+		}
+		if err != nil {
+			return err
+		}
+
+		current, ok := cm.Data[key]
+		switch {
+		case value == "" && !ok:
+			return nil
+		case value == "":
+			delete(cm.Data, key)
+		case ok && current == value:
+			return nil
+		default:
+			if cm.Data == nil {
+				cm.Data = make(map[string]string)
+			}
+			cm.Data[key] = value
+		}
+		return c.Update(ctx, cm)
+	})
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+func TestExportPoolStatus(t *testing.T) {
+	ctx := context.Background()
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Status: mcov1alpha1.MachineConfigPoolStatus{
+			TargetRevision: "worker-abc",
+			MachineCount:   3,
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme()).
+		WithObjects(pool).WithStatusSubresource(pool).Build()
+	key := client.ObjectKey{Namespace: MCONamespace, Name: "mco-status"}
+
+	if err := ExportPoolStatus(ctx, c, "mco-status", "worker"); err != nil {
+		t.Fatalf("ExportPoolStatus() error = %v", err)
+	}
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(ctx, key, cm); err != nil {
+		t.Fatalf("ConfigMap not created: %v", err)
+	}
+	var got mcov1alpha1.MachineConfigPoolStatus
+	if err := json.Unmarshal([]byte(cm.Data["worker.json"]), &got); err != nil {
+		t.Fatalf("worker.json is not valid status JSON: %v", err)
+	}
+	if got.TargetRevision != "worker-abc" || got.MachineCount != 3 {
+		t.Errorf("exported status = %+v", got)
+	}
+
+	// Other pools share the ConfigMap; a deleted pool's key is removed.
+	cm.Data["infra.json"] = "{}"
+	if err := c.Update(ctx, cm); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Delete(ctx, pool); err != nil {
+		t.Fatal(err)
+	}
+	if err := ExportPoolStatus(ctx, c, "mco-status", "worker"); err != nil {
+		t.Fatalf("ExportPoolStatus() error = %v", err)
+	}
+	if err := c.Get(ctx, key, cm); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cm.Data["worker.json"]; ok {
+		t.Error("worker.json should be removed after the pool is deleted")
+	}
+	if _, ok := cm.Data["infra.json"]; !ok {
+		t.Error("keys of other pools must be kept")
+	}
+}
@@ -17,10 +17,13 @@ limitations under the License.
 package controller
 
 import (
+	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
@@ -59,7 +62,7 @@ func TestAggregateStatus_AllUpdated(t *testing.T) {
 		makeNode("worker-3", "workers-abc", annotations.StateIdle),
 	}
 
-	status := AggregateStatus("workers-abc", nodes, 0)
+	status := AggregateStatus("workers-abc", nodes, 0, 0)
 
 	if status.MachineCount != 3 {
 		t.Errorf("MachineCount = %d, want 3", status.MachineCount)
@@ -97,7 +100,7 @@ func TestAggregateStatus_PartialUpdate(t *testing.T) {
 		makeNode("worker-3", "workers-old", annotations.StateIdle),
 	}
 
-	status := AggregateStatus("workers-new", nodes, 0)
+	status := AggregateStatus("workers-new", nodes, 0, 0)
 
 	if status.UpdatedMachineCount != 1 {
 		t.Errorf("UpdatedMachineCount = %d, want 1", status.UpdatedMachineCount)
@@ -129,7 +132,7 @@ func TestAggregateStatus_Degraded(t *testing.T) {
 		makeNode("worker-2", "workers-old", annotations.StateError),
 	}
 
-	status := AggregateStatus("workers-abc", nodes, 0)
+	status := AggregateStatus("workers-abc", nodes, 0, 0)
 
 	if status.DegradedMachineCount != 1 {
 		t.Errorf("DegradedMachineCount = %d, want 1", status.DegradedMachineCount)
@@ -163,7 +166,7 @@ func TestAggregateStatus_Degraded(t *testing.T) {
 
 // TestAggregateStatus_Empty verifies status with no nodes.
 func TestAggregateStatus_Empty(t *testing.T) {
-	status := AggregateStatus("workers-abc", []corev1.Node{}, 0)
+	status := AggregateStatus("workers-abc", []corev1.Node{}, 0, 0)
 
 	if status.MachineCount != 0 {
 		t.Errorf("MachineCount = %d, want 0", status.MachineCount)
@@ -192,7 +195,7 @@ func TestAggregateStatus_NilAnnotations(t *testing.T) {
 		makeNode("worker-2", "workers-abc", annotations.StateDone),
 	}
 
-	status := AggregateStatus("workers-abc", nodes, 0)
+	status := AggregateStatus("workers-abc", nodes, 0, 0)
 
 	if status.MachineCount != 2 {
 		t.Errorf("MachineCount = %d, want 2", status.MachineCount)
@@ -210,13 +213,266 @@ func TestAggregateStatus_RebootPending(t *testing.T) {
 		makeNode("worker-3", "workers-abc", annotations.StateDone),
 	}
 
-	status := AggregateStatus("workers-abc", nodes, 0)
+	status := AggregateStatus("workers-abc", nodes, 0, 0)
 
 	if status.PendingRebootCount != 2 {
 		t.Errorf("PendingRebootCount = %d, want 2", status.PendingRebootCount)
 	}
 }
 
+// TestAggregateStatus_RebootPendingReasons verifies pending reboots are
+// broken down by the reason the agent reported.
+func TestAggregateStatus_RebootPendingReasons(t *testing.T) {
+	never := makeNodeWithReboot("worker-1", "workers-abc", annotations.StateDone, true)
+	never.Annotations[annotations.RebootPendingReason] = annotations.RebootPendingStrategyNever
+	interval := makeNodeWithReboot("worker-2", "workers-abc", annotations.StateDone, true)
+	interval.Annotations[annotations.RebootPendingReason] = annotations.RebootPendingMinInterval
+	nodes := []corev1.Node{
+		never,
+		interval,
+		makeNodeWithReboot("worker-3", "workers-abc", annotations.StateDone, true),
+		makeNode("worker-4", "workers-abc", annotations.StateDone),
+	}
+
+	status := AggregateStatus("workers-abc", nodes, 0, 0)
+
+	want := map[string]int{
+		annotations.RebootPendingStrategyNever: 1,
+		annotations.RebootPendingMinInterval:   1,
+		RebootPendingUnknown:                   1,
+	}
+	if !reflect.DeepEqual(status.PendingRebootReasons, want) {
+		t.Errorf("PendingRebootReasons = %v, want %v", status.PendingRebootReasons, want)
+	}
+	for _, ns := range status.NodeStatuses {
+		if ns.Node == "worker-1" && ns.RebootPendingReason != annotations.RebootPendingStrategyNever {
+			t.Errorf("worker-1 RebootPendingReason = %q", ns.RebootPendingReason)
+		}
+		if ns.Node == "worker-4" && ns.RebootPendingReason != "" {
+			t.Errorf("worker-4 RebootPendingReason = %q, want empty", ns.RebootPendingReason)
+		}
+	}
+
+	cond := meta.FindStatusCondition(computeConditions(status), mcov1alpha1.ConditionRolloutComplete)
+	wantMsg := "3 nodes waiting to reboot (MinInterval: 1, StrategyNever: 1, Unknown: 1)"
+	if cond == nil || cond.Message != wantMsg {
+		t.Errorf("RolloutComplete message = %v, want %q", cond, wantMsg)
+	}
+}
+
+// TestAggregateStatus_Unacknowledged verifies nodes that never pick up the
+// desired revision are counted separately from nodes that are applying.
+func TestAggregateStatus_Unacknowledged(t *testing.T) {
+	stale := time.Now().Add(-(DefaultAcknowledgeGraceSeconds + 60) * time.Second).UTC().Format(time.RFC3339)
+	fresh := time.Now().UTC().Format(time.RFC3339)
+
+	withDesired := func(node corev1.Node, setAt string) corev1.Node {
+		node.Annotations[annotations.DesiredRevision] = "workers-new"
+		node.Annotations[annotations.DesiredRevisionSetAt] = setAt
+		return node
+	}
+
+	nodes := []corev1.Node{
+		withDesired(makeNode("agent-down", "workers-old", annotations.StateIdle), stale),
+		withDesired(makeNode("agent-missing", "", ""), stale),
+		withDesired(makeNode("just-set", "workers-old", annotations.StateIdle), fresh),
+		withDesired(makeNode("applying", "workers-old", annotations.StateApplying), stale),
+		withDesired(makeNode("updated", "workers-new", annotations.StateDone), stale),
+	}
+
+	status := AggregateStatus("workers-new", nodes, 0, 0)
+
+	if status.UnacknowledgedMachineCount != 2 {
+		t.Errorf("UnacknowledgedMachineCount = %d, want 2", status.UnacknowledgedMachineCount)
+	}
+	if len(status.UnacknowledgedNodes) != 2 ||
+		status.UnacknowledgedNodes[0] != "agent-down" || status.UnacknowledgedNodes[1] != "agent-missing" {
+		t.Errorf("UnacknowledgedNodes = %v, want [agent-down agent-missing]", status.UnacknowledgedNodes)
+	}
+	if status.UpdatingMachineCount != 1 {
+		t.Errorf("UpdatingMachineCount = %d, want 1 (slow apply is not unacknowledged)", status.UpdatingMachineCount)
+	}
+
+	var cond *metav1.Condition
+	conditions := computeConditions(status)
+	for i := range conditions {
+		if conditions[i].Type == mcov1alpha1.ConditionNodesUnacknowledged {
+			cond = &conditions[i]
+		}
+	}
+	if cond == nil {
+		t.Fatal("NodesUnacknowledged condition not found")
+	}
+	if cond.Status != metav1.ConditionTrue || cond.Reason != "AgentNotResponding" {
+		t.Errorf("NodesUnacknowledged = %s/%s, want True/AgentNotResponding", cond.Status, cond.Reason)
+	}
+}
+
+// TestAggregateStatus_UnacknowledgedMessageCapped verifies the
+// NodesUnacknowledged message names at most maxNodesInCondition nodes.
+func TestAggregateStatus_UnacknowledgedMessageCapped(t *testing.T) {
+	stale := time.Now().Add(-(DefaultAcknowledgeGraceSeconds + 60) * time.Second).UTC().Format(time.RFC3339)
+
+	var nodes []corev1.Node
+	for i := range maxNodesInCondition + 2 {
+		node := makeNode(fmt.Sprintf("node-%02d", i), "workers-old", annotations.StateIdle)
+		node.Annotations[annotations.DesiredRevision] = "workers-new"
+		node.Annotations[annotations.DesiredRevisionSetAt] = stale
+		nodes = append(nodes, node)
+	}
+
+	status := AggregateStatus("workers-new", nodes, 0, 0)
+	cond := meta.FindStatusCondition(status.Conditions, mcov1alpha1.ConditionNodesUnacknowledged)
+	if cond == nil {
+		t.Fatal("NodesUnacknowledged condition not found")
+	}
+	want := "12 nodes have not picked up the target revision: node-00, node-01, node-02, node-03, node-04, " +
+		"node-05, node-06, node-07, node-08, node-09 and 2 more"
+	if cond.Message != want {
+		t.Errorf("Message = %q, want %q", cond.Message, want)
+	}
+}
+
+// TestAggregateStatus_OldestUpdatingAge verifies the age of the longest-running
+// in-progress node is reported, ignoring nodes that finished or were not started.
+func TestAggregateStatus_OldestUpdatingAge(t *testing.T) {
+	setAt := func(node corev1.Node, desired string, ago time.Duration) corev1.Node {
+		node.Annotations[annotations.DesiredRevision] = desired
+		node.Annotations[annotations.DesiredRevisionSetAt] = time.Now().Add(-ago).UTC().Format(time.RFC3339)
+		return node
+	}
+
+	nodes := []corev1.Node{
+		setAt(makeNode("recent", "workers-old", annotations.StateApplying), "workers-new", 2*time.Minute),
+		setAt(makeNode("wedged", "workers-old", annotations.StateApplying), "workers-new", 45*time.Minute),
+		setAt(makeNode("finished", "workers-new", annotations.StateDone), "workers-new", 3*time.Hour),
+		setAt(makeNode("previous-target", "workers-old", annotations.StateDone), "workers-old", 5*time.Hour),
+		makeNode("not-started", "workers-old", annotations.StateIdle),
+	}
+
+	status := AggregateStatus("workers-new", nodes, 0, 0)
+
+	if status.OldestUpdatingAge < 44*time.Minute || status.OldestUpdatingAge > 46*time.Minute {
+		t.Errorf("OldestUpdatingAge = %v, want ~45m", status.OldestUpdatingAge)
+	}
+
+	pool := &mcov1alpha1.MachineConfigPool{}
+	ApplyStatusToPool(pool, status)
+	if pool.Status.OldestUpdatingAgeSeconds < 44*60 || pool.Status.OldestUpdatingAgeSeconds > 46*60 {
+		t.Errorf("OldestUpdatingAgeSeconds = %d, want ~2700", pool.Status.OldestUpdatingAgeSeconds)
+	}
+
+	idle := AggregateStatus("workers-new", []corev1.Node{makeNode("done", "workers-new", annotations.StateDone)}, 0, 0)
+	if idle.OldestUpdatingAge != 0 {
+		t.Errorf("OldestUpdatingAge = %v, want 0 when nothing is updating", idle.OldestUpdatingAge)
+	}
+}
+
+// TestAggregateStatus_NotReadyTolerance verifies a node only counts as degraded
+// once it has been NotReady for longer than the tolerance.
+func TestAggregateStatus_NotReadyTolerance(t *testing.T) {
+	withReady := func(node corev1.Node, status corev1.ConditionStatus, since time.Duration) corev1.Node {
+		node.Status.Conditions = []corev1.NodeCondition{{
+			Type:               corev1.NodeReady,
+			Status:             status,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-since)),
+		}}
+		return node
+	}
+
+	tests := []struct {
+		name            string
+		node            corev1.Node
+		tolerance       int
+		wantDegraded    int
+		wantUnavailable int
+		wantUpdating    int
+		wantReady       int
+	}{
+		{
+			name:      "ready node",
+			node:      withReady(makeNode("n", "workers-abc", annotations.StateDone), corev1.ConditionTrue, time.Hour),
+			wantReady: 1,
+		},
+		{
+			name:      "brief NotReady within default tolerance",
+			node:      withReady(makeNode("n", "workers-abc", annotations.StateDone), corev1.ConditionFalse, time.Minute),
+			wantReady: 1,
+		},
+		{
+			name:            "NotReady past default tolerance",
+			node:            withReady(makeNode("n", "workers-abc", annotations.StateDone), corev1.ConditionUnknown, 10*time.Minute),
+			wantDegraded:    1,
+			wantUnavailable: 1,
+		},
+		{
+			name:            "NotReady past custom tolerance",
+			node:            withReady(makeNode("n", "workers-abc", annotations.StateDone), corev1.ConditionFalse, 2*time.Minute),
+			tolerance:       60,
+			wantDegraded:    1,
+			wantUnavailable: 1,
+		},
+		{
+			name:            "idle NotReady",
+			node:            withReady(makeNode("n", "workers-abc", annotations.StateIdle), corev1.ConditionFalse, time.Hour),
+			wantDegraded:    1,
+			wantUnavailable: 1,
+		},
+		{
+			name:            "applying NotReady",
+			node:            withReady(makeNode("n", "workers-old", annotations.StateApplying), corev1.ConditionFalse, time.Hour),
+			wantDegraded:    1,
+			wantUnavailable: 1,
+		},
+		{
+			name:            "applying brief NotReady keeps updating",
+			node:            withReady(makeNode("n", "workers-old", annotations.StateApplying), corev1.ConditionFalse, time.Minute),
+			wantUnavailable: 1,
+			wantUpdating:    1,
+		},
+		{
+			name:            "error state NotReady is counted once",
+			node:            withReady(makeNode("n", "workers-abc", annotations.StateError), corev1.ConditionFalse, time.Hour),
+			wantDegraded:    1,
+			wantUnavailable: 1,
+		},
+		{
+			name:            "unknown state NotReady",
+			node:            withReady(makeNode("n", "workers-old", ""), corev1.ConditionFalse, time.Hour),
+			wantDegraded:    1,
+			wantUnavailable: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := AggregateStatus("workers-abc", []corev1.Node{tt.node}, 0, tt.tolerance)
+			if status.DegradedMachineCount != tt.wantDegraded {
+				t.Errorf("DegradedMachineCount = %d, want %d", status.DegradedMachineCount, tt.wantDegraded)
+			}
+			if status.UnavailableMachineCount != tt.wantUnavailable {
+				t.Errorf("UnavailableMachineCount = %d, want %d", status.UnavailableMachineCount, tt.wantUnavailable)
+			}
+			if status.UpdatingMachineCount != tt.wantUpdating {
+				t.Errorf("UpdatingMachineCount = %d, want %d", status.UpdatingMachineCount, tt.wantUpdating)
+			}
+			if status.ReadyMachineCount != tt.wantReady {
+				t.Errorf("ReadyMachineCount = %d, want %d", status.ReadyMachineCount, tt.wantReady)
+			}
+			if len(status.TimedOutNodes) != 0 {
+				t.Errorf("TimedOutNodes = %v, want none", status.TimedOutNodes)
+			}
+		})
+	}
+
+	// A node waiting out the tolerance schedules a recheck when it expires.
+	brief := withReady(makeNode("n", "workers-abc", annotations.StateDone), corev1.ConditionFalse, time.Minute)
+	status := AggregateStatus("workers-abc", []corev1.Node{brief}, 0, 0)
+	if status.NotReadyRecheckAfter <= 3*time.Minute || status.NotReadyRecheckAfter > 4*time.Minute {
+		t.Errorf("NotReadyRecheckAfter = %v, want ~4m", status.NotReadyRecheckAfter)
+	}
+}
+
 // TestComputeCurrentRevision_MostCommon verifies most common revision wins.
 func TestComputeCurrentRevision_MostCommon(t *testing.T) {
 	counts := map[string]int{
@@ -280,9 +536,9 @@ func TestComputeConditions_AllTrue(t *testing.T) {
 
 	conditions := computeConditions(status)
 
-	// Expect 4 conditions: Ready, Updating, Degraded, Draining
-	if len(conditions) != 4 {
-		t.Errorf("len(conditions) = %d, want 4", len(conditions))
+	// Expect 6 conditions: Ready, Updating, Degraded, NodesUnacknowledged, Draining, RolloutComplete
+	if len(conditions) != 6 {
+		t.Errorf("len(conditions) = %d, want 6", len(conditions))
 	}
 
 	// Find Ready condition
@@ -764,7 +1020,7 @@ func TestAggregateStatus_NoCordoned(t *testing.T) {
 		makeNode("worker-2", "workers-abc", annotations.StateDone),
 	}
 
-	status := AggregateStatus("workers-abc", nodes, 0)
+	status := AggregateStatus("workers-abc", nodes, 0, 0)
 
 	if status.CordonedMachineCount != 0 {
 		t.Errorf("CordonedMachineCount = %d, want 0", status.CordonedMachineCount)
@@ -780,7 +1036,7 @@ func TestAggregateStatus_OneCordoned(t *testing.T) {
 		makeNode("worker-2", "workers-abc", annotations.StateDone),
 	}
 
-	status := AggregateStatus("workers-abc", nodes, 0)
+	status := AggregateStatus("workers-abc", nodes, 0, 0)
 
 	if status.CordonedMachineCount != 1 {
 		t.Errorf("CordonedMachineCount = %d, want 1", status.CordonedMachineCount)
@@ -798,7 +1054,7 @@ func TestAggregateStatus_MultipleCordoned(t *testing.T) {
 		makeNode("worker-4", "workers-abc", annotations.StateDone),
 	}
 
-	status := AggregateStatus("workers-abc", nodes, 0)
+	status := AggregateStatus("workers-abc", nodes, 0, 0)
 
 	if status.CordonedMachineCount != 3 {
 		t.Errorf("CordonedMachineCount = %d, want 3", status.CordonedMachineCount)
@@ -813,7 +1069,7 @@ func TestAggregateStatus_Draining(t *testing.T) {
 		makeNode("worker-3", "workers-abc", annotations.StateDone),
 	}
 
-	status := AggregateStatus("workers-abc", nodes, 0)
+	status := AggregateStatus("workers-abc", nodes, 0, 0)
 
 	if status.CordonedMachineCount != 2 {
 		t.Errorf("CordonedMachineCount = %d, want 2", status.CordonedMachineCount)
@@ -831,7 +1087,7 @@ func TestAggregateStatus_MixedCordonDrain(t *testing.T) {
 		makeNode("worker-3", "workers-abc", annotations.StateDone),
 	}
 
-	status := AggregateStatus("workers-abc", nodes, 0)
+	status := AggregateStatus("workers-abc", nodes, 0, 0)
 
 	if status.CordonedMachineCount != 2 {
 		t.Errorf("CordonedMachineCount = %d, want 2", status.CordonedMachineCount)
@@ -841,6 +1097,92 @@ func TestAggregateStatus_MixedCordonDrain(t *testing.T) {
 	}
 }
 
+func TestAggregateStatus_DrainCompleted(t *testing.T) {
+	started := time.Now().Add(-5 * time.Minute).UTC()
+	completed := started.Add(90 * time.Second)
+	drained := makeCordonedNode("worker-1", started.Format(time.RFC3339))
+	drained.Annotations[annotations.DrainCompletedAt] = completed.Format(time.RFC3339)
+	nodes := []corev1.Node{
+		drained,
+		makeCordonedNode("worker-2", started.Format(time.RFC3339)),
+	}
+
+	status := AggregateStatus("workers-abc", nodes, 0, 0)
+
+	if status.DrainingMachineCount != 1 {
+		t.Errorf("DrainingMachineCount = %d, want 1 (completed drain is not draining)", status.DrainingMachineCount)
+	}
+	if len(status.DrainDurations) != 1 {
+		t.Fatalf("DrainDurations = %v, want 1 entry", status.DrainDurations)
+	}
+	if d := status.DrainDurations[0]; d.Node != "worker-1" || d.DurationSeconds != 90 {
+		t.Errorf("DrainDurations[0] = %+v, want worker-1 90s", d)
+	}
+}
+
+func TestApplyStatusToPool_MergesDrainDurations(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		Status: mcov1alpha1.MachineConfigPoolStatus{
+			DrainDurations: []mcov1alpha1.NodeDrainDuration{
+				{Node: "worker-1", DurationSeconds: 30},
+				{Node: "worker-2", DurationSeconds: 40},
+				{Node: "removed", DurationSeconds: 50},
+			},
+		},
+	}
+	started := time.Now().Add(-time.Hour).UTC()
+	drained := makeCordonedNode("worker-2", started.Format(time.RFC3339))
+	drained.Annotations[annotations.DrainCompletedAt] = started.Add(2 * time.Minute).Format(time.RFC3339)
+	nodes := []corev1.Node{
+		makeNode("worker-1", "workers-abc", annotations.StateDone),
+		drained,
+	}
+
+	ApplyStatusToPool(pool, AggregateStatus("workers-abc", nodes, 0, 0))
+
+	got := pool.Status.DrainDurations
+	if len(got) != 2 {
+		t.Fatalf("DrainDurations = %v, want worker-1 and worker-2", got)
+	}
+	if got[0].Node != "worker-1" || got[0].DurationSeconds != 30 {
+		t.Errorf("worker-1 = %+v, want previous 30s kept", got[0])
+	}
+	if got[1].Node != "worker-2" || got[1].DurationSeconds != 120 {
+		t.Errorf("worker-2 = %+v, want new 120s measurement", got[1])
+	}
+}
+
+func TestAggregateStatus_Maintenance(t *testing.T) {
+	maint := makeCordonedNode("worker-1", time.Now().Format(time.RFC3339))
+	maint.Annotations[annotations.Maintenance] = "true"
+	maint.Annotations[annotations.CurrentRevision] = "workers-old"
+	maint.Annotations[annotations.AgentState] = annotations.StateError
+	nodes := []corev1.Node{
+		maint,
+		makeNode("worker-2", "workers-abc", annotations.StateDone),
+	}
+
+	status := AggregateStatus("workers-abc", nodes, 0, 0)
+
+	if status.MachineCount != 2 {
+		t.Errorf("MachineCount = %d, want 2", status.MachineCount)
+	}
+	if status.MaintenanceMachineCount != 1 {
+		t.Errorf("MaintenanceMachineCount = %d, want 1", status.MaintenanceMachineCount)
+	}
+	if status.DegradedMachineCount != 0 || status.UnavailableMachineCount != 0 {
+		t.Errorf("degraded=%d unavailable=%d, want maintenance node ignored",
+			status.DegradedMachineCount, status.UnavailableMachineCount)
+	}
+	if status.CordonedMachineCount != 0 || status.DrainingMachineCount != 0 {
+		t.Errorf("cordoned=%d draining=%d, want maintenance node ignored",
+			status.CordonedMachineCount, status.DrainingMachineCount)
+	}
+	if status.UpdatedMachineCount != 1 {
+		t.Errorf("UpdatedMachineCount = %d, want 1", status.UpdatedMachineCount)
+	}
+}
+
 func TestAggregateStatus_UnschedulableWithoutAnnotation(t *testing.T) {
 	node := corev1.Node{
 		ObjectMeta: metav1.ObjectMeta{
@@ -853,7 +1195,7 @@ func TestAggregateStatus_UnschedulableWithoutAnnotation(t *testing.T) {
 	}
 	nodes := []corev1.Node{node}
 
-	status := AggregateStatus("workers-abc", nodes, 0)
+	status := AggregateStatus("workers-abc", nodes, 0, 0)
 
 	if status.CordonedMachineCount != 1 {
 		t.Errorf("CordonedMachineCount = %d, want 1 (unschedulable)", status.CordonedMachineCount)
@@ -1162,7 +1504,7 @@ func TestAggregateStatus_ApplyTimeout(t *testing.T) {
 	}
 
 	// Use default timeout (0 means use DefaultApplyTimeoutSeconds = 600)
-	status := AggregateStatus("workers-new", nodes, 0)
+	status := AggregateStatus("workers-new", nodes, 0, 0)
 
 	// Node should be degraded, not updating
 	if status.DegradedMachineCount != 1 {
@@ -1192,7 +1534,7 @@ func TestAggregateStatus_ApplyWithinTimeout(t *testing.T) {
 		},
 	}
 
-	status := AggregateStatus("workers-new", nodes, 0)
+	status := AggregateStatus("workers-new", nodes, 0, 0)
 
 	// Node should be updating, not degraded
 	if status.UpdatingMachineCount != 1 {
@@ -1223,7 +1565,7 @@ func TestAggregateStatus_DefaultTimeout(t *testing.T) {
 	}
 
 	// Pass 0 to use default
-	status := AggregateStatus("workers-new", nodes, 0)
+	status := AggregateStatus("workers-new", nodes, 0, 0)
 
 	// Should timeout because default is 600s and 650s > 600s
 	if status.DegradedMachineCount != 1 {
@@ -1248,14 +1590,14 @@ func TestAggregateStatus_CustomTimeout(t *testing.T) {
 	}
 
 	// Custom timeout of 300s - node should be timed out (500s > 300s)
-	status := AggregateStatus("workers-new", nodes, 300)
+	status := AggregateStatus("workers-new", nodes, 300, 0)
 
 	if status.DegradedMachineCount != 1 {
 		t.Errorf("DegradedMachineCount = %d, want 1 (custom timeout 300s)", status.DegradedMachineCount)
 	}
 
 	// Same node with 600s timeout - should NOT be timed out (500s < 600s)
-	status2 := AggregateStatus("workers-new", nodes, 600)
+	status2 := AggregateStatus("workers-new", nodes, 600, 0)
 
 	if status2.DegradedMachineCount != 0 {
 		t.Errorf("DegradedMachineCount = %d, want 0 (custom timeout 600s)", status2.DegradedMachineCount)
@@ -1280,7 +1622,7 @@ func TestAggregateStatus_ApplyNoTimestamp(t *testing.T) {
 		},
 	}
 
-	status := AggregateStatus("workers-new", nodes, 0)
+	status := AggregateStatus("workers-new", nodes, 0, 0)
 
 	// Should be updating, not degraded (can't determine timeout)
 	if status.UpdatingMachineCount != 1 {
@@ -1338,7 +1680,7 @@ func TestAggregateStatus_MixedTimeoutAndNormal(t *testing.T) {
 		},
 	}
 
-	status := AggregateStatus("workers-new", nodes, 0)
+	status := AggregateStatus("workers-new", nodes, 0, 0)
 
 	if status.MachineCount != 4 {
 		t.Errorf("MachineCount = %d, want 4", status.MachineCount)
@@ -1538,7 +1880,7 @@ func TestAggregateStatus_DrainingCondition_True(t *testing.T) {
 		},
 	}
 
-	status := AggregateStatus("workers-new", nodes, 0)
+	status := AggregateStatus("workers-new", nodes, 0, 0)
 
 	if status.DrainingMachineCount != 1 {
 		t.Errorf("DrainingMachineCount = %d, want 1", status.DrainingMachineCount)
@@ -1566,7 +1908,7 @@ func TestAggregateStatus_DrainingCondition_False(t *testing.T) {
 		makeNode("worker-1", "workers-new", annotations.StateDone),
 	}
 
-	status := AggregateStatus("workers-new", nodes, 0)
+	status := AggregateStatus("workers-new", nodes, 0, 0)
 
 	if status.DrainingMachineCount != 0 {
 		t.Errorf("DrainingMachineCount = %d, want 0", status.DrainingMachineCount)
@@ -1587,3 +1929,161 @@ func TestAggregateStatus_DrainingCondition_False(t *testing.T) {
 		t.Error("Draining condition not found")
 	}
 }
+
+func TestApplyStatusToPool_ManagedCounts(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+	}
+	rmc := &mcov1alpha1.RenderedMachineConfig{
+		Spec: mcov1alpha1.RenderedMachineConfigSpec{
+			Config: mcov1alpha1.RenderedConfig{
+				Files: []mcov1alpha1.FileSpec{{Path: "/etc/a"}, {Path: "/etc/b"}, {Path: "/etc/c"}},
+				Systemd: mcov1alpha1.SystemdSpec{
+					Units: []mcov1alpha1.UnitSpec{{Name: "a.service"}},
+				},
+			},
+		},
+	}
+
+	status := &AggregatedStatus{TargetRevision: "workers-new"}
+	SetManagedCounts(status, rmc)
+	ApplyStatusToPool(pool, status)
+
+	if pool.Status.ManagedFileCount != 3 {
+		t.Errorf("ManagedFileCount = %d, want 3", pool.Status.ManagedFileCount)
+	}
+	if pool.Status.ManagedUnitCount != 1 {
+		t.Errorf("ManagedUnitCount = %d, want 1", pool.Status.ManagedUnitCount)
+	}
+
+	// nil RMC leaves counts untouched
+	SetManagedCounts(status, nil)
+	if status.ManagedFileCount != 3 {
+		t.Errorf("ManagedFileCount = %d after nil RMC, want 3", status.ManagedFileCount)
+	}
+}
+
+func TestComputeConditions_RolloutComplete(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     AggregatedStatus
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "all updated and ready",
+			status:     AggregatedStatus{MachineCount: 3, UpdatedMachineCount: 3, ReadyMachineCount: 3},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "RolloutSucceeded",
+		},
+		{
+			name:       "no nodes",
+			status:     AggregatedStatus{},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "NoNodes",
+		},
+		{
+			name:       "not all updated",
+			status:     AggregatedStatus{MachineCount: 3, UpdatedMachineCount: 2, ReadyMachineCount: 2},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "NodesNotUpdated",
+		},
+		{
+			name:       "updated but not ready",
+			status:     AggregatedStatus{MachineCount: 3, UpdatedMachineCount: 3, ReadyMachineCount: 2},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "NodesNotReady",
+		},
+		{
+			name:       "degraded",
+			status:     AggregatedStatus{MachineCount: 3, UpdatedMachineCount: 3, ReadyMachineCount: 2, DegradedMachineCount: 1},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "NodesDegraded",
+		},
+		{
+			name:       "reboot pending",
+			status:     AggregatedStatus{MachineCount: 3, UpdatedMachineCount: 3, ReadyMachineCount: 3, PendingRebootCount: 1},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "RebootPending",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cond := meta.FindStatusCondition(computeConditions(&tt.status), mcov1alpha1.ConditionRolloutComplete)
+			if cond == nil {
+				t.Fatal("RolloutComplete condition not found")
+			}
+			if cond.Status != tt.wantStatus || cond.Reason != tt.wantReason {
+				t.Errorf("RolloutComplete = %s/%s, want %s/%s", cond.Status, cond.Reason, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestAggregateStatus_NodeStatuses(t *testing.T) {
+	stale := time.Now().Add(-(DefaultApplyTimeoutSeconds + 60) * time.Second).UTC().Format(time.RFC3339)
+
+	draining := makeNode("b-draining", "workers-old", annotations.StateIdle)
+	draining.Spec.Unschedulable = true
+	draining.Annotations[annotations.DrainStartedAt] = time.Now().UTC().Format(time.RFC3339)
+	stuck := makeNode("a-stuck", "workers-old", annotations.StateApplying)
+	stuck.Annotations[annotations.DesiredRevision] = "workers-new"
+	stuck.Annotations[annotations.DesiredRevisionSetAt] = stale
+	nodes := []corev1.Node{
+		makeNode("c-done", "workers-new", annotations.StateDone),
+		draining,
+		stuck,
+	}
+
+	status := AggregateStatus("workers-new", nodes, 0, 0)
+
+	want := []mcov1alpha1.NodeStatus{
+		{Node: "a-stuck", CurrentRevision: "workers-old", DesiredRevision: "workers-new",
+			AgentState: annotations.StateApplying, TimedOut: true},
+		{Node: "b-draining", CurrentRevision: "workers-old", AgentState: annotations.StateIdle,
+			Cordoned: true, Draining: true},
+		{Node: "c-done", CurrentRevision: "workers-new", AgentState: annotations.StateDone},
+	}
+	if len(status.NodeStatuses) != len(want) {
+		t.Fatalf("NodeStatuses = %+v, want %+v", status.NodeStatuses, want)
+	}
+	for i := range want {
+		if status.NodeStatuses[i] != want[i] {
+			t.Errorf("NodeStatuses[%d] = %+v, want %+v", i, status.NodeStatuses[i], want[i])
+		}
+	}
+	if status.NodeStatusesTruncated {
+		t.Error("NodeStatusesTruncated = true, want false")
+	}
+
+	pool := &mcov1alpha1.MachineConfigPool{}
+	ApplyStatusToPool(pool, status)
+	if len(pool.Status.NodeStatuses) != 3 || pool.Status.NodeStatusesTruncated {
+		t.Errorf("pool NodeStatuses = %+v, truncated %v", pool.Status.NodeStatuses, pool.Status.NodeStatusesTruncated)
+	}
+}
+
+func TestAggregateStatus_NodeStatusesTruncated(t *testing.T) {
+	var nodes []corev1.Node
+	for i := 0; i < MaxNodeStatuses+20; i++ {
+		nodes = append(nodes, makeNode(fmt.Sprintf("node-%03d", i), "workers-new", annotations.StateDone))
+	}
+	// The last node in name order is the only one still updating.
+	nodes[len(nodes)-1].Annotations[annotations.CurrentRevision] = "workers-old"
+
+	status := AggregateStatus("workers-new", nodes, 0, 0)
+
+	if len(status.NodeStatuses) != MaxNodeStatuses {
+		t.Fatalf("len(NodeStatuses) = %d, want %d", len(status.NodeStatuses), MaxNodeStatuses)
+	}
+	if !status.NodeStatusesTruncated {
+		t.Error("NodeStatusesTruncated = false, want true")
+	}
+	if last := status.NodeStatuses[MaxNodeStatuses-1].Node; last != nodes[len(nodes)-1].Name {
+		t.Errorf("updating node %s not kept, last entry is %s", nodes[len(nodes)-1].Name, last)
+	}
+	if status.UpdatedMachineCount != MaxNodeStatuses+19 {
+		t.Errorf("UpdatedMachineCount = %d, want %d", status.UpdatedMachineCount, MaxNodeStatuses+19)
+	}
+}
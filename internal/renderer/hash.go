@@ -39,11 +39,12 @@ type HashResult struct {
 
 // canonicalFile represents a file for hashing (alphabetical field order).
 type canonicalFile struct {
-	Content string `json:"content"`
-	Mode    int    `json:"mode"`
-	Owner   string `json:"owner"`
-	Path    string `json:"path"`
-	State   string `json:"state"`
+	Content  string `json:"content"`
+	Encoding string `json:"encoding,omitempty"`
+	Mode     int    `json:"mode"`
+	Owner    string `json:"owner"`
+	Path     string `json:"path"`
+	State    string `json:"state"`
 }
 
 // canonicalUnit represents a systemd unit for hashing (alphabetical field order).
@@ -65,58 +66,16 @@ type canonicalSystemd struct {
 }
 
 type canonicalConfig struct {
-	Files   []canonicalFile  `json:"files"`
-	Reboot  canonicalReboot  `json:"reboot"`
-	Systemd canonicalSystemd `json:"systemd"`
+	Files         []canonicalFile  `json:"files"`
+	KernelArgs    []string         `json:"kernelArgs,omitempty"`
+	PrepullImages []string         `json:"prepullImages,omitempty"`
+	Reboot        canonicalReboot  `json:"reboot"`
+	Systemd       canonicalSystemd `json:"systemd"`
 }
 
 // ComputeHash computes a deterministic SHA256 hash of the merged configuration.
 func ComputeHash(merged *MergedConfig) HashResult {
-	if merged == nil {
-		merged = &MergedConfig{
-			Files: []mcov1alpha1.FileSpec{},
-			Units: []mcov1alpha1.UnitSpec{},
-		}
-	}
-
-	files := make([]canonicalFile, len(merged.Files))
-	for i, f := range merged.Files {
-		files[i] = canonicalFile{
-			Content: f.Content,
-			Mode:    f.Mode,
-			Owner:   f.Owner,
-			Path:    f.Path,
-			State:   f.State,
-		}
-	}
-	sort.Slice(files, func(i, j int) bool {
-		return files[i].Path < files[j].Path
-	})
-
-	units := make([]canonicalUnit, len(merged.Units))
-	for i, u := range merged.Units {
-		units[i] = canonicalUnit{
-			Enabled: u.Enabled,
-			Mask:    u.Mask,
-			Name:    u.Name,
-			State:   u.State,
-		}
-	}
-	sort.Slice(units, func(i, j int) bool {
-		return units[i].Name < units[j].Name
-	})
-
-	canonical := canonicalConfig{
-		Files: files,
-		Reboot: canonicalReboot{
-			Required: merged.RebootRequired,
-		},
-		Systemd: canonicalSystemd{
-			Units: units,
-		},
-	}
-
-	jsonBytes, err := json.Marshal(canonical)
+	jsonBytes, err := json.Marshal(buildCanonical(merged))
 	if err != nil {
 		return HashResult{Short: "", Full: ""}
 	}
@@ -139,6 +98,11 @@ func RMCName(poolName string, hash HashResult) string {
 
 // ToCanonicalJSON returns the canonical JSON representation used for hashing.
 func ToCanonicalJSON(merged *MergedConfig) ([]byte, error) {
+	return json.Marshal(buildCanonical(merged))
+}
+
+// buildCanonical converts a merged config into its canonical hashing form.
+func buildCanonical(merged *MergedConfig) canonicalConfig {
 	if merged == nil {
 		merged = &MergedConfig{
 			Files: []mcov1alpha1.FileSpec{},
@@ -149,11 +113,12 @@ func ToCanonicalJSON(merged *MergedConfig) ([]byte, error) {
 	files := make([]canonicalFile, len(merged.Files))
 	for i, f := range merged.Files {
 		files[i] = canonicalFile{
-			Content: f.Content,
-			Mode:    f.Mode,
-			Owner:   f.Owner,
-			Path:    f.Path,
-			State:   f.State,
+			Content:  f.Content,
+			Encoding: f.Encoding,
+			Mode:     f.Mode,
+			Owner:    f.Owner,
+			Path:     f.Path,
+			State:    f.State,
 		}
 	}
 	sort.Slice(files, func(i, j int) bool {
@@ -173,8 +138,10 @@ func ToCanonicalJSON(merged *MergedConfig) ([]byte, error) {
 		return units[i].Name < units[j].Name
 	})
 
-	canonical := canonicalConfig{
-		Files: files,
+	return canonicalConfig{
+		Files:         files,
+		KernelArgs:    merged.KernelArgs,
+		PrepullImages: merged.PrepullImages,
 		Reboot: canonicalReboot{
 			Required: merged.RebootRequired,
 		},
@@ -182,6 +149,4 @@ func ToCanonicalJSON(merged *MergedConfig) ([]byte, error) {
 			Units: units,
 		},
 	}
-
-	return json.Marshal(canonical)
 }
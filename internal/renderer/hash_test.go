@@ -268,6 +268,12 @@ func TestComputeHash_IncludedFields(t *testing.T) {
 			},
 			RebootRequired: true,
 		}},
+		{"different kernel args", &MergedConfig{
+			Files: []mcov1alpha1.FileSpec{
+				{Path: "/etc/test.conf", Content: "base", Mode: 420, Owner: "root:root", State: "present"},
+			},
+			KernelArgs: []string{"console=ttyS0"},
+		}},
 	}
 
 	baseHash := ComputeHash(base)
@@ -543,3 +549,17 @@ func TestComputeHash_RealWorldScenario(t *testing.T) {
 		t.Errorf("RMC name length = %d, want %d", len(rmcName), len("worker-")+10)
 	}
 }
+
+// TestComputeHash_PrepullImages verifies images change the hash.
+func TestComputeHash_PrepullImages(t *testing.T) {
+	base := &MergedConfig{Files: []mcov1alpha1.FileSpec{}, Units: []mcov1alpha1.UnitSpec{}}
+	withImages := &MergedConfig{
+		Files:         []mcov1alpha1.FileSpec{},
+		Units:         []mcov1alpha1.UnitSpec{},
+		PrepullImages: []string{"a:1"},
+	}
+
+	if ComputeHash(base).Full == ComputeHash(withImages).Full {
+		t.Error("hash should differ when prepull images are added")
+	}
+}
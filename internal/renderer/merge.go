@@ -17,7 +17,10 @@ limitations under the License.
 package renderer
 
 import (
+	"errors"
+	"slices"
 	"sort"
+	"strings"
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
 )
@@ -55,50 +58,208 @@ type MergedConfig struct {
 	// (the one that contributed this unit) has reboot.required=true.
 	// Used for diff-based reboot determination.
 	UnitRebootRequirements map[string]bool `json:"unitRebootRequirements,omitempty"`
+
+	// FileSources and UnitSources map each file path and unit name to the
+	// MachineConfig that won it.
+	FileSources map[string]string `json:"fileSources,omitempty"`
+	UnitSources map[string]string `json:"unitSources,omitempty"`
+
+	// PrepullImages is the sorted, deduplicated union of images from all sources.
+	PrepullImages []string `json:"prepullImages,omitempty"`
+
+	// KernelArgs is the merged kernel argument list: deduplicated, in the
+	// order arguments were first added, with "-arg" removals applied.
+	KernelArgs []string `json:"kernelArgs,omitempty"`
+
+	// Annotations are the MachineConfig annotations the pool propagates to
+	// the RMC (spec.propagateAnnotations). Not part of the config hash.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// AnnotationConflicts lists, sorted, the propagated annotation keys that
+	// sources set to different values; the highest-priority value wins.
+	AnnotationConflicts []string `json:"annotationConflicts,omitempty"`
+
+	// Duplicates lists the source configs that name a file path or unit
+	// more than once. Such a merge must not be rendered; see
+	// DuplicateEntries.
+	Duplicates []*DuplicateEntriesError `json:"-"`
+
+	// ExclusiveConflicts lists the exclusive groups more than one source
+	// config belongs to. Such a merge must not be rendered either; see
+	// Conflicts.
+	ExclusiveConflicts []*ExclusiveConfigsError `json:"-"`
+}
+
+// DuplicateEntries returns the duplicate entry errors recorded by the
+// merge, joined, or nil if every source config was unambiguous.
+func (m *MergedConfig) DuplicateEntries() error {
+	if m == nil || len(m.Duplicates) == 0 {
+		return nil
+	}
+	errs := make([]error, len(m.Duplicates))
+	for i, d := range m.Duplicates {
+		errs[i] = d
+	}
+	return errors.Join(errs...)
+}
+
+// Conflicts returns every reason the merge must not be rendered, joined:
+// duplicate entries and mutually exclusive sources. Nil means the merge can
+// be rendered.
+func (m *MergedConfig) Conflicts() error {
+	if m == nil {
+		return nil
+	}
+	errs := []error{m.DuplicateEntries()}
+	for _, c := range m.ExclusiveConflicts {
+		errs = append(errs, c)
+	}
+	return errors.Join(errs...)
 }
 
 // Merge combines multiple MachineConfigs into a single MergedConfig.
 // Returns an empty MergedConfig if configs is empty (not an error).
 func Merge(configs []*mcov1alpha1.MachineConfig) *MergedConfig {
-	if len(configs) == 0 {
+	return MergeWithBase(nil, configs)
+}
+
+// MergeForPool merges configs for pool. The MachineConfig named by the
+// pool's baseConfigRef, if among configs, is merged as the base.
+// Annotations listed in the pool's propagateAnnotations are collected
+// from the configs for the RMC.
+func MergeForPool(pool *mcov1alpha1.MachineConfigPool, configs []*mcov1alpha1.MachineConfig) *MergedConfig {
+	if pool == nil {
+		return Merge(configs)
+	}
+	var base *mcov1alpha1.MachineConfig
+	rest := configs
+	if pool.Spec.BaseConfigRef != nil {
+		rest = make([]*mcov1alpha1.MachineConfig, 0, len(configs))
+		for _, mc := range configs {
+			if mc.Name == pool.Spec.BaseConfigRef.Name {
+				base = mc
+				continue
+			}
+			rest = append(rest, mc)
+		}
+	}
+	merged := MergeWithBase(base, rest)
+
+	ordered := sortByPriority(rest)
+	if base != nil {
+		ordered = append([]*mcov1alpha1.MachineConfig{base}, ordered...)
+	}
+	merged.Annotations, merged.AnnotationConflicts = mergeAnnotations(pool.Spec.PropagateAnnotations, ordered)
+	return merged
+}
+
+// mergeAnnotations collects the annotations named by keys from configs,
+// given in merge order: a later config overrides an earlier one, as for
+// files. Keys set to different values are reported as conflicts.
+func mergeAnnotations(keys []string, configs []*mcov1alpha1.MachineConfig) (map[string]string, []string) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	values := make(map[string]string)
+	conflicts := make(map[string]bool)
+	for _, mc := range configs {
+		for _, key := range keys {
+			value, ok := mc.Annotations[key]
+			if !ok {
+				continue
+			}
+			if prev, seen := values[key]; seen && prev != value {
+				conflicts[key] = true
+			}
+			values[key] = value
+		}
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	var conflicting []string
+	for key := range conflicts {
+		conflicting = append(conflicting, key)
+	}
+	sort.Strings(conflicting)
+	return values, conflicting
+}
+
+// MergeWithBase is Merge with base applied first, below every config in
+// configs regardless of priority. A nil base is the same as Merge.
+func MergeWithBase(base *mcov1alpha1.MachineConfig, configs []*mcov1alpha1.MachineConfig) *MergedConfig {
+	if len(configs) == 0 && base == nil {
 		return &MergedConfig{
 			Files:                  []mcov1alpha1.FileSpec{},
 			Units:                  []mcov1alpha1.UnitSpec{},
 			Sources:                []ConfigSource{},
 			FileRebootRequirements: map[string]bool{},
 			UnitRebootRequirements: map[string]bool{},
+			FileSources:            map[string]string{},
+			UnitSources:            map[string]string{},
 		}
 	}
 
 	sorted := sortByPriority(configs)
+	if base != nil {
+		sorted = append([]*mcov1alpha1.MachineConfig{base}, sorted...)
+	}
 
 	filesByPath := make(map[string]mcov1alpha1.FileSpec)
 	unitsByName := make(map[string]mcov1alpha1.UnitSpec)
 
 	fileSourceReboot := make(map[string]bool)
 	unitSourceReboot := make(map[string]bool)
+	fileSource := make(map[string]string)
+	unitSource := make(map[string]string)
+	unitPriority := make(map[string]int)
 
 	rebootRequired := false
 	sources := make([]ConfigSource, 0, len(sorted))
+	images := make(map[string]bool)
+	var dups []*DuplicateEntriesError
+	var kernelArgs []string
 
 	for _, mc := range sorted {
+		if dup := FindDuplicateEntries(mc); dup != nil {
+			dups = append(dups, dup)
+		}
+
 		for _, f := range mc.Spec.Files {
 			filesByPath[f.Path] = f
 			fileSourceReboot[f.Path] = mc.Spec.Reboot.Required
+			fileSource[f.Path] = mc.Name
 		}
 
 		for _, u := range mc.Spec.Systemd.Units {
+			// The base has no priority of its own: it never ties.
+			if p, ok := unitPriority[u.Name]; ok && p == mc.Spec.GetPriority() {
+				u.Enabled = resolveEnabled(unitsByName[u.Name].Enabled, u.Enabled)
+			}
 			unitsByName[u.Name] = u
 			unitSourceReboot[u.Name] = mc.Spec.Reboot.Required
+			unitSource[u.Name] = mc.Name
+			if mc != base {
+				unitPriority[u.Name] = mc.Spec.GetPriority()
+			}
 		}
 
 		if mc.Spec.Reboot.Required {
 			rebootRequired = true
 		}
 
+		for _, img := range mc.Spec.PrepullImages {
+			images[img] = true
+		}
+
+		// Kernel arguments only take effect on the next boot.
+		if len(mc.Spec.KernelArgs) > 0 {
+			rebootRequired = true
+		}
+		kernelArgs = mergeKernelArgs(kernelArgs, mc.Spec.KernelArgs)
+
 		sources = append(sources, ConfigSource{
 			Name:     mc.Name,
-			Priority: mc.Spec.Priority,
+			Priority: mc.Spec.GetPriority(),
 		})
 	}
 
@@ -112,7 +273,48 @@ func Merge(configs []*mcov1alpha1.MachineConfig) *MergedConfig {
 		Sources:                sources,
 		FileRebootRequirements: fileSourceReboot,
 		UnitRebootRequirements: unitSourceReboot,
+		FileSources:            fileSource,
+		UnitSources:            unitSource,
+		PrepullImages:          imagesToSortedSlice(images),
+		KernelArgs:             kernelArgs,
+		Duplicates:             dups,
+		ExclusiveConflicts:     FindExclusiveConflicts(sorted),
+	}
+}
+
+// mergeKernelArgs applies the kernel arguments of one MachineConfig on top
+// of args. A new argument is appended once; "-name" removes name and any
+// name=value, "-name=value" removes only that argument.
+func mergeKernelArgs(args, next []string) []string {
+	for _, arg := range next {
+		if name, ok := strings.CutPrefix(arg, "-"); ok {
+			args = slices.DeleteFunc(args, func(a string) bool {
+				return a == name || (!strings.Contains(name, "=") && strings.HasPrefix(a, name+"="))
+			})
+			continue
+		}
+		if !slices.Contains(args, arg) {
+			args = append(args, arg)
+		}
+	}
+	return args
+}
+
+// resolveEnabled decides the Enabled value of a unit defined by two
+// MachineConfigs with equal priority, where next is merged after prev.
+//
+// Enabled is tri-state: nil leaves enablement unmanaged, true/false is an
+// explicit request. Resolution rules for a unit, in order:
+//  1. A higher-priority MachineConfig wins outright, including a nil Enabled.
+//     (Handled by the caller: this function is only used for equal priority.)
+//  2. At equal priority an explicit value beats nil, regardless of name order.
+//  3. At equal priority with two explicit values, the usual name ordering
+//     applies: the later name wins.
+func resolveEnabled(prev, next *bool) *bool {
+	if next == nil {
+		return prev
 	}
+	return next
 }
 
 // sortByPriority returns a new slice sorted by priority ASC, then name ASC.
@@ -122,8 +324,8 @@ func sortByPriority(configs []*mcov1alpha1.MachineConfig) []*mcov1alpha1.Machine
 	copy(sorted, configs)
 
 	sort.Slice(sorted, func(i, j int) bool {
-		if sorted[i].Spec.Priority != sorted[j].Spec.Priority {
-			return sorted[i].Spec.Priority < sorted[j].Spec.Priority
+		if sorted[i].Spec.GetPriority() != sorted[j].Spec.GetPriority() {
+			return sorted[i].Spec.GetPriority() < sorted[j].Spec.GetPriority()
 		}
 		return sorted[i].Name < sorted[j].Name
 	})
@@ -168,3 +370,19 @@ func unitsToSortedSlice(units map[string]mcov1alpha1.UnitSpec) []mcov1alpha1.Uni
 
 	return result
 }
+
+// imagesToSortedSlice converts a set of images to a sorted slice.
+// Returns nil for an empty set so that configs without images hash as before.
+func imagesToSortedSlice(images map[string]bool) []string {
+	if len(images) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(images))
+	for img := range images {
+		result = append(result, img)
+	}
+	sort.Strings(result)
+
+	return result
+}
@@ -17,10 +17,12 @@ limitations under the License.
 package renderer
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
 )
@@ -32,7 +34,7 @@ func newMachineConfig(name string, priority int) *mcov1alpha1.MachineConfig {
 			Name: name,
 		},
 		Spec: mcov1alpha1.MachineConfigSpec{
-			Priority: priority,
+			Priority: ptr.To(priority),
 		},
 	}
 }
@@ -62,6 +64,9 @@ func TestMerge_EmptyConfigs(t *testing.T) {
 	if result2 == nil {
 		t.Fatal("Merge([]) returned nil")
 	}
+	if err := result2.DuplicateEntries(); err != nil {
+		t.Errorf("DuplicateEntries() = %v, want nil", err)
+	}
 }
 
 // TestMerge_SingleConfig verifies single config passes through correctly.
@@ -95,6 +100,95 @@ func TestMerge_SingleConfig(t *testing.T) {
 	if len(result.Sources) != 1 || result.Sources[0].Name != "single" {
 		t.Errorf("Sources = %v, want [{single 50}]", result.Sources)
 	}
+	if err := result.DuplicateEntries(); err != nil {
+		t.Errorf("DuplicateEntries() = %v, want nil", err)
+	}
+}
+
+// TestMerge_KernelArgs verifies kernel arguments are deduplicated in the
+// order first added, higher-priority configs can remove them with a "-"
+// prefix, and any kernel arguments require a reboot.
+func TestMerge_KernelArgs(t *testing.T) {
+	low := newMachineConfig("low", 10)
+	low.Spec.KernelArgs = []string{"console=ttyS0", "hugepages=16", "quiet", "mitigations=auto"}
+	mid := newMachineConfig("mid", 50)
+	mid.Spec.KernelArgs = []string{"quiet", "iommu=pt", "-hugepages"}
+	high := newMachineConfig("high", 90)
+	high.Spec.KernelArgs = []string{"-mitigations=off", "-quiet=1", "-console=ttyS0", "console=tty0"}
+
+	result := Merge([]*mcov1alpha1.MachineConfig{high, low, mid})
+
+	want := []string{"quiet", "mitigations=auto", "iommu=pt", "console=tty0"}
+	if !reflect.DeepEqual(result.KernelArgs, want) {
+		t.Errorf("KernelArgs = %v, want %v", result.KernelArgs, want)
+	}
+	if !result.RebootRequired {
+		t.Error("RebootRequired = false, want true for kernel args")
+	}
+
+	if Merge([]*mcov1alpha1.MachineConfig{newMachineConfig("none", 50)}).RebootRequired {
+		t.Error("RebootRequired = true without kernel args")
+	}
+}
+
+// TestMerge_DuplicateEntriesInOneConfig verifies that a path or unit listed
+// twice in one config is reported, while the same path in two configs is
+// an ordinary override.
+func TestMerge_DuplicateEntriesInOneConfig(t *testing.T) {
+	dup := newMachineConfig("dup", 50)
+	dup.Spec.Files = []mcov1alpha1.FileSpec{
+		{Path: "/etc/foo.conf", Content: "a"},
+		{Path: "/etc/bar.conf", Content: "b"},
+		{Path: "/etc/foo.conf", Content: "c"},
+	}
+	dup.Spec.Systemd.Units = []mcov1alpha1.UnitSpec{
+		{Name: "x.service"},
+		{Name: "x.service"},
+	}
+	other := newMachineConfig("other", 60)
+	other.Spec.Files = []mcov1alpha1.FileSpec{{Path: "/etc/bar.conf", Content: "d"}}
+
+	result := Merge([]*mcov1alpha1.MachineConfig{dup, other})
+
+	err := result.DuplicateEntries()
+	var dupErr *DuplicateEntriesError
+	if !errors.As(err, &dupErr) {
+		t.Fatalf("DuplicateEntries() = %v, want *DuplicateEntriesError", err)
+	}
+	want := &DuplicateEntriesError{Config: "dup", Paths: []string{"/etc/foo.conf"}, Units: []string{"x.service"}}
+	if !reflect.DeepEqual(dupErr, want) {
+		t.Errorf("DuplicateEntriesError = %+v, want %+v", dupErr, want)
+	}
+
+	if err := Merge([]*mcov1alpha1.MachineConfig{other}).DuplicateEntries(); err != nil {
+		t.Errorf("DuplicateEntries() for a single-entry config = %v, want nil", err)
+	}
+}
+
+// TestMerge_ExclusiveGroups verifies that configs sharing an exclusive
+// group conflict, while a single member or distinct groups merge.
+func TestMerge_ExclusiveGroups(t *testing.T) {
+	calico := newMachineConfig("calico", 50)
+	calico.Spec.ExclusiveGroup = "network-plugin"
+	cilium := newMachineConfig("cilium", 60)
+	cilium.Spec.ExclusiveGroup = "network-plugin"
+	chrony := newMachineConfig("chrony", 50)
+	chrony.Spec.ExclusiveGroup = "time-sync"
+
+	result := Merge([]*mcov1alpha1.MachineConfig{cilium, chrony, calico})
+
+	want := []*ExclusiveConfigsError{{Group: "network-plugin", Configs: []string{"calico", "cilium"}}}
+	if !reflect.DeepEqual(result.ExclusiveConflicts, want) {
+		t.Errorf("ExclusiveConflicts = %+v, want %+v", result.ExclusiveConflicts, want)
+	}
+	var exclusive *ExclusiveConfigsError
+	if err := result.Conflicts(); !errors.As(err, &exclusive) {
+		t.Errorf("Conflicts() = %v, want *ExclusiveConfigsError", err)
+	}
+
+	if err := Merge([]*mcov1alpha1.MachineConfig{calico, chrony}).Conflicts(); err != nil {
+		t.Errorf("Conflicts() for one member per group = %v, want nil", err)
+	}
 }
 
 // TestMerge_PriorityOrdering verifies configs are sorted by priority ASC.
@@ -557,6 +651,10 @@ func TestMerge_FileRebootRequirements_Override(t *testing.T) {
 	if !result.RebootRequired {
 		t.Error("RebootRequired = false, want true (OR of all MCs)")
 	}
+
+	if got := result.FileSources["/etc/foo.conf"]; got != "50-override" {
+		t.Errorf("FileSources['/etc/foo.conf'] = %q, want 50-override", got)
+	}
 }
 
 // TestMerge_FileRebootRequirements_NameTiebreaker verifies name tiebreaker for reboot requirements.
@@ -787,3 +885,184 @@ func TestMerge_TieBreaker_NamingConvention(t *testing.T) {
 		t.Errorf("File content = %q, want 'override' (99-override should win over 00-base)", result.Files[0].Content)
 	}
 }
+
+// TestMerge_UnitEnabledResolution verifies tri-state Enabled resolution rules.
+func TestMerge_UnitEnabledResolution(t *testing.T) {
+	enabled := true
+	disabled := false
+
+	tests := []struct {
+		name     string
+		first    *mcov1alpha1.MachineConfig
+		second   *mcov1alpha1.MachineConfig
+		firstEn  *bool
+		secondEn *bool
+		want     *bool
+	}{
+		{
+			name:  "higher priority nil wins over lower explicit",
+			first: newMachineConfig("a", 10), firstEn: &enabled,
+			second: newMachineConfig("b", 20), secondEn: nil,
+			want: nil,
+		},
+		{
+			name:  "higher priority explicit wins",
+			first: newMachineConfig("z", 10), firstEn: &enabled,
+			second: newMachineConfig("a", 20), secondEn: &disabled,
+			want: &disabled,
+		},
+		{
+			name:  "equal priority explicit beats later nil",
+			first: newMachineConfig("a", 50), firstEn: &disabled,
+			second: newMachineConfig("b", 50), secondEn: nil,
+			want: &disabled,
+		},
+		{
+			name:  "equal priority explicit beats earlier nil",
+			first: newMachineConfig("a", 50), firstEn: nil,
+			second: newMachineConfig("b", 50), secondEn: &enabled,
+			want: &enabled,
+		},
+		{
+			name:  "equal priority both explicit, later name wins",
+			first: newMachineConfig("a", 50), firstEn: &enabled,
+			second: newMachineConfig("b", 50), secondEn: &disabled,
+			want: &disabled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.first.Spec.Systemd.Units = []mcov1alpha1.UnitSpec{{Name: "x.service", Enabled: tt.firstEn}}
+			tt.second.Spec.Systemd.Units = []mcov1alpha1.UnitSpec{{Name: "x.service", Enabled: tt.secondEn, State: "started"}}
+
+			// Input order must not matter
+			for _, configs := range [][]*mcov1alpha1.MachineConfig{
+				{tt.first, tt.second},
+				{tt.second, tt.first},
+			} {
+				result := Merge(configs)
+				if len(result.Units) != 1 {
+					t.Fatalf("Units count = %d, want 1", len(result.Units))
+				}
+				got := result.Units[0].Enabled
+				if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+					t.Errorf("Enabled = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+// TestMerge_PrepullImagesUnion verifies images are unioned, deduplicated and sorted.
+func TestMerge_PrepullImagesUnion(t *testing.T) {
+	mc1 := newMachineConfig("mc1", 10)
+	mc1.Spec.PrepullImages = []string{"b:1", "a:1"}
+	mc2 := newMachineConfig("mc2", 20)
+	mc2.Spec.PrepullImages = []string{"a:1", "c:1"}
+
+	result := Merge([]*mcov1alpha1.MachineConfig{mc1, mc2})
+
+	want := []string{"a:1", "b:1", "c:1"}
+	if !reflect.DeepEqual(result.PrepullImages, want) {
+		t.Errorf("PrepullImages = %v, want %v", result.PrepullImages, want)
+	}
+
+	if Merge([]*mcov1alpha1.MachineConfig{newMachineConfig("mc3", 10)}).PrepullImages != nil {
+		t.Error("PrepullImages should be nil when no source lists images")
+	}
+}
+
+// TestMergeWithBase verifies the base is overridden by every config,
+// even one with a lower priority.
+func TestMergeWithBase(t *testing.T) {
+	base := newMachineConfig("00-base", 90000)
+	base.Spec.Files = []mcov1alpha1.FileSpec{
+		{Path: "/etc/foo.conf", Content: "base"},
+		{Path: "/etc/base-only.conf", Content: "base"},
+	}
+
+	low := newMachineConfig("10-low", 10)
+	low.Spec.Files = []mcov1alpha1.FileSpec{
+		{Path: "/etc/foo.conf", Content: "low"},
+	}
+
+	result := MergeWithBase(base, []*mcov1alpha1.MachineConfig{low})
+
+	if len(result.Files) != 2 {
+		t.Fatalf("len(Files) = %d, want 2", len(result.Files))
+	}
+	if result.FileSources["/etc/foo.conf"] != "10-low" {
+		t.Errorf("/etc/foo.conf from %q, want 10-low", result.FileSources["/etc/foo.conf"])
+	}
+	if result.FileSources["/etc/base-only.conf"] != "00-base" {
+		t.Errorf("/etc/base-only.conf from %q, want 00-base", result.FileSources["/etc/base-only.conf"])
+	}
+	if len(result.Sources) != 2 || result.Sources[0].Name != "00-base" {
+		t.Errorf("Sources = %v, want base first", result.Sources)
+	}
+}
+
+// TestMergeWithBase_UnitEnabledNoTie verifies a base unit never ties with
+// a config of the same priority: the config's nil Enabled wins outright.
+func TestMergeWithBase_UnitEnabledNoTie(t *testing.T) {
+	enabled := true
+
+	base := newMachineConfig("base", 50)
+	base.Spec.Systemd.Units = []mcov1alpha1.UnitSpec{{Name: "foo.service", Enabled: &enabled}}
+
+	mc := newMachineConfig("mc", 50)
+	mc.Spec.Systemd.Units = []mcov1alpha1.UnitSpec{{Name: "foo.service"}}
+
+	result := MergeWithBase(base, []*mcov1alpha1.MachineConfig{mc})
+	if result.Units[0].Enabled != nil {
+		t.Errorf("Enabled = %v, want nil from mc", *result.Units[0].Enabled)
+	}
+}
+
+// TestMergeForPool verifies the pool's base is picked out of configs.
+func TestMergeForPool(t *testing.T) {
+	base := newMachineConfig("base", 100)
+	base.Spec.Files = []mcov1alpha1.FileSpec{{Path: "/etc/foo.conf", Content: "base"}}
+	mc := newMachineConfig("mc", 10)
+	mc.Spec.Files = []mcov1alpha1.FileSpec{{Path: "/etc/foo.conf", Content: "mc"}}
+	configs := []*mcov1alpha1.MachineConfig{base, mc}
+
+	pool := &mcov1alpha1.MachineConfigPool{}
+	if got := MergeForPool(pool, configs).Files[0].Content; got != "base" {
+		t.Errorf("without baseConfigRef content = %q, want base (higher priority)", got)
+	}
+
+	pool.Spec.BaseConfigRef = &mcov1alpha1.MachineConfigReference{Name: "base"}
+	if got := MergeForPool(pool, configs).Files[0].Content; got != "mc" {
+		t.Errorf("with baseConfigRef content = %q, want mc", got)
+	}
+}
+
+func TestMergeForPool_PropagateAnnotations(t *testing.T) {
+	low := newMachineConfig("low", 10)
+	low.Annotations = map[string]string{"example.com/commit": "aaa", "example.com/owner": "team-a", "other": "x"}
+	high := newMachineConfig("high", 50)
+	high.Annotations = map[string]string{"example.com/commit": "bbb"}
+	configs := []*mcov1alpha1.MachineConfig{high, low}
+
+	pool := &mcov1alpha1.MachineConfigPool{}
+	if got := MergeForPool(pool, configs).Annotations; got != nil {
+		t.Errorf("without propagateAnnotations annotations = %v, want none", got)
+	}
+
+	pool.Spec.PropagateAnnotations = []string{"example.com/commit", "example.com/owner", "example.com/missing"}
+	merged := MergeForPool(pool, configs)
+	want := map[string]string{"example.com/commit": "bbb", "example.com/owner": "team-a"}
+	if !reflect.DeepEqual(merged.Annotations, want) {
+		t.Errorf("annotations = %v, want %v", merged.Annotations, want)
+	}
+	if !reflect.DeepEqual(merged.AnnotationConflicts, []string{"example.com/commit"}) {
+		t.Errorf("conflicts = %v, want [example.com/commit]", merged.AnnotationConflicts)
+	}
+
+	hashWithout := ComputeHash(MergeForPool(&mcov1alpha1.MachineConfigPool{}, configs))
+	if ComputeHash(merged) != hashWithout {
+		t.Error("propagated annotations must not change the config hash")
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -31,6 +32,9 @@ import (
 // ErrHashCollision is returned when two different configs produce the same short hash.
 var ErrHashCollision = errors.New("hash collision detected")
 
+// ErrRMCImmutable is returned when an update would change the content of an existing RMC.
+var ErrRMCImmutable = errors.New("RenderedMachineConfig spec is immutable")
+
 // RenderResult contains the output of a render operation.
 type RenderResult struct {
 	// RMC is the RenderedMachineConfig object to create or use.
@@ -62,6 +66,8 @@ func BuildRMC(poolName string, merged *MergedConfig, pool *mcov1alpha1.MachineCo
 		Systemd: mcov1alpha1.SystemdSpec{
 			Units: merged.Units,
 		},
+		PrepullImages: merged.PrepullImages,
+		KernelArgs:    merged.KernelArgs,
 	}
 
 	sources := make([]mcov1alpha1.ConfigSource, len(merged.Sources))
@@ -73,7 +79,9 @@ func BuildRMC(poolName string, merged *MergedConfig, pool *mcov1alpha1.MachineCo
 	}
 
 	var rebootSpec mcov1alpha1.RenderedRebootSpec
+	var unitRestart mcov1alpha1.UnitRestartPolicy
 	if pool != nil {
+		unitRestart = *pool.Spec.UnitRestart.DeepCopy()
 		rebootSpec = mcov1alpha1.RenderedRebootSpec{
 			Required:           merged.RebootRequired,
 			Strategy:           pool.Spec.Reboot.Strategy,
@@ -90,8 +98,10 @@ func BuildRMC(poolName string, merged *MergedConfig, pool *mcov1alpha1.MachineCo
 	configHash := strings.TrimPrefix(hash.Full, "sha256:")
 
 	rebootRequirements := mcov1alpha1.RebootRequirements{
-		Files: merged.FileRebootRequirements,
-		Units: merged.UnitRebootRequirements,
+		Files:       merged.FileRebootRequirements,
+		Units:       merged.UnitRebootRequirements,
+		FileSources: merged.FileSources,
+		UnitSources: merged.UnitSources,
 	}
 	// Ensure maps are not nil (use empty maps for consistency)
 	if rebootRequirements.Files == nil {
@@ -107,6 +117,7 @@ func BuildRMC(poolName string, merged *MergedConfig, pool *mcov1alpha1.MachineCo
 			Labels: map[string]string{
 				"mco.in-cloud.io/pool": poolName,
 			},
+			Annotations: PropagatedAnnotations(merged),
 		},
 		Spec: mcov1alpha1.RenderedMachineConfigSpec{
 			PoolName:           poolName,
@@ -116,10 +127,57 @@ func BuildRMC(poolName string, merged *MergedConfig, pool *mcov1alpha1.MachineCo
 			Sources:            sources,
 			RebootRequirements: rebootRequirements,
 			Reboot:             rebootSpec,
+			UnitRestart:        unitRestart,
 		},
 	}
 }
 
+// AnnotationConflictsAnnotation lists, comma-separated, the propagated
+// MachineConfig annotations that sources of an RMC set to different values.
+const AnnotationConflictsAnnotation = "mco.in-cloud.io/annotation-conflicts"
+
+// PropagatedAnnotations returns the RMC annotations carried over from the
+// merged MachineConfigs, plus AnnotationConflictsAnnotation when sources
+// disagreed. Nil when there are none.
+func PropagatedAnnotations(merged *MergedConfig) map[string]string {
+	if len(merged.Annotations) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(merged.Annotations)+1)
+	for key, value := range merged.Annotations {
+		result[key] = value
+	}
+	if len(merged.AnnotationConflicts) > 0 {
+		result[AnnotationConflictsAnnotation] = strings.Join(merged.AnnotationConflicts, ",")
+	}
+	return result
+}
+
+// SyncPropagatedAnnotations copies the propagated annotations of rendered,
+// for the given keys and AnnotationConflictsAnnotation, onto existing,
+// removing the ones rendered no longer has. It reports whether existing
+// changed. Used when a re-render keeps the revision but source annotations
+// changed.
+func SyncPropagatedAnnotations(existing, rendered *mcov1alpha1.RenderedMachineConfig, keys []string) bool {
+	changed := false
+	for _, key := range append([]string{AnnotationConflictsAnnotation}, keys...) {
+		want, ok := rendered.Annotations[key]
+		got, had := existing.Annotations[key]
+		switch {
+		case ok && (!had || got != want):
+			if existing.Annotations == nil {
+				existing.Annotations = make(map[string]string)
+			}
+			existing.Annotations[key] = want
+			changed = true
+		case !ok && had:
+			delete(existing.Annotations, key)
+			changed = true
+		}
+	}
+	return changed
+}
+
 // CheckExistingRMC checks if an RMC with the same name already exists.
 // Returns:
 //   - existing RMC if found with matching hash (no action needed)
@@ -147,6 +205,32 @@ func CheckExistingRMC(ctx context.Context, c client.Client, newRMC *mcov1alpha1.
 	)
 }
 
+// ValidateRMCUpdate checks that an update to an existing RMC does not change its content.
+// Agents rely on a stable name -> content mapping, so any change to files, units,
+// sources or identity must produce a new RMC instead.
+//
+// The only permitted exceptions are the pool-level reboot policy
+// (spec.reboot.strategy and spec.reboot.minIntervalSeconds) and unit restart
+// policy (spec.unitRestart), which the controller syncs onto the current RMC
+// without changing its revision.
+func ValidateRMCUpdate(oldRMC, newRMC *mcov1alpha1.RenderedMachineConfig) error {
+	oldSpec := oldRMC.Spec.DeepCopy()
+	newSpec := newRMC.Spec.DeepCopy()
+
+	for _, spec := range []*mcov1alpha1.RenderedMachineConfigSpec{oldSpec, newSpec} {
+		spec.Reboot.Strategy = ""
+		spec.Reboot.MinIntervalSeconds = 0
+		spec.UnitRestart = mcov1alpha1.UnitRestartPolicy{}
+	}
+
+	if !equality.Semantic.DeepEqual(oldSpec, newSpec) {
+		return fmt.Errorf("%w: %s: only spec.reboot.strategy, spec.reboot.minIntervalSeconds and spec.unitRestart may change",
+			ErrRMCImmutable, oldRMC.Name)
+	}
+
+	return nil
+}
+
 // HandleCollision modifies the RMC name to resolve a collision.
 // It appends a numeric suffix to the name.
 func HandleCollision(rmc *mcov1alpha1.RenderedMachineConfig, suffix int) *mcov1alpha1.RenderedMachineConfig {
@@ -159,7 +243,7 @@ func Render(ctx context.Context, c client.Client, poolName string, configs []*mc
 		return nil, errors.New("poolName is required")
 	}
 
-	merged := Merge(configs)
+	merged := MergeForPool(pool, configs)
 	if err := validateMerged(merged); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
@@ -190,10 +274,47 @@ func Render(ctx context.Context, c client.Client, poolName string, configs []*mc
 	}, nil
 }
 
+// ValidateRenderedConfig validates every file and unit of a rendered config
+// and returns one result per entry, so a failing render points at the entry
+// that broke it.
+func ValidateRenderedConfig(config mcov1alpha1.RenderedConfig) []mcov1alpha1.ValidationResult {
+	results := make([]mcov1alpha1.ValidationResult, 0, len(config.Files)+len(config.Systemd.Units))
+
+	for _, f := range config.Files {
+		results = append(results, validationResult(mcov1alpha1.ValidationKindFile, f.Path, ValidateFileSpec(f)))
+	}
+	for _, u := range config.Systemd.Units {
+		results = append(results, validationResult(mcov1alpha1.ValidationKindUnit, u.Name, ValidateUnitSpec(u)))
+	}
+
+	return results
+}
+
+// FailedValidations returns the results that did not pass.
+func FailedValidations(results []mcov1alpha1.ValidationResult) []mcov1alpha1.ValidationResult {
+	var failed []mcov1alpha1.ValidationResult
+	for _, r := range results {
+		if !r.Valid {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
+
+func validationResult(kind, name string, err error) mcov1alpha1.ValidationResult {
+	if err != nil {
+		return mcov1alpha1.ValidationResult{Kind: kind, Name: name, Valid: false, Message: err.Error()}
+	}
+	return mcov1alpha1.ValidationResult{Kind: kind, Name: name, Valid: true}
+}
+
 func validateMerged(merged *MergedConfig) error {
 	if merged == nil {
 		return errors.New("merged config is nil")
 	}
+	if err := merged.Conflicts(); err != nil {
+		return err
+	}
 
 	for i, f := range merged.Files {
 		if err := ValidateFileSpec(f); err != nil {
@@ -207,5 +328,11 @@ func validateMerged(merged *MergedConfig) error {
 		}
 	}
 
+	for i, arg := range merged.KernelArgs {
+		if err := ValidateKernelArg(arg); err != nil {
+			return fmt.Errorf("kernelArgs[%d]: %w", i, err)
+		}
+	}
+
 	return nil
 }
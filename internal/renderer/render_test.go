@@ -23,6 +23,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -127,6 +128,43 @@ func TestBuildRMC_NilPool(t *testing.T) {
 	}
 }
 
+// TestBuildRMC_PropagatedAnnotations verifies MachineConfig annotations
+// reach the RMC and can be synced onto an existing one.
+func TestBuildRMC_PropagatedAnnotations(t *testing.T) {
+	merged := &MergedConfig{
+		Annotations:         map[string]string{"example.com/commit": "bbb"},
+		AnnotationConflicts: []string{"example.com/commit"},
+	}
+
+	rmc := BuildRMC("worker", merged, nil)
+	if rmc.Annotations["example.com/commit"] != "bbb" {
+		t.Errorf("commit annotation = %q, want bbb", rmc.Annotations["example.com/commit"])
+	}
+	if rmc.Annotations[AnnotationConflictsAnnotation] != "example.com/commit" {
+		t.Errorf("conflicts annotation = %q", rmc.Annotations[AnnotationConflictsAnnotation])
+	}
+
+	existing := &mcov1alpha1.RenderedMachineConfig{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{"example.com/commit": "aaa", "unrelated": "keep"},
+	}}
+	if !SyncPropagatedAnnotations(existing, rmc, []string{"example.com/commit"}) {
+		t.Fatal("expected existing RMC to change")
+	}
+	if existing.Annotations["example.com/commit"] != "bbb" || existing.Annotations["unrelated"] != "keep" {
+		t.Errorf("synced annotations = %v", existing.Annotations)
+	}
+	if SyncPropagatedAnnotations(existing, rmc, []string{"example.com/commit"}) {
+		t.Error("second sync should be a no-op")
+	}
+
+	if !SyncPropagatedAnnotations(existing, BuildRMC("worker", &MergedConfig{}, nil), []string{"example.com/commit"}) {
+		t.Fatal("expected annotations to be removed")
+	}
+	if _, ok := existing.Annotations["example.com/commit"]; ok {
+		t.Error("commit annotation should be removed once no source sets it")
+	}
+}
+
 // TestBuildRMC_EmptyMerged verifies handling of empty merged config.
 func TestBuildRMC_EmptyMerged(t *testing.T) {
 	merged := &MergedConfig{}
@@ -273,7 +311,7 @@ func TestRender_NewRMC(t *testing.T) {
 		{
 			ObjectMeta: metav1.ObjectMeta{Name: "mc-test"},
 			Spec: mcov1alpha1.MachineConfigSpec{
-				Priority: 50,
+				Priority: ptr.To(50),
 				Files: []mcov1alpha1.FileSpec{
 					{Path: "/etc/test.conf", Content: "test", Mode: 420, Owner: "root:root", State: "present"},
 				},
@@ -313,7 +351,7 @@ func TestRender_Existing(t *testing.T) {
 		{
 			ObjectMeta: metav1.ObjectMeta{Name: "mc-test"},
 			Spec: mcov1alpha1.MachineConfigSpec{
-				Priority: 50,
+				Priority: ptr.To(50),
 				Files: []mcov1alpha1.FileSpec{
 					{Path: "/etc/test.conf", Content: "test", Mode: 420, Owner: "root:root", State: "present"},
 				},
@@ -463,3 +501,118 @@ func TestValidateMerged(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRMCUpdate(t *testing.T) {
+	base := &mcov1alpha1.RenderedMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-abc"},
+		Spec: mcov1alpha1.RenderedMachineConfigSpec{
+			PoolName:   "worker",
+			Revision:   "abc",
+			ConfigHash: "abc123",
+			Config: mcov1alpha1.RenderedConfig{
+				Files: []mcov1alpha1.FileSpec{{Path: "/etc/a", Content: "a"}},
+			},
+			Reboot: mcov1alpha1.RenderedRebootSpec{Required: true, Strategy: "IfRequired", MinIntervalSeconds: 60},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*mcov1alpha1.RenderedMachineConfig)
+		wantErr bool
+	}{
+		{name: "no change", mutate: func(*mcov1alpha1.RenderedMachineConfig) {}},
+		{name: "metadata change", mutate: func(r *mcov1alpha1.RenderedMachineConfig) {
+			r.Labels = map[string]string{"x": "y"}
+		}},
+		{name: "reboot strategy", mutate: func(r *mcov1alpha1.RenderedMachineConfig) {
+			r.Spec.Reboot.Strategy = "Never"
+			r.Spec.Reboot.MinIntervalSeconds = 600
+		}},
+		{name: "file content", wantErr: true, mutate: func(r *mcov1alpha1.RenderedMachineConfig) {
+			r.Spec.Config.Files[0].Content = "b"
+		}},
+		{name: "unit added", wantErr: true, mutate: func(r *mcov1alpha1.RenderedMachineConfig) {
+			r.Spec.Config.Systemd.Units = []mcov1alpha1.UnitSpec{{Name: "x.service"}}
+		}},
+		{name: "config hash", wantErr: true, mutate: func(r *mcov1alpha1.RenderedMachineConfig) {
+			r.Spec.ConfigHash = "def456"
+		}},
+		{name: "reboot required", wantErr: true, mutate: func(r *mcov1alpha1.RenderedMachineConfig) {
+			r.Spec.Reboot.Required = false
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updated := base.DeepCopy()
+			tt.mutate(updated)
+
+			err := ValidateRMCUpdate(base, updated)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateRMCUpdate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrRMCImmutable) {
+				t.Errorf("error = %v, want ErrRMCImmutable", err)
+			}
+		})
+	}
+}
+
+func TestValidateRenderedConfig(t *testing.T) {
+	config := mcov1alpha1.RenderedConfig{
+		Files: []mcov1alpha1.FileSpec{
+			{Path: "/etc/ok.conf", Content: "ok"},
+			{Path: "/etc/kubernetes/kubelet.conf", Content: "bad"},
+		},
+		Systemd: mcov1alpha1.SystemdSpec{
+			Units: []mcov1alpha1.UnitSpec{
+				{Name: "chronyd.service"},
+				{Name: "kubelet.service"},
+			},
+		},
+	}
+
+	results := ValidateRenderedConfig(config)
+
+	want := []struct {
+		kind  string
+		name  string
+		valid bool
+	}{
+		{mcov1alpha1.ValidationKindFile, "/etc/ok.conf", true},
+		{mcov1alpha1.ValidationKindFile, "/etc/kubernetes/kubelet.conf", false},
+		{mcov1alpha1.ValidationKindUnit, "chronyd.service", true},
+		{mcov1alpha1.ValidationKindUnit, "kubelet.service", false},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("len(results) = %d, want %d", len(results), len(want))
+	}
+	for i, w := range want {
+		r := results[i]
+		if r.Kind != w.kind || r.Name != w.name || r.Valid != w.valid {
+			t.Errorf("results[%d] = %+v, want kind=%s name=%s valid=%v", i, r, w.kind, w.name, w.valid)
+		}
+		if !r.Valid && r.Message == "" {
+			t.Errorf("results[%d] has no message for failed entry", i)
+		}
+	}
+
+	failed := FailedValidations(results)
+	if len(failed) != 2 {
+		t.Errorf("len(FailedValidations()) = %d, want 2", len(failed))
+	}
+}
+
+func TestValidateRMCUpdate_AllowsUnitRestartPolicy(t *testing.T) {
+	oldRMC := &mcov1alpha1.RenderedMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-abc"},
+		Spec:       mcov1alpha1.RenderedMachineConfigSpec{PoolName: "worker"},
+	}
+	newRMC := oldRMC.DeepCopy()
+	newRMC.Spec.UnitRestart = mcov1alpha1.UnitRestartPolicy{DelaySeconds: 10, Order: []string{"a.service"}}
+
+	if err := ValidateRMCUpdate(oldRMC, newRMC); err != nil {
+		t.Errorf("ValidateRMCUpdate() error = %v, want unit restart policy change allowed", err)
+	}
+}
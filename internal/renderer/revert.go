@@ -0,0 +1,42 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renderer
+
+import (
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+// RevertConfig returns the config that undoes config on a node, used when
+// every MachineConfig of a pool is deleted. It is an empty config: applied
+// incrementally on top of config, the agent removes only the files it
+// created and leaves files that were on the host before it, such as
+// /etc/resolv.conf, with their last content. Kernel arguments are dropped.
+// Units config masked are unmasked; units it only enabled, started or
+// restarted are left as they are, since they may be core services like
+// kubelet.
+//
+// Reverting a revert yields the same config, so the revert renders once.
+func RevertConfig(config mcov1alpha1.RenderedConfig) *MergedConfig {
+	merged := &MergedConfig{}
+	for _, u := range config.Systemd.Units {
+		// An entry with nothing but the name is a revert's unmask.
+		if u.Mask || (u.Enabled == nil && u.State == "") {
+			merged.Units = append(merged.Units, mcov1alpha1.UnitSpec{Name: u.Name})
+		}
+	}
+	return merged
+}
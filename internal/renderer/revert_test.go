@@ -0,0 +1,62 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renderer
+
+import (
+	"reflect"
+	"testing"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+func TestRevertConfig(t *testing.T) {
+	enabled := true
+	config := mcov1alpha1.RenderedConfig{
+		Files: []mcov1alpha1.FileSpec{
+			{Path: "/etc/app.conf", Content: "v1", State: "present"},
+			{Path: "/etc/hosts", Content: "10.0.0.1 db", State: "line-present"},
+			{Path: "/etc/old.conf", State: "absent"},
+		},
+		Systemd: mcov1alpha1.SystemdSpec{Units: []mcov1alpha1.UnitSpec{
+			{Name: "app.service", Enabled: &enabled, State: "started"},
+			{Name: "telnet.socket", Mask: true},
+		}},
+		KernelArgs: []string{"hugepages=16"},
+	}
+
+	got := RevertConfig(config)
+
+	if len(got.Files) != 0 {
+		t.Errorf("Files = %+v, want none: the incremental apply removes what the agent created", got.Files)
+	}
+	wantUnits := []mcov1alpha1.UnitSpec{{Name: "telnet.socket"}}
+	if !reflect.DeepEqual(got.Units, wantUnits) {
+		t.Errorf("Units = %+v, want only telnet.socket unmasked, app.service left alone", got.Units)
+	}
+	if len(got.KernelArgs) != 0 || got.RebootRequired {
+		t.Errorf("KernelArgs = %v, RebootRequired = %v, want none", got.KernelArgs, got.RebootRequired)
+	}
+
+	// Reverting the revert renders the same config.
+	again := RevertConfig(mcov1alpha1.RenderedConfig{
+		Files:   got.Files,
+		Systemd: mcov1alpha1.SystemdSpec{Units: got.Units},
+	})
+	if ComputeHash(again).Full != ComputeHash(got).Full {
+		t.Error("revert of a revert changed the config hash")
+	}
+}
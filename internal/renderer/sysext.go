@@ -0,0 +1,175 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renderer
+
+import (
+	"fmt"
+	"strings"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+// sysextRoots are the hierarchies systemd-sysext overlays.
+var sysextRoots = []string{"/usr/", "/opt/"}
+
+// confextRoot is the hierarchy systemd-confext overlays.
+const confextRoot = "/etc/"
+
+// ExtensionManifest describes a rendered config packaged as systemd
+// extension images. Files under /usr and /opt go into a sysext image,
+// files under /etc into a confext image; each image can be merged onto
+// the host atomically.
+type ExtensionManifest struct {
+	// Name is the extension name, the RMC name.
+	Name string `json:"name"`
+
+	// Sysext is the /usr and /opt overlay, nil if there are no such files.
+	Sysext *ExtensionImage `json:"sysext,omitempty"`
+
+	// Confext is the /etc overlay, nil if there are no such files.
+	Confext *ExtensionImage `json:"confext,omitempty"`
+
+	// Excluded lists entries that an extension image cannot express and
+	// still have to be applied by the agent.
+	Excluded []ExcludedEntry `json:"excluded,omitempty"`
+}
+
+// ExtensionImage is the content of one extension image.
+type ExtensionImage struct {
+	// ReleaseFile is the extension-release file path inside the image.
+	ReleaseFile string `json:"releaseFile"`
+
+	// ReleaseContent is the extension-release file content.
+	ReleaseContent string `json:"releaseContent"`
+
+	// Files are the managed files, with paths relative to the image root.
+	Files []ExtensionFile `json:"files"`
+}
+
+// ExtensionFile is a file inside an extension image.
+type ExtensionFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+	// Encoding is the FileSpec encoding of Content, empty for plain text.
+	Encoding string `json:"encoding,omitempty"`
+	Mode     int    `json:"mode"`
+	Owner    string `json:"owner"`
+}
+
+// ExcludedEntry is a rendered config entry left out of the extension images.
+type ExcludedEntry struct {
+	// Entry is the file path or "unit:<name>".
+	Entry  string `json:"entry"`
+	Reason string `json:"reason"`
+}
+
+// ExportExtension packages the files of rmc into sysext/confext overlay
+// descriptions. Absent files, files outside the overlaid hierarchies and
+// unit states cannot be expressed by an overlay and are reported in
+// Excluded.
+func ExportExtension(rmc *mcov1alpha1.RenderedMachineConfig) (*ExtensionManifest, error) {
+	if rmc == nil {
+		return nil, fmt.Errorf("nil RenderedMachineConfig")
+	}
+	if rmc.Name == "" {
+		return nil, fmt.Errorf("RenderedMachineConfig has no name")
+	}
+
+	m := &ExtensionManifest{Name: rmc.Name}
+	var sysextFiles, confextFiles []ExtensionFile
+
+	for _, f := range rmc.Spec.Config.Files {
+		if f.State == "absent" {
+			m.Excluded = append(m.Excluded, ExcludedEntry{
+				Entry:  f.Path,
+				Reason: "overlays cannot remove files",
+			})
+			continue
+		}
+		if f.State == "line-present" {
+			m.Excluded = append(m.Excluded, ExcludedEntry{
+				Entry:  f.Path,
+				Reason: "overlays cannot edit files in place",
+			})
+			continue
+		}
+
+		ef := ExtensionFile{
+			Path:     strings.TrimPrefix(f.Path, "/"),
+			Content:  f.Content,
+			Encoding: f.Encoding,
+			Mode:     f.Mode,
+			Owner:    f.Owner,
+		}
+		if ef.Mode == 0 {
+			ef.Mode = 0644
+		}
+		if ef.Owner == "" {
+			ef.Owner = "root:root"
+		}
+
+		switch {
+		case hasAnyPrefix(f.Path, sysextRoots):
+			sysextFiles = append(sysextFiles, ef)
+		case strings.HasPrefix(f.Path, confextRoot):
+			confextFiles = append(confextFiles, ef)
+		default:
+			m.Excluded = append(m.Excluded, ExcludedEntry{
+				Entry:  f.Path,
+				Reason: "path is outside /usr, /opt and /etc",
+			})
+		}
+	}
+
+	for _, u := range rmc.Spec.Config.Systemd.Units {
+		m.Excluded = append(m.Excluded, ExcludedEntry{
+			Entry:  "unit:" + u.Name,
+			Reason: "unit state is not part of an extension image",
+		})
+	}
+
+	if len(sysextFiles) > 0 {
+		m.Sysext = &ExtensionImage{
+			ReleaseFile:    "usr/lib/extension-release.d/extension-release." + rmc.Name,
+			ReleaseContent: extensionRelease(rmc.Name),
+			Files:          sysextFiles,
+		}
+	}
+	if len(confextFiles) > 0 {
+		m.Confext = &ExtensionImage{
+			ReleaseFile:    "etc/extension-release.d/extension-release." + rmc.Name,
+			ReleaseContent: extensionRelease(rmc.Name),
+			Files:          confextFiles,
+		}
+	}
+
+	return m, nil
+}
+
+// extensionRelease returns an extension-release file that matches any host OS.
+func extensionRelease(version string) string {
+	return "ID=_any\nIMAGE_VERSION=" + version + "\n"
+}
+
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
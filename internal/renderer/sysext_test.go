@@ -0,0 +1,112 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package renderer
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+func TestExportExtension(t *testing.T) {
+	enabled := true
+	rmc := &mcov1alpha1.RenderedMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-abc123"},
+		Spec: mcov1alpha1.RenderedMachineConfigSpec{
+			Config: mcov1alpha1.RenderedConfig{
+				Files: []mcov1alpha1.FileSpec{
+					{Path: "/etc/app.conf", Content: "a", Mode: 0600, Owner: "app:app"},
+					{Path: "/etc/old.conf", State: "absent"},
+					{Path: "/opt/tool/config", Content: "t"},
+					{Path: "/usr/lib/sysctl.d/90-mco.conf", Content: "vm.swappiness=10", Mode: 0644, Owner: "root:root"},
+					{Path: "/var/lib/app/state", Content: "s"},
+				},
+				Systemd: mcov1alpha1.SystemdSpec{
+					Units: []mcov1alpha1.UnitSpec{{Name: "app.service", Enabled: &enabled}},
+				},
+			},
+		},
+	}
+
+	m, err := ExportExtension(rmc)
+	if err != nil {
+		t.Fatalf("ExportExtension() error = %v", err)
+	}
+
+	if m.Sysext == nil {
+		t.Fatal("Sysext = nil")
+	}
+	if got := m.Sysext.ReleaseFile; got != "usr/lib/extension-release.d/extension-release.worker-abc123" {
+		t.Errorf("Sysext.ReleaseFile = %q", got)
+	}
+	if len(m.Sysext.Files) != 2 ||
+		m.Sysext.Files[0].Path != "opt/tool/config" ||
+		m.Sysext.Files[1].Path != "usr/lib/sysctl.d/90-mco.conf" {
+		t.Errorf("Sysext.Files = %+v", m.Sysext.Files)
+	}
+	if f := m.Sysext.Files[0]; f.Mode != 0644 || f.Owner != "root:root" {
+		t.Errorf("defaults not applied: %+v", f)
+	}
+
+	if m.Confext == nil {
+		t.Fatal("Confext = nil")
+	}
+	if got := m.Confext.ReleaseFile; got != "etc/extension-release.d/extension-release.worker-abc123" {
+		t.Errorf("Confext.ReleaseFile = %q", got)
+	}
+	if len(m.Confext.Files) != 1 || m.Confext.Files[0].Mode != 0600 || m.Confext.Files[0].Owner != "app:app" {
+		t.Errorf("Confext.Files = %+v", m.Confext.Files)
+	}
+
+	excluded := map[string]bool{}
+	for _, e := range m.Excluded {
+		excluded[e.Entry] = true
+	}
+	for _, want := range []string{"/etc/old.conf", "/var/lib/app/state", "unit:app.service"} {
+		if !excluded[want] {
+			t.Errorf("%s not excluded, got %+v", want, m.Excluded)
+		}
+	}
+}
+
+func TestExportExtension_NoOverlayFiles(t *testing.T) {
+	rmc := &mcov1alpha1.RenderedMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-abc123"},
+		Spec: mcov1alpha1.RenderedMachineConfigSpec{
+			Config: mcov1alpha1.RenderedConfig{
+				Files: []mcov1alpha1.FileSpec{{Path: "/etc/motd", Content: "hi"}},
+			},
+		},
+	}
+
+	m, err := ExportExtension(rmc)
+	if err != nil {
+		t.Fatalf("ExportExtension() error = %v", err)
+	}
+	if m.Sysext != nil {
+		t.Errorf("Sysext = %+v, want nil", m.Sysext)
+	}
+	if m.Confext == nil {
+		t.Error("Confext = nil")
+	}
+
+	if _, err := ExportExtension(nil); err == nil {
+		t.Error("ExportExtension(nil) should fail")
+	}
+}
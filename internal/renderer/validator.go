@@ -19,9 +19,11 @@ package renderer
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/filecontent"
 )
 
 // ForbiddenPaths lists paths that cannot be managed by MachineConfig.
@@ -58,6 +60,10 @@ var ValidUnitSuffixes = []string{
 	".timer",
 	".socket",
 	".mount",
+	".automount",
+	".path",
+	".slice",
+	".swap",
 	".target",
 }
 
@@ -150,11 +156,30 @@ func ValidateFileSpec(f mcov1alpha1.FileSpec) error {
 		return fmt.Errorf("content is required when state=present for path: %s", f.Path)
 	}
 
+	if f.State == "line-present" {
+		line := strings.TrimSuffix(f.Content, "\n")
+		if line == "" {
+			return fmt.Errorf("content is required when state=line-present for path: %s", f.Path)
+		}
+		if strings.ContainsAny(line, "\r\n") {
+			return fmt.Errorf("content must be a single line when state=line-present for path: %s", f.Path)
+		}
+	}
+
 	if len(f.Content) > MaxFileContentSize {
 		return fmt.Errorf("content exceeds maximum size (%d bytes) for path: %s",
 			MaxFileContentSize, f.Path)
 	}
 
+	if f.Encoding != "" && f.Encoding != filecontent.EncodingPlain {
+		if f.State != "" && f.State != "present" {
+			return fmt.Errorf("encoding %s requires state=present for path: %s", f.Encoding, f.Path)
+		}
+		if _, err := filecontent.Decode(f); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -163,6 +188,113 @@ func ValidateUnitSpec(u mcov1alpha1.UnitSpec) error {
 	return ValidateUnitName(u.Name)
 }
 
+// ValidateKernelArg validates a single kernel argument. Arguments are
+// written into a shell-sourced bootloader config, so whitespace, quotes,
+// "$" and backslashes are rejected.
+func ValidateKernelArg(arg string) error {
+	if arg == "" {
+		return fmt.Errorf("kernel argument cannot be empty")
+	}
+	if strings.ContainsAny(arg, " \t\n\r\"'$\\`") {
+		return fmt.Errorf("kernel argument %q contains whitespace, quotes, \"$\" or backslash", arg)
+	}
+	return nil
+}
+
+// DuplicateEntriesError reports file paths or unit names listed more than
+// once in a single MachineConfig. Which entry wins would depend on list
+// order, so such a config is not rendered.
+type DuplicateEntriesError struct {
+	// Config is the MachineConfig name.
+	Config string
+	// Paths and Units are the duplicated file paths and unit names, sorted.
+	Paths []string
+	Units []string
+}
+
+func (e *DuplicateEntriesError) Error() string {
+	var parts []string
+	if len(e.Paths) > 0 {
+		parts = append(parts, "files "+strings.Join(e.Paths, ", "))
+	}
+	if len(e.Units) > 0 {
+		parts = append(parts, "units "+strings.Join(e.Units, ", "))
+	}
+	return fmt.Sprintf("MachineConfig %q has duplicate entries: %s", e.Config, strings.Join(parts, "; "))
+}
+
+// FindDuplicateEntries returns a *DuplicateEntriesError if mc lists a file
+// path or unit name more than once, nil otherwise.
+func FindDuplicateEntries(mc *mcov1alpha1.MachineConfig) *DuplicateEntriesError {
+	if mc == nil {
+		return nil
+	}
+	paths := make([]string, len(mc.Spec.Files))
+	for i, f := range mc.Spec.Files {
+		paths[i] = f.Path
+	}
+	units := make([]string, len(mc.Spec.Systemd.Units))
+	for i, u := range mc.Spec.Systemd.Units {
+		units[i] = u.Name
+	}
+	dup := &DuplicateEntriesError{Config: mc.Name, Paths: duplicates(paths), Units: duplicates(units)}
+	if len(dup.Paths) == 0 && len(dup.Units) == 0 {
+		return nil
+	}
+	return dup
+}
+
+func duplicates(names []string) []string {
+	seen := make(map[string]int, len(names))
+	var dups []string
+	for _, n := range names {
+		seen[n]++
+		if seen[n] == 2 {
+			dups = append(dups, n)
+		}
+	}
+	sort.Strings(dups)
+	return dups
+}
+
+// ExclusiveConfigsError reports MachineConfigs of the same exclusive group
+// selected together. Only one of them may apply to a pool, so the pool is
+// not rendered.
+type ExclusiveConfigsError struct {
+	// Group is the shared spec.exclusiveGroup.
+	Group string
+	// Configs are the MachineConfig names in the group, sorted.
+	Configs []string
+}
+
+func (e *ExclusiveConfigsError) Error() string {
+	return fmt.Sprintf("MachineConfigs %s are mutually exclusive (exclusiveGroup %q), select only one",
+		strings.Join(e.Configs, ", "), e.Group)
+}
+
+// FindExclusiveConflicts returns one *ExclusiveConfigsError per exclusive
+// group that more than one of configs belongs to, sorted by group.
+func FindExclusiveConflicts(configs []*mcov1alpha1.MachineConfig) []*ExclusiveConfigsError {
+	members := make(map[string][]string)
+	for _, mc := range configs {
+		if mc == nil || mc.Spec.ExclusiveGroup == "" {
+			continue
+		}
+		members[mc.Spec.ExclusiveGroup] = append(members[mc.Spec.ExclusiveGroup], mc.Name)
+	}
+
+	var conflicts []*ExclusiveConfigsError
+	for group, names := range members {
+		if len(names) < 2 {
+			continue
+		}
+		sort.Strings(names)
+		conflicts = append(conflicts, &ExclusiveConfigsError{Group: group, Configs: names})
+	}
+	sort.Slice(conflicts, func(i, j int) bool { return conflicts[i].Group < conflicts[j].Group })
+	return conflicts
+}
+
 // ValidateMachineConfig validates an entire MachineConfig.
 // Returns an error describing the first validation failure found.
 func ValidateMachineConfig(mc *mcov1alpha1.MachineConfig) error {
@@ -170,6 +302,10 @@ func ValidateMachineConfig(mc *mcov1alpha1.MachineConfig) error {
 		return fmt.Errorf("MachineConfig cannot be nil")
 	}
 
+	if dup := FindDuplicateEntries(mc); dup != nil {
+		return dup
+	}
+
 	for i, f := range mc.Spec.Files {
 		if err := ValidateFileSpec(f); err != nil {
 			return fmt.Errorf("files[%d]: %w", i, err)
@@ -182,6 +318,12 @@ func ValidateMachineConfig(mc *mcov1alpha1.MachineConfig) error {
 		}
 	}
 
+	for i, arg := range mc.Spec.KernelArgs {
+		if err := ValidateKernelArg(strings.TrimPrefix(arg, "-")); err != nil {
+			return fmt.Errorf("kernelArgs[%d]: %w", i, err)
+		}
+	}
+
 	return nil
 }
 
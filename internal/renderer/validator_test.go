@@ -110,11 +110,17 @@ func TestHasValidUnitSuffix(t *testing.T) {
 		{"socket", "myapp.socket", true},
 		{"mount", "data.mount", true},
 		{"target", "multi-user.target", true},
+		{"automount", "data.automount", true},
+		{"path", "watch-config.path", true},
+		{"slice", "workload.slice", true},
+		{"swap", "dev-sdb1.swap", true},
 
 		// Invalid suffixes
 		{"no suffix", "nginx", false},
 		{"wrong suffix", "nginx.conf", false},
 		{"partial suffix", "nginx.serv", false},
+		{"typo suffix", "foo.servic", false},
+		{"device unit", "dev-sda.device", false},
 		{"uppercase suffix", "nginx.SERVICE", false},
 	}
 
@@ -241,6 +247,64 @@ func TestValidateFileSpec(t *testing.T) {
 			},
 			wantError: false,
 		},
+		{
+			name: "valid base64 content",
+			spec: mcov1alpha1.FileSpec{
+				Path:     "/etc/blob.bin",
+				Content:  "AAECAw==",
+				Encoding: "base64",
+			},
+			wantError: false,
+		},
+		{
+			name: "invalid base64 content",
+			spec: mcov1alpha1.FileSpec{
+				Path:     "/etc/blob.bin",
+				Content:  "not base64!",
+				Encoding: "base64",
+			},
+			wantError: true,
+			errMsg:    "decode base64",
+		},
+		{
+			name: "encoding with line-present",
+			spec: mcov1alpha1.FileSpec{
+				Path:     "/etc/fstab",
+				Content:  "AAECAw==",
+				Encoding: "base64",
+				State:    "line-present",
+			},
+			wantError: true,
+			errMsg:    "requires state=present",
+		},
+		{
+			name: "valid line-present",
+			spec: mcov1alpha1.FileSpec{
+				Path:    "/etc/fstab",
+				Content: "tmpfs /scratch tmpfs defaults 0 0\n",
+				State:   "line-present",
+			},
+			wantError: false,
+		},
+		{
+			name: "multi-line line-present",
+			spec: mcov1alpha1.FileSpec{
+				Path:    "/etc/fstab",
+				Content: "first\nsecond",
+				State:   "line-present",
+			},
+			wantError: true,
+			errMsg:    "single line",
+		},
+		{
+			name: "empty line-present",
+			spec: mcov1alpha1.FileSpec{
+				Path:  "/etc/fstab",
+				State: "line-present",
+			},
+			wantError: true,
+			errMsg:    "content is required",
+		},
 		{
 			name: "missing content for present",
 			spec: mcov1alpha1.FileSpec{
@@ -360,6 +424,20 @@ func TestValidateMachineConfig(t *testing.T) {
 			wantError: true,
 			errMsg:    "cannot be nil",
 		},
+		{
+			name: "duplicate file path",
+			mc: &mcov1alpha1.MachineConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test"},
+				Spec: mcov1alpha1.MachineConfigSpec{
+					Files: []mcov1alpha1.FileSpec{
+						{Path: "/etc/test.conf", Content: "a"},
+						{Path: "/etc/test.conf", Content: "b"},
+					},
+				},
+			},
+			wantError: true,
+			errMsg:    "duplicate entries: files /etc/test.conf",
+		},
 		{
 			name: "valid config with files",
 			mc: &mcov1alpha1.MachineConfig{
@@ -434,6 +512,19 @@ func TestValidateMachineConfig(t *testing.T) {
 	}
 }
 
+func TestValidateKernelArg(t *testing.T) {
+	for _, arg := range []string{"quiet", "console=ttyS0,115200n8", "hugepages=16"} {
+		if err := ValidateKernelArg(arg); err != nil {
+			t.Errorf("ValidateKernelArg(%q) = %v, want nil", arg, err)
+		}
+	}
+	for _, arg := range []string{"", "a b", `root="x"`, "x=$HOME", "a\\b", "a`b`"} {
+		if err := ValidateKernelArg(arg); err == nil {
+			t.Errorf("ValidateKernelArg(%q) = nil, want error", arg)
+		}
+	}
+}
+
 func TestValidateMachineConfigs(t *testing.T) {
 	validMC := &mcov1alpha1.MachineConfig{
 		ObjectMeta: metav1.ObjectMeta{Name: "valid"},
@@ -0,0 +1,243 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/internal/controller"
+	"in-cloud.io/machine-config/internal/renderer"
+	"in-cloud.io/machine-config/pkg/annotations"
+	"in-cloud.io/machine-config/pkg/checksum"
+)
+
+var machineconfiglog = logf.Log.WithName("machineconfig-resource")
+
+// SetupMachineConfigWebhookWithManager registers the MachineConfig webhook with the manager.
+func SetupMachineConfigWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&mcov1alpha1.MachineConfig{}).
+		WithValidator(&MachineConfigCustomValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-mco-in-cloud-io-v1alpha1-machineconfig,mutating=false,failurePolicy=ignore,sideEffects=None,groups=mco.in-cloud.io,resources=machineconfigs,verbs=create;update,versions=v1alpha1,name=vmachineconfig-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// MachineConfigCustomValidator performs a trial render of every pool the
+// MachineConfig belongs to and reports the result as admission warnings.
+// It never rejects a request: the summary is informational feedback for
+// authors, the controller remains the source of truth.
+type MachineConfigCustomValidator struct {
+	Client client.Client
+}
+
+var _ admission.CustomValidator = &MachineConfigCustomValidator{}
+
+// ValidateCreate returns a render summary for the new MachineConfig.
+func (v *MachineConfigCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	mc, ok := obj.(*mcov1alpha1.MachineConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected a MachineConfig object but got %T", obj)
+	}
+	machineconfiglog.V(1).Info("Validation for MachineConfig upon creation", "name", mc.GetName())
+
+	return append(checksumWarnings(mc), v.renderSummary(ctx, mc)...), nil
+}
+
+// ValidateUpdate returns a render summary for the updated MachineConfig.
+func (v *MachineConfigCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	mc, ok := newObj.(*mcov1alpha1.MachineConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected a MachineConfig object for the newObj but got %T", newObj)
+	}
+	machineconfiglog.V(1).Info("Validation for MachineConfig upon update", "name", mc.GetName())
+
+	return append(checksumWarnings(mc), v.renderSummary(ctx, mc)...), nil
+}
+
+// ValidateDelete is a no-op; deletions are not rendered.
+func (v *MachineConfigCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// checksumWarnings verifies the content-checksum annotation, if present,
+// against mc's spec and returns a warning on mismatch.
+func checksumWarnings(mc *mcov1alpha1.MachineConfig) admission.Warnings {
+	expected := annotations.GetAnnotation(mc.Annotations, annotations.ContentChecksum)
+	if expected == "" {
+		return nil
+	}
+	actual, err := checksum.MachineConfig(mc)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("content checksum unavailable: %v", err)}
+	}
+	if !strings.HasPrefix(expected, checksum.Prefix) {
+		expected = checksum.Prefix + expected
+	}
+	if expected != actual {
+		return admission.Warnings{fmt.Sprintf("content checksum mismatch: annotation %s is %s, spec is %s",
+			annotations.ContentChecksum, expected, actual)}
+	}
+	return nil
+}
+
+// renderSummary merges mc with its siblings in every matching pool and
+// describes the outcome. Lookup failures are reported as a warning instead
+// of failing admission.
+func (v *MachineConfigCustomValidator) renderSummary(ctx context.Context, mc *mcov1alpha1.MachineConfig) admission.Warnings {
+	poolList := &mcov1alpha1.MachineConfigPoolList{}
+	if err := v.Client.List(ctx, poolList); err != nil {
+		return admission.Warnings{fmt.Sprintf("render preview unavailable: failed to list pools: %v", err)}
+	}
+
+	pools := poolList.Items
+	sort.Slice(pools, func(i, j int) bool { return pools[i].Name < pools[j].Name })
+
+	var warnings admission.Warnings
+	for i := range pools {
+		pool := &pools[i]
+		matches, err := controller.MachineConfigMatchesPool(mc, pool)
+		if err != nil || !matches && !isBaseConfig(pool, mc) {
+			continue
+		}
+
+		siblings, err := controller.SelectMachineConfigs(ctx, v.Client, pool)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("pool %s: render preview unavailable: %v", pool.Name, err))
+			continue
+		}
+
+		// Preview the config with the priority this pool would give it.
+		effective := []mcov1alpha1.MachineConfig{*mc.DeepCopy()}
+		controller.ApplyDefaultPriority(pool, effective)
+
+		warnings = append(warnings, SummarizeRender(pool, &effective[0], siblings)...)
+	}
+
+	if len(warnings) == 0 {
+		warnings = append(warnings, "MachineConfig does not match any MachineConfigPool")
+	}
+
+	return warnings
+}
+
+// SummarizeRender merges mc into siblings (replacing any stored copy of mc)
+// the way the controller renders pool, base config and propagated
+// annotations included, and returns one warning with the resulting totals
+// for the pool, followed by one warning per file path or unit name that mc
+// shares with another MachineConfig, one if another MachineConfig shares
+// mc's exclusive group, and one if mc sets a propagated annotation to a
+// value another MachineConfig disagrees with.
+func SummarizeRender(pool *mcov1alpha1.MachineConfigPool, mc *mcov1alpha1.MachineConfig, siblings []mcov1alpha1.MachineConfig) []string {
+	poolName := pool.Name
+	configs := make([]*mcov1alpha1.MachineConfig, 0, len(siblings)+1)
+	for i := range siblings {
+		if siblings[i].Name == mc.Name {
+			continue
+		}
+		configs = append(configs, &siblings[i])
+	}
+	configs = append(configs, mc)
+
+	merged := renderer.MergeForPool(pool, configs)
+
+	warnings := []string{fmt.Sprintf("pool %s: render would contain %d files and %d units from %d MachineConfigs",
+		poolName, len(merged.Files), len(merged.Units), len(merged.Sources))}
+
+	fileOwners := make(map[string][]*mcov1alpha1.MachineConfig)
+	unitOwners := make(map[string][]*mcov1alpha1.MachineConfig)
+	for _, cfg := range configs {
+		if cfg == mc {
+			continue
+		}
+		for _, f := range cfg.Spec.Files {
+			fileOwners[f.Path] = append(fileOwners[f.Path], cfg)
+		}
+		for _, u := range cfg.Spec.Systemd.Units {
+			unitOwners[u.Name] = append(unitOwners[u.Name], cfg)
+		}
+	}
+
+	for _, f := range mc.Spec.Files {
+		if others := fileOwners[f.Path]; len(others) > 0 {
+			warnings = append(warnings, conflictWarning(pool, "file", f.Path, mc, others))
+		}
+	}
+	for _, u := range mc.Spec.Systemd.Units {
+		if others := unitOwners[u.Name]; len(others) > 0 {
+			warnings = append(warnings, conflictWarning(pool, "unit", u.Name, mc, others))
+		}
+	}
+	for _, c := range merged.ExclusiveConflicts {
+		if c.Group == mc.Spec.ExclusiveGroup {
+			warnings = append(warnings, fmt.Sprintf("pool %s: %v; the pool will not render", poolName, c))
+		}
+	}
+	for _, key := range merged.AnnotationConflicts {
+		if _, ok := mc.Annotations[key]; ok {
+			warnings = append(warnings, fmt.Sprintf("pool %s: propagated annotation %s is set to different values; %s is used",
+				poolName, key, merged.Annotations[key]))
+		}
+	}
+
+	return warnings
+}
+
+// conflictWarning describes a path or unit defined by several MachineConfigs
+// and names the one whose definition ends up in the rendered config.
+func conflictWarning(pool *mcov1alpha1.MachineConfigPool, kind, key string, mc *mcov1alpha1.MachineConfig, others []*mcov1alpha1.MachineConfig) string {
+	winner := mc
+	names := make([]string, 0, len(others))
+	for _, other := range others {
+		names = append(names, other.Name)
+		if overrides(pool, other, winner) {
+			winner = other
+		}
+	}
+	sort.Strings(names)
+
+	return fmt.Sprintf("pool %s: %s %s is also defined by %v; %s (priority %d) wins",
+		pool.Name, kind, key, names, winner.Name, winner.Spec.GetPriority())
+}
+
+// overrides reports whether a is merged after b, mirroring the renderer's
+// priority ASC, name ASC ordering where the last writer wins. The pool's
+// base config is merged first and never overrides another config.
+func overrides(pool *mcov1alpha1.MachineConfigPool, a, b *mcov1alpha1.MachineConfig) bool {
+	if isBaseConfig(pool, a) || isBaseConfig(pool, b) {
+		return isBaseConfig(pool, b)
+	}
+	if a.Spec.GetPriority() != b.Spec.GetPriority() {
+		return a.Spec.GetPriority() > b.Spec.GetPriority()
+	}
+	return a.Name > b.Name
+}
+
+// isBaseConfig reports whether mc is the pool's baseConfigRef, which the
+// pool renders whether or not its selector matches mc.
+func isBaseConfig(pool *mcov1alpha1.MachineConfigPool, mc *mcov1alpha1.MachineConfig) bool {
+	return pool.Spec.BaseConfigRef != nil && pool.Spec.BaseConfigRef.Name == mc.Name
+}
@@ -0,0 +1,305 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/annotations"
+	"in-cloud.io/machine-config/pkg/checksum"
+)
+
+func newTestScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	_ = mcov1alpha1.AddToScheme(scheme)
+	return scheme
+}
+
+func newMC(name string, priority int, labels map[string]string, paths ...string) *mcov1alpha1.MachineConfig {
+	mc := &mcov1alpha1.MachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec:       mcov1alpha1.MachineConfigSpec{Priority: ptr.To(priority)},
+	}
+	for _, p := range paths {
+		mc.Spec.Files = append(mc.Spec.Files, mcov1alpha1.FileSpec{Path: p, Content: name})
+	}
+	return mc
+}
+
+func workerPool() *mcov1alpha1.MachineConfigPool {
+	return &mcov1alpha1.MachineConfigPool{ObjectMeta: metav1.ObjectMeta{Name: "worker"}}
+}
+
+// TestSummarizeRender_Counts verifies totals include siblings and replace the stored copy.
+func TestSummarizeRender_Counts(t *testing.T) {
+	stored := newMC("a", 50, nil, "/etc/old")
+	sibling := newMC("b", 50, nil, "/etc/b")
+	sibling.Spec.Systemd.Units = []mcov1alpha1.UnitSpec{{Name: "b.service"}}
+
+	edited := newMC("a", 50, nil, "/etc/a1", "/etc/a2")
+
+	warnings := SummarizeRender(workerPool(), edited, []mcov1alpha1.MachineConfig{*stored, *sibling})
+
+	if len(warnings) != 1 {
+		t.Fatalf("SummarizeRender() returned %d warnings, want 1: %v", len(warnings), warnings)
+	}
+	want := "pool worker: render would contain 3 files and 1 units from 2 MachineConfigs"
+	if warnings[0] != want {
+		t.Errorf("warning = %q, want %q", warnings[0], want)
+	}
+}
+
+// TestSummarizeRender_Conflicts verifies shared paths and units are reported with the winner.
+func TestSummarizeRender_Conflicts(t *testing.T) {
+	low := newMC("base", 10, nil, "/etc/shared")
+	high := newMC("override", 90, nil, "/etc/other")
+	high.Spec.Systemd.Units = []mcov1alpha1.UnitSpec{{Name: "x.service"}}
+
+	edited := newMC("mine", 50, nil, "/etc/shared", "/etc/other")
+	edited.Spec.Systemd.Units = []mcov1alpha1.UnitSpec{{Name: "x.service"}}
+
+	warnings := SummarizeRender(workerPool(), edited, []mcov1alpha1.MachineConfig{*low, *high})
+
+	if len(warnings) != 4 {
+		t.Fatalf("SummarizeRender() returned %d warnings, want 4: %v", len(warnings), warnings)
+	}
+
+	tests := []string{
+		"file /etc/shared is also defined by [base]; mine (priority 50) wins",
+		"file /etc/other is also defined by [override]; override (priority 90) wins",
+		"unit x.service is also defined by [override]; override (priority 90) wins",
+	}
+	for i, want := range tests {
+		if !strings.HasSuffix(warnings[i+1], want) {
+			t.Errorf("warnings[%d] = %q, want suffix %q", i+1, warnings[i+1], want)
+		}
+	}
+}
+
+// TestSummarizeRender_ExclusiveGroup verifies a second member of an
+// exclusive group is reported as blocking the render.
+func TestSummarizeRender_ExclusiveGroup(t *testing.T) {
+	calico := newMC("calico", 50, nil, "/etc/cni/calico")
+	calico.Spec.ExclusiveGroup = "network-plugin"
+	edited := newMC("cilium", 50, nil, "/etc/cni/cilium")
+	edited.Spec.ExclusiveGroup = "network-plugin"
+
+	warnings := SummarizeRender(workerPool(), edited, []mcov1alpha1.MachineConfig{*calico})
+
+	if len(warnings) != 2 {
+		t.Fatalf("SummarizeRender() returned %d warnings, want 2: %v", len(warnings), warnings)
+	}
+	want := `pool worker: MachineConfigs calico, cilium are mutually exclusive (exclusiveGroup "network-plugin"), ` +
+		"select only one; the pool will not render"
+	if warnings[1] != want {
+		t.Errorf("warning = %q, want %q", warnings[1], want)
+	}
+}
+
+// TestSummarizeRender_SamePriorityNameOrder verifies ties are broken by name like the renderer.
+func TestSummarizeRender_SamePriorityNameOrder(t *testing.T) {
+	other := newMC("zz", 50, nil, "/etc/f")
+	edited := newMC("aa", 50, nil, "/etc/f")
+
+	warnings := SummarizeRender(workerPool(), edited, []mcov1alpha1.MachineConfig{*other})
+
+	if len(warnings) != 2 || !strings.HasSuffix(warnings[1], "zz (priority 50) wins") {
+		t.Errorf("SummarizeRender() = %v, want zz to win", warnings)
+	}
+}
+
+// TestSummarizeRender_BaseConfig verifies the pool's base config is merged
+// below every other config, whatever its priority.
+func TestSummarizeRender_BaseConfig(t *testing.T) {
+	pool := workerPool()
+	pool.Spec.BaseConfigRef = &mcov1alpha1.MachineConfigReference{Name: "os-base"}
+	base := newMC("os-base", 99, nil, "/etc/shared", "/etc/base")
+	edited := newMC("mine", 10, nil, "/etc/shared")
+
+	warnings := SummarizeRender(pool, edited, []mcov1alpha1.MachineConfig{*base})
+	if len(warnings) != 2 {
+		t.Fatalf("SummarizeRender() returned %d warnings, want 2: %v", len(warnings), warnings)
+	}
+	if want := "pool worker: render would contain 2 files and 0 units from 2 MachineConfigs"; warnings[0] != want {
+		t.Errorf("warning = %q, want %q", warnings[0], want)
+	}
+	if want := "mine (priority 10) wins"; !strings.HasSuffix(warnings[1], want) {
+		t.Errorf("warning = %q, want suffix %q", warnings[1], want)
+	}
+
+	// Editing the base config itself: every other config overrides it.
+	other := newMC("other", 10, nil, "/etc/shared")
+	warnings = SummarizeRender(pool, base, []mcov1alpha1.MachineConfig{*other})
+	if len(warnings) != 2 || !strings.HasSuffix(warnings[1], "other (priority 10) wins") {
+		t.Errorf("SummarizeRender() = %v, want other to win over the base config", warnings)
+	}
+}
+
+// TestSummarizeRender_PropagatedAnnotationConflict verifies a propagated
+// annotation set to different values is reported with the value the RMC gets.
+func TestSummarizeRender_PropagatedAnnotationConflict(t *testing.T) {
+	pool := workerPool()
+	pool.Spec.PropagateAnnotations = []string{"example.com/git-sha"}
+	other := newMC("other", 90, nil, "/etc/other")
+	other.Annotations = map[string]string{"example.com/git-sha": "abc"}
+	edited := newMC("mine", 50, nil, "/etc/mine")
+	edited.Annotations = map[string]string{"example.com/git-sha": "def"}
+
+	warnings := SummarizeRender(pool, edited, []mcov1alpha1.MachineConfig{*other})
+	if len(warnings) != 2 {
+		t.Fatalf("SummarizeRender() returned %d warnings, want 2: %v", len(warnings), warnings)
+	}
+	want := "pool worker: propagated annotation example.com/git-sha is set to different values; abc is used"
+	if warnings[1] != want {
+		t.Errorf("warning = %q, want %q", warnings[1], want)
+	}
+
+	// Annotations the pool does not propagate are not previewed.
+	pool.Spec.PropagateAnnotations = nil
+	if warnings := SummarizeRender(pool, edited, []mcov1alpha1.MachineConfig{*other}); len(warnings) != 1 {
+		t.Errorf("SummarizeRender() = %v, want totals only", warnings)
+	}
+}
+
+// TestValidateCreate_MatchingPools verifies only pools selecting the MC are summarized.
+func TestValidateCreate_MatchingPools(t *testing.T) {
+	workerPool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			MachineConfigSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "worker"}},
+		},
+	}
+	masterPool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "master"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			MachineConfigSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "master"}},
+		},
+	}
+	existing := newMC("existing", 50, map[string]string{"pool": "worker"}, "/etc/existing")
+
+	c := fake.NewClientBuilder().WithScheme(newTestScheme()).
+		WithObjects(workerPool, masterPool, existing).Build()
+	v := &MachineConfigCustomValidator{Client: c}
+
+	mc := newMC("new", 50, map[string]string{"pool": "worker"}, "/etc/new")
+	warnings, err := v.ValidateCreate(context.Background(), mc)
+	if err != nil {
+		t.Fatalf("ValidateCreate() error = %v", err)
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("ValidateCreate() returned %d warnings, want 1: %v", len(warnings), warnings)
+	}
+	if !strings.HasPrefix(warnings[0], "pool worker: render would contain 2 files") {
+		t.Errorf("warning = %q, want worker pool summary", warnings[0])
+	}
+}
+
+// TestValidateUpdate_BaseConfigOutsideSelector verifies a pool's base config
+// is previewed for that pool even though the selector does not match it.
+func TestValidateUpdate_BaseConfigOutsideSelector(t *testing.T) {
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Spec: mcov1alpha1.MachineConfigPoolSpec{
+			MachineConfigSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"pool": "worker"}},
+			BaseConfigRef:         &mcov1alpha1.MachineConfigReference{Name: "os-base"},
+		},
+	}
+	base := newMC("os-base", 50, nil, "/etc/base")
+	existing := newMC("existing", 50, map[string]string{"pool": "worker"}, "/etc/existing")
+
+	c := fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(pool, base, existing).Build()
+	v := &MachineConfigCustomValidator{Client: c}
+
+	edited := newMC("os-base", 50, nil, "/etc/base", "/etc/base2")
+	warnings, err := v.ValidateUpdate(context.Background(), base, edited)
+	if err != nil {
+		t.Fatalf("ValidateUpdate() error = %v", err)
+	}
+	want := "pool worker: render would contain 3 files and 0 units from 2 MachineConfigs"
+	if len(warnings) != 1 || warnings[0] != want {
+		t.Errorf("ValidateUpdate() = %v, want [%q]", warnings, want)
+	}
+}
+
+// TestValidateUpdate_NoPools verifies an unselected MC gets an explanatory warning.
+func TestValidateUpdate_NoPools(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(newTestScheme()).Build()
+	v := &MachineConfigCustomValidator{Client: c}
+
+	mc := newMC("orphan", 50, nil, "/etc/x")
+	warnings, err := v.ValidateUpdate(context.Background(), mc, mc)
+	if err != nil {
+		t.Fatalf("ValidateUpdate() error = %v", err)
+	}
+
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "does not match any") {
+		t.Errorf("ValidateUpdate() = %v, want no-pool warning", warnings)
+	}
+}
+
+// TestValidateUpdate_ContentChecksum verifies the checksum annotation is checked against the spec.
+func TestValidateUpdate_ContentChecksum(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(newTestScheme()).Build()
+	v := &MachineConfigCustomValidator{Client: c}
+
+	mc := newMC("gitops", 50, nil, "/etc/x")
+	sum, err := checksum.MachineConfig(mc)
+	if err != nil {
+		t.Fatalf("checksum.MachineConfig() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		checksum string
+		mismatch bool
+	}{
+		{"absent", "", false},
+		{"match", sum, false},
+		{"match without prefix", strings.TrimPrefix(sum, "sha256:"), false},
+		{"mismatch", "sha256:0000", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := mc.DeepCopy()
+			if tt.checksum != "" {
+				obj.Annotations = map[string]string{annotations.ContentChecksum: tt.checksum}
+			}
+			warnings, err := v.ValidateUpdate(context.Background(), obj, obj)
+			if err != nil {
+				t.Fatalf("ValidateUpdate() error = %v", err)
+			}
+			got := false
+			for _, w := range warnings {
+				if strings.Contains(w, "content checksum mismatch") {
+					got = true
+				}
+			}
+			if got != tt.mismatch {
+				t.Errorf("mismatch warning = %v, want %v: %v", got, tt.mismatch, warnings)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/internal/renderer"
+)
+
+var renderedmachineconfiglog = logf.Log.WithName("renderedmachineconfig-resource")
+
+// SetupRenderedMachineConfigWebhookWithManager registers the RenderedMachineConfig webhook with the manager.
+func SetupRenderedMachineConfigWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&mcov1alpha1.RenderedMachineConfig{}).
+		WithValidator(&RenderedMachineConfigCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-mco-in-cloud-io-v1alpha1-renderedmachineconfig,mutating=false,failurePolicy=fail,sideEffects=None,groups=mco.in-cloud.io,resources=renderedmachineconfigs,verbs=update,versions=v1alpha1,name=vrenderedmachineconfig-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// RenderedMachineConfigCustomValidator rejects updates that change the
+// content of an existing RenderedMachineConfig. See renderer.ValidateRMCUpdate.
+type RenderedMachineConfigCustomValidator struct{}
+
+var _ admission.CustomValidator = &RenderedMachineConfigCustomValidator{}
+
+// ValidateCreate allows all creations.
+func (v *RenderedMachineConfigCustomValidator) ValidateCreate(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate rejects spec mutations other than the reboot policy.
+func (v *RenderedMachineConfigCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldRMC, ok := oldObj.(*mcov1alpha1.RenderedMachineConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected a RenderedMachineConfig object for the oldObj but got %T", oldObj)
+	}
+	newRMC, ok := newObj.(*mcov1alpha1.RenderedMachineConfig)
+	if !ok {
+		return nil, fmt.Errorf("expected a RenderedMachineConfig object for the newObj but got %T", newObj)
+	}
+	renderedmachineconfiglog.V(1).Info("Validation for RenderedMachineConfig upon update", "name", newRMC.GetName())
+
+	return nil, renderer.ValidateRMCUpdate(oldRMC, newRMC)
+}
+
+// ValidateDelete allows all deletions; old revisions are garbage collected by the controller.
+func (v *RenderedMachineConfigCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+// TestRMCValidateUpdate verifies content changes are rejected and reboot policy changes allowed.
+func TestRMCValidateUpdate(t *testing.T) {
+	v := &RenderedMachineConfigCustomValidator{}
+	oldRMC := &mcov1alpha1.RenderedMachineConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-abc"},
+		Spec: mcov1alpha1.RenderedMachineConfigSpec{
+			PoolName: "worker",
+			Config: mcov1alpha1.RenderedConfig{
+				Files: []mcov1alpha1.FileSpec{{Path: "/etc/a", Content: "a"}},
+			},
+			Reboot: mcov1alpha1.RenderedRebootSpec{Strategy: "Never"},
+		},
+	}
+
+	rebootChange := oldRMC.DeepCopy()
+	rebootChange.Spec.Reboot.Strategy = "IfRequired"
+	if _, err := v.ValidateUpdate(context.Background(), oldRMC, rebootChange); err != nil {
+		t.Errorf("ValidateUpdate() reboot policy change error = %v, want nil", err)
+	}
+
+	contentChange := oldRMC.DeepCopy()
+	contentChange.Spec.Config.Files[0].Content = "tampered"
+	if _, err := v.ValidateUpdate(context.Background(), oldRMC, contentChange); err == nil {
+		t.Error("ValidateUpdate() content change error = nil, want rejection")
+	}
+}
@@ -30,6 +30,15 @@ const (
 	// Pool is the name of the MachineConfigPool this node belongs to.
 	Pool = Prefix + "pool"
 
+	// RebootStrategy overrides the RMC reboot strategy on this node. The
+	// controller sets it to IfRequired when the pool asks for Kexec but the
+	// node's agent did not report kexec support.
+	RebootStrategy = Prefix + "reboot-strategy"
+
+	// SkipReason explains why a node that needs the target revision is not
+	// being updated. Only written when the pool sets annotateSkipReasons.
+	SkipReason = Prefix + "skip-reason"
+
 	// Agent-written annotations.
 
 	// CurrentRevision is the last successfully applied RMC name.
@@ -44,28 +53,128 @@ const (
 	// RebootPending is "true" if a reboot is needed but blocked by policy.
 	RebootPending = Prefix + "reboot-pending"
 
+	// RebootPendingReason says why a pending reboot has not run yet, one
+	// of the reboot pending reason values. Removed together with
+	// RebootPending.
+	RebootPendingReason = Prefix + "reboot-pending-reason"
+
 	// Cordoned is "true" if the node was cordoned by MCO for update.
 	Cordoned = Prefix + "cordoned"
 
+	// CordonedAt contains the timestamp when MCO cordoned the node.
+	CordonedAt = Prefix + "cordoned-at"
+
 	// DrainStartedAt contains the timestamp when drain started.
 	DrainStartedAt = Prefix + "drain-started-at"
 
+	// DrainCompletedAt contains the timestamp when drain finished.
+	DrainCompletedAt = Prefix + "drain-completed-at"
+
+	// RebootMethods lists the reboot methods the agent found usable on the
+	// host at startup, comma-separated (e.g. "reboot,kexec").
+	RebootMethods = Prefix + "reboot-methods"
+
+	// LastApplied is a bounded JSON summary (revision, config hash, per-file
+	// and per-unit digests) of the config the agent last wrote to the host.
+	LastApplied = Prefix + "last-applied"
+
+	// UnitDrift lists, comma-separated, the managed unit files the agent
+	// last found edited out-of-band and restored. Removed once a drift
+	// check finds none.
+	UnitDrift = Prefix + "unit-drift"
+
+	// UnitStateDrift summarizes the managed units whose runtime state
+	// (masked, enabled, active) last differed from the spec, e.g.
+	// "app.service active=failed (want active)". Entries are separated by
+	// "; ". Removed once a drift check finds none.
+	UnitStateDrift = Prefix + "unit-state-drift"
+
+	// RebootReason names the change, and the MachineConfig it came from,
+	// behind the agent's last decision to reboot the node.
+	RebootReason = Prefix + "reboot-reason"
+
 	// DrainRetryCount contains the number of drain retry attempts.
 	DrainRetryCount = Prefix + "drain-retry-count"
 
+	// DrainBlockedReason says why the last drain attempt failed:
+	// DrainBlockedPDB or DrainBlockedEvictionFailed.
+	DrainBlockedReason = Prefix + "drain-blocked-reason"
+
+	// DrainLowestRemaining is the lowest number of pods left to evict seen
+	// during the current drain.
+	DrainLowestRemaining = Prefix + "drain-lowest-remaining"
+
+	// DrainNoProgressCount counts consecutive drain checks that did not
+	// get below DrainLowestRemaining.
+	DrainNoProgressCount = Prefix + "drain-no-progress-count"
+
+	// DrainProgressCheckedAt is when the last drain progress check was
+	// counted (RFC3339). Checks closer together than the drain retry
+	// interval are not counted again.
+	DrainProgressCheckedAt = Prefix + "drain-progress-checked-at"
+
+	// OnUpdateLabels lists, comma-separated, the keys of the pool's
+	// onUpdateLabels the controller set on the node, so they can be removed
+	// once the node regresses or the key is no longer configured.
+	OnUpdateLabels = Prefix + "on-update-labels"
+
 	// Control annotations (set by user/operator).
 
 	// Paused is "true" to exclude the node from rollout.
 	Paused = Prefix + "paused"
 
+	// Maintenance is "true" to exclude the node from all MCO actions
+	// (no cordon, drain, revision or label changes, no apply by the agent).
+	// The node is still counted in pool status.
+	Maintenance = Prefix + "maintenance"
+
 	// ForceReboot is "true" to force reboot ignoring minInterval.
 	ForceReboot = Prefix + "force-reboot"
 
+	// UpdateRequested is "true" to ask for the node to be updated in a pool
+	// using the OnDelete update strategy. The controller removes it once
+	// the node has been handed its new desired revision.
+	UpdateRequested = Prefix + "update-requested"
+
+	// UpdateNow is "true" to drive the node through cordon, drain, apply
+	// and uncordon right away, outside the pool's maxUnavailable budget
+	// and update strategy. Paused, maintenance and frozen pools are still
+	// respected. The controller removes it once the node has been handed
+	// its new desired revision.
+	UpdateNow = Prefix + "update-now"
+
+	// RetryFailed is "true" on a MachineConfigPool to clear the error state of
+	// its failed nodes so they are re-driven. The controller removes it once done.
+	RetryFailed = Prefix + "retry-failed"
+
+	// ContentChecksum is an optional "sha256:<hex>" checksum of a
+	// MachineConfig's spec, set by GitOps pipelines. The admission webhook
+	// warns when the stored spec does not match it. Compute it with
+	// pkg/checksum, which defines the canonical encoding.
+	ContentChecksum = Prefix + "content-checksum"
+
+	// DebugApplyPaths is a break-glass debugging aid set on a node: a
+	// comma-separated list of file path prefixes and unit names. An agent
+	// started with --allow-debug-apply-paths applies only those entries of
+	// the desired revision and reports an error instead of done.
+	DebugApplyPaths = Prefix + "debug-apply-paths"
+
 	// DesiredRevisionSetAt records when the controller set desired-revision.
 	// Used for apply timeout detection.
 	DesiredRevisionSetAt = Prefix + "desired-revision-set-at"
 )
 
+// Node labels mirrored by the controller when a pool sets mirrorNodeLabels.
+// Labels carry the same information as the annotations above in a form
+// that can be used in label selectors.
+const (
+	// LabelPool is the name of the MachineConfigPool the node belongs to.
+	LabelPool = Prefix + "pool"
+
+	// LabelRevision is the short revision hash of the node's current RMC.
+	LabelRevision = Prefix + "revision"
+)
+
 // Boolean annotation values.
 const (
 	// ValueTrue is the string "true" used for boolean annotations.
@@ -89,6 +198,27 @@ const (
 	StateError = "error"
 )
 
+// Drain blocked reason values.
+const (
+	// DrainBlockedPDB means every failed eviction was refused by a
+	// PodDisruptionBudget.
+	DrainBlockedPDB = "PDBBlocked"
+
+	// DrainBlockedEvictionFailed means evictions failed for other reasons.
+	DrainBlockedEvictionFailed = "EvictionFailed"
+)
+
+// Reboot pending reason values.
+const (
+	// RebootPendingStrategyNever means the reboot strategy suppresses
+	// reboots; the node waits for a force-reboot or a manual reboot.
+	RebootPendingStrategyNever = "StrategyNever"
+
+	// RebootPendingMinInterval means the reboot is deferred until the
+	// minimum interval since the last reboot has elapsed.
+	RebootPendingMinInterval = "MinInterval"
+)
+
 // GetAnnotation safely gets an annotation value from a map.
 // Returns empty string if annotations is nil or key doesn't exist.
 func GetAnnotation(annotations map[string]string, key string) string {
@@ -128,6 +258,11 @@ func IsNodePaused(annotations map[string]string) bool {
 	return GetBoolAnnotation(annotations, Paused)
 }
 
+// IsNodeInMaintenance checks if a node is excluded for maintenance.
+func IsNodeInMaintenance(annotations map[string]string) bool {
+	return GetBoolAnnotation(annotations, Maintenance)
+}
+
 // NeedsUpdate checks if desired-revision differs from current-revision.
 // Returns false if desired-revision is not set.
 func NeedsUpdate(annotations map[string]string) bool {
@@ -244,6 +244,27 @@ func TestIsNodePaused(t *testing.T) {
 	}
 }
 
+func TestIsNodeInMaintenance(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{
+		{"nil annotations", nil, false},
+		{"maintenance true", map[string]string{Maintenance: "true"}, true},
+		{"maintenance false", map[string]string{Maintenance: "false"}, false},
+		{"paused only", map[string]string{Paused: "true"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNodeInMaintenance(tt.annotations); got != tt.want {
+				t.Errorf("IsNodeInMaintenance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNeedsUpdate(t *testing.T) {
 	tests := []struct {
 		name        string
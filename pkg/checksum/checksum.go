@@ -0,0 +1,84 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package checksum computes the content checksum of a MachineConfig, the
+// value GitOps pipelines put in the mco.in-cloud.io/content-checksum
+// annotation so the admission webhook can warn when the stored spec drifts
+// from their source.
+//
+// The checksum is "sha256:" followed by the hex SHA-256 of the canonical
+// JSON encoding of the spec. The canonical encoding fills in the values the
+// API server defaults (file mode 420, owner root:root, state present) and
+// spells an empty encoding as plain, then marshals the spec with its JSON
+// field names in declaration order, omitting empty fields. A manifest and
+// the object the API server stores from it therefore have the same
+// checksum, whether or not the manifest spells the defaults out.
+package checksum
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/pkg/filecontent"
+)
+
+// Prefix starts every checksum.
+const Prefix = "sha256:"
+
+// File defaults the API server applies to a MachineConfig.
+const (
+	defaultFileMode  = 0644
+	defaultFileOwner = "root:root"
+	defaultFileState = "present"
+)
+
+// MachineConfig returns the content checksum of mc's spec.
+func MachineConfig(mc *mcov1alpha1.MachineConfig) (string, error) {
+	return Spec(mc.Spec)
+}
+
+// Spec returns the content checksum of spec.
+func Spec(spec mcov1alpha1.MachineConfigSpec) (string, error) {
+	data, err := json.Marshal(canonical(spec))
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return Prefix + hex.EncodeToString(sum[:]), nil
+}
+
+// canonical returns a copy of spec with defaulted fields filled in.
+func canonical(spec mcov1alpha1.MachineConfigSpec) mcov1alpha1.MachineConfigSpec {
+	out := *spec.DeepCopy()
+	for i := range out.Files {
+		f := &out.Files[i]
+		if f.Mode == 0 {
+			f.Mode = defaultFileMode
+		}
+		if f.Owner == "" {
+			f.Owner = defaultFileOwner
+		}
+		if f.State == "" {
+			f.State = defaultFileState
+		}
+		if f.Encoding == "" {
+			f.Encoding = filecontent.EncodingPlain
+		}
+	}
+	return out
+}
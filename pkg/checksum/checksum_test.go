@@ -0,0 +1,97 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package checksum
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/utils/ptr"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+func TestSpec_DefaultsDoNotChangeChecksum(t *testing.T) {
+	source := mcov1alpha1.MachineConfigSpec{
+		Priority: ptr.To(20),
+		Files:    []mcov1alpha1.FileSpec{{Path: "/etc/a.conf", Content: "a"}},
+	}
+	stored := mcov1alpha1.MachineConfigSpec{
+		Priority: ptr.To(20),
+		Files: []mcov1alpha1.FileSpec{{
+			Path:     "/etc/a.conf",
+			Content:  "a",
+			Encoding: "plain",
+			Mode:     420,
+			Owner:    "root:root",
+			State:    "present",
+		}},
+	}
+
+	want, err := Spec(source)
+	if err != nil {
+		t.Fatalf("Spec() error = %v", err)
+	}
+	got, err := Spec(stored)
+	if err != nil {
+		t.Fatalf("Spec() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("defaulted spec checksum = %s, want %s", got, want)
+	}
+	if !strings.HasPrefix(want, Prefix) || len(want) != len(Prefix)+64 {
+		t.Errorf("checksum = %q, want sha256:<64 hex>", want)
+	}
+	if source.Files[0].Mode != 0 {
+		t.Error("Spec() modified its argument")
+	}
+}
+
+func TestSpec_ContentChangesChecksum(t *testing.T) {
+	base := mcov1alpha1.MachineConfigSpec{
+		Files: []mcov1alpha1.FileSpec{{Path: "/etc/a.conf", Content: "a"}},
+	}
+	tests := []struct {
+		name   string
+		mutate func(*mcov1alpha1.MachineConfigSpec)
+	}{
+		{"content", func(s *mcov1alpha1.MachineConfigSpec) { s.Files[0].Content = "b" }},
+		{"mode", func(s *mcov1alpha1.MachineConfigSpec) { s.Files[0].Mode = 0600 }},
+		{"priority", func(s *mcov1alpha1.MachineConfigSpec) { s.Priority = ptr.To(50) }},
+		{"unit", func(s *mcov1alpha1.MachineConfigSpec) {
+			s.Systemd.Units = []mcov1alpha1.UnitSpec{{Name: "a.service"}}
+		}},
+	}
+
+	want, err := Spec(base)
+	if err != nil {
+		t.Fatalf("Spec() error = %v", err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := *base.DeepCopy()
+			tt.mutate(&spec)
+			got, err := Spec(spec)
+			if err != nil {
+				t.Fatalf("Spec() error = %v", err)
+			}
+			if got == want {
+				t.Errorf("checksum unchanged after editing %s", tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+// RolloutProgress summarizes a pool's rollout for external tooling.
+type RolloutProgress struct {
+	// Pool is the MachineConfigPool name.
+	Pool string
+	// TargetRevision is the revision being rolled out.
+	TargetRevision string
+
+	// NodesTotal, NodesUpdated and NodesRemaining count pool nodes by
+	// whether they run their target revision.
+	NodesTotal     int
+	NodesUpdated   int
+	NodesRemaining int
+	// PercentComplete is NodesUpdated as a percentage of NodesTotal,
+	// rounded down. An empty pool is 100% complete.
+	PercentComplete int
+
+	// Complete mirrors the RolloutComplete condition.
+	Complete bool
+
+	// ETA estimates the time left from the pace since the rollout started.
+	// Zero when unknown: no rollout running or no node updated yet.
+	ETA time.Duration
+
+	// BlockingReasons lists what currently holds the rollout back, such as
+	// a paused pool or a True Degraded, DrainStuck or Frozen condition.
+	BlockingReasons []string
+}
+
+// blockingConditions are pool conditions that hold a rollout back while True.
+var blockingConditions = []string{
+	mcov1alpha1.ConditionDegraded,
+	mcov1alpha1.ConditionPoolOverlap,
+	mcov1alpha1.ConditionDrainStuck,
+	mcov1alpha1.ConditionCordonStuck,
+	mcov1alpha1.ConditionNodeUpdateFailed,
+	mcov1alpha1.ConditionNodesUnacknowledged,
+	mcov1alpha1.ConditionFrozen,
+	mcov1alpha1.ConditionEvictionLimitReached,
+	mcov1alpha1.ConditionRolloutTimeout,
+}
+
+// ProgressFromPool computes rollout progress from pool status as of now.
+func ProgressFromPool(pool *mcov1alpha1.MachineConfigPool, now time.Time) *RolloutProgress {
+	status := pool.Status
+	p := &RolloutProgress{
+		Pool:           pool.Name,
+		TargetRevision: status.TargetRevision,
+		NodesTotal:     status.MachineCount,
+		NodesUpdated:   status.UpdatedMachineCount,
+		NodesRemaining: max(status.MachineCount-status.UpdatedMachineCount, 0),
+		Complete:       meta.IsStatusConditionTrue(status.Conditions, mcov1alpha1.ConditionRolloutComplete),
+	}
+
+	p.PercentComplete = 100
+	if p.NodesTotal > 0 {
+		p.PercentComplete = p.NodesUpdated * 100 / p.NodesTotal
+	}
+
+	if started := status.RolloutStartedAt; started != nil && p.NodesUpdated > 0 && p.NodesRemaining > 0 {
+		perNode := now.Sub(started.Time) / time.Duration(p.NodesUpdated)
+		p.ETA = perNode * time.Duration(p.NodesRemaining)
+	}
+
+	if pool.Spec.Paused {
+		p.BlockingReasons = append(p.BlockingReasons, "pool is paused")
+	}
+	for _, condType := range blockingConditions {
+		if cond := meta.FindStatusCondition(status.Conditions, condType); cond != nil && cond.Status == metav1.ConditionTrue {
+			p.BlockingReasons = append(p.BlockingReasons, fmt.Sprintf("%s: %s", cond.Type, cond.Message))
+		}
+	}
+	for _, skipped := range status.SkippedMachines {
+		// Waiting for budget is normal rollout pacing, not a blocker.
+		if skipped.Reason == "budget" {
+			continue
+		}
+		p.BlockingReasons = append(p.BlockingReasons, fmt.Sprintf("%d nodes skipped: %s", skipped.Count, skipped.Reason))
+	}
+
+	return p
+}
+
+// RolloutProgress fetches the named pool and returns its rollout progress.
+func (r *RuntimeClient) RolloutProgress(ctx context.Context, poolName string) (*RolloutProgress, error) {
+	pool := &mcov1alpha1.MachineConfigPool{}
+	// MachineConfigPool is cluster-scoped, so Namespace is empty
+	if err := r.client.Get(ctx, types.NamespacedName{Name: poolName}, pool); err != nil {
+		return nil, err
+	}
+	return ProgressFromPool(pool, time.Now()), nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+func TestProgressFromPool(t *testing.T) {
+	now := time.Now()
+	started := metav1.NewTime(now.Add(-30 * time.Minute))
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Status: mcov1alpha1.MachineConfigPoolStatus{
+			TargetRevision:      "worker-abc",
+			MachineCount:        4,
+			UpdatedMachineCount: 3,
+			RolloutStartedAt:    &started,
+			SkippedMachines: []mcov1alpha1.SkippedMachineCount{
+				{Reason: "budget", Count: 2},
+				{Reason: "paused", Count: 1},
+			},
+			Conditions: []metav1.Condition{
+				{Type: mcov1alpha1.ConditionDrainStuck, Status: metav1.ConditionTrue, Message: "Drain stuck on nodes: n4"},
+				{Type: mcov1alpha1.ConditionDegraded, Status: metav1.ConditionFalse},
+			},
+		},
+	}
+
+	p := ProgressFromPool(pool, now)
+	if p.PercentComplete != 75 || p.NodesRemaining != 1 || p.Complete {
+		t.Errorf("progress = %+v, want 75%% with 1 remaining, not complete", p)
+	}
+	if p.ETA != 10*time.Minute {
+		t.Errorf("ETA = %v, want 10m", p.ETA)
+	}
+	reasons := strings.Join(p.BlockingReasons, "\n")
+	if !strings.Contains(reasons, "DrainStuck: Drain stuck on nodes: n4") || !strings.Contains(reasons, "1 nodes skipped: paused") {
+		t.Errorf("BlockingReasons = %v", p.BlockingReasons)
+	}
+	if strings.Contains(reasons, "budget") || strings.Contains(reasons, "Degraded") {
+		t.Errorf("BlockingReasons should skip budget and False conditions, got %v", p.BlockingReasons)
+	}
+}
+
+func TestRuntimeClient_RolloutProgress(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = mcov1alpha1.AddToScheme(scheme)
+	pool := &mcov1alpha1.MachineConfigPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+		Status: mcov1alpha1.MachineConfigPoolStatus{
+			MachineCount:        2,
+			UpdatedMachineCount: 2,
+			Conditions: []metav1.Condition{
+				{Type: mcov1alpha1.ConditionRolloutComplete, Status: metav1.ConditionTrue},
+			},
+		},
+	}
+	rc := NewRuntimeClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(pool).Build())
+
+	p, err := rc.RolloutProgress(context.Background(), "worker")
+	if err != nil {
+		t.Fatalf("RolloutProgress() error = %v", err)
+	}
+	if !p.Complete || p.PercentComplete != 100 || p.ETA != 0 {
+		t.Errorf("progress = %+v, want complete at 100%% with no ETA", p)
+	}
+
+	if _, err := rc.RolloutProgress(context.Background(), "missing"); err == nil {
+		t.Error("RolloutProgress() for missing pool should fail")
+	}
+}
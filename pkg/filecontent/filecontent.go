@@ -0,0 +1,89 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package filecontent decodes FileSpec content. The renderer validates it
+// and the agent writes the decoded bytes to disk.
+package filecontent
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+// Content encodings of FileSpec.Encoding.
+const (
+	EncodingPlain      = "plain"
+	EncodingBase64     = "base64"
+	EncodingGzipBase64 = "gzip+base64"
+)
+
+// MaxDecodedSize caps the size of decompressed gzip content so a small
+// payload cannot expand without bound on the node.
+const MaxDecodedSize = 16 * 1024 * 1024
+
+// Decode returns the bytes f.Content stands for under f.Encoding.
+func Decode(f mcov1alpha1.FileSpec) ([]byte, error) {
+	switch f.Encoding {
+	case "", EncodingPlain:
+		return []byte(f.Content), nil
+	case EncodingBase64:
+		data, err := base64.StdEncoding.DecodeString(f.Content)
+		if err != nil {
+			return nil, fmt.Errorf("decode base64 content of %s: %w", f.Path, err)
+		}
+		return data, nil
+	case EncodingGzipBase64:
+		compressed, err := base64.StdEncoding.DecodeString(f.Content)
+		if err != nil {
+			return nil, fmt.Errorf("decode base64 content of %s: %w", f.Path, err)
+		}
+		zr, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return nil, fmt.Errorf("decompress content of %s: %w", f.Path, err)
+		}
+		defer func() { _ = zr.Close() }()
+		data, err := io.ReadAll(io.LimitReader(zr, MaxDecodedSize+1))
+		if err != nil {
+			return nil, fmt.Errorf("decompress content of %s: %w", f.Path, err)
+		}
+		if len(data) > MaxDecodedSize {
+			return nil, fmt.Errorf("decompressed content of %s exceeds %d bytes", f.Path, MaxDecodedSize)
+		}
+		return data, nil
+	default:
+		return nil, fmt.Errorf("unknown content encoding %q for %s", f.Encoding, f.Path)
+	}
+}
+
+// Equal reports whether a and b decode to the same bytes, so re-encoding
+// a payload is not a change. Content that does not decode is compared as
+// written.
+func Equal(a, b mcov1alpha1.FileSpec) bool {
+	if a.Encoding == b.Encoding && a.Content == b.Content {
+		return true
+	}
+	da, errA := Decode(a)
+	db, errB := Decode(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return bytes.Equal(da, db)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filecontent
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+)
+
+func gzipBase64(t *testing.T, data []byte) string {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestDecode(t *testing.T) {
+	payload := []byte("\x00\x01binary\xff")
+
+	tests := []struct {
+		name    string
+		file    mcov1alpha1.FileSpec
+		want    []byte
+		wantErr string
+	}{
+		{"empty encoding", mcov1alpha1.FileSpec{Content: "text"}, []byte("text"), ""},
+		{"plain", mcov1alpha1.FileSpec{Content: "text", Encoding: EncodingPlain}, []byte("text"), ""},
+		{"base64", mcov1alpha1.FileSpec{Content: base64.StdEncoding.EncodeToString(payload), Encoding: EncodingBase64}, payload, ""},
+		{"gzip+base64", mcov1alpha1.FileSpec{Content: gzipBase64(t, payload), Encoding: EncodingGzipBase64}, payload, ""},
+		{"invalid base64", mcov1alpha1.FileSpec{Content: "not base64!", Encoding: EncodingBase64}, nil, "decode base64"},
+		{"corrupt gzip", mcov1alpha1.FileSpec{Content: base64.StdEncoding.EncodeToString([]byte("plain")), Encoding: EncodingGzipBase64}, nil, "decompress"},
+		{"unknown encoding", mcov1alpha1.FileSpec{Content: "x", Encoding: "zstd"}, nil, "unknown content encoding"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Decode(tt.file)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Decode() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("Decode() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode_GzipSizeCap(t *testing.T) {
+	f := mcov1alpha1.FileSpec{
+		Content:  gzipBase64(t, make([]byte, MaxDecodedSize+1)),
+		Encoding: EncodingGzipBase64,
+	}
+	if _, err := Decode(f); err == nil || !strings.Contains(err.Error(), "exceeds") {
+		t.Fatalf("Decode() error = %v, want size cap error", err)
+	}
+}
+
+func TestEqual_ReencodedPayload(t *testing.T) {
+	plain := mcov1alpha1.FileSpec{Content: "same payload"}
+	b64 := mcov1alpha1.FileSpec{Content: base64.StdEncoding.EncodeToString([]byte("same payload")), Encoding: EncodingBase64}
+	gz := mcov1alpha1.FileSpec{Content: gzipBase64(t, []byte("same payload")), Encoding: EncodingGzipBase64}
+
+	if !Equal(plain, b64) || !Equal(b64, gz) {
+		t.Error("Equal() = false for the same payload in different encodings")
+	}
+	if Equal(plain, mcov1alpha1.FileSpec{Content: "other"}) {
+		t.Error("Equal() = true for different payloads")
+	}
+}
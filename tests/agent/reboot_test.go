@@ -11,7 +11,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
-	"in-cloud.io/machine-config/internal/agent"
+	"in-cloud.io/machine-config/internal/configdiff"
 	"in-cloud.io/machine-config/tests/mocks"
 )
 
@@ -56,14 +56,14 @@ func TestRebootDecision_NotRequired(t *testing.T) {
 
 	mockFetcher.EXPECT().FetchRMC(gomock.Any(), "rmc-current").Return(currentRMC, nil)
 
-	determiner := agent.NewRebootDeterminer(mockFetcher)
+	determiner := configdiff.NewRebootDeterminer(mockFetcher)
 	decision := determiner.DetermineReboot(context.Background(), "rmc-current", newRMC)
 
 	if decision.Required {
 		t.Errorf("expected Required=false, reasons: %v", decision.Reasons)
 	}
-	if decision.Method != agent.MethodDiffBased {
-		t.Errorf("expected Method=%s, got %s", agent.MethodDiffBased, decision.Method)
+	if decision.Method != configdiff.MethodDiffBased {
+		t.Errorf("expected Method=%s, got %s", configdiff.MethodDiffBased, decision.Method)
 	}
 }
 
@@ -109,14 +109,14 @@ func TestRebootDecision_RequiredWithFileChange(t *testing.T) {
 
 	mockFetcher.EXPECT().FetchRMC(gomock.Any(), "rmc-current").Return(currentRMC, nil)
 
-	determiner := agent.NewRebootDeterminer(mockFetcher)
+	determiner := configdiff.NewRebootDeterminer(mockFetcher)
 	decision := determiner.DetermineReboot(context.Background(), "rmc-current", newRMC)
 
 	if !decision.Required {
 		t.Error("expected Required=true")
 	}
-	if decision.Method != agent.MethodDiffBased {
-		t.Errorf("expected Method=%s, got %s", agent.MethodDiffBased, decision.Method)
+	if decision.Method != configdiff.MethodDiffBased {
+		t.Errorf("expected Method=%s, got %s", configdiff.MethodDiffBased, decision.Method)
 	}
 	if len(decision.Reasons) == 0 {
 		t.Error("expected at least one reason")
@@ -140,14 +140,14 @@ func TestRebootDecision_FirstApply(t *testing.T) {
 		},
 	}
 
-	determiner := agent.NewRebootDeterminer(mockFetcher)
+	determiner := configdiff.NewRebootDeterminer(mockFetcher)
 	decision := determiner.DetermineReboot(context.Background(), "", newRMC) // Empty current
 
 	if !decision.Required {
 		t.Error("expected Required=true for first apply with reboot required")
 	}
-	if decision.Method != agent.MethodLegacyFirstApply {
-		t.Errorf("expected Method=%s, got %s", agent.MethodLegacyFirstApply, decision.Method)
+	if decision.Method != configdiff.MethodLegacyFirstApply {
+		t.Errorf("expected Method=%s, got %s", configdiff.MethodLegacyFirstApply, decision.Method)
 	}
 }
 
@@ -168,14 +168,14 @@ func TestRebootDecision_SameRevision(t *testing.T) {
 		},
 	}
 
-	determiner := agent.NewRebootDeterminer(mockFetcher)
+	determiner := configdiff.NewRebootDeterminer(mockFetcher)
 	decision := determiner.DetermineReboot(context.Background(), "rmc-same", rmc)
 
 	if decision.Required {
 		t.Error("expected Required=false for same revision")
 	}
-	if decision.Method != agent.MethodSameRevision {
-		t.Errorf("expected Method=%s, got %s", agent.MethodSameRevision, decision.Method)
+	if decision.Method != configdiff.MethodSameRevision {
+		t.Errorf("expected Method=%s, got %s", configdiff.MethodSameRevision, decision.Method)
 	}
 }
 
@@ -220,14 +220,14 @@ func TestRebootDecision_UnitChange(t *testing.T) {
 
 	mockFetcher.EXPECT().FetchRMC(gomock.Any(), "rmc-current").Return(currentRMC, nil)
 
-	determiner := agent.NewRebootDeterminer(mockFetcher)
+	determiner := configdiff.NewRebootDeterminer(mockFetcher)
 	decision := determiner.DetermineReboot(context.Background(), "rmc-current", newRMC)
 
 	if !decision.Required {
 		t.Error("expected Required=true for unit requiring reboot")
 	}
-	if decision.Method != agent.MethodDiffBased {
-		t.Errorf("expected Method=%s, got %s", agent.MethodDiffBased, decision.Method)
+	if decision.Method != configdiff.MethodDiffBased {
+		t.Errorf("expected Method=%s, got %s", configdiff.MethodDiffBased, decision.Method)
 	}
 }
 
@@ -271,7 +271,7 @@ func TestRebootDecision_RemovedFile(t *testing.T) {
 
 	mockFetcher.EXPECT().FetchRMC(gomock.Any(), "rmc-current").Return(currentRMC, nil)
 
-	determiner := agent.NewRebootDeterminer(mockFetcher)
+	determiner := configdiff.NewRebootDeterminer(mockFetcher)
 	decision := determiner.DetermineReboot(context.Background(), "rmc-current", newRMC)
 
 	if !decision.Required {
@@ -298,14 +298,14 @@ func TestRebootDecision_RMCFetchError(t *testing.T) {
 		},
 	}
 
-	determiner := agent.NewRebootDeterminer(mockFetcher)
+	determiner := configdiff.NewRebootDeterminer(mockFetcher)
 	decision := determiner.DetermineReboot(context.Background(), "rmc-missing", newRMC)
 
 	if !decision.Required {
 		t.Error("expected Required=true (fallback to legacy)")
 	}
-	if decision.Method != agent.MethodLegacyFallback {
-		t.Errorf("expected Method=%s, got %s", agent.MethodLegacyFallback, decision.Method)
+	if decision.Method != configdiff.MethodLegacyFallback {
+		t.Errorf("expected Method=%s, got %s", configdiff.MethodLegacyFallback, decision.Method)
 	}
 }
 
@@ -347,11 +347,11 @@ func TestRebootDecision_NoRebootRequirements(t *testing.T) {
 
 	mockFetcher.EXPECT().FetchRMC(gomock.Any(), "rmc-current").Return(currentRMC, nil)
 
-	determiner := agent.NewRebootDeterminer(mockFetcher)
+	determiner := configdiff.NewRebootDeterminer(mockFetcher)
 	decision := determiner.DetermineReboot(context.Background(), "rmc-current", newRMC)
 
-	if decision.Method != agent.MethodLegacyFallback {
-		t.Errorf("expected Method=%s, got %s", agent.MethodLegacyFallback, decision.Method)
+	if decision.Method != configdiff.MethodLegacyFallback {
+		t.Errorf("expected Method=%s, got %s", configdiff.MethodLegacyFallback, decision.Method)
 	}
 }
 
@@ -411,7 +411,7 @@ func TestRebootDecision_MultipleChanges(t *testing.T) {
 
 	mockFetcher.EXPECT().FetchRMC(gomock.Any(), "rmc-current").Return(currentRMC, nil)
 
-	determiner := agent.NewRebootDeterminer(mockFetcher)
+	determiner := configdiff.NewRebootDeterminer(mockFetcher)
 	decision := determiner.DetermineReboot(context.Background(), "rmc-current", newRMC)
 
 	if !decision.Required {
@@ -32,6 +32,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 
 	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
 	"in-cloud.io/machine-config/tests/testutil"
@@ -90,7 +91,7 @@ func createTestMachineConfig(name, poolName string) *mcov1alpha1.MachineConfig {
 			},
 		},
 		Spec: mcov1alpha1.MachineConfigSpec{
-			Priority: 50,
+			Priority: ptr.To(50),
 			Files: []mcov1alpha1.FileSpec{
 				{
 					Path:    fmt.Sprintf("/etc/mco-test/%s.conf", name),
@@ -121,6 +121,12 @@ func (b *PoolBuilder) WithPaused(paused bool) *PoolBuilder {
 	return b
 }
 
+// WithDefaultMachineConfigPriority sets the priority for unprioritized MCs.
+func (b *PoolBuilder) WithDefaultMachineConfigPriority(priority int) *PoolBuilder {
+	b.pool.Spec.DefaultMachineConfigPriority = ptr.To(priority)
+	return b
+}
+
 // WithLabels adds labels to pool.
 func (b *PoolBuilder) WithLabels(labels map[string]string) *PoolBuilder {
 	if b.pool.Labels == nil {
@@ -151,7 +157,7 @@ func NewMC(name string) *MCBuilder {
 				Labels: map[string]string{},
 			},
 			Spec: mcov1alpha1.MachineConfigSpec{
-				Priority: 50,
+				Priority: ptr.To(50),
 				Files:    []mcov1alpha1.FileSpec{},
 				Systemd:  mcov1alpha1.SystemdSpec{},
 				Reboot: mcov1alpha1.RebootRequirementSpec{
@@ -170,7 +176,13 @@ func (b *MCBuilder) ForPool(poolName string) *MCBuilder {
 
 // WithPriority sets the priority.
 func (b *MCBuilder) WithPriority(priority int) *MCBuilder {
-	b.mc.Spec.Priority = priority
+	b.mc.Spec.Priority = ptr.To(priority)
+	return b
+}
+
+// WithoutPriority leaves the priority unset.
+func (b *MCBuilder) WithoutPriority() *MCBuilder {
+	b.mc.Spec.Priority = nil
 	return b
 }
 
@@ -0,0 +1,81 @@
+//go:build envtest && !unit && !e2e
+
+package envtest
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	mcov1alpha1 "in-cloud.io/machine-config/api/v1alpha1"
+	"in-cloud.io/machine-config/tests/envtest/fixtures"
+)
+
+var _ = Describe("Default MachineConfig Priority", func() {
+
+	It("should apply the pool default only to MCs without a priority", func() {
+		poolName := uniqueName("pool-priority")
+
+		unset := fixtures.NewMC("mc-unset-"+poolName).
+			ForPool(poolName).
+			WithoutPriority().
+			WithFile("/etc/priority.conf", "unset", 0644).
+			Build()
+		Expect(k8sClient.Create(ctx, unset)).To(Succeed())
+		DeferCleanup(func() {
+			_ = k8sClient.Delete(ctx, unset)
+		})
+
+		zero := fixtures.NewMC("mc-zero-"+poolName).
+			ForPool(poolName).
+			WithPriority(0).
+			WithFile("/etc/priority-zero.conf", "zero", 0644).
+			Build()
+		Expect(k8sClient.Create(ctx, zero)).To(Succeed())
+		DeferCleanup(func() {
+			_ = k8sClient.Delete(ctx, zero)
+		})
+
+		explicit := fixtures.NewMC("mc-explicit-"+poolName).
+			ForPool(poolName).
+			WithPriority(45).
+			WithFile("/etc/priority.conf", "explicit", 0644).
+			Build()
+		Expect(k8sClient.Create(ctx, explicit)).To(Succeed())
+		DeferCleanup(func() {
+			_ = k8sClient.Delete(ctx, explicit)
+		})
+
+		By("verifying the API server keeps an unset priority unset")
+		stored := &mcov1alpha1.MachineConfig{}
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(unset), stored)).To(Succeed())
+		Expect(stored.Spec.Priority).To(BeNil())
+		Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(zero), stored)).To(Succeed())
+		Expect(stored.Spec.Priority).NotTo(BeNil())
+		Expect(*stored.Spec.Priority).To(Equal(0))
+
+		pool := fixtures.NewPool(poolName).
+			WithDefaultMachineConfigPriority(40).
+			Build()
+		Expect(k8sClient.Create(ctx, pool)).To(Succeed())
+		DeferCleanup(func() {
+			_ = fixtures.CleanupPool(ctx, k8sClient, poolName)
+		})
+
+		By("waiting for the RMC to merge with the pool default priority")
+		rmc := fixtures.WaitForRMC(ctx, k8sClient, poolName)
+		priorities := make(map[string]int)
+		for _, src := range rmc.Spec.Sources {
+			priorities[src.Name] = src.Priority
+		}
+		Expect(priorities).To(HaveKeyWithValue(unset.Name, 40))
+		Expect(priorities).To(HaveKeyWithValue(zero.Name, 0))
+		Expect(priorities).To(HaveKeyWithValue(explicit.Name, 45))
+
+		contents := make(map[string]string)
+		for _, f := range rmc.Spec.Config.Files {
+			contents[f.Path] = f.Content
+		}
+		Expect(contents).To(HaveKeyWithValue("/etc/priority.conf", "explicit"))
+	})
+})
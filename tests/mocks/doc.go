@@ -6,7 +6,7 @@
 // Mocks are generated from production interfaces:
 //   - internal/agent/files.go → FileOperations
 //   - internal/agent/systemd.go → SystemdConnection
-//   - internal/agent/reboot_decision.go → RMCFetcher
+//   - internal/configdiff/reboot.go → RMCFetcher
 //   - internal/agent/reboot/handler.go → NodeAnnotationWriter, RebootExecutor
 package mocks
 
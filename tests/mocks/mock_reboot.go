@@ -96,6 +96,20 @@ func (mr *MockNodeAnnotationWriterMockRecorder) SetRebootPending(ctx, pending an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRebootPending", reflect.TypeOf((*MockNodeAnnotationWriter)(nil).SetRebootPending), ctx, pending)
 }
 
+// SetRebootPendingReason mocks base method.
+func (m *MockNodeAnnotationWriter) SetRebootPendingReason(ctx context.Context, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRebootPendingReason", ctx, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRebootPendingReason indicates an expected call of SetRebootPendingReason.
+func (mr *MockNodeAnnotationWriterMockRecorder) SetRebootPendingReason(ctx, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRebootPendingReason", reflect.TypeOf((*MockNodeAnnotationWriter)(nil).SetRebootPendingReason), ctx, reason)
+}
+
 // SetState mocks base method.
 func (m *MockNodeAnnotationWriter) SetState(ctx context.Context, state string) error {
 	m.ctrl.T.Helper()
@@ -147,3 +161,31 @@ func (mr *MockRebootExecutorMockRecorder) Execute(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockRebootExecutor)(nil).Execute), ctx)
 }
+
+// ExecuteKexec mocks base method.
+func (m *MockRebootExecutor) ExecuteKexec(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteKexec", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecuteKexec indicates an expected call of ExecuteKexec.
+func (mr *MockRebootExecutorMockRecorder) ExecuteKexec(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteKexec", reflect.TypeOf((*MockRebootExecutor)(nil).ExecuteKexec), ctx)
+}
+
+// SupportedMethods mocks base method.
+func (m *MockRebootExecutor) SupportedMethods() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SupportedMethods")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// SupportedMethods indicates an expected call of SupportedMethods.
+func (mr *MockRebootExecutorMockRecorder) SupportedMethods() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportedMethods", reflect.TypeOf((*MockRebootExecutor)(nil).SupportedMethods))
+}
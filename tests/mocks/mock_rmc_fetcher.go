@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/agent/reboot_decision.go
+// Source: internal/configdiff/reboot.go
 //
 // Generated by this command:
 //
-//	mockgen -source=internal/agent/reboot_decision.go -destination=tests/mocks/mock_rmc_fetcher.go -package=mocks
+//	mockgen -source=internal/configdiff/reboot.go -destination=tests/mocks/mock_rmc_fetcher.go -package=mocks
 //
 
 // Package mocks is a generated GoMock package.
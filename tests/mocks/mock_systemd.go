@@ -178,3 +178,17 @@ func (mr *MockSystemdConnectionMockRecorder) UnmaskUnit(ctx, name any) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnmaskUnit", reflect.TypeOf((*MockSystemdConnection)(nil).UnmaskUnit), ctx, name)
 }
+
+// VerifyUnitFile mocks base method.
+func (m *MockSystemdConnection) VerifyUnitFile(ctx context.Context, path string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyUnitFile", ctx, path)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyUnitFile indicates an expected call of VerifyUnitFile.
+func (mr *MockSystemdConnectionMockRecorder) VerifyUnitFile(ctx, path any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyUnitFile", reflect.TypeOf((*MockSystemdConnection)(nil).VerifyUnitFile), ctx, path)
+}